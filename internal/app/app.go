@@ -11,6 +11,9 @@ import (
 	"noteApp/pkg/db"
 	"noteApp/pkg/hasher"
 	"noteApp/pkg/logger"
+	"noteApp/pkg/mailer"
+	"noteApp/pkg/pubsub"
+	"noteApp/pkg/signer"
 	"os"
 	"os/signal"
 	"syscall"
@@ -66,11 +69,72 @@ func Start() {
 	zapLogger.Info("initializing password hasher")
 	hasher := hasher.NewHasher()
 
+	zapLogger.Info("initializing token signer",
+		zap.Int("keys", len(cfg.Auth.Keys)),
+		zap.String("signing_key_id", cfg.Auth.SigningKeyID),
+	)
+	signer, err := signer.NewSigner(cfg.Auth)
+	if err != nil {
+		zapLogger.Fatal("failed to initialize token signer",
+			zap.Error(err),
+		)
+	}
+
+	zapLogger.Info("initializing mailer",
+		zap.String("driver", cfg.Mail.Driver),
+	)
+	mailer, closeMailer, err := mailer.New(cfg.Mail)
+	if err != nil {
+		zapLogger.Fatal("failed to initialize mailer",
+			zap.Error(err),
+		)
+	}
+	defer func() {
+		if err := closeMailer(); err != nil {
+			zapLogger.Error("failed to close mailer",
+				zap.Error(err),
+			)
+		}
+	}()
+
+	zapLogger.Info("initializing event hub")
+	hub := pubsub.NewHub()
+
 	zapLogger.Info("initializing services")
-	services := service.NewService(repos, hasher, cfg.Auth, zapLogger)
+	services := service.NewService(repos, hasher, signer, hub, mailer, cfg.Auth, cfg.Mail, zapLogger)
+
+	zapLogger.Info("starting trash purge worker",
+		zap.Duration("retention", cfg.Trash.Retention),
+		zap.Duration("interval", cfg.Trash.PurgeInterval),
+	)
+	purgeCtx, stopPurge := context.WithCancel(context.Background())
+	purgeDone := make(chan struct{})
+	go func() {
+		defer close(purgeDone)
+		services.RunTrashPurge(purgeCtx, cfg.Trash.PurgeInterval, cfg.Trash.Retention)
+	}()
+
+	zapLogger.Info("starting token purge worker",
+		zap.Duration("interval", cfg.Auth.PurgeInterval),
+	)
+	tokenPurgeDone := make(chan struct{})
+	go func() {
+		defer close(tokenPurgeDone)
+		services.RunTokenPurge(purgeCtx, cfg.Auth.PurgeInterval)
+	}()
+
+	zapLogger.Info("starting note event purge worker",
+		zap.Duration("retention", cfg.Events.Retention),
+		zap.Duration("interval", cfg.Events.PurgeInterval),
+	)
+	eventPurgeDone := make(chan struct{})
+	go func() {
+		defer close(eventPurgeDone)
+		services.RunEventPurge(purgeCtx, cfg.Events.PurgeInterval, cfg.Events.Retention)
+	}()
 
 	zapLogger.Info("initializing HTTP handlers")
-	handlers := handler.NewHandler(services, zapLogger, cfg.Auth.RefreshTokenTTL)
+	handlers := handler.NewHandler(services, zapLogger, cfg.Auth.RefreshTokenTTL, cfg.Server.AllowedOrigins, cfg.Server.TrustedProxies)
 
 	zapLogger.Info("initializing HTTP server",
 		zap.String("port", cfg.Server.Port),
@@ -112,5 +176,11 @@ func Start() {
 		)
 	}
 
+	zapLogger.Info("stopping purge workers...")
+	stopPurge()
+	<-purgeDone
+	<-tokenPurgeDone
+	<-eventPurgeDone
+
 	zapLogger.Info("server stopped gracefully. Goodbye!")
 }
@@ -50,12 +50,57 @@ type ServerCfg struct {
 	IdleTimeout     time.Duration `mapstructure:"idle_timeout" validate:"required"`
 	MaxHeaderBytes  int           `mapstructure:"max_header_bytes" validate:"required"`
 	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout" validate:"required"`
+	AllowedOrigins  []string      `mapstructure:"allowed_origins" validate:"dive,url"`
+	TrustedProxies  []string      `mapstructure:"trusted_proxies" validate:"dive,ip|cidr"`
+}
+
+type JWTKeyCfg struct {
+	ID             string `mapstructure:"id" validate:"required"`
+	Algorithm      string `mapstructure:"algorithm" validate:"oneof=RS256 EdDSA"`
+	PrivateKeyFile string `mapstructure:"private_key_file" validate:"required_without=PublicKeyFile"`
+	PublicKeyFile  string `mapstructure:"public_key_file" validate:"required_without=PrivateKeyFile"`
+}
+
+type LockoutCfg struct {
+	AccountThreshold int           `mapstructure:"account_threshold" validate:"min=1"`
+	IPThreshold      int           `mapstructure:"ip_threshold" validate:"min=1"`
+	BaseDelay        time.Duration `mapstructure:"base_delay" validate:"required"`
+	MaxDelay         time.Duration `mapstructure:"max_delay" validate:"required,gtefield=BaseDelay"`
+	ResetAfter       time.Duration `mapstructure:"reset_after" validate:"required"`
 }
 
 type AuthCfg struct {
 	AccessTokenTTL  time.Duration `mapstructure:"access_token_ttl" validate:"required"`
 	RefreshTokenTTL time.Duration `mapstructure:"refresh_token_ttl" validate:"required"`
-	JwtSecret       string        `mapstructure:"jwt_secret" validate:"required"`
+	ChallengeTTL    time.Duration `mapstructure:"challenge_ttl" validate:"required"`
+	PurgeInterval   time.Duration `mapstructure:"purge_interval" validate:"required"`
+	JwtSecret       string        `mapstructure:"jwt_secret" validate:"required_without=Keys"`
+	SigningKeyID    string        `mapstructure:"signing_key_id" validate:"required_with=Keys"`
+	Keys            []JWTKeyCfg   `mapstructure:"keys" validate:"dive"`
+	Lockout         LockoutCfg    `mapstructure:"lockout"`
+}
+
+type MailCfg struct {
+	Driver       string        `mapstructure:"driver" validate:"oneof=smtp file"`
+	From         string        `mapstructure:"from" validate:"required,email"`
+	BaseURL      string        `mapstructure:"base_url" validate:"required,url"`
+	VerifyTTL    time.Duration `mapstructure:"verify_ttl" validate:"required"`
+	ResetTTL     time.Duration `mapstructure:"reset_ttl" validate:"required"`
+	FilePath     string        `mapstructure:"file_path"`
+	SMTPHost     string        `mapstructure:"smtp_host" validate:"required_if=Driver smtp"`
+	SMTPPort     string        `mapstructure:"smtp_port" validate:"required_if=Driver smtp"`
+	SMTPUsername string        `mapstructure:"smtp_username"`
+	SMTPPassword string        `mapstructure:"smtp_password"`
+}
+
+type TrashCfg struct {
+	Retention     time.Duration `mapstructure:"retention" validate:"required"`
+	PurgeInterval time.Duration `mapstructure:"purge_interval" validate:"required"`
+}
+
+type EventsCfg struct {
+	Retention     time.Duration `mapstructure:"retention" validate:"required"`
+	PurgeInterval time.Duration `mapstructure:"purge_interval" validate:"required"`
 }
 
 type Config struct {
@@ -63,6 +108,9 @@ type Config struct {
 	DB     DBConfig  `mapstructure:"db"`
 	Server ServerCfg `mapstructure:"server"`
 	Logger LoggerCfg `mapstructure:"logger"`
+	Mail   MailCfg   `mapstructure:"mail"`
+	Trash  TrashCfg  `mapstructure:"trash"`
+	Events EventsCfg `mapstructure:"events"`
 }
 
 func InitConfig() (*Config, error) {
@@ -134,6 +182,11 @@ func loadViper() (*viper.Viper, error) {
 		return nil, fmt.Errorf("fail bind JWT_SECRET: %w", err)
 	}
 
+	err = v.BindEnv("mail.smtp_password", "SMTP_PASSWORD")
+	if err != nil {
+		return nil, fmt.Errorf("fail bind SMTP_PASSWORD: %w", err)
+	}
+
 	if err := v.ReadInConfig(); err != nil {
 		return nil, fmt.Errorf("failed to read config: %w", err)
 	}
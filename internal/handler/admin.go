@@ -0,0 +1,233 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+	"noteApp/pkg/valid"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type AdminSI interface {
+	Users(ctx context.Context, p dto.Paginated) (dto.PaginatedResponse, error)
+	DisableUser(ctx context.Context, adminID, userID uuid.UUID) error
+	EnableUser(ctx context.Context, adminID, userID uuid.UUID) error
+	ResetUserPassword(ctx context.Context, adminID uuid.UUID, reset dto.AdminPasswordReset) error
+}
+
+type adminH struct {
+	service AdminSI
+	log     *logger.Logger
+}
+
+func newAdminHandler(service AdminSI, log *logger.Logger) *adminH {
+	return &adminH{
+		service: service,
+		log:     log,
+	}
+}
+
+func (h *Handler) InitAdminAPIs(api *gin.RouterGroup) {
+	h.log.Info("init admin APIs")
+	admin := api.Group("/admin", h.authMiddleware, h.requireRole(adminKey))
+	{
+		admin.GET("/users", h.users)
+		admin.POST("/users/:user_id/disable", h.disableUser)
+		admin.POST("/users/:user_id/enable", h.enableUser)
+		admin.POST("/users/:user_id/reset-password", h.resetUserPassword)
+	}
+}
+
+func (a *adminH) users(c *gin.Context) {
+	adminID, err := getUserID(c)
+	if err != nil {
+		a.log.Debug("unauthorized access attempt",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("method", c.Request.Method),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	var p dto.Paginated
+	if err := c.ShouldBindQuery(&p); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := valid.ValidateStruct(p); err != nil {
+		a.log.Debug("validation failed for admin users request",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("admin_id", adminID.String()),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	users, err := a.service.Users(c.Request.Context(), p)
+	if err != nil {
+		a.log.Error("get users failed",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("admin_id", adminID.String()),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	newSuccessResponse(c, http.StatusOK, "users", users)
+}
+
+func (a *adminH) disableUser(c *gin.Context) {
+	adminID, userID, ok := a.userParams(c)
+	if !ok {
+		return
+	}
+
+	if err := a.service.DisableUser(c.Request.Context(), adminID, userID); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			newErrorResponse(c, http.StatusNotFound, err.Error())
+		case errors.Is(err, domain.ErrForbidden):
+			newErrorResponse(c, http.StatusForbidden, err.Error())
+		default:
+			a.log.Error("failed to disable user",
+				zap.Error(err),
+				zap.String("client_ip", c.ClientIP()),
+				zap.String("admin_id", adminID.String()),
+				zap.String("user_id", userID.String()),
+			)
+			newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	a.log.Info("user disabled successfully",
+		zap.String("client_ip", c.ClientIP()),
+		zap.String("admin_id", adminID.String()),
+		zap.String("user_id", userID.String()),
+	)
+
+	newSuccessResponse(c, http.StatusOK, "data", "ok")
+}
+
+func (a *adminH) enableUser(c *gin.Context) {
+	adminID, userID, ok := a.userParams(c)
+	if !ok {
+		return
+	}
+
+	if err := a.service.EnableUser(c.Request.Context(), adminID, userID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			newErrorResponse(c, http.StatusNotFound, err.Error())
+			return
+		}
+		a.log.Error("failed to enable user",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("admin_id", adminID.String()),
+			zap.String("user_id", userID.String()),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	a.log.Info("user enabled successfully",
+		zap.String("client_ip", c.ClientIP()),
+		zap.String("admin_id", adminID.String()),
+		zap.String("user_id", userID.String()),
+	)
+
+	newSuccessResponse(c, http.StatusOK, "data", "ok")
+}
+
+func (a *adminH) resetUserPassword(c *gin.Context) {
+	adminID, userID, ok := a.userParams(c)
+	if !ok {
+		return
+	}
+
+	var reset dto.AdminPasswordReset
+	if err := c.ShouldBindJSON(&reset); err != nil {
+		a.log.Debug("invalid JSON in reset password request",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("admin_id", adminID.String()),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	reset.UserID = userID
+
+	if err := valid.ValidateStruct(reset); err != nil {
+		a.log.Debug("validation failed for reset password",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("admin_id", adminID.String()),
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := a.service.ResetUserPassword(c.Request.Context(), adminID, reset); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			newErrorResponse(c, http.StatusNotFound, err.Error())
+			return
+		}
+		a.log.Error("failed to reset user password",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("admin_id", adminID.String()),
+			zap.String("user_id", userID.String()),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	a.log.Info("user password reset successfully",
+		zap.String("client_ip", c.ClientIP()),
+		zap.String("admin_id", adminID.String()),
+		zap.String("user_id", userID.String()),
+	)
+
+	newSuccessResponse(c, http.StatusOK, "data", "ok")
+}
+
+func (a *adminH) userParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	adminID, err := getUserID(c)
+	if err != nil {
+		a.log.Debug("unauthorized access attempt",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("method", c.Request.Method),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	userID, err := getParamUUID(c, "user_id")
+	if err != nil {
+		a.log.Debug("invalid user_id in URL",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("admin_id", adminID.String()),
+			zap.String("param_value", c.Param("user_id")),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return adminID, userID, true
+}
@@ -0,0 +1,339 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	mock_handler "noteApp/internal/handler/mock"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockAdminHandler(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock_handler.MockServiceI)) *Handler {
+	t.Helper()
+
+	service := mock_handler.NewMockServiceI(ctrl)
+
+	if setupMock != nil {
+		setupMock(service)
+	}
+
+	return &Handler{
+		adminH: newAdminHandler(service, logger.LoggerForTest()),
+	}
+}
+
+func Test_adminH_users(t *testing.T) {
+	t.Parallel()
+
+	adminID := uuid.New()
+
+	users := dto.MakePaginatedResponse([]dto.AdminUserOutput{
+		{
+			UserOutput: dto.UserOutput{ID: uuid.New(), Username: "alice", Email: "alice@example.com"},
+			Role:       domain.UserRoleUser,
+		},
+	}, 1, 0, 10)
+
+	bytes, err := json.Marshal(users)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name                 string
+		query                string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "success",
+			query: "limit=10&offset=0",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().Users(gomock.Any(), dto.Paginated{Limit: 10}).Return(users, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"users":` + string(bytes) + `}`,
+		},
+		{
+			name:                 "invalid limit",
+			query:                "limit=1",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"validation failed: Field: Limit, Tag: gte, Param: 10"}`,
+		},
+		{
+			name:  "service error",
+			query: "limit=10",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().Users(gomock.Any(), dto.Paginated{Limit: 10}).Return(dto.PaginatedResponse{}, errors.New("service error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"service error"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockAdminHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.GET("/admin/users", func(c *gin.Context) {
+				c.Set(userIDKey, adminID.String())
+				handler.users(c)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/admin/users?"+tt.query, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func Test_adminH_disableUser(t *testing.T) {
+	t.Parallel()
+
+	adminID := uuid.New()
+	userID := uuid.New()
+
+	tests := []struct {
+		name                 string
+		param                string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "success",
+			param: userID.String(),
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().DisableUser(gomock.Any(), adminID, userID).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"data":"ok"}`,
+		},
+		{
+			name:                 "missing user_id in context",
+			param:                userID.String(),
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"error":"user id not found in context"}`,
+		},
+		{
+			name:                 "invalid user_id param",
+			param:                "123",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"user_id is not uuid"}`,
+		},
+		{
+			name:  "own account",
+			param: adminID.String(),
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().DisableUser(gomock.Any(), adminID, adminID).Return(domain.ErrForbidden)
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"error":"forbidden"}`,
+		},
+		{
+			name:  "not found",
+			param: userID.String(),
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().DisableUser(gomock.Any(), adminID, userID).Return(domain.ErrNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"not found"}`,
+		},
+		{
+			name:  "service error",
+			param: userID.String(),
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().DisableUser(gomock.Any(), adminID, userID).Return(errors.New("service error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"service error"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockAdminHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.POST("/admin/users/:user_id/disable", func(c *gin.Context) {
+				if tt.name != "missing user_id in context" {
+					c.Set(userIDKey, adminID.String())
+				}
+				handler.disableUser(c)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/admin/users/"+tt.param+"/disable", nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func Test_adminH_enableUser(t *testing.T) {
+	t.Parallel()
+
+	adminID := uuid.New()
+	userID := uuid.New()
+
+	tests := []struct {
+		name                 string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "success",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().EnableUser(gomock.Any(), adminID, userID).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"data":"ok"}`,
+		},
+		{
+			name: "not found",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().EnableUser(gomock.Any(), adminID, userID).Return(domain.ErrNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"not found"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockAdminHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.POST("/admin/users/:user_id/enable", func(c *gin.Context) {
+				c.Set(userIDKey, adminID.String())
+				handler.enableUser(c)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/admin/users/"+userID.String()+"/enable", nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func Test_adminH_resetUserPassword(t *testing.T) {
+	t.Parallel()
+
+	adminID := uuid.New()
+	userID := uuid.New()
+
+	tests := []struct {
+		name                 string
+		inputBody            string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "success",
+			inputBody: `{"new_password":"new_password"}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().ResetUserPassword(gomock.Any(), adminID, dto.AdminPasswordReset{
+					UserID:      userID,
+					NewPassword: "new_password",
+				}).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"data":"ok"}`,
+		},
+		{
+			name:                 "empty body",
+			inputBody:            ``,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"EOF"}`,
+		},
+		{
+			name:                 "short password",
+			inputBody:            `{"new_password":"123"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"validation failed: Field: NewPassword, Tag: min, Param: 8"}`,
+		},
+		{
+			name:      "not found",
+			inputBody: `{"new_password":"new_password"}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().ResetUserPassword(gomock.Any(), adminID, gomock.Any()).Return(domain.ErrNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"not found"}`,
+		},
+		{
+			name:      "service error",
+			inputBody: `{"new_password":"new_password"}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().ResetUserPassword(gomock.Any(), adminID, gomock.Any()).Return(errors.New("service error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"service error"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockAdminHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.POST("/admin/users/:user_id/reset-password", func(c *gin.Context) {
+				c.Set(userIDKey, adminID.String())
+				handler.resetUserPassword(c)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/admin/users/"+userID.String()+"/reset-password", strings.NewReader(tt.inputBody))
+			req.Header.Set("Content-type", "application/json")
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
@@ -2,23 +2,30 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"math"
 	"net/http"
+	"noteApp/internal/models/domain"
 	"noteApp/internal/models/dto"
 	"noteApp/pkg/logger"
 	"noteApp/pkg/valid"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/lestrrat-go/jwx/v2/jwk"
 	"go.uber.org/zap"
 )
 
 type AuthSI interface {
 	SignUp(ctx context.Context, user dto.UserCreate) (uuid.UUID, error)
-	SignIn(ctx context.Context, data dto.UserSignIn) (dto.TokenOutput, error)
+	SignIn(ctx context.Context, data dto.UserSignIn, device dto.Device) (dto.TokenOutput, error)
+	SignInTwoFactor(ctx context.Context, data dto.TwoFactorSignIn, device dto.Device) (dto.TokenOutput, error)
 	Logout(ctx context.Context, tokenID string) error
-	ParseToken(ctx context.Context, accessToken string) (uuid.UUID, error)
-	RefreshToken(ctx context.Context, tokenID string) (dto.TokenOutput, error)
+	ParseToken(ctx context.Context, accessToken string) (dto.TokenClaims, error)
+	RefreshToken(ctx context.Context, tokenID string, device dto.Device) (dto.TokenOutput, error)
+	JWKS() jwk.Set
 }
 
 type authH struct {
@@ -41,12 +48,18 @@ func (h *Handler) InitAuthAPIs(path *gin.RouterGroup) {
 	{
 		auth.POST("/sign-up", h.signUp)
 		auth.POST("/sign-in", h.signIn)
+		auth.POST("/sign-in/2fa", h.signInTwoFactor)
 		auth.GET("/logout", h.logout)
 		auth.GET("/refresh", h.refresh)
 	}
 
 }
 
+func (h *authH) jwks(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.service.JWKS())
+}
+
 func (h *authH) signUp(c *gin.Context) {
 	var user dto.UserCreate
 
@@ -112,8 +125,19 @@ func (h *authH) signIn(c *gin.Context) {
 		return
 	}
 
-	token, err := h.service.SignIn(c.Request.Context(), user)
+	token, err := h.service.SignIn(c.Request.Context(), user, getDevice(c))
 	if err != nil {
+		if h.lockedOut(c, err) {
+			return
+		}
+		if errors.Is(err, domain.ErrAccountDisabled) {
+			h.log.Warn("sign-in rejected: account disabled",
+				zap.String("email", user.Email),
+				zap.String("client_ip", c.ClientIP()),
+			)
+			newErrorResponse(c, http.StatusForbidden, err.Error())
+			return
+		}
 		h.log.Error("sign-in failed",
 			zap.Error(err),
 			zap.String("email", user.Email),
@@ -123,6 +147,15 @@ func (h *authH) signIn(c *gin.Context) {
 		return
 	}
 
+	if token.ChallengeToken != "" {
+		h.log.Info("two-factor challenge issued",
+			zap.String("email", user.Email),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		newSuccessResponse(c, http.StatusOK, challengeToken, token.ChallengeToken)
+		return
+	}
+
 	h.log.Info("user signed in successfully",
 		zap.String("email", user.Email),
 		zap.String("client_ip", c.ClientIP()),
@@ -132,6 +165,73 @@ func (h *authH) signIn(c *gin.Context) {
 	newSuccessResponse(c, http.StatusOK, accessToken, token.AccessToken)
 }
 
+func (h *authH) signInTwoFactor(c *gin.Context) {
+	var data dto.TwoFactorSignIn
+
+	if err := c.ShouldBindJSON(&data); err != nil {
+		h.log.Debug("invalid JSON in two-factor sign-in request",
+			zap.String("client_ip", c.ClientIP()),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := valid.ValidateStruct(data); err != nil {
+		h.log.Debug("validation failed during two-factor sign-in",
+			zap.String("client_ip", c.ClientIP()),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	token, err := h.service.SignInTwoFactor(c.Request.Context(), data, getDevice(c))
+	if err != nil {
+		if h.lockedOut(c, err) {
+			return
+		}
+		switch {
+		case errors.Is(err, domain.ErrInvalidOTP):
+			newErrorResponse(c, http.StatusUnauthorized, domain.ErrInvalidOTP.Error())
+		case errors.Is(err, domain.ErrNotFound):
+			newErrorResponse(c, http.StatusUnauthorized, "invalid or expired challenge")
+		case errors.Is(err, domain.ErrAccountDisabled):
+			newErrorResponse(c, http.StatusForbidden, err.Error())
+		default:
+			h.log.Error("two-factor sign-in failed",
+				zap.Error(err),
+				zap.String("client_ip", c.ClientIP()),
+			)
+			newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	h.log.Info("user signed in with two-factor successfully",
+		zap.String("client_ip", c.ClientIP()),
+	)
+
+	c.SetCookie(refreshToken, token.RefreshToken, int(h.refreshTokenTTL.Seconds()), "/", "", false, true)
+	newSuccessResponse(c, http.StatusOK, accessToken, token.AccessToken)
+}
+
+func (h *authH) lockedOut(c *gin.Context, err error) bool {
+	var lockout *domain.LockoutError
+	if !errors.As(err, &lockout) {
+		return false
+	}
+
+	h.log.Warn("sign-in rejected: too many attempts",
+		zap.String("client_ip", c.ClientIP()),
+		zap.String("path", c.Request.URL.Path),
+		zap.Duration("retry_after", lockout.RetryAfter),
+	)
+	c.Header(retryHeader, strconv.Itoa(int(math.Ceil(lockout.RetryAfter.Seconds()))))
+	newErrorResponse(c, http.StatusTooManyRequests, err.Error())
+	return true
+}
+
 func (h *authH) logout(c *gin.Context) {
 	tokenID, err := getAccessToken(c)
 	if err != nil {
@@ -173,8 +273,24 @@ func (h *authH) refresh(c *gin.Context) {
 		return
 	}
 
-	token, err := h.service.RefreshToken(c.Request.Context(), refreshTkn)
+	token, err := h.service.RefreshToken(c.Request.Context(), refreshTkn, getDevice(c))
 	if err != nil {
+		if errors.Is(err, domain.ErrTokenReused) {
+			h.log.Warn("refresh token reuse rejected",
+				zap.String("client_ip", c.ClientIP()),
+			)
+			c.SetCookie(refreshToken, "", -1, "/", "", false, true)
+			newErrorResponse(c, http.StatusUnauthorized, err.Error())
+			return
+		}
+		if errors.Is(err, domain.ErrAccountDisabled) {
+			h.log.Warn("refresh rejected: account disabled",
+				zap.String("client_ip", c.ClientIP()),
+			)
+			c.SetCookie(refreshToken, "", -1, "/", "", false, true)
+			newErrorResponse(c, http.StatusForbidden, err.Error())
+			return
+		}
 		h.log.Error("refresh token failed due to internal error",
 			zap.Error(err),
 			zap.String("client_ip", c.ClientIP()),
@@ -1,19 +1,25 @@
 package handler
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	mock_handler "noteApp/internal/handler/mock"
+	"noteApp/internal/models/domain"
 	"noteApp/internal/models/dto"
 	"noteApp/pkg/logger"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
+	"github.com/lestrrat-go/jwx/v2/jwk"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -190,12 +196,13 @@ func Test_authH_signIn(t *testing.T) {
 		expectedStatusCode   int
 		expectedResponseBody string
 		expectedCookie       string
+		expectedRetryAfter   string
 	}{
 		{
 			name:      "success",
 			inputBody: `{"email":"test_email@gmail.com", "password":"qwerty123"}`,
 			f: func(msi *mock_handler.MockServiceI) {
-				msi.EXPECT().SignIn(gomock.Any(), gomock.Any()).Return(token, nil)
+				msi.EXPECT().SignIn(gomock.Any(), gomock.Any(), gomock.Any()).Return(token, nil)
 			},
 			expectedStatusCode:   http.StatusOK,
 			expectedResponseBody: fmt.Sprintf(`{"%v":"%v"}`, accessToken, token.AccessToken),
@@ -231,11 +238,39 @@ func Test_authH_signIn(t *testing.T) {
 			expectedStatusCode:   http.StatusBadRequest,
 			expectedResponseBody: `{"error":"validation failed: Field: Password, Tag: min, Param: 8"}`,
 		},
+		{
+			name:      "two-factor challenge",
+			inputBody: `{"email":"test_email@gmail.com","password":"qwerty123"}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().SignIn(gomock.Any(), gomock.Any(), gomock.Any()).Return(dto.TokenOutput{ChallengeToken: "challenge-1234"}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: fmt.Sprintf(`{"%v":"challenge-1234"}`, challengeToken),
+		},
+		{
+			name:      "disabled account",
+			inputBody: `{"email":"test_email@gmail.com","password":"qwerty123"}`,
+			f: func(s *mock_handler.MockServiceI) {
+				s.EXPECT().SignIn(gomock.Any(), gomock.Any(), gomock.Any()).Return(dto.TokenOutput{}, domain.ErrAccountDisabled)
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"error":"account disabled"}`,
+		},
+		{
+			name:      "too many attempts",
+			inputBody: `{"email":"test_email@gmail.com","password":"qwerty123"}`,
+			f: func(s *mock_handler.MockServiceI) {
+				s.EXPECT().SignIn(gomock.Any(), gomock.Any(), gomock.Any()).Return(dto.TokenOutput{}, &domain.LockoutError{RetryAfter: 89500 * time.Millisecond})
+			},
+			expectedStatusCode:   http.StatusTooManyRequests,
+			expectedResponseBody: `{"error":"too many sign-in attempts, try again later"}`,
+			expectedRetryAfter:   "90",
+		},
 		{
 			name:      "service error",
 			inputBody: `{"email":"test_email@gmail.com","password":"qwerty123"}`,
 			f: func(s *mock_handler.MockServiceI) {
-				s.EXPECT().SignIn(gomock.Any(), gomock.Any()).Return(dto.TokenOutput{}, errors.New("service error"))
+				s.EXPECT().SignIn(gomock.Any(), gomock.Any(), gomock.Any()).Return(dto.TokenOutput{}, errors.New("service error"))
 			},
 			expectedStatusCode:   http.StatusInternalServerError,
 			expectedResponseBody: `{"error":"service error"}`,
@@ -266,6 +301,8 @@ func Test_authH_signIn(t *testing.T) {
 				assert.Equal(t, tt.expectedResponseBody, w.Body.String())
 			}
 
+			assert.Equal(t, tt.expectedRetryAfter, w.Header().Get("Retry-After"))
+
 			if tt.expectedCookie != "" {
 				cookie := w.Result().Cookies()[0]
 				assert.Equal(t, refreshToken, cookie.Name)
@@ -391,7 +428,7 @@ func Test_authH_refresh(t *testing.T) {
 		{
 			name: "success",
 			f: func(msi *mock_handler.MockServiceI) {
-				msi.EXPECT().RefreshToken(gomock.Any(), gomock.Any()).Return(token, nil)
+				msi.EXPECT().RefreshToken(gomock.Any(), token.RefreshToken, dto.Device{IP: "192.0.2.1"}).Return(token, nil)
 			},
 			cookie: &http.Cookie{
 				Name:     refreshToken,
@@ -413,10 +450,36 @@ func Test_authH_refresh(t *testing.T) {
 			expectedResponseBody: `{"error":"token ID not found in cookie: http: named cookie not present"}`,
 			expectedCookie:       "",
 		},
+		{
+			name: "reused token",
+			f: func(s *mock_handler.MockServiceI) {
+				s.EXPECT().RefreshToken(gomock.Any(), gomock.Any(), gomock.Any()).Return(dto.TokenOutput{}, domain.ErrTokenReused)
+			},
+			cookie: &http.Cookie{
+				Name:  refreshToken,
+				Value: "refresh-token-1234",
+			},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"error":"refresh token reused"}`,
+			expectedCookie:       "",
+		},
+		{
+			name: "disabled account",
+			f: func(s *mock_handler.MockServiceI) {
+				s.EXPECT().RefreshToken(gomock.Any(), gomock.Any(), gomock.Any()).Return(dto.TokenOutput{}, domain.ErrAccountDisabled)
+			},
+			cookie: &http.Cookie{
+				Name:  refreshToken,
+				Value: "refresh-token-1234",
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"error":"account disabled"}`,
+			expectedCookie:       "",
+		},
 		{
 			name: "service error",
 			f: func(s *mock_handler.MockServiceI) {
-				s.EXPECT().RefreshToken(gomock.Any(), gomock.Any()).Return(dto.TokenOutput{}, errors.New("service error"))
+				s.EXPECT().RefreshToken(gomock.Any(), gomock.Any(), gomock.Any()).Return(dto.TokenOutput{}, errors.New("service error"))
 			},
 			cookie: &http.Cookie{
 				Name:     refreshToken,
@@ -469,3 +532,186 @@ func Test_authH_refresh(t *testing.T) {
 		})
 	}
 }
+
+func Test_authH_jwks(t *testing.T) {
+	t.Parallel()
+
+	public, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	key, err := jwk.FromRaw(public)
+	require.NoError(t, err)
+	require.NoError(t, key.Set(jwk.KeyIDKey, "2024-06"))
+	require.NoError(t, key.Set(jwk.AlgorithmKey, "EdDSA"))
+
+	set := jwk.NewSet()
+	require.NoError(t, set.AddKey(key))
+
+	tests := []struct {
+		name                 string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "success",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().JWKS().Return(set)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponseBody: fmt.Sprintf(`{"keys":[{"alg":"EdDSA","crv":"Ed25519","kid":"2024-06","kty":"OKP","x":"%s"}]}`,
+				base64.RawURLEncoding.EncodeToString(public)),
+		},
+		{
+			name: "no keys",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().JWKS().Return(jwk.NewSet())
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"keys":[]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockAuthHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.GET("/.well-known/jwks.json", handler.jwks)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/.well-known/jwks.json", nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.JSONEq(t, tt.expectedResponseBody, w.Body.String())
+			assert.Equal(t, "public, max-age=300", w.Header().Get("Cache-Control"))
+		})
+	}
+}
+
+func Test_authH_signInTwoFactor(t *testing.T) {
+	t.Parallel()
+
+	token := dto.TokenOutput{
+		AccessToken:  "valid-access-token-1234",
+		RefreshToken: "valid-refresh-token-1234",
+	}
+
+	tests := []struct {
+		name                 string
+		inputBody            string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+		expectCookie         bool
+	}{
+		{
+			name:      "success",
+			inputBody: `{"challenge_token":"challenge-1234","code":"123456"}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().SignInTwoFactor(gomock.Any(), dto.TwoFactorSignIn{ChallengeToken: "challenge-1234", Code: "123456"}, gomock.Any()).Return(token, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: fmt.Sprintf(`{"%v":"%v"}`, accessToken, token.AccessToken),
+			expectCookie:         true,
+		},
+		{
+			name:                 "missing code",
+			inputBody:            `{"challenge_token":"challenge-1234"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"validation failed: Field: Code, Tag: required, Param: "}`,
+		},
+		{
+			name:                 "missing challenge",
+			inputBody:            `{"code":"123456"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"validation failed: Field: ChallengeToken, Tag: required, Param: "}`,
+		},
+		{
+			name:      "invalid code",
+			inputBody: `{"challenge_token":"challenge-1234","code":"000000"}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().SignInTwoFactor(gomock.Any(), gomock.Any(), gomock.Any()).Return(dto.TokenOutput{}, domain.ErrInvalidOTP)
+			},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"error":"invalid two-factor code"}`,
+		},
+		{
+			name:      "unknown challenge",
+			inputBody: `{"challenge_token":"challenge-1234","code":"123456"}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().SignInTwoFactor(gomock.Any(), gomock.Any(), gomock.Any()).Return(dto.TokenOutput{}, domain.ErrNotFound)
+			},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"error":"invalid or expired challenge"}`,
+		},
+		{
+			name:      "too many attempts",
+			inputBody: `{"challenge_token":"challenge-1234","code":"123456"}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().SignInTwoFactor(gomock.Any(), gomock.Any(), gomock.Any()).Return(dto.TokenOutput{}, &domain.LockoutError{RetryAfter: 30 * time.Second})
+			},
+			expectedStatusCode:   http.StatusTooManyRequests,
+			expectedResponseBody: `{"error":"too many sign-in attempts, try again later"}`,
+		},
+		{
+			name:      "disabled account",
+			inputBody: `{"challenge_token":"challenge-1234","code":"123456"}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().SignInTwoFactor(gomock.Any(), gomock.Any(), gomock.Any()).Return(dto.TokenOutput{}, domain.ErrAccountDisabled)
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"error":"account disabled"}`,
+		},
+		{
+			name:      "service error",
+			inputBody: `{"challenge_token":"challenge-1234","code":"123456"}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().SignInTwoFactor(gomock.Any(), gomock.Any(), gomock.Any()).Return(dto.TokenOutput{}, errors.New("service error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"service error"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockAuthHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.POST("/sign-in/2fa", handler.signInTwoFactor)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/sign-in/2fa", strings.NewReader(tt.inputBody))
+			req.Header.Set("Content-type", "application/json")
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, w.Body.String())
+
+			cookies := w.Result().Cookies()
+			if !tt.expectCookie {
+				assert.Empty(t, cookies)
+				return
+			}
+
+			require.NotEmpty(t, cookies)
+			assert.Equal(t, refreshToken, cookies[0].Name)
+			assert.Equal(t, token.RefreshToken, cookies[0].Value)
+			assert.Equal(t, int(handler.refreshTokenTTL.Seconds()), cookies[0].MaxAge)
+		})
+	}
+}
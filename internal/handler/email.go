@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+	"noteApp/pkg/valid"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type EmailSI interface {
+	SendVerificationEmail(ctx context.Context, userID uuid.UUID) error
+	VerifyEmail(ctx context.Context, data dto.EmailVerify) error
+	EmailVerified(ctx context.Context, userID uuid.UUID) error
+	ForgotPassword(ctx context.Context, data dto.ForgotPassword) error
+	ResetPassword(ctx context.Context, data dto.PasswordReset) error
+}
+
+type emailH struct {
+	service EmailSI
+	log     *logger.Logger
+}
+
+func newEmailHandler(service EmailSI, log *logger.Logger) *emailH {
+	return &emailH{
+		service: service,
+		log:     log,
+	}
+}
+
+func (h *Handler) InitEmailAPIs(api *gin.RouterGroup) {
+	h.log.Info("init email APIs")
+	auth := api.Group("/auth")
+	{
+		auth.POST("/verify-email", h.verifyEmail)
+		auth.POST("/forgot-password", h.forgotPassword)
+		auth.POST("/reset-password", h.resetPassword)
+	}
+
+	profile := api.Group("/profile", h.authMiddleware)
+	{
+		profile.POST("/verify-email", h.sendVerificationEmail)
+	}
+}
+
+func (e *emailH) sendVerificationEmail(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		e.log.Debug("unauthorized access attempt",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("method", c.Request.Method),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	if err := e.service.SendVerificationEmail(c.Request.Context(), userID); err != nil {
+		if errors.Is(err, domain.ErrEmailVerified) {
+			newErrorResponse(c, http.StatusConflict, err.Error())
+			return
+		}
+		e.log.Error("failed to send verification email",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	newSuccessResponse(c, http.StatusOK, "data", "ok")
+}
+
+func (e *emailH) verifyEmail(c *gin.Context) {
+	var data dto.EmailVerify
+	if !e.bind(c, &data) {
+		return
+	}
+
+	if err := e.service.VerifyEmail(c.Request.Context(), data); err != nil {
+		e.tokenError(c, err)
+		return
+	}
+
+	e.log.Info("email verified successfully",
+		zap.String("client_ip", c.ClientIP()),
+	)
+
+	newSuccessResponse(c, http.StatusOK, "data", "ok")
+}
+
+func (e *emailH) forgotPassword(c *gin.Context) {
+	var data dto.ForgotPassword
+	if !e.bind(c, &data) {
+		return
+	}
+
+	if err := e.service.ForgotPassword(c.Request.Context(), data); err != nil {
+		e.log.Error("forgot password request failed",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	newSuccessResponse(c, http.StatusOK, "data", "ok")
+}
+
+func (e *emailH) resetPassword(c *gin.Context) {
+	var data dto.PasswordReset
+	if !e.bind(c, &data) {
+		return
+	}
+
+	if err := e.service.ResetPassword(c.Request.Context(), data); err != nil {
+		e.tokenError(c, err)
+		return
+	}
+
+	e.log.Info("password reset successfully",
+		zap.String("client_ip", c.ClientIP()),
+	)
+
+	newSuccessResponse(c, http.StatusOK, "data", "ok")
+}
+
+func (e *emailH) bind(c *gin.Context, data any) bool {
+	if err := c.ShouldBindJSON(data); err != nil {
+		e.log.Debug("invalid JSON in email request",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("path", c.Request.URL.Path),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return false
+	}
+
+	if err := valid.ValidateStruct(data); err != nil {
+		e.log.Debug("validation failed for email request",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("path", c.Request.URL.Path),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return false
+	}
+
+	return true
+}
+
+func (e *emailH) tokenError(c *gin.Context, err error) {
+	if errors.Is(err, domain.ErrInvalidEmailToken) {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	e.log.Error("email token request failed",
+		zap.Error(err),
+		zap.String("client_ip", c.ClientIP()),
+		zap.String("path", c.Request.URL.Path),
+	)
+	newErrorResponse(c, http.StatusInternalServerError, err.Error())
+}
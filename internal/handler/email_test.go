@@ -0,0 +1,299 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	mock_handler "noteApp/internal/handler/mock"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func mockEmailHandler(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock_handler.MockServiceI)) *Handler {
+	t.Helper()
+
+	service := mock_handler.NewMockServiceI(ctrl)
+
+	if setupMock != nil {
+		setupMock(service)
+	}
+
+	return &Handler{
+		emailH: newEmailHandler(service, logger.LoggerForTest()),
+	}
+}
+
+func Test_emailH_sendVerificationEmail(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+
+	tests := []struct {
+		name                 string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "success",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().SendVerificationEmail(gomock.Any(), userID).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"data":"ok"}`,
+		},
+		{
+			name: "already verified",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().SendVerificationEmail(gomock.Any(), userID).Return(domain.ErrEmailVerified)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"error":"email already verified"}`,
+		},
+		{
+			name: "service error",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().SendVerificationEmail(gomock.Any(), userID).Return(errors.New("service error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"service error"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockEmailHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.POST("/profile/verify-email", func(c *gin.Context) {
+				c.Set(userIDKey, userID.String())
+				handler.sendVerificationEmail(c)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/profile/verify-email", nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func Test_emailH_verifyEmail(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                 string
+		inputBody            string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "success",
+			inputBody: `{"token":"verify-token"}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().VerifyEmail(gomock.Any(), dto.EmailVerify{Token: "verify-token"}).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"data":"ok"}`,
+		},
+		{
+			name:                 "missing token",
+			inputBody:            `{}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"validation failed: Field: Token, Tag: required, Param: "}`,
+		},
+		{
+			name:      "invalid token",
+			inputBody: `{"token":"verify-token"}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().VerifyEmail(gomock.Any(), gomock.Any()).Return(domain.ErrInvalidEmailToken)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"invalid or expired token"}`,
+		},
+		{
+			name:      "service error",
+			inputBody: `{"token":"verify-token"}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().VerifyEmail(gomock.Any(), gomock.Any()).Return(errors.New("service error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"service error"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockEmailHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.POST("/auth/verify-email", handler.verifyEmail)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/auth/verify-email", strings.NewReader(tt.inputBody))
+			req.Header.Set("Content-type", "application/json")
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func Test_emailH_forgotPassword(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                 string
+		inputBody            string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "success",
+			inputBody: `{"email":"alice@example.com"}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().ForgotPassword(gomock.Any(), dto.ForgotPassword{Email: "alice@example.com"}).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"data":"ok"}`,
+		},
+		{
+			name:                 "invalid email",
+			inputBody:            `{"email":"alice"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"validation failed: Field: Email, Tag: email, Param: "}`,
+		},
+		{
+			name:                 "empty body",
+			inputBody:            ``,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"EOF"}`,
+		},
+		{
+			name:      "service error",
+			inputBody: `{"email":"alice@example.com"}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().ForgotPassword(gomock.Any(), gomock.Any()).Return(errors.New("service error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"service error"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockEmailHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.POST("/auth/forgot-password", handler.forgotPassword)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/auth/forgot-password", strings.NewReader(tt.inputBody))
+			req.Header.Set("Content-type", "application/json")
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func Test_emailH_resetPassword(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                 string
+		inputBody            string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "success",
+			inputBody: `{"token":"reset-token","password":"new_password"}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().ResetPassword(gomock.Any(), dto.PasswordReset{Token: "reset-token", Password: "new_password"}).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"data":"ok"}`,
+		},
+		{
+			name:                 "short password",
+			inputBody:            `{"token":"reset-token","password":"short"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"validation failed: Field: Password, Tag: min, Param: 8"}`,
+		},
+		{
+			name:      "invalid token",
+			inputBody: `{"token":"reset-token","password":"new_password"}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().ResetPassword(gomock.Any(), gomock.Any()).Return(domain.ErrInvalidEmailToken)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"invalid or expired token"}`,
+		},
+		{
+			name:      "service error",
+			inputBody: `{"token":"reset-token","password":"new_password"}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().ResetPassword(gomock.Any(), gomock.Any()).Return(errors.New("service error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"service error"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockEmailHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.POST("/auth/reset-password", handler.resetPassword)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/auth/reset-password", strings.NewReader(tt.inputBody))
+			req.Header.Set("Content-type", "application/json")
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	lastEventIDHeader    = "Last-Event-ID"
+	sseHeartbeatInterval = 15 * time.Second
+)
+
+type EventSI interface {
+	NoteEventStream(ctx context.Context, userID uuid.UUID, lastEventID *int64) (<-chan dto.NoteEventOutput, error)
+}
+
+type eventH struct {
+	service   EventSI
+	heartbeat time.Duration
+	log       *logger.Logger
+}
+
+func newEventHandler(service EventSI, log *logger.Logger) *eventH {
+	return &eventH{
+		service:   service,
+		heartbeat: sseHeartbeatInterval,
+		log:       log,
+	}
+}
+
+func (h *Handler) InitEventAPIs(api *gin.RouterGroup) {
+	h.log.Info("init events APIs")
+	event := api.Group("/events", h.queryAccessToken, h.requireScope(domain.ScopeNotesRead, domain.ScopeNotesWrite), h.authMiddleware)
+	{
+		event.GET("", h.noteEvents)
+	}
+}
+
+func (e *eventH) noteEvents(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		e.log.Debug("unauthorized access attempt",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("method", c.Request.Method),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	lastEventID, err := getLastEventID(c)
+	if err != nil {
+		e.log.Debug("invalid Last-Event-ID",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+
+	events, err := e.service.NoteEventStream(ctx, userID, lastEventID)
+	if err != nil {
+		e.log.Error("failed to open note event stream",
+			zap.Error(err),
+			zap.String("request_id", c.GetString(requestContext)),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if err := http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		e.log.Warn("failed to clear write deadline for note event stream",
+			zap.Error(err),
+			zap.String("request_id", c.GetString(requestContext)),
+		)
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	e.log.Info("note event stream opened",
+		zap.String("request_id", c.GetString(requestContext)),
+		zap.String("client_ip", c.ClientIP()),
+		zap.String("user_id", userID.String()),
+		zap.Int64p("last_event_id", lastEventID),
+	)
+
+	ticker := time.NewTicker(e.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+
+			data, err := json.Marshal(event)
+			if err != nil {
+				e.log.Error("failed to marshal note event", zap.Error(err))
+				continue
+			}
+
+			if _, err := fmt.Fprintf(c.Writer, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if _, err := fmt.Fprint(c.Writer, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+		c.Writer.Flush()
+	}
+}
+
+func getLastEventID(c *gin.Context) (*int64, error) {
+	value := c.GetHeader(lastEventIDHeader)
+	if value == "" {
+		value = c.Query("last_event_id")
+	}
+
+	if value == "" {
+		return nil, nil
+	}
+
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || id < 0 {
+		return nil, fmt.Errorf("invalid %s: %q", lastEventIDHeader, value)
+	}
+
+	return &id, nil
+}
@@ -0,0 +1,190 @@
+package handler
+
+import (
+	"bufio"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	mock_handler "noteApp/internal/handler/mock"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockEventHandler(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock_handler.MockServiceI)) *Handler {
+	t.Helper()
+
+	service := mock_handler.NewMockServiceI(ctrl)
+
+	if setupMock != nil {
+		setupMock(service)
+	}
+
+	return &Handler{
+		eventH: newEventHandler(service, logger.LoggerForTest()),
+	}
+}
+
+func Test_eventH_noteEvents(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	noteID := uuid.New()
+
+	newServer := func(t *testing.T, handler *Handler) *httptest.Server {
+		t.Helper()
+
+		gin.SetMode(gin.TestMode)
+		r := gin.New()
+		r.GET("/events", func(c *gin.Context) {
+			c.Set(userIDKey, userID.String())
+			handler.noteEvents(c)
+		})
+
+		srv := httptest.NewServer(r)
+		t.Cleanup(srv.Close)
+
+		return srv
+	}
+
+	readFrame := func(t *testing.T, r *bufio.Reader) string {
+		t.Helper()
+
+		var frame strings.Builder
+		for {
+			line, err := r.ReadString('\n')
+			require.NoError(t, err)
+			if line == "\n" {
+				return frame.String()
+			}
+			frame.WriteString(line)
+		}
+	}
+
+	t.Run("resumes from Last-Event-ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		events := make(chan dto.NoteEventOutput, 1)
+		events <- dto.NoteEventOutput{ID: 3, Type: "updated", NoteID: noteID, UserID: userID}
+
+		handler := mockEventHandler(t, ctrl, func(msi *mock_handler.MockServiceI) {
+			msi.EXPECT().NoteEventStream(gomock.Any(), userID, &[]int64{2}[0]).Return(events, nil)
+		})
+
+		srv := newServer(t, handler)
+
+		req, err := http.NewRequest("GET", srv.URL+"/events", nil)
+		require.NoError(t, err)
+		req.Header.Set(lastEventIDHeader, "2")
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+		frame := readFrame(t, bufio.NewReader(resp.Body))
+		assert.Equal(t, "id: 3\nevent: updated\ndata: "+
+			`{"id":3,"type":"updated","note_id":"`+noteID.String()+`","user_id":"`+userID.String()+`","occurred_at":"0001-01-01T00:00:00Z"}`+"\n", frame)
+	})
+
+	t.Run("sends heartbeats", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		handler := mockEventHandler(t, ctrl, func(msi *mock_handler.MockServiceI) {
+			msi.EXPECT().NoteEventStream(gomock.Any(), userID, (*int64)(nil)).Return(make(chan dto.NoteEventOutput), nil)
+		})
+		handler.eventH.heartbeat = 10 * time.Millisecond
+
+		srv := newServer(t, handler)
+
+		resp, err := http.Get(srv.URL + "/events")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, ": heartbeat\n", readFrame(t, bufio.NewReader(resp.Body)))
+	})
+
+	t.Run("outlives server write timeout", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		handler := mockEventHandler(t, ctrl, func(msi *mock_handler.MockServiceI) {
+			msi.EXPECT().NoteEventStream(gomock.Any(), userID, (*int64)(nil)).Return(make(chan dto.NoteEventOutput), nil)
+		})
+		handler.eventH.heartbeat = 20 * time.Millisecond
+
+		gin.SetMode(gin.TestMode)
+		r := gin.New()
+		r.GET("/events", func(c *gin.Context) {
+			c.Set(userIDKey, userID.String())
+			handler.noteEvents(c)
+		})
+
+		srv := httptest.NewUnstartedServer(r)
+		srv.Config.WriteTimeout = 100 * time.Millisecond
+		srv.Start()
+		t.Cleanup(srv.Close)
+
+		resp, err := http.Get(srv.URL + "/events")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		body := bufio.NewReader(resp.Body)
+		deadline := time.Now().Add(3 * srv.Config.WriteTimeout)
+		for time.Now().Before(deadline) {
+			assert.Equal(t, ": heartbeat\n", readFrame(t, body))
+		}
+	})
+
+	t.Run("invalid Last-Event-ID", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		srv := newServer(t, mockEventHandler(t, ctrl, nil))
+
+		resp, err := http.Get(srv.URL + "/events?last_event_id=abc")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		handler := mockEventHandler(t, ctrl, func(msi *mock_handler.MockServiceI) {
+			msi.EXPECT().NoteEventStream(gomock.Any(), userID, (*int64)(nil)).Return(nil, errors.New("db error"))
+		})
+
+		srv := newServer(t, handler)
+
+		resp, err := http.Get(srv.URL + "/events")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	})
+}
@@ -6,27 +6,69 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 )
 
 type ServiceI interface {
+	AdminSI
 	AuthSI
+	EmailSI
+	EventSI
+	LinkSI
 	NoteSI
+	NotebookSI
+	PersonalTokenSI
+	RevisionSI
+	SessionSI
+	ShareSI
+	SyncSI
+	TagSI
+	TrashSI
+	TwoFactorSI
 	UserSI
 }
 
 type Handler struct {
+	*adminH
 	*authH
+	*emailH
+	*eventH
+	*linkH
 	*noteH
+	*notebookH
+	*personalTokenH
+	*revisionH
+	*sessionH
+	*shareH
+	*syncH
+	*tagH
+	*trashH
+	*twoFactorH
 	*userH
-	log *logger.Logger
+	trustedProxies []string
+	log            *logger.Logger
 }
 
-func NewHandler(service ServiceI, log *logger.Logger, refreshTokenTTL time.Duration) *Handler {
+func NewHandler(service ServiceI, log *logger.Logger, refreshTokenTTL time.Duration, allowedOrigins, trustedProxies []string) *Handler {
 	return &Handler{
-		authH: newAuthHandler(service, refreshTokenTTL, log),
-		noteH: newNoteHandler(service, log),
-		userH: newUserHandler(service, log),
-		log:   log,
+		adminH:         newAdminHandler(service, log),
+		authH:          newAuthHandler(service, refreshTokenTTL, log),
+		emailH:         newEmailHandler(service, log),
+		eventH:         newEventHandler(service, log),
+		linkH:          newLinkHandler(service, log),
+		noteH:          newNoteHandler(service, allowedOrigins, log),
+		notebookH:      newNotebookHandler(service, log),
+		personalTokenH: newPersonalTokenHandler(service, log),
+		revisionH:      newRevisionHandler(service, log),
+		sessionH:       newSessionHandler(service, log),
+		shareH:         newShareHandler(service, log),
+		syncH:          newSyncHandler(service, log),
+		tagH:           newTagHandler(service, log),
+		trashH:         newTrashHandler(service, log),
+		twoFactorH:     newTwoFactorHandler(service, log),
+		userH:          newUserHandler(service, log),
+		trustedProxies: trustedProxies,
+		log:            log,
 	}
 }
 
@@ -34,6 +76,10 @@ func (h *Handler) Init() *gin.Engine {
 	gin.SetMode(gin.ReleaseMode)
 
 	router := gin.New()
+	if err := router.SetTrustedProxies(h.trustedProxies); err != nil {
+		h.log.Error("invalid trusted proxies, using remote address", zap.Error(err))
+		_ = router.SetTrustedProxies(nil)
+	}
 	router.Use(
 		h.requestID(),
 		h.logging(),
@@ -46,11 +92,26 @@ func (h *Handler) Init() *gin.Engine {
 
 func (h *Handler) initAPIs(router *gin.Engine) {
 	router.GET("/ping", h.ping)
+	router.GET("/.well-known/jwks.json", h.jwks)
+	h.InitPublicAPIs(router.Group("/public"))
 	api := router.Group("/api")
 	{
 		api.GET("/home", h.home)
+		h.InitAdminAPIs(api)
 		h.InitAuthAPIs(api)
+		h.InitEmailAPIs(api)
+		h.InitEventAPIs(api)
+		h.InitLinkAPIs(api)
 		h.InitNoteAPIs(api)
+		h.InitNotebookAPIs(api)
+		h.InitPersonalTokenAPIs(api)
+		h.InitRevisionAPIs(api)
+		h.InitSessionAPIs(api)
+		h.InitShareAPIs(api)
+		h.InitSyncAPIs(api)
+		h.InitTagAPIs(api)
+		h.InitTrashAPIs(api)
+		h.InitTwoFactorAPIs(api)
 		h.InitUserAPIs(api)
 	}
 }
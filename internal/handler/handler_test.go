@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	mock_handler "noteApp/internal/handler/mock"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_InitClientIP(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		trustedProxies []string
+		wantIP         string
+	}{
+		{
+			name:   "no trusted proxies ignores forwarded header",
+			wantIP: "192.0.2.1",
+		},
+		{
+			name:           "untrusted proxy ignores forwarded header",
+			trustedProxies: []string{"10.0.0.0/8"},
+			wantIP:         "192.0.2.1",
+		},
+		{
+			name:           "trusted proxy uses forwarded header",
+			trustedProxies: []string{"192.0.2.0/24"},
+			wantIP:         "203.0.113.7",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mock_handler.NewMockServiceI(ctrl)
+			service.EXPECT().SignIn(gomock.Any(), gomock.Any(), dto.Device{IP: tt.wantIP}).Return(dto.TokenOutput{AccessToken: "access"}, nil)
+
+			refreshTokenTTL, err := getRefreshTokenTTL()
+			require.NoError(t, err)
+
+			r := NewHandler(service, logger.LoggerForTest(), refreshTokenTTL, nil, tt.trustedProxies).Init()
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/api/auth/sign-in", strings.NewReader(`{"email":"test_email@gmail.com","password":"qwerty123"}`))
+			req.Header.Set("Content-type", "application/json")
+			req.Header.Set("X-Forwarded-For", "203.0.113.7")
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+	"noteApp/pkg/valid"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const linkPasswordHeader = "X-Link-Password"
+
+type LinkSI interface {
+	CreateNoteLink(ctx context.Context, link dto.NoteLinkCreate) (dto.NoteLinkOutput, error)
+	NoteLinks(ctx context.Context, userID, noteID uuid.UUID) ([]dto.NoteLinkOutput, error)
+	DeleteNoteLink(ctx context.Context, userID, noteID, linkID uuid.UUID) error
+	PublicNote(ctx context.Context, token, password string) (dto.PublicNoteOutput, error)
+}
+
+type linkH struct {
+	service LinkSI
+	log     *logger.Logger
+}
+
+func newLinkHandler(service LinkSI, log *logger.Logger) *linkH {
+	return &linkH{
+		service: service,
+		log:     log,
+	}
+}
+
+func (h *Handler) InitLinkAPIs(api *gin.RouterGroup) {
+	h.log.Info("init links APIs")
+	link := api.Group("/notes/:note_id/links", h.requireScope(domain.ScopeNotesRead, domain.ScopeNotesWrite), h.authMiddleware)
+	{
+		link.POST("/", h.requireVerified, h.createNoteLink)
+		link.GET("/", h.noteLinks)
+		link.DELETE("/:link_id", h.deleteNoteLink)
+	}
+}
+
+func (h *Handler) InitPublicAPIs(public *gin.RouterGroup) {
+	h.log.Info("init public APIs")
+	public.GET("/notes/:token", h.publicNote)
+}
+
+func (l *linkH) createNoteLink(c *gin.Context) {
+	userID, noteID, ok := l.noteParams(c)
+	if !ok {
+		return
+	}
+
+	var link dto.NoteLinkCreate
+	if err := c.ShouldBindJSON(&link); err != nil {
+		l.log.Debug("invalid JSON in create note link request",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	link.NoteID = noteID
+	link.UserID = userID
+
+	if err := valid.ValidateStruct(link); err != nil {
+		l.log.Debug("validation failed for create note link",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	output, err := l.service.CreateNoteLink(c.Request.Context(), link)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			newErrorResponse(c, http.StatusNotFound, err.Error())
+			return
+		}
+		l.log.Error("failed to create note link",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.String("note_id", noteID.String()),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	l.log.Info("note link created successfully",
+		zap.String("client_ip", c.ClientIP()),
+		zap.String("user_id", userID.String()),
+		zap.String("note_id", noteID.String()),
+		zap.String("link_id", output.ID.String()),
+	)
+
+	newSuccessResponse(c, http.StatusCreated, "link", output)
+}
+
+func (l *linkH) noteLinks(c *gin.Context) {
+	userID, noteID, ok := l.noteParams(c)
+	if !ok {
+		return
+	}
+
+	links, err := l.service.NoteLinks(c.Request.Context(), userID, noteID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			newErrorResponse(c, http.StatusNotFound, err.Error())
+			return
+		}
+		l.log.Error("failed to get note links",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.String("note_id", noteID.String()),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	newSuccessResponse(c, http.StatusOK, "links", links)
+}
+
+func (l *linkH) deleteNoteLink(c *gin.Context) {
+	userID, noteID, ok := l.noteParams(c)
+	if !ok {
+		return
+	}
+
+	linkID, err := getParamUUID(c, "link_id")
+	if err != nil {
+		l.log.Debug("invalid link_id in URL",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.String("param_value", c.Param("link_id")),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := l.service.DeleteNoteLink(c.Request.Context(), userID, noteID, linkID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			newErrorResponse(c, http.StatusNotFound, err.Error())
+			return
+		}
+		l.log.Error("failed to delete note link",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.String("note_id", noteID.String()),
+			zap.String("link_id", linkID.String()),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	l.log.Info("note link revoked successfully",
+		zap.String("client_ip", c.ClientIP()),
+		zap.String("user_id", userID.String()),
+		zap.String("note_id", noteID.String()),
+		zap.String("link_id", linkID.String()),
+	)
+
+	newSuccessResponse(c, http.StatusOK, "data", "ok")
+}
+
+func (l *linkH) publicNote(c *gin.Context) {
+	token := c.Param("token")
+
+	note, err := l.service.PublicNote(c.Request.Context(), token, c.GetHeader(linkPasswordHeader))
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			newErrorResponse(c, http.StatusNotFound, "note not found")
+		case errors.Is(err, domain.ErrIncorrectPassword):
+			newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		default:
+			l.log.Error("failed to get public note",
+				zap.Error(err),
+				zap.String("client_ip", c.ClientIP()),
+			)
+			newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	newSuccessResponse(c, http.StatusOK, "note", note)
+}
+
+func (l *linkH) noteParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	userID, err := getUserID(c)
+	if err != nil {
+		l.log.Debug("unauthorized access attempt",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("method", c.Request.Method),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	noteID, err := getParamUUID(c, "note_id")
+	if err != nil {
+		l.log.Debug("invalid note_id in URL",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.String("param_value", c.Param("note_id")),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return userID, noteID, true
+}
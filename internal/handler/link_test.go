@@ -0,0 +1,309 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	mock_handler "noteApp/internal/handler/mock"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func mockLinkHandler(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock_handler.MockServiceI)) *Handler {
+	t.Helper()
+
+	service := mock_handler.NewMockServiceI(ctrl)
+
+	if setupMock != nil {
+		setupMock(service)
+	}
+
+	return &Handler{
+		linkH: newLinkHandler(service, logger.LoggerForTest()),
+	}
+}
+
+func Test_linkH_createNoteLink(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	noteID := uuid.New()
+	linkID := uuid.New()
+
+	tests := []struct {
+		name                 string
+		param                string
+		body                 string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "success",
+			param: noteID.String(),
+			body:  `{"password":"secret"}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().CreateNoteLink(gomock.Any(), dto.NoteLinkCreate{
+					NoteID:   noteID,
+					UserID:   userID,
+					Password: "secret",
+				}).Return(dto.NoteLinkOutput{ID: linkID, Token: "token", HasPassword: true}, nil)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: `{"link":{"id":"` + linkID.String() + `","token":"token","has_password":true,"expires_at":null,"created_at":"0001-01-01T00:00:00Z"}}`,
+		},
+		{
+			name:                 "short password",
+			param:                noteID.String(),
+			body:                 `{"password":"abc"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"validation failed: Field: Password, Tag: min, Param: 4"}`,
+		},
+		{
+			name:                 "expiry in the past",
+			param:                noteID.String(),
+			body:                 `{"expires_at":"2000-01-01T00:00:00Z"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"validation failed: Field: ExpiresAt, Tag: gt, Param: "}`,
+		},
+		{
+			name:                 "invalid note_id param",
+			param:                "123",
+			body:                 `{}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"note_id is not uuid"}`,
+		},
+		{
+			name:  "note not found",
+			param: noteID.String(),
+			body:  `{}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().CreateNoteLink(gomock.Any(), gomock.Any()).Return(dto.NoteLinkOutput{}, domain.ErrNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"not found"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockLinkHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.POST("/notes/:note_id/links", func(c *gin.Context) {
+				c.Set(userIDKey, userID.String())
+				handler.createNoteLink(c)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/notes/"+tt.param+"/links", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func Test_linkH_noteLinks(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	noteID := uuid.New()
+	linkID := uuid.New()
+
+	tests := []struct {
+		name                 string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "success",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().NoteLinks(gomock.Any(), userID, noteID).
+					Return([]dto.NoteLinkOutput{{ID: linkID}}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"links":[{"id":"` + linkID.String() + `","has_password":false,"expires_at":null,"created_at":"0001-01-01T00:00:00Z"}]}`,
+		},
+		{
+			name: "note not found",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().NoteLinks(gomock.Any(), userID, noteID).Return(nil, domain.ErrNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"not found"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockLinkHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.GET("/notes/:note_id/links", func(c *gin.Context) {
+				c.Set(userIDKey, userID.String())
+				handler.noteLinks(c)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/notes/"+noteID.String()+"/links", nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func Test_linkH_deleteNoteLink(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	noteID := uuid.New()
+	linkID := uuid.New()
+
+	tests := []struct {
+		name                 string
+		param                string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "success",
+			param: linkID.String(),
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().DeleteNoteLink(gomock.Any(), userID, noteID, linkID).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"data":"ok"}`,
+		},
+		{
+			name:                 "invalid link_id param",
+			param:                "123",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"link_id is not uuid"}`,
+		},
+		{
+			name:  "link not found",
+			param: linkID.String(),
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().DeleteNoteLink(gomock.Any(), userID, noteID, linkID).Return(domain.ErrNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"not found"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockLinkHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.DELETE("/notes/:note_id/links/:link_id", func(c *gin.Context) {
+				c.Set(userIDKey, userID.String())
+				handler.deleteNoteLink(c)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", "/notes/"+noteID.String()+"/links/"+tt.param, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func Test_linkH_publicNote(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                 string
+		password             string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:     "success",
+			password: "secret",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().PublicNote(gomock.Any(), "token", "secret").
+					Return(dto.PublicNoteOutput{Heading: "heading", Content: "content", Tags: []string{"work"}}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"note":{"heading":"heading","content":"content","done":false,"tags":["work"],"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"}}`,
+		},
+		{
+			name: "incorrect password",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().PublicNote(gomock.Any(), "token", "").Return(dto.PublicNoteOutput{}, domain.ErrIncorrectPassword)
+			},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"error":"incorrect password"}`,
+		},
+		{
+			name: "link not found or expired",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().PublicNote(gomock.Any(), "token", "").
+					Return(dto.PublicNoteOutput{}, domain.MakeError(domain.ErrReceiving, domain.ErrNotFound, "note link"))
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"note not found"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockLinkHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.GET("/public/notes/:token", handler.publicNote)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/public/notes/token", nil)
+			if tt.password != "" {
+				req.Header.Set(linkPasswordHeader, tt.password)
+			}
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
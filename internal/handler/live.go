@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"noteApp/internal/models/domain"
+	"slices"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"golang.org/x/net/websocket"
+)
+
+func (n *noteH) noteLive(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		n.log.Debug("unauthorized access attempt",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("method", c.Request.Method),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	noteID, err := getParamUUID(c, "note_id")
+	if err != nil {
+		n.log.Debug("invalid note_id in URL",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.String("param_value", c.Param("note_id")),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+
+	events, err := n.service.NoteEvents(ctx, userID, noteID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			newErrorResponse(c, http.StatusNotFound, err.Error())
+			return
+		}
+		n.log.Error("failed to subscribe to note events",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.String("note_id", noteID.String()),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	server := websocket.Server{
+		Handshake: n.checkOrigin,
+		Handler: func(ws *websocket.Conn) {
+			if err := ws.SetDeadline(time.Time{}); err != nil {
+				n.log.Warn("failed to clear deadlines for live note connection",
+					zap.Error(err),
+					zap.String("user_id", userID.String()),
+				)
+			}
+
+			go func() {
+				defer cancel()
+				var msg string
+				for {
+					if err := websocket.Message.Receive(ws, &msg); err != nil {
+						return
+					}
+				}
+			}()
+
+			for payload := range events {
+				if err := websocket.Message.Send(ws, string(payload)); err != nil {
+					n.log.Debug("failed to send note event",
+						zap.Error(err),
+						zap.String("user_id", userID.String()),
+						zap.String("note_id", noteID.String()),
+					)
+					return
+				}
+			}
+		},
+	}
+
+	n.log.Info("live note connection opened",
+		zap.String("client_ip", c.ClientIP()),
+		zap.String("user_id", userID.String()),
+		zap.String("note_id", noteID.String()),
+	)
+
+	server.ServeHTTP(c.Writer, c.Request)
+
+	n.log.Info("live note connection closed",
+		zap.String("client_ip", c.ClientIP()),
+		zap.String("user_id", userID.String()),
+		zap.String("note_id", noteID.String()),
+	)
+}
+
+func (n *noteH) checkOrigin(config *websocket.Config, req *http.Request) error {
+	origin, err := websocket.Origin(config, req)
+	if err != nil {
+		return err
+	}
+
+	if origin == nil {
+		return nil
+	}
+
+	if origin.Host != req.Host && !slices.Contains(n.origins, origin.Scheme+"://"+origin.Host) {
+		n.log.Warn("live note connection rejected: origin not allowed",
+			zap.String("origin", origin.String()),
+			zap.String("client_ip", req.RemoteAddr),
+		)
+		return errors.New("origin not allowed")
+	}
+
+	config.Origin = origin
+	return nil
+}
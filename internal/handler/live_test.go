@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	mock_handler "noteApp/internal/handler/mock"
+	"noteApp/internal/models/domain"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+)
+
+func Test_noteH_noteLive(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	noteID := uuid.New()
+
+	newServer := func(t *testing.T, handler *Handler) *httptest.Server {
+		t.Helper()
+
+		gin.SetMode(gin.TestMode)
+		r := gin.New()
+		r.GET("/notes/:note_id/live", func(c *gin.Context) {
+			c.Set(userIDKey, userID.String())
+			handler.noteLive(c)
+		})
+
+		srv := httptest.NewServer(r)
+		t.Cleanup(srv.Close)
+
+		return srv
+	}
+
+	t.Run("streams events", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		events := make(chan []byte, 1)
+		done := make(chan struct{})
+
+		handler := mockNoteHandler(t, ctrl, func(msi *mock_handler.MockServiceI) {
+			msi.EXPECT().NoteEvents(gomock.Any(), userID, noteID).DoAndReturn(
+				func(ctx context.Context, _, _ uuid.UUID) (<-chan []byte, error) {
+					go func() {
+						<-ctx.Done()
+						close(events)
+						close(done)
+					}()
+					return events, nil
+				})
+		})
+
+		srv := newServer(t, handler)
+
+		ws, err := websocket.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/notes/"+noteID.String()+"/live", "", srv.URL)
+		require.NoError(t, err)
+
+		events <- []byte(`{"type":"updated"}`)
+
+		var msg string
+		require.NoError(t, websocket.Message.Receive(ws, &msg))
+		assert.Equal(t, `{"type":"updated"}`, msg)
+
+		require.NoError(t, ws.Close())
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("subscription was not cancelled after client disconnect")
+		}
+	})
+
+	t.Run("origin check", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name    string
+			origin  string
+			wantErr bool
+		}{
+			{name: "same origin"},
+			{name: "allowed origin", origin: "https://app.example.com"},
+			{name: "foreign origin", origin: "https://evil.example.com", wantErr: true},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				handler := mockNoteHandler(t, ctrl, func(msi *mock_handler.MockServiceI) {
+					msi.EXPECT().NoteEvents(gomock.Any(), userID, noteID).Return(make(chan []byte), nil)
+				})
+				handler.noteH.origins = []string{"https://app.example.com"}
+
+				srv := newServer(t, handler)
+
+				origin := tt.origin
+				if origin == "" {
+					origin = srv.URL
+				}
+
+				ws, err := websocket.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/notes/"+noteID.String()+"/live", "", origin)
+				if tt.wantErr {
+					require.Error(t, err)
+					return
+				}
+
+				require.NoError(t, err)
+				require.NoError(t, ws.Close())
+			})
+		}
+	})
+
+	t.Run("outlives server timeouts", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		events := make(chan []byte, 1)
+
+		handler := mockNoteHandler(t, ctrl, func(msi *mock_handler.MockServiceI) {
+			msi.EXPECT().NoteEvents(gomock.Any(), userID, noteID).Return(events, nil)
+		})
+
+		gin.SetMode(gin.TestMode)
+		r := gin.New()
+		r.GET("/notes/:note_id/live", func(c *gin.Context) {
+			c.Set(userIDKey, userID.String())
+			handler.noteLive(c)
+		})
+
+		srv := httptest.NewUnstartedServer(r)
+		srv.Config.ReadTimeout = 100 * time.Millisecond
+		srv.Config.WriteTimeout = 100 * time.Millisecond
+		srv.Start()
+		t.Cleanup(srv.Close)
+
+		ws, err := websocket.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/notes/"+noteID.String()+"/live", "", srv.URL)
+		require.NoError(t, err)
+		defer ws.Close()
+
+		time.Sleep(3 * srv.Config.WriteTimeout)
+		events <- []byte(`{"type":"updated"}`)
+
+		var msg string
+		require.NoError(t, websocket.Message.Receive(ws, &msg))
+		assert.Equal(t, `{"type":"updated"}`, msg)
+	})
+
+	t.Run("note not found", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		handler := mockNoteHandler(t, ctrl, func(msi *mock_handler.MockServiceI) {
+			msi.EXPECT().NoteEvents(gomock.Any(), userID, noteID).Return(nil, domain.ErrNotFound)
+		})
+
+		srv := newServer(t, handler)
+
+		resp, err := http.Get(srv.URL + "/notes/" + noteID.String() + "/live")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("invalid note_id param", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		srv := newServer(t, mockNoteHandler(t, ctrl, nil))
+
+		resp, err := http.Get(srv.URL + "/notes/123/live")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
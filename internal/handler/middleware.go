@@ -4,6 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"slices"
 	"strings"
 	"time"
 
@@ -15,13 +19,19 @@ import (
 const (
 	userIDKey      = "user_id"
 	roleKey        = "role"
+	scopeKey       = "scope"
 	adminKey       = "admin"
 	userKey        = "user"
 	refreshToken   = "refresh_token"
 	accessToken    = "access_token"
+	challengeToken = "challenge_token"
 	authHeader     = "Authorization"
+	retryHeader    = "Retry-After"
 	requestHeader  = "X-Request-ID"
 	requestContext = "request_id"
+	redactedValue  = "REDACTED"
+
+	maxUserAgentLength = 512
 )
 
 func (h *Handler) logging() gin.HandlerFunc {
@@ -50,16 +60,34 @@ func (h *Handler) logging() gin.HandlerFunc {
 		logFunc(msg,
 			zap.String("request_id", c.GetString("request_id")),
 			zap.String("method", c.Request.Method),
-			zap.String("path", c.Request.URL.Path),
+			zap.String("path", logPath(c)),
 			zap.Int("status", status),
 			zap.Duration("duration", duration),
 			zap.String("client_ip", c.ClientIP()),
 			zap.String("user_agent", c.Request.UserAgent()),
-			zap.String("query", c.Request.URL.RawQuery),
+			zap.String("query", redactQuery(c.Request.URL)),
 		)
 	}
 }
 
+func logPath(c *gin.Context) string {
+	if c.Param("token") != "" {
+		return c.FullPath()
+	}
+
+	return c.Request.URL.Path
+}
+
+func redactQuery(u *url.URL) string {
+	query := u.Query()
+	if !query.Has(accessToken) {
+		return u.RawQuery
+	}
+
+	query.Set(accessToken, redactedValue)
+	return query.Encode()
+}
+
 func (h *Handler) requestID() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		requestID := c.GetHeader(requestHeader)
@@ -73,6 +101,11 @@ func (h *Handler) requestID() gin.HandlerFunc {
 }
 
 func (h *Handler) authMiddleware(c *gin.Context) {
+	if token, err := getAccessToken(c); err == nil && strings.HasPrefix(token, domain.PersonalTokenPrefix) {
+		h.personalTokenAuth(c, token)
+		return
+	}
+
 	_, err := getRefreshToken(c)
 	if err != nil {
 		c.Redirect(http.StatusSeeOther, "/api/auth/login")
@@ -87,14 +120,129 @@ func (h *Handler) authMiddleware(c *gin.Context) {
 		return
 	}
 
-	userID, err := h.authH.service.ParseToken(c.Request.Context(), accessToken)
+	claims, err := h.authH.service.ParseToken(c.Request.Context(), accessToken)
 	if err != nil {
+		if errors.Is(err, domain.ErrAccountDisabled) {
+			h.log.Warn("access denied: account disabled",
+				zap.String("client_ip", c.ClientIP()),
+				zap.String("path", c.Request.URL.Path),
+				zap.String("method", c.Request.Method),
+			)
+			newErrorResponse(c, http.StatusForbidden, err.Error())
+			return
+		}
 		c.Redirect(http.StatusSeeOther, "/api/auth/login")
 		c.Abort()
 		return
 	}
 
-	c.Set(userIDKey, userID)
+	c.Set(userIDKey, claims.UserID.String())
+	c.Set(roleKey, claims.Role)
+
+	c.Next()
+}
+
+func (h *Handler) personalTokenAuth(c *gin.Context, token string) {
+	scope := c.GetString(scopeKey)
+	if scope == "" {
+		h.log.Debug("personal access token used on unscoped route",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("method", c.Request.Method),
+		)
+		newErrorResponse(c, http.StatusForbidden, "personal access tokens are not allowed for this resource")
+		return
+	}
+
+	claims, err := h.personalTokenH.service.AuthenticatePersonalToken(c.Request.Context(), token)
+	if err != nil {
+		newErrorResponse(c, http.StatusUnauthorized, "invalid token")
+		return
+	}
+
+	if !slices.Contains(claims.Scopes, scope) {
+		h.log.Warn("access denied: insufficient token scope",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("method", c.Request.Method),
+			zap.String("user_id", claims.UserID.String()),
+			zap.String("required_scope", scope),
+		)
+		newErrorResponse(c, http.StatusForbidden, "token is missing scope "+scope)
+		return
+	}
+
+	c.Set(userIDKey, claims.UserID.String())
+
+	c.Next()
+}
+
+func (h *Handler) requireScope(read, write string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Set(scopeKey, read)
+		default:
+			c.Set(scopeKey, write)
+		}
+
+		c.Next()
+	}
+}
+
+func (h *Handler) requireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString(roleKey) != role {
+			h.log.Warn("access denied: insufficient role",
+				zap.String("client_ip", c.ClientIP()),
+				zap.String("path", c.Request.URL.Path),
+				zap.String("method", c.Request.Method),
+				zap.String("required_role", role),
+				zap.String("role", c.GetString(roleKey)),
+			)
+			newErrorResponse(c, http.StatusForbidden, "forbidden")
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func (h *Handler) requireVerified(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	if err := h.emailH.service.EmailVerified(c.Request.Context(), userID); err != nil {
+		if errors.Is(err, domain.ErrEmailNotVerified) {
+			h.log.Debug("access denied: email not verified",
+				zap.String("client_ip", c.ClientIP()),
+				zap.String("path", c.Request.URL.Path),
+				zap.String("method", c.Request.Method),
+				zap.String("user_id", userID.String()),
+			)
+			newErrorResponse(c, http.StatusForbidden, err.Error())
+			return
+		}
+		h.log.Error("failed to check email verification",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.Next()
+}
+
+func (h *Handler) queryAccessToken(c *gin.Context) {
+	if c.GetHeader(authHeader) == "" {
+		if token := c.Query(accessToken); token != "" {
+			c.Request.Header.Set(authHeader, "Bearer "+token)
+		}
+	}
 
 	c.Next()
 }
@@ -145,6 +293,18 @@ func getRefreshToken(c *gin.Context) (string, error) {
 	return tokenID, nil
 }
 
+func getDevice(c *gin.Context) dto.Device {
+	userAgent := c.Request.UserAgent()
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = strings.ToValidUTF8(userAgent[:maxUserAgentLength], "")
+	}
+
+	return dto.Device{
+		UserAgent: userAgent,
+		IP:        c.ClientIP(),
+	}
+}
+
 func getParamUUID(c *gin.Context, param string) (uuid.UUID, error) {
 	id := c.Param(param)
 	res, err := uuid.Parse(id)
@@ -0,0 +1,450 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	mock_handler "noteApp/internal/handler/mock"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestHandler_logging(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		route     string
+		target    string
+		wantPath  string
+		wantQuery string
+	}{
+		{
+			name:      "plain query",
+			route:     "/api/notes",
+			target:    "/api/notes?limit=10",
+			wantPath:  "/api/notes",
+			wantQuery: "limit=10",
+		},
+		{
+			name:      "access token redacted",
+			route:     "/api/live",
+			target:    "/api/live?access_token=secret-token&since=5",
+			wantPath:  "/api/live",
+			wantQuery: "access_token=REDACTED&since=5",
+		},
+		{
+			name:     "public link token hidden",
+			route:    "/public/notes/:token",
+			target:   "/public/notes/secret-token",
+			wantPath: "/public/notes/:token",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			core, logs := observer.New(zap.InfoLevel)
+			h := &Handler{log: &logger.Logger{Logger: zap.New(core)}}
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.Use(h.logging())
+			r.GET(tt.route, func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", tt.target, nil)
+
+			r.ServeHTTP(w, req)
+
+			entries := logs.FilterMessage("HTTP Request").All()
+			require.Len(t, entries, 1)
+
+			fields := entries[0].ContextMap()
+			assert.Equal(t, tt.wantPath, fields["path"])
+			assert.Equal(t, tt.wantQuery, fields["query"])
+			assert.NotContains(t, fields["path"], "secret-token")
+			assert.NotContains(t, fields["query"], "secret-token")
+		})
+	}
+}
+
+func TestHandler_queryAccessToken(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		header string
+		query  string
+		want   string
+	}{
+		{
+			name:  "token from query",
+			query: "?access_token=abc",
+			want:  "Bearer abc",
+		},
+		{
+			name:   "header wins over query",
+			header: "Bearer header",
+			query:  "?access_token=abc",
+			want:   "Bearer header",
+		},
+		{
+			name: "no token",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := &Handler{}
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.GET("/live", h.queryAccessToken, func(c *gin.Context) {
+				c.String(http.StatusOK, c.GetHeader(authHeader))
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/live"+tt.query, nil)
+			if tt.header != "" {
+				req.Header.Set(authHeader, tt.header)
+			}
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.want, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_authMiddleware(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+
+	tests := []struct {
+		name               string
+		f                  func(*mock_handler.MockServiceI)
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name: "claims stored in context",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().ParseToken(gomock.Any(), "token").Return(dto.TokenClaims{UserID: userID, Role: adminKey}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       userID.String() + " " + adminKey,
+		},
+		{
+			name: "invalid token",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().ParseToken(gomock.Any(), "token").Return(dto.TokenClaims{}, errors.New("invalid token"))
+			},
+			expectedStatusCode: http.StatusSeeOther,
+		},
+		{
+			name: "disabled account",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().ParseToken(gomock.Any(), "token").Return(dto.TokenClaims{}, domain.ErrAccountDisabled)
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedBody:       `{"error":"account disabled"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mock_handler.NewMockServiceI(ctrl)
+			tt.f(service)
+
+			h := &Handler{
+				authH: newAuthHandler(service, 0, logger.LoggerForTest()),
+				log:   logger.LoggerForTest(),
+			}
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.GET("/me", h.authMiddleware, func(c *gin.Context) {
+				id, err := getUserID(c)
+				if err != nil {
+					c.String(http.StatusInternalServerError, err.Error())
+					return
+				}
+				c.String(http.StatusOK, id.String()+" "+c.GetString(roleKey))
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/me", nil)
+			req.Header.Set(authHeader, "Bearer token")
+			req.AddCookie(&http.Cookie{Name: refreshToken, Value: "refresh"})
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			if tt.expectedBody != "" {
+				assert.Equal(t, tt.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_requireRole(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		role               string
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:               "admin allowed",
+			role:               adminKey,
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "ok",
+		},
+		{
+			name:               "user forbidden",
+			role:               userKey,
+			expectedStatusCode: http.StatusForbidden,
+			expectedBody:       `{"error":"forbidden"}`,
+		},
+		{
+			name:               "no role forbidden",
+			expectedStatusCode: http.StatusForbidden,
+			expectedBody:       `{"error":"forbidden"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			h := &Handler{log: logger.LoggerForTest()}
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.GET("/admin", func(c *gin.Context) {
+				if tt.role != "" {
+					c.Set(roleKey, tt.role)
+				}
+				c.Next()
+			}, h.requireRole(adminKey), func(c *gin.Context) {
+				c.String(http.StatusOK, "ok")
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/admin", nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_authMiddlewarePersonalToken(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	token := domain.PersonalTokenPrefix + "secret"
+
+	tests := []struct {
+		name               string
+		method             string
+		scoped             bool
+		f                  func(*mock_handler.MockServiceI)
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:   "read scope allows GET",
+			method: http.MethodGet,
+			scoped: true,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().AuthenticatePersonalToken(gomock.Any(), token).Return(dto.TokenClaims{
+					UserID: userID,
+					Scopes: []string{domain.ScopeNotesRead},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       userID.String(),
+		},
+		{
+			name:   "read scope rejects POST",
+			method: http.MethodPost,
+			scoped: true,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().AuthenticatePersonalToken(gomock.Any(), token).Return(dto.TokenClaims{
+					UserID: userID,
+					Scopes: []string{domain.ScopeNotesRead},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedBody:       `{"error":"token is missing scope notes:write"}`,
+		},
+		{
+			name:   "write scope allows POST",
+			method: http.MethodPost,
+			scoped: true,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().AuthenticatePersonalToken(gomock.Any(), token).Return(dto.TokenClaims{
+					UserID: userID,
+					Scopes: []string{domain.ScopeNotesWrite},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       userID.String(),
+		},
+		{
+			name:               "unscoped route",
+			method:             http.MethodGet,
+			expectedStatusCode: http.StatusForbidden,
+			expectedBody:       `{"error":"personal access tokens are not allowed for this resource"}`,
+		},
+		{
+			name:   "invalid token",
+			method: http.MethodGet,
+			scoped: true,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().AuthenticatePersonalToken(gomock.Any(), token).Return(dto.TokenClaims{}, domain.ErrNotFound)
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedBody:       `{"error":"invalid token"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mock_handler.NewMockServiceI(ctrl)
+			if tt.f != nil {
+				tt.f(service)
+			}
+
+			h := &Handler{
+				authH:          newAuthHandler(service, 0, logger.LoggerForTest()),
+				personalTokenH: newPersonalTokenHandler(service, logger.LoggerForTest()),
+				log:            logger.LoggerForTest(),
+			}
+
+			handlers := []gin.HandlerFunc{h.authMiddleware, func(c *gin.Context) {
+				id, err := getUserID(c)
+				if err != nil {
+					c.String(http.StatusInternalServerError, err.Error())
+					return
+				}
+				c.String(http.StatusOK, id.String())
+			}}
+			if tt.scoped {
+				handlers = append([]gin.HandlerFunc{h.requireScope(domain.ScopeNotesRead, domain.ScopeNotesWrite)}, handlers...)
+			}
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.Handle(tt.method, "/notes", handlers...)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, "/notes", nil)
+			req.Header.Set(authHeader, "Bearer "+token)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_requireVerified(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+
+	tests := []struct {
+		name               string
+		f                  func(*mock_handler.MockServiceI)
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name: "verified",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().EmailVerified(gomock.Any(), userID).Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "ok",
+		},
+		{
+			name: "not verified",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().EmailVerified(gomock.Any(), userID).Return(domain.ErrEmailNotVerified)
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedBody:       `{"error":"email not verified"}`,
+		},
+		{
+			name: "service error",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().EmailVerified(gomock.Any(), userID).Return(errors.New("service error"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedBody:       `{"error":"service error"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mock_handler.NewMockServiceI(ctrl)
+			tt.f(service)
+
+			h := &Handler{
+				emailH: newEmailHandler(service, logger.LoggerForTest()),
+				log:    logger.LoggerForTest(),
+			}
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.POST("/shares", func(c *gin.Context) {
+				c.Set(userIDKey, userID.String())
+				c.Next()
+			}, h.requireVerified, func(c *gin.Context) {
+				c.String(http.StatusOK, "ok")
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/shares", nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedBody, w.Body.String())
+		})
+	}
+}
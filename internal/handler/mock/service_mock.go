@@ -11,6 +11,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
+	jwk "github.com/lestrrat-go/jwx/v2/jwk"
 )
 
 // MockServiceI is a mock of ServiceI interface.
@@ -36,6 +37,65 @@ func (m *MockServiceI) EXPECT() *MockServiceIMockRecorder {
 	return m.recorder
 }
 
+// ApplySyncChanges mocks base method.
+func (m *MockServiceI) ApplySyncChanges(arg0 context.Context, arg1 uuid.UUID, arg2 dto.SyncPush) dto.SyncPushResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplySyncChanges", arg0, arg1, arg2)
+	ret0, _ := ret[0].(dto.SyncPushResponse)
+	return ret0
+}
+
+// ApplySyncChanges indicates an expected call of ApplySyncChanges.
+func (mr *MockServiceIMockRecorder) ApplySyncChanges(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplySyncChanges", reflect.TypeOf((*MockServiceI)(nil).ApplySyncChanges), arg0, arg1, arg2)
+}
+
+// AuthenticatePersonalToken mocks base method.
+func (m *MockServiceI) AuthenticatePersonalToken(arg0 context.Context, arg1 string) (dto.TokenClaims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthenticatePersonalToken", arg0, arg1)
+	ret0, _ := ret[0].(dto.TokenClaims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthenticatePersonalToken indicates an expected call of AuthenticatePersonalToken.
+func (mr *MockServiceIMockRecorder) AuthenticatePersonalToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticatePersonalToken", reflect.TypeOf((*MockServiceI)(nil).AuthenticatePersonalToken), arg0, arg1)
+}
+
+// BatchNotes mocks base method.
+func (m *MockServiceI) BatchNotes(arg0 context.Context, arg1 uuid.UUID, arg2 dto.NoteBatch) (dto.NoteBatchResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchNotes", arg0, arg1, arg2)
+	ret0, _ := ret[0].(dto.NoteBatchResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchNotes indicates an expected call of BatchNotes.
+func (mr *MockServiceIMockRecorder) BatchNotes(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchNotes", reflect.TypeOf((*MockServiceI)(nil).BatchNotes), arg0, arg1, arg2)
+}
+
+// ConfirmTwoFactor mocks base method.
+func (m *MockServiceI) ConfirmTwoFactor(arg0 context.Context, arg1 dto.TwoFactorCode) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmTwoFactor", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmTwoFactor indicates an expected call of ConfirmTwoFactor.
+func (mr *MockServiceIMockRecorder) ConfirmTwoFactor(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTwoFactor", reflect.TypeOf((*MockServiceI)(nil).ConfirmTwoFactor), arg0, arg1)
+}
+
 // CreateNote mocks base method.
 func (m *MockServiceI) CreateNote(arg0 context.Context, arg1 dto.NoteCreate) (uuid.UUID, error) {
 	m.ctrl.T.Helper()
@@ -51,18 +111,119 @@ func (mr *MockServiceIMockRecorder) CreateNote(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNote", reflect.TypeOf((*MockServiceI)(nil).CreateNote), arg0, arg1)
 }
 
+// CreateNoteLink mocks base method.
+func (m *MockServiceI) CreateNoteLink(arg0 context.Context, arg1 dto.NoteLinkCreate) (dto.NoteLinkOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNoteLink", arg0, arg1)
+	ret0, _ := ret[0].(dto.NoteLinkOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateNoteLink indicates an expected call of CreateNoteLink.
+func (mr *MockServiceIMockRecorder) CreateNoteLink(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNoteLink", reflect.TypeOf((*MockServiceI)(nil).CreateNoteLink), arg0, arg1)
+}
+
+// CreateNotebook mocks base method.
+func (m *MockServiceI) CreateNotebook(arg0 context.Context, arg1 dto.NotebookCreate) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNotebook", arg0, arg1)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateNotebook indicates an expected call of CreateNotebook.
+func (mr *MockServiceIMockRecorder) CreateNotebook(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNotebook", reflect.TypeOf((*MockServiceI)(nil).CreateNotebook), arg0, arg1)
+}
+
+// CreatePersonalToken mocks base method.
+func (m *MockServiceI) CreatePersonalToken(arg0 context.Context, arg1 dto.PersonalTokenCreate) (dto.PersonalTokenOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePersonalToken", arg0, arg1)
+	ret0, _ := ret[0].(dto.PersonalTokenOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePersonalToken indicates an expected call of CreatePersonalToken.
+func (mr *MockServiceIMockRecorder) CreatePersonalToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePersonalToken", reflect.TypeOf((*MockServiceI)(nil).CreatePersonalToken), arg0, arg1)
+}
+
 // DeleteNote mocks base method.
-func (m *MockServiceI) DeleteNote(arg0 context.Context, arg1, arg2 uuid.UUID) error {
+func (m *MockServiceI) DeleteNote(arg0 context.Context, arg1, arg2 uuid.UUID, arg3 *int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteNote", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "DeleteNote", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteNote indicates an expected call of DeleteNote.
-func (mr *MockServiceIMockRecorder) DeleteNote(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockServiceIMockRecorder) DeleteNote(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNote", reflect.TypeOf((*MockServiceI)(nil).DeleteNote), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNote", reflect.TypeOf((*MockServiceI)(nil).DeleteNote), arg0, arg1, arg2, arg3)
+}
+
+// DeleteNoteLink mocks base method.
+func (m *MockServiceI) DeleteNoteLink(arg0 context.Context, arg1, arg2, arg3 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNoteLink", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNoteLink indicates an expected call of DeleteNoteLink.
+func (mr *MockServiceIMockRecorder) DeleteNoteLink(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNoteLink", reflect.TypeOf((*MockServiceI)(nil).DeleteNoteLink), arg0, arg1, arg2, arg3)
+}
+
+// DeleteNotebook mocks base method.
+func (m *MockServiceI) DeleteNotebook(arg0 context.Context, arg1, arg2 uuid.UUID, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNotebook", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNotebook indicates an expected call of DeleteNotebook.
+func (mr *MockServiceIMockRecorder) DeleteNotebook(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNotebook", reflect.TypeOf((*MockServiceI)(nil).DeleteNotebook), arg0, arg1, arg2, arg3)
+}
+
+// DeletePersonalToken mocks base method.
+func (m *MockServiceI) DeletePersonalToken(arg0 context.Context, arg1, arg2 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePersonalToken", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePersonalToken indicates an expected call of DeletePersonalToken.
+func (mr *MockServiceIMockRecorder) DeletePersonalToken(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePersonalToken", reflect.TypeOf((*MockServiceI)(nil).DeletePersonalToken), arg0, arg1, arg2)
+}
+
+// DeleteTag mocks base method.
+func (m *MockServiceI) DeleteTag(arg0 context.Context, arg1, arg2 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTag", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTag indicates an expected call of DeleteTag.
+func (mr *MockServiceIMockRecorder) DeleteTag(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTag", reflect.TypeOf((*MockServiceI)(nil).DeleteTag), arg0, arg1, arg2)
 }
 
 // DeleteUser mocks base method.
@@ -79,6 +240,135 @@ func (mr *MockServiceIMockRecorder) DeleteUser(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockServiceI)(nil).DeleteUser), arg0, arg1)
 }
 
+// DiffNoteRevisions mocks base method.
+func (m *MockServiceI) DiffNoteRevisions(arg0 context.Context, arg1, arg2 uuid.UUID, arg3 dto.NoteRevisionDiff) (dto.NoteDiffOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffNoteRevisions", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(dto.NoteDiffOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffNoteRevisions indicates an expected call of DiffNoteRevisions.
+func (mr *MockServiceIMockRecorder) DiffNoteRevisions(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffNoteRevisions", reflect.TypeOf((*MockServiceI)(nil).DiffNoteRevisions), arg0, arg1, arg2, arg3)
+}
+
+// DisableTwoFactor mocks base method.
+func (m *MockServiceI) DisableTwoFactor(arg0 context.Context, arg1 dto.TwoFactorCode) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableTwoFactor", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableTwoFactor indicates an expected call of DisableTwoFactor.
+func (mr *MockServiceIMockRecorder) DisableTwoFactor(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableTwoFactor", reflect.TypeOf((*MockServiceI)(nil).DisableTwoFactor), arg0, arg1)
+}
+
+// DisableUser mocks base method.
+func (m *MockServiceI) DisableUser(arg0 context.Context, arg1, arg2 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableUser", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableUser indicates an expected call of DisableUser.
+func (mr *MockServiceIMockRecorder) DisableUser(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableUser", reflect.TypeOf((*MockServiceI)(nil).DisableUser), arg0, arg1, arg2)
+}
+
+// EmailVerified mocks base method.
+func (m *MockServiceI) EmailVerified(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EmailVerified", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EmailVerified indicates an expected call of EmailVerified.
+func (mr *MockServiceIMockRecorder) EmailVerified(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EmailVerified", reflect.TypeOf((*MockServiceI)(nil).EmailVerified), arg0, arg1)
+}
+
+// EmptyTrash mocks base method.
+func (m *MockServiceI) EmptyTrash(arg0 context.Context, arg1 uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EmptyTrash", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EmptyTrash indicates an expected call of EmptyTrash.
+func (mr *MockServiceIMockRecorder) EmptyTrash(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EmptyTrash", reflect.TypeOf((*MockServiceI)(nil).EmptyTrash), arg0, arg1)
+}
+
+// EnableUser mocks base method.
+func (m *MockServiceI) EnableUser(arg0 context.Context, arg1, arg2 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableUser", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableUser indicates an expected call of EnableUser.
+func (mr *MockServiceIMockRecorder) EnableUser(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableUser", reflect.TypeOf((*MockServiceI)(nil).EnableUser), arg0, arg1, arg2)
+}
+
+// EnrollTwoFactor mocks base method.
+func (m *MockServiceI) EnrollTwoFactor(arg0 context.Context, arg1 uuid.UUID) (dto.TwoFactorEnrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollTwoFactor", arg0, arg1)
+	ret0, _ := ret[0].(dto.TwoFactorEnrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnrollTwoFactor indicates an expected call of EnrollTwoFactor.
+func (mr *MockServiceIMockRecorder) EnrollTwoFactor(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollTwoFactor", reflect.TypeOf((*MockServiceI)(nil).EnrollTwoFactor), arg0, arg1)
+}
+
+// ForgotPassword mocks base method.
+func (m *MockServiceI) ForgotPassword(arg0 context.Context, arg1 dto.ForgotPassword) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForgotPassword", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForgotPassword indicates an expected call of ForgotPassword.
+func (mr *MockServiceIMockRecorder) ForgotPassword(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgotPassword", reflect.TypeOf((*MockServiceI)(nil).ForgotPassword), arg0, arg1)
+}
+
+// JWKS mocks base method.
+func (m *MockServiceI) JWKS() jwk.Set {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JWKS")
+	ret0, _ := ret[0].(jwk.Set)
+	return ret0
+}
+
+// JWKS indicates an expected call of JWKS.
+func (mr *MockServiceIMockRecorder) JWKS() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JWKS", reflect.TypeOf((*MockServiceI)(nil).JWKS))
+}
+
 // Logout mocks base method.
 func (m *MockServiceI) Logout(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -93,6 +383,48 @@ func (mr *MockServiceIMockRecorder) Logout(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockServiceI)(nil).Logout), arg0, arg1)
 }
 
+// MergeTags mocks base method.
+func (m *MockServiceI) MergeTags(arg0 context.Context, arg1 dto.TagMerge) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeTags", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergeTags indicates an expected call of MergeTags.
+func (mr *MockServiceIMockRecorder) MergeTags(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeTags", reflect.TypeOf((*MockServiceI)(nil).MergeTags), arg0, arg1)
+}
+
+// MoveNote mocks base method.
+func (m *MockServiceI) MoveNote(arg0 context.Context, arg1 dto.NoteMove) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveNote", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveNote indicates an expected call of MoveNote.
+func (mr *MockServiceIMockRecorder) MoveNote(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveNote", reflect.TypeOf((*MockServiceI)(nil).MoveNote), arg0, arg1)
+}
+
+// MoveNotebook mocks base method.
+func (m *MockServiceI) MoveNotebook(arg0 context.Context, arg1 dto.NotebookMove) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveNotebook", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveNotebook indicates an expected call of MoveNotebook.
+func (mr *MockServiceIMockRecorder) MoveNotebook(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveNotebook", reflect.TypeOf((*MockServiceI)(nil).MoveNotebook), arg0, arg1)
+}
+
 // Note mocks base method.
 func (m *MockServiceI) Note(arg0 context.Context, arg1, arg2 uuid.UUID) (dto.NoteOutput, error) {
 	m.ctrl.T.Helper()
@@ -108,8 +440,128 @@ func (mr *MockServiceIMockRecorder) Note(arg0, arg1, arg2 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Note", reflect.TypeOf((*MockServiceI)(nil).Note), arg0, arg1, arg2)
 }
 
+// NoteEventStream mocks base method.
+func (m *MockServiceI) NoteEventStream(arg0 context.Context, arg1 uuid.UUID, arg2 *int64) (<-chan dto.NoteEventOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NoteEventStream", arg0, arg1, arg2)
+	ret0, _ := ret[0].(<-chan dto.NoteEventOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NoteEventStream indicates an expected call of NoteEventStream.
+func (mr *MockServiceIMockRecorder) NoteEventStream(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NoteEventStream", reflect.TypeOf((*MockServiceI)(nil).NoteEventStream), arg0, arg1, arg2)
+}
+
+// NoteEvents mocks base method.
+func (m *MockServiceI) NoteEvents(arg0 context.Context, arg1, arg2 uuid.UUID) (<-chan []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NoteEvents", arg0, arg1, arg2)
+	ret0, _ := ret[0].(<-chan []byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NoteEvents indicates an expected call of NoteEvents.
+func (mr *MockServiceIMockRecorder) NoteEvents(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NoteEvents", reflect.TypeOf((*MockServiceI)(nil).NoteEvents), arg0, arg1, arg2)
+}
+
+// NoteLinks mocks base method.
+func (m *MockServiceI) NoteLinks(arg0 context.Context, arg1, arg2 uuid.UUID) ([]dto.NoteLinkOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NoteLinks", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]dto.NoteLinkOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NoteLinks indicates an expected call of NoteLinks.
+func (mr *MockServiceIMockRecorder) NoteLinks(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NoteLinks", reflect.TypeOf((*MockServiceI)(nil).NoteLinks), arg0, arg1, arg2)
+}
+
+// NoteRevision mocks base method.
+func (m *MockServiceI) NoteRevision(arg0 context.Context, arg1, arg2 uuid.UUID, arg3 int) (dto.NoteRevisionOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NoteRevision", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(dto.NoteRevisionOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NoteRevision indicates an expected call of NoteRevision.
+func (mr *MockServiceIMockRecorder) NoteRevision(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NoteRevision", reflect.TypeOf((*MockServiceI)(nil).NoteRevision), arg0, arg1, arg2, arg3)
+}
+
+// NoteRevisions mocks base method.
+func (m *MockServiceI) NoteRevisions(arg0 context.Context, arg1, arg2 uuid.UUID) ([]dto.NoteRevisionOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NoteRevisions", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]dto.NoteRevisionOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NoteRevisions indicates an expected call of NoteRevisions.
+func (mr *MockServiceIMockRecorder) NoteRevisions(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NoteRevisions", reflect.TypeOf((*MockServiceI)(nil).NoteRevisions), arg0, arg1, arg2)
+}
+
+// NoteShares mocks base method.
+func (m *MockServiceI) NoteShares(arg0 context.Context, arg1, arg2 uuid.UUID) ([]dto.NoteShareOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NoteShares", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]dto.NoteShareOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NoteShares indicates an expected call of NoteShares.
+func (mr *MockServiceIMockRecorder) NoteShares(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NoteShares", reflect.TypeOf((*MockServiceI)(nil).NoteShares), arg0, arg1, arg2)
+}
+
+// Notebook mocks base method.
+func (m *MockServiceI) Notebook(arg0 context.Context, arg1, arg2 uuid.UUID) (dto.NotebookOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notebook", arg0, arg1, arg2)
+	ret0, _ := ret[0].(dto.NotebookOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Notebook indicates an expected call of Notebook.
+func (mr *MockServiceIMockRecorder) Notebook(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notebook", reflect.TypeOf((*MockServiceI)(nil).Notebook), arg0, arg1, arg2)
+}
+
+// Notebooks mocks base method.
+func (m *MockServiceI) Notebooks(arg0 context.Context, arg1 uuid.UUID) ([]dto.NotebookOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notebooks", arg0, arg1)
+	ret0, _ := ret[0].([]dto.NotebookOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Notebooks indicates an expected call of Notebooks.
+func (mr *MockServiceIMockRecorder) Notebooks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notebooks", reflect.TypeOf((*MockServiceI)(nil).Notebooks), arg0, arg1)
+}
+
 // Notes mocks base method.
-func (m *MockServiceI) Notes(arg0 context.Context, arg1 uuid.UUID, arg2 dto.Paginated) (dto.PaginatedResponse, error) {
+func (m *MockServiceI) Notes(arg0 context.Context, arg1 uuid.UUID, arg2 dto.NoteFilter) (dto.PaginatedResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Notes", arg0, arg1, arg2)
 	ret0, _ := ret[0].(dto.PaginatedResponse)
@@ -123,11 +575,26 @@ func (mr *MockServiceIMockRecorder) Notes(arg0, arg1, arg2 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notes", reflect.TypeOf((*MockServiceI)(nil).Notes), arg0, arg1, arg2)
 }
 
+// NotesByCursor mocks base method.
+func (m *MockServiceI) NotesByCursor(arg0 context.Context, arg1 uuid.UUID, arg2 dto.NoteFilter) (dto.CursorPaginatedResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotesByCursor", arg0, arg1, arg2)
+	ret0, _ := ret[0].(dto.CursorPaginatedResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NotesByCursor indicates an expected call of NotesByCursor.
+func (mr *MockServiceIMockRecorder) NotesByCursor(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotesByCursor", reflect.TypeOf((*MockServiceI)(nil).NotesByCursor), arg0, arg1, arg2)
+}
+
 // ParseToken mocks base method.
-func (m *MockServiceI) ParseToken(arg0 context.Context, arg1 string) (uuid.UUID, error) {
+func (m *MockServiceI) ParseToken(arg0 context.Context, arg1 string) (dto.TokenClaims, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ParseToken", arg0, arg1)
-	ret0, _ := ret[0].(uuid.UUID)
+	ret0, _ := ret[0].(dto.TokenClaims)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -138,34 +605,278 @@ func (mr *MockServiceIMockRecorder) ParseToken(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseToken", reflect.TypeOf((*MockServiceI)(nil).ParseToken), arg0, arg1)
 }
 
+// PersonalTokens mocks base method.
+func (m *MockServiceI) PersonalTokens(arg0 context.Context, arg1 uuid.UUID) ([]dto.PersonalTokenOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PersonalTokens", arg0, arg1)
+	ret0, _ := ret[0].([]dto.PersonalTokenOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PersonalTokens indicates an expected call of PersonalTokens.
+func (mr *MockServiceIMockRecorder) PersonalTokens(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PersonalTokens", reflect.TypeOf((*MockServiceI)(nil).PersonalTokens), arg0, arg1)
+}
+
+// PublicNote mocks base method.
+func (m *MockServiceI) PublicNote(arg0 context.Context, arg1, arg2 string) (dto.PublicNoteOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublicNote", arg0, arg1, arg2)
+	ret0, _ := ret[0].(dto.PublicNoteOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublicNote indicates an expected call of PublicNote.
+func (mr *MockServiceIMockRecorder) PublicNote(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublicNote", reflect.TypeOf((*MockServiceI)(nil).PublicNote), arg0, arg1, arg2)
+}
+
+// PurgeNote mocks base method.
+func (m *MockServiceI) PurgeNote(arg0 context.Context, arg1, arg2 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeNote", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeNote indicates an expected call of PurgeNote.
+func (mr *MockServiceIMockRecorder) PurgeNote(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeNote", reflect.TypeOf((*MockServiceI)(nil).PurgeNote), arg0, arg1, arg2)
+}
+
 // RefreshToken mocks base method.
-func (m *MockServiceI) RefreshToken(arg0 context.Context, arg1 string) (dto.TokenOutput, error) {
+func (m *MockServiceI) RefreshToken(arg0 context.Context, arg1 string, arg2 dto.Device) (dto.TokenOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RefreshToken", arg0, arg1)
+	ret := m.ctrl.Call(m, "RefreshToken", arg0, arg1, arg2)
 	ret0, _ := ret[0].(dto.TokenOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // RefreshToken indicates an expected call of RefreshToken.
-func (mr *MockServiceIMockRecorder) RefreshToken(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockServiceIMockRecorder) RefreshToken(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshToken", reflect.TypeOf((*MockServiceI)(nil).RefreshToken), arg0, arg1, arg2)
+}
+
+// RenameNotebook mocks base method.
+func (m *MockServiceI) RenameNotebook(arg0 context.Context, arg1 dto.NotebookUpdate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameNotebook", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenameNotebook indicates an expected call of RenameNotebook.
+func (mr *MockServiceIMockRecorder) RenameNotebook(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameNotebook", reflect.TypeOf((*MockServiceI)(nil).RenameNotebook), arg0, arg1)
+}
+
+// RenameTag mocks base method.
+func (m *MockServiceI) RenameTag(arg0 context.Context, arg1 dto.TagUpdate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameTag", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenameTag indicates an expected call of RenameTag.
+func (mr *MockServiceIMockRecorder) RenameTag(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameTag", reflect.TypeOf((*MockServiceI)(nil).RenameTag), arg0, arg1)
+}
+
+// ResetPassword mocks base method.
+func (m *MockServiceI) ResetPassword(arg0 context.Context, arg1 dto.PasswordReset) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockServiceIMockRecorder) ResetPassword(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockServiceI)(nil).ResetPassword), arg0, arg1)
+}
+
+// ResetUserPassword mocks base method.
+func (m *MockServiceI) ResetUserPassword(arg0 context.Context, arg1 uuid.UUID, arg2 dto.AdminPasswordReset) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetUserPassword", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetUserPassword indicates an expected call of ResetUserPassword.
+func (mr *MockServiceIMockRecorder) ResetUserPassword(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetUserPassword", reflect.TypeOf((*MockServiceI)(nil).ResetUserPassword), arg0, arg1, arg2)
+}
+
+// RestoreNote mocks base method.
+func (m *MockServiceI) RestoreNote(arg0 context.Context, arg1, arg2 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreNote", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreNote indicates an expected call of RestoreNote.
+func (mr *MockServiceIMockRecorder) RestoreNote(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreNote", reflect.TypeOf((*MockServiceI)(nil).RestoreNote), arg0, arg1, arg2)
+}
+
+// RestoreNoteRevision mocks base method.
+func (m *MockServiceI) RestoreNoteRevision(arg0 context.Context, arg1, arg2 uuid.UUID, arg3 int, arg4 *int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreNoteRevision", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreNoteRevision indicates an expected call of RestoreNoteRevision.
+func (mr *MockServiceIMockRecorder) RestoreNoteRevision(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreNoteRevision", reflect.TypeOf((*MockServiceI)(nil).RestoreNoteRevision), arg0, arg1, arg2, arg3, arg4)
+}
+
+// RevokeOtherSessions mocks base method.
+func (m *MockServiceI) RevokeOtherSessions(arg0 context.Context, arg1 uuid.UUID, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeOtherSessions", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeOtherSessions indicates an expected call of RevokeOtherSessions.
+func (mr *MockServiceIMockRecorder) RevokeOtherSessions(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeOtherSessions", reflect.TypeOf((*MockServiceI)(nil).RevokeOtherSessions), arg0, arg1, arg2)
+}
+
+// RevokeSession mocks base method.
+func (m *MockServiceI) RevokeSession(arg0 context.Context, arg1, arg2 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockServiceIMockRecorder) RevokeSession(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockServiceI)(nil).RevokeSession), arg0, arg1, arg2)
+}
+
+// SearchNotes mocks base method.
+func (m *MockServiceI) SearchNotes(arg0 context.Context, arg1 uuid.UUID, arg2 dto.NoteSearch) (dto.PaginatedResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchNotes", arg0, arg1, arg2)
+	ret0, _ := ret[0].(dto.PaginatedResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchNotes indicates an expected call of SearchNotes.
+func (mr *MockServiceIMockRecorder) SearchNotes(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchNotes", reflect.TypeOf((*MockServiceI)(nil).SearchNotes), arg0, arg1, arg2)
+}
+
+// SendVerificationEmail mocks base method.
+func (m *MockServiceI) SendVerificationEmail(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendVerificationEmail", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendVerificationEmail indicates an expected call of SendVerificationEmail.
+func (mr *MockServiceIMockRecorder) SendVerificationEmail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendVerificationEmail", reflect.TypeOf((*MockServiceI)(nil).SendVerificationEmail), arg0, arg1)
+}
+
+// Sessions mocks base method.
+func (m *MockServiceI) Sessions(arg0 context.Context, arg1 uuid.UUID, arg2 string) ([]dto.SessionOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sessions", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]dto.SessionOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sessions indicates an expected call of Sessions.
+func (mr *MockServiceIMockRecorder) Sessions(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sessions", reflect.TypeOf((*MockServiceI)(nil).Sessions), arg0, arg1, arg2)
+}
+
+// ShareNote mocks base method.
+func (m *MockServiceI) ShareNote(arg0 context.Context, arg1 dto.NoteShareCreate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShareNote", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ShareNote indicates an expected call of ShareNote.
+func (mr *MockServiceIMockRecorder) ShareNote(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShareNote", reflect.TypeOf((*MockServiceI)(nil).ShareNote), arg0, arg1)
+}
+
+// SharedNotes mocks base method.
+func (m *MockServiceI) SharedNotes(arg0 context.Context, arg1 uuid.UUID, arg2 dto.Paginated) (dto.PaginatedResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SharedNotes", arg0, arg1, arg2)
+	ret0, _ := ret[0].(dto.PaginatedResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SharedNotes indicates an expected call of SharedNotes.
+func (mr *MockServiceIMockRecorder) SharedNotes(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshToken", reflect.TypeOf((*MockServiceI)(nil).RefreshToken), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SharedNotes", reflect.TypeOf((*MockServiceI)(nil).SharedNotes), arg0, arg1, arg2)
 }
 
 // SignIn mocks base method.
-func (m *MockServiceI) SignIn(arg0 context.Context, arg1 dto.UserSignIn) (dto.TokenOutput, error) {
+func (m *MockServiceI) SignIn(arg0 context.Context, arg1 dto.UserSignIn, arg2 dto.Device) (dto.TokenOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SignIn", arg0, arg1)
+	ret := m.ctrl.Call(m, "SignIn", arg0, arg1, arg2)
 	ret0, _ := ret[0].(dto.TokenOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SignIn indicates an expected call of SignIn.
-func (mr *MockServiceIMockRecorder) SignIn(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockServiceIMockRecorder) SignIn(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignIn", reflect.TypeOf((*MockServiceI)(nil).SignIn), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignIn", reflect.TypeOf((*MockServiceI)(nil).SignIn), arg0, arg1, arg2)
+}
+
+// SignInTwoFactor mocks base method.
+func (m *MockServiceI) SignInTwoFactor(arg0 context.Context, arg1 dto.TwoFactorSignIn, arg2 dto.Device) (dto.TokenOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignInTwoFactor", arg0, arg1, arg2)
+	ret0, _ := ret[0].(dto.TokenOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignInTwoFactor indicates an expected call of SignInTwoFactor.
+func (mr *MockServiceIMockRecorder) SignInTwoFactor(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignInTwoFactor", reflect.TypeOf((*MockServiceI)(nil).SignInTwoFactor), arg0, arg1, arg2)
 }
 
 // SignUp mocks base method.
@@ -183,6 +894,65 @@ func (mr *MockServiceIMockRecorder) SignUp(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignUp", reflect.TypeOf((*MockServiceI)(nil).SignUp), arg0, arg1)
 }
 
+// SyncChanges mocks base method.
+func (m *MockServiceI) SyncChanges(arg0 context.Context, arg1 uuid.UUID, arg2 dto.SyncPull) (dto.SyncPullResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncChanges", arg0, arg1, arg2)
+	ret0, _ := ret[0].(dto.SyncPullResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncChanges indicates an expected call of SyncChanges.
+func (mr *MockServiceIMockRecorder) SyncChanges(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncChanges", reflect.TypeOf((*MockServiceI)(nil).SyncChanges), arg0, arg1, arg2)
+}
+
+// Tags mocks base method.
+func (m *MockServiceI) Tags(arg0 context.Context, arg1 uuid.UUID) ([]dto.TagOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Tags", arg0, arg1)
+	ret0, _ := ret[0].([]dto.TagOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Tags indicates an expected call of Tags.
+func (mr *MockServiceIMockRecorder) Tags(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tags", reflect.TypeOf((*MockServiceI)(nil).Tags), arg0, arg1)
+}
+
+// TrashedNotes mocks base method.
+func (m *MockServiceI) TrashedNotes(arg0 context.Context, arg1 uuid.UUID, arg2 dto.Paginated) (dto.PaginatedResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrashedNotes", arg0, arg1, arg2)
+	ret0, _ := ret[0].(dto.PaginatedResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TrashedNotes indicates an expected call of TrashedNotes.
+func (mr *MockServiceIMockRecorder) TrashedNotes(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrashedNotes", reflect.TypeOf((*MockServiceI)(nil).TrashedNotes), arg0, arg1, arg2)
+}
+
+// UnshareNote mocks base method.
+func (m *MockServiceI) UnshareNote(arg0 context.Context, arg1 dto.NoteShareDelete) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnshareNote", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnshareNote indicates an expected call of UnshareNote.
+func (mr *MockServiceIMockRecorder) UnshareNote(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnshareNote", reflect.TypeOf((*MockServiceI)(nil).UnshareNote), arg0, arg1)
+}
+
 // UpdateNote mocks base method.
 func (m *MockServiceI) UpdateNote(arg0 context.Context, arg1 dto.NoteUpdate) error {
 	m.ctrl.T.Helper()
@@ -239,3 +1009,32 @@ func (mr *MockServiceIMockRecorder) UserByID(arg0, arg1 interface{}) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserByID", reflect.TypeOf((*MockServiceI)(nil).UserByID), arg0, arg1)
 }
+
+// Users mocks base method.
+func (m *MockServiceI) Users(arg0 context.Context, arg1 dto.Paginated) (dto.PaginatedResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Users", arg0, arg1)
+	ret0, _ := ret[0].(dto.PaginatedResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Users indicates an expected call of Users.
+func (mr *MockServiceIMockRecorder) Users(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Users", reflect.TypeOf((*MockServiceI)(nil).Users), arg0, arg1)
+}
+
+// VerifyEmail mocks base method.
+func (m *MockServiceI) VerifyEmail(arg0 context.Context, arg1 dto.EmailVerify) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockServiceIMockRecorder) VerifyEmail(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockServiceI)(nil).VerifyEmail), arg0, arg1)
+}
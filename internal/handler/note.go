@@ -3,11 +3,14 @@ package handler
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"noteApp/internal/models/domain"
 	"noteApp/internal/models/dto"
 	"noteApp/pkg/logger"
 	"noteApp/pkg/valid"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -17,33 +20,48 @@ import (
 type NoteSI interface {
 	CreateNote(ctx context.Context, note dto.NoteCreate) (uuid.UUID, error)
 	Note(ctx context.Context, userID, nodeID uuid.UUID) (dto.NoteOutput, error)
-	Notes(ctx context.Context, userID uuid.UUID, p dto.Paginated) (dto.PaginatedResponse, error)
+	Notes(ctx context.Context, userID uuid.UUID, f dto.NoteFilter) (dto.PaginatedResponse, error)
+	NotesByCursor(ctx context.Context, userID uuid.UUID, f dto.NoteFilter) (dto.CursorPaginatedResponse, error)
+	SearchNotes(ctx context.Context, userID uuid.UUID, s dto.NoteSearch) (dto.PaginatedResponse, error)
 	UpdateNote(ctx context.Context, note dto.NoteUpdate) error
-	DeleteNote(ctx context.Context, userID, noteID uuid.UUID) error
+	MoveNote(ctx context.Context, move dto.NoteMove) error
+	DeleteNote(ctx context.Context, userID, noteID uuid.UUID, version *int) error
+	NoteEvents(ctx context.Context, userID, noteID uuid.UUID) (<-chan []byte, error)
+	BatchNotes(ctx context.Context, userID uuid.UUID, batch dto.NoteBatch) (dto.NoteBatchResponse, error)
 }
 
 type noteH struct {
 	service NoteSI
+	origins []string
 	log     *logger.Logger
 }
 
-func newNoteHandler(service NoteSI, log *logger.Logger) *noteH {
+func newNoteHandler(service NoteSI, origins []string, log *logger.Logger) *noteH {
 	return &noteH{
 		service: service,
+		origins: origins,
 		log:     log,
 	}
 }
 
 func (h *Handler) InitNoteAPIs(api *gin.RouterGroup) {
 	h.log.Info("init notes APIs")
-	note := api.Group("/notes", h.authMiddleware)
+	note := api.Group("/notes", h.requireScope(domain.ScopeNotesRead, domain.ScopeNotesWrite), h.authMiddleware)
 	{
 		note.POST("/", h.createNote)
 		note.GET("/", h.notes)
+		note.GET("/search", h.searchNotes)
+		note.POST("/batch", h.batchNotes)
 		note.GET("/:note_id", h.note)
 		note.PUT("/:note_id", h.updateNote)
+		note.PUT("/:note_id/move", h.moveNote)
 		note.DELETE("/:note_id", h.deleteNote)
 	}
+
+	live := api.Group("/notes/:note_id/live", h.queryAccessToken, h.requireScope(domain.ScopeNotesRead, domain.ScopeNotesWrite), h.authMiddleware)
+	{
+		live.GET("", h.noteLive)
+	}
 }
 
 func (n *noteH) createNote(c *gin.Context) {
@@ -71,6 +89,7 @@ func (n *noteH) createNote(c *gin.Context) {
 		return
 	}
 
+	note.ID = uuid.Nil
 	note.UserID = userID
 
 	if err := valid.ValidateStruct(note); err != nil {
@@ -85,6 +104,14 @@ func (n *noteH) createNote(c *gin.Context) {
 
 	id, err := n.service.CreateNote(c.Request.Context(), note)
 	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			n.log.Warn("notebook not found",
+				zap.String("client_ip", c.ClientIP()),
+				zap.String("user_id", userID.String()),
+			)
+			newErrorResponse(c, http.StatusNotFound, err.Error())
+			return
+		}
 		n.log.Error("create note failed",
 			zap.Error(err),
 			zap.String("client_ip", c.ClientIP()),
@@ -116,8 +143,8 @@ func (n *noteH) notes(c *gin.Context) {
 		return
 	}
 
-	var p dto.Paginated
-	if err := c.ShouldBindQuery(&p); err != nil {
+	var f dto.NoteFilter
+	if err := c.ShouldBindQuery(&f); err != nil {
 		n.log.Debug("invalid query in notes request",
 			zap.String("client_ip", c.ClientIP()),
 			zap.String("user_id", userID.String()),
@@ -127,7 +154,7 @@ func (n *noteH) notes(c *gin.Context) {
 		return
 	}
 
-	if err := valid.ValidateStruct(p); err != nil {
+	if err := valid.ValidateStruct(f); err != nil {
 		n.log.Debug("validation failed for notes request",
 			zap.String("client_ip", c.ClientIP()),
 			zap.String("user_id", userID.String()),
@@ -137,7 +164,12 @@ func (n *noteH) notes(c *gin.Context) {
 		return
 	}
 
-	notes, err := n.service.Notes(c.Request.Context(), userID, p)
+	if f.Paging == "cursor" || f.After != "" || f.Before != "" {
+		n.notesByCursor(c, userID, f)
+		return
+	}
+
+	notes, err := n.service.Notes(c.Request.Context(), userID, f)
 	if err != nil {
 		n.log.Error("get notes failed",
 			zap.Error(err),
@@ -159,6 +191,169 @@ func (n *noteH) notes(c *gin.Context) {
 	newSuccessResponse(c, http.StatusOK, "notes", notes)
 }
 
+func (n *noteH) notesByCursor(c *gin.Context, userID uuid.UUID, f dto.NoteFilter) {
+	notes, err := n.service.NotesByCursor(c.Request.Context(), userID, f)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidCursor) {
+			n.log.Debug("invalid cursor in notes request",
+				zap.String("client_ip", c.ClientIP()),
+				zap.String("user_id", userID.String()),
+			)
+			newErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		n.log.Error("get notes by cursor failed",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	n.log.Info("notes retrieved successfully by cursor",
+		zap.String("client_ip", c.ClientIP()),
+		zap.String("user_id", userID.String()),
+		zap.Int("limit", notes.Pagination.Limit),
+		zap.Bool("has_next", notes.Pagination.NextCursor != ""),
+	)
+
+	newSuccessResponse(c, http.StatusOK, "notes", notes)
+}
+
+func (n *noteH) searchNotes(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		n.log.Debug("unauthorized access attempt",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("method", c.Request.Method),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	var s dto.NoteSearch
+	if err := c.ShouldBindQuery(&s); err != nil {
+		n.log.Debug("invalid query in search notes request",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := valid.ValidateStruct(s); err != nil {
+		n.log.Debug("validation failed for search notes request",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	notes, err := n.service.SearchNotes(c.Request.Context(), userID, s)
+	if err != nil {
+		if errors.Is(err, domain.ErrEmptySearchQuery) {
+			newErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		n.log.Error("search notes failed",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	n.log.Info("notes searched successfully",
+		zap.String("client_ip", c.ClientIP()),
+		zap.String("user_id", userID.String()),
+		zap.Int("total", notes.Pagination.Total),
+	)
+
+	newSuccessResponse(c, http.StatusOK, "notes", notes)
+}
+
+func (n *noteH) notebookNotes(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		n.log.Debug("unauthorized access attempt",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("method", c.Request.Method),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	notebookID, err := getParamUUID(c, "notebook_id")
+	if err != nil {
+		n.log.Debug("invalid notebook_id in URL",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.String("param_value", c.Param("notebook_id")),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var f dto.NoteFilter
+	if err := c.ShouldBindQuery(&f); err != nil {
+		n.log.Debug("invalid query in notebook notes request",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	f.NotebookID = notebookID.String()
+
+	if err := valid.ValidateStruct(f); err != nil {
+		n.log.Debug("validation failed for notebook notes request",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if f.Paging == "cursor" || f.After != "" || f.Before != "" {
+		n.notesByCursor(c, userID, f)
+		return
+	}
+
+	notes, err := n.service.Notes(c.Request.Context(), userID, f)
+	if err != nil {
+		n.log.Error("get notebook notes failed",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.String("notebook_id", notebookID.String()),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	n.log.Info("notebook notes retrieved successfully",
+		zap.String("client_ip", c.ClientIP()),
+		zap.String("user_id", userID.String()),
+		zap.String("notebook_id", notebookID.String()),
+		zap.Int("total", notes.Pagination.Total),
+	)
+
+	newSuccessResponse(c, http.StatusOK, "notes", notes)
+}
+
 func (n *noteH) note(c *gin.Context) {
 	userID, err := getUserID(c)
 	if err != nil {
@@ -206,6 +401,14 @@ func (n *noteH) note(c *gin.Context) {
 		return
 	}
 
+	etag := noteETag(note.Version)
+	c.Header("ETag", etag)
+
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
 	n.log.Info("note retrieved successfully",
 		zap.String("client_ip", c.ClientIP()),
 		zap.String("user_id", userID.String()),
@@ -254,6 +457,12 @@ func (n *noteH) updateNote(c *gin.Context) {
 	note.UserID = userID
 	note.ID = noteID
 
+	note.Version, err = ifMatchVersion(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusPreconditionFailed, err.Error())
+		return
+	}
+
 	if err := valid.ValidateStruct(note); err != nil {
 		n.log.Debug("validation failed for update note",
 			zap.String("client_ip", c.ClientIP()),
@@ -266,7 +475,7 @@ func (n *noteH) updateNote(c *gin.Context) {
 	}
 
 	if err := n.service.UpdateNote(c.Request.Context(), note); err != nil {
-		if err == domain.ErrNotFound {
+		if errors.Is(err, domain.ErrNotFound) {
 			n.log.Warn("note not found",
 				zap.String("client_ip", c.ClientIP()),
 				zap.String("user_id", userID.String()),
@@ -275,6 +484,14 @@ func (n *noteH) updateNote(c *gin.Context) {
 			newErrorResponse(c, http.StatusNotFound, err.Error())
 			return
 		}
+		if errors.Is(err, domain.ErrVersionMismatch) {
+			newErrorResponse(c, http.StatusPreconditionFailed, err.Error())
+			return
+		}
+		if errors.Is(err, domain.ErrForbidden) {
+			newErrorResponse(c, http.StatusForbidden, err.Error())
+			return
+		}
 		n.log.Error("failed to update note",
 			zap.Error(err),
 			zap.String("client_ip", c.ClientIP()),
@@ -294,6 +511,74 @@ func (n *noteH) updateNote(c *gin.Context) {
 	newSuccessResponse(c, http.StatusOK, "data", "ok")
 }
 
+func (n *noteH) moveNote(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		n.log.Debug("unauthorized access attempt",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("method", c.Request.Method),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	noteID, err := getParamUUID(c, "note_id")
+	if err != nil {
+		n.log.Debug("invalid note_id in URL",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.String("param_value", c.Param("note_id")),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var move dto.NoteMove
+	if err := c.ShouldBindJSON(&move); err != nil {
+		n.log.Debug("invalid JSON in move note request",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	move.ID = noteID
+	move.UserID = userID
+
+	if err := n.service.MoveNote(c.Request.Context(), move); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			n.log.Warn("note or notebook not found",
+				zap.String("client_ip", c.ClientIP()),
+				zap.String("user_id", userID.String()),
+				zap.String("note_id", noteID.String()),
+			)
+			newErrorResponse(c, http.StatusNotFound, err.Error())
+			return
+		}
+		n.log.Error("failed to move note",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.String("note_id", noteID.String()),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	n.log.Info("note moved successfully",
+		zap.String("client_ip", c.ClientIP()),
+		zap.String("user_id", userID.String()),
+		zap.String("note_id", noteID.String()),
+	)
+
+	newSuccessResponse(c, http.StatusOK, "data", "ok")
+}
+
 func (n *noteH) deleteNote(c *gin.Context) {
 	userID, err := getUserID(c)
 	if err != nil {
@@ -319,8 +604,14 @@ func (n *noteH) deleteNote(c *gin.Context) {
 		return
 	}
 
-	if err := n.service.DeleteNote(c.Request.Context(), userID, noteID); err != nil {
-		if err == domain.ErrNotFound {
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusPreconditionFailed, err.Error())
+		return
+	}
+
+	if err := n.service.DeleteNote(c.Request.Context(), userID, noteID, version); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
 			n.log.Warn("note not found",
 				zap.String("client_ip", c.ClientIP()),
 				zap.String("user_id", userID.String()),
@@ -329,6 +620,14 @@ func (n *noteH) deleteNote(c *gin.Context) {
 			newErrorResponse(c, http.StatusNotFound, err.Error())
 			return
 		}
+		if errors.Is(err, domain.ErrVersionMismatch) {
+			newErrorResponse(c, http.StatusPreconditionFailed, err.Error())
+			return
+		}
+		if errors.Is(err, domain.ErrForbidden) {
+			newErrorResponse(c, http.StatusForbidden, err.Error())
+			return
+		}
 		n.log.Error("failed to delete note",
 			zap.Error(err),
 			zap.String("client_ip", c.ClientIP()),
@@ -347,3 +646,87 @@ func (n *noteH) deleteNote(c *gin.Context) {
 
 	newSuccessResponse(c, http.StatusOK, "data", "ok")
 }
+
+func noteETag(version int) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+func ifMatchVersion(c *gin.Context) (*int, error) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return nil, nil
+	}
+
+	version, err := strconv.Atoi(strings.Trim(header, `"`))
+	if err != nil || !strings.HasPrefix(header, `"`) || !strings.HasSuffix(header, `"`) {
+		return nil, domain.ErrVersionMismatch
+	}
+
+	return &version, nil
+}
+
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (n *noteH) batchNotes(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		n.log.Debug("unauthorized access attempt",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("method", c.Request.Method),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	var batch dto.NoteBatch
+	if err := c.ShouldBindJSON(&batch); err != nil {
+		n.log.Debug("invalid JSON in batch request",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := valid.ValidateStruct(batch); err != nil {
+		n.log.Debug("validation failed for batch request",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response, err := n.service.BatchNotes(c.Request.Context(), userID, batch)
+	if err != nil {
+		n.log.Error("failed to execute note batch",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	n.log.Info("note batch processed",
+		zap.String("client_ip", c.ClientIP()),
+		zap.String("user_id", userID.String()),
+		zap.Int("ops", len(batch.Ops)),
+		zap.Bool("committed", response.Committed),
+	)
+
+	newSuccessResponse(c, http.StatusOK, "batch", response)
+}
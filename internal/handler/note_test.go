@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -31,7 +32,7 @@ func mockNoteHandler(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock
 	}
 
 	return &Handler{
-		noteH: newNoteHandler(service, logger.LoggerForTest()),
+		noteH: newNoteHandler(service, nil, logger.LoggerForTest()),
 	}
 }
 
@@ -100,6 +101,16 @@ func Test_noteH_createNote(t *testing.T) {
 			expectedStatusCode:   http.StatusBadRequest,
 			expectedResponseBody: `{"error":"validation failed: Field: Content, Tag: max, Param: 255"}`,
 		},
+		{
+			name:      "notebook not found",
+			inputBody: `{"heading":"test_heading","content":"test_content","notebook_id":"` + uuid.New().String() + `"}`,
+			userID:    uuid.New(),
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().CreateNote(gomock.Any(), gomock.Any()).Return(uuid.Nil, domain.ErrNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"not found"}`,
+		},
 		{
 			name:      "service error",
 			inputBody: `{"heading":"test_heading","content":"test_content","done":false}`,
@@ -177,6 +188,11 @@ func Test_noteH_notes(t *testing.T) {
 	bytes, err := json.Marshal(paginatedNotes)
 	require.NoError(t, err)
 
+	cursorNotes := dto.MakeCursorPaginatedResponse(paginatedNotes.Data, 10, "next", "")
+
+	cursorBytes, err := json.Marshal(cursorNotes)
+	require.NoError(t, err)
+
 	tests := []struct {
 		name                 string
 		query                string
@@ -197,7 +213,10 @@ func Test_noteH_notes(t *testing.T) {
 			name:  "success with done=true",
 			query: "limit=10&offset=0&done=true",
 			f: func(msi *mock_handler.MockServiceI) {
-				msi.EXPECT().Notes(gomock.Any(), userID, gomock.Any()).Return(paginatedNotes, nil)
+				msi.EXPECT().Notes(gomock.Any(), userID, dto.NoteFilter{
+					Paginated: dto.Paginated{Limit: 10, Offset: 0},
+					Done:      &[]bool{true}[0],
+				}).Return(paginatedNotes, nil)
 			},
 			expectedStatusCode:   http.StatusOK,
 			expectedResponseBody: `{"notes":` + string(bytes) + `}`,
@@ -206,11 +225,116 @@ func Test_noteH_notes(t *testing.T) {
 			name:  "success with done=false",
 			query: "limit=10&offset=0&done=false",
 			f: func(msi *mock_handler.MockServiceI) {
-				msi.EXPECT().Notes(gomock.Any(), userID, gomock.Any()).Return(paginatedNotes, nil)
+				msi.EXPECT().Notes(gomock.Any(), userID, dto.NoteFilter{
+					Paginated: dto.Paginated{Limit: 10, Offset: 0},
+					Done:      &[]bool{false}[0],
+				}).Return(paginatedNotes, nil)
 			},
 			expectedStatusCode:   http.StatusOK,
 			expectedResponseBody: `{"notes":` + string(bytes) + `}`,
 		},
+		{
+			name:  "success with tags",
+			query: "limit=10&offset=0&tag=work&tag=home&tag_match=all",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().Notes(gomock.Any(), userID, dto.NoteFilter{
+					Paginated: dto.Paginated{Limit: 10, Offset: 0},
+					Tags:      []string{"work", "home"},
+					TagMatch:  "all",
+				}).Return(paginatedNotes, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"notes":` + string(bytes) + `}`,
+		},
+		{
+			name:  "success with sort and range",
+			query: "limit=10&offset=0&sort=updated_at&order=desc&created_after=2024-01-01T00:00:00Z&updated_before=2024-02-01T10:00:00%2B03:00",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().Notes(gomock.Any(), userID, gomock.Any()).DoAndReturn(
+					func(_ context.Context, _ uuid.UUID, f dto.NoteFilter) (dto.PaginatedResponse, error) {
+						assert.Equal(t, "updated_at", f.Sort)
+						assert.Equal(t, "desc", f.Order)
+						require.NotNil(t, f.CreatedAfter)
+						assert.True(t, f.CreatedAfter.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+						require.NotNil(t, f.UpdatedBefore)
+						assert.True(t, f.UpdatedBefore.Equal(time.Date(2024, 2, 1, 7, 0, 0, 0, time.UTC)))
+						assert.Nil(t, f.CreatedBefore)
+						assert.Nil(t, f.UpdatedAfter)
+						return paginatedNotes, nil
+					})
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"notes":` + string(bytes) + `}`,
+		},
+		{
+			name:  "success with cursor paging",
+			query: "limit=10&paging=cursor",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().NotesByCursor(gomock.Any(), userID, dto.NoteFilter{
+					Paginated: dto.Paginated{Limit: 10},
+					Paging:    "cursor",
+				}).Return(cursorNotes, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"notes":` + string(cursorBytes) + `}`,
+		},
+		{
+			name:  "success with after cursor",
+			query: "limit=10&after=abc",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().NotesByCursor(gomock.Any(), userID, dto.NoteFilter{
+					Paginated: dto.Paginated{Limit: 10},
+					After:     "abc",
+				}).Return(cursorNotes, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"notes":` + string(cursorBytes) + `}`,
+		},
+		{
+			name:  "invalid cursor",
+			query: "limit=10&before=abc",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().NotesByCursor(gomock.Any(), gomock.Any(), gomock.Any()).Return(dto.CursorPaginatedResponse{}, domain.ErrInvalidCursor)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"invalid cursor"}`,
+		},
+		{
+			name:                 "both after and before",
+			query:                "limit=10&after=abc&before=def",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"validation failed: Field: After, Tag: excluded_with, Param: Before"}`,
+		},
+		{
+			name:                 "invalid sort",
+			query:                "limit=10&offset=0&sort=content",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"validation failed: Field: Sort, Tag: oneof, Param: created_at updated_at heading"}`,
+		},
+		{
+			name:                 "invalid order",
+			query:                "limit=10&offset=0&order=sideways",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"validation failed: Field: Order, Tag: oneof, Param: asc desc"}`,
+		},
+		{
+			name:                 "invalid date",
+			query:                "limit=10&offset=0&created_after=yesterday",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"parsing time \"yesterday\" as \"2006-01-02T15:04:05Z07:00\": cannot parse \"yesterday\" as \"2006\""}`,
+		},
+		{
+			name:                 "invalid tag match",
+			query:                "limit=10&offset=0&tag=work&tag_match=some",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"validation failed: Field: TagMatch, Tag: oneof, Param: any all"}`,
+		},
+		{
+			name:                 "invalid done",
+			query:                "limit=10&offset=0&done=maybe",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"strconv.ParseBool: parsing \"maybe\": invalid syntax"}`,
+		},
 		{
 			name:                 "invalid limit",
 			query:                "limit=-5&offset=0",
@@ -270,6 +394,136 @@ func Test_noteH_notes(t *testing.T) {
 	}
 }
 
+func Test_noteH_searchNotes(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+
+	results := dto.MakePaginatedResponse([]dto.NoteSearchOutput{
+		{
+			NoteOutput: dto.NoteOutput{
+				ID:        uuid.New(),
+				UserID:    userID,
+				Heading:   "Grocery list",
+				Content:   "milk",
+				CreatedAt: time.Now(),
+				UpdatedAt: time.Now(),
+			},
+			Rank:     0.6,
+			Headline: "<mark>Grocery</mark> list milk",
+		},
+	}, 1, 0, 10)
+
+	bytes, err := json.Marshal(results)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name                 string
+		query                string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "success",
+			query: "q=grocery&limit=10&offset=0",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().SearchNotes(gomock.Any(), userID, dto.NoteSearch{
+					Paginated: dto.Paginated{Limit: 10},
+					Query:     "grocery",
+				}).Return(results, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"notes":` + string(bytes) + `}`,
+		},
+		{
+			name:                 "missing user_id in context",
+			query:                "q=grocery&limit=10",
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"error":"user id not found in context"}`,
+		},
+		{
+			name:  "fuzzy with threshold",
+			query: "q=grocry&mode=fuzzy&threshold=0.4&content=true&limit=10",
+			f: func(msi *mock_handler.MockServiceI) {
+				threshold := 0.4
+				msi.EXPECT().SearchNotes(gomock.Any(), userID, dto.NoteSearch{
+					Paginated: dto.Paginated{Limit: 10},
+					Query:     "grocry",
+					Mode:      domain.NoteSearchFuzzy,
+					Threshold: &threshold,
+					Content:   true,
+				}).Return(results, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"notes":` + string(bytes) + `}`,
+		},
+		{
+			name:                 "invalid mode",
+			query:                "q=grocery&mode=regex&limit=10",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"validation failed: Field: Mode, Tag: oneof, Param: fulltext fuzzy"}`,
+		},
+		{
+			name:                 "threshold out of range",
+			query:                "q=grocery&mode=fuzzy&threshold=1.5&limit=10",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"validation failed: Field: Threshold, Tag: lte, Param: 1"}`,
+		},
+		{
+			name:                 "missing query",
+			query:                "limit=10",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"validation failed: Field: Query, Tag: required, Param: "}`,
+		},
+		{
+			name:  "no searchable words",
+			query: "q=*&limit=10",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().SearchNotes(gomock.Any(), gomock.Any(), gomock.Any()).Return(dto.PaginatedResponse{}, domain.ErrEmptySearchQuery)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"` + domain.ErrEmptySearchQuery.Error() + `"}`,
+		},
+		{
+			name:  "service error",
+			query: "q=grocery&limit=10",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().SearchNotes(gomock.Any(), gomock.Any(), gomock.Any()).Return(dto.PaginatedResponse{}, errors.New("service error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"service error"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockNoteHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.GET("/notes/search", func(c *gin.Context) {
+				if tt.name != "missing user_id in context" {
+					c.Set(userIDKey, userID.String())
+				}
+				handler.searchNotes(c)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/notes/search?"+tt.query, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
 func Test_noteH_note(t *testing.T) {
 	t.Parallel()
 
@@ -519,6 +773,112 @@ func Test_noteH_updateNote(t *testing.T) {
 	}
 }
 
+func Test_noteH_moveNote(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	noteID := uuid.New()
+	notebookID := uuid.New()
+
+	tests := []struct {
+		name                 string
+		param                string
+		inputBody            string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "success",
+			param:     noteID.String(),
+			inputBody: `{"notebook_id":"` + notebookID.String() + `"}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().MoveNote(gomock.Any(), dto.NoteMove{
+					ID:         noteID,
+					UserID:     userID,
+					NotebookID: &notebookID,
+				}).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"data":"ok"}`,
+		},
+		{
+			name:      "to root",
+			param:     noteID.String(),
+			inputBody: `{"notebook_id":null}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().MoveNote(gomock.Any(), dto.NoteMove{
+					ID:     noteID,
+					UserID: userID,
+				}).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"data":"ok"}`,
+		},
+		{
+			name:                 "missing user_id in context",
+			param:                noteID.String(),
+			inputBody:            `{}`,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"error":"user id not found in context"}`,
+		},
+		{
+			name:                 "invalid note_id param",
+			param:                "123",
+			inputBody:            `{}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"note_id is not uuid"}`,
+		},
+		{
+			name:      "not found",
+			param:     noteID.String(),
+			inputBody: `{"notebook_id":"` + notebookID.String() + `"}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().MoveNote(gomock.Any(), gomock.Any()).Return(domain.ErrNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"not found"}`,
+		},
+		{
+			name:      "service error",
+			param:     noteID.String(),
+			inputBody: `{}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().MoveNote(gomock.Any(), gomock.Any()).Return(errors.New("service error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"service error"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockNoteHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.PUT("/notes/:note_id/move", func(c *gin.Context) {
+				if tt.name != "missing user_id in context" {
+					c.Set(userIDKey, userID.String())
+				}
+				handler.moveNote(c)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", "/notes/"+tt.param+"/move", strings.NewReader(tt.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
 func Test_noteH_deleteNote(t *testing.T) {
 	t.Parallel()
 
@@ -535,7 +895,7 @@ func Test_noteH_deleteNote(t *testing.T) {
 			name:  "success",
 			param: uuid.New().String(),
 			f: func(msi *mock_handler.MockServiceI) {
-				msi.EXPECT().DeleteNote(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				msi.EXPECT().DeleteNote(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 			},
 			expectedStatusCode:   http.StatusOK,
 			expectedResponseBody: `{"data":"ok"}`,
@@ -556,7 +916,7 @@ func Test_noteH_deleteNote(t *testing.T) {
 			name:  "not found",
 			param: uuid.New().String(),
 			f: func(msi *mock_handler.MockServiceI) {
-				msi.EXPECT().DeleteNote(gomock.Any(), gomock.Any(), gomock.Any()).Return(domain.ErrNotFound)
+				msi.EXPECT().DeleteNote(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(domain.ErrNotFound)
 			},
 			expectedStatusCode:   http.StatusNotFound,
 			expectedResponseBody: `{"error":"not found"}`,
@@ -565,7 +925,7 @@ func Test_noteH_deleteNote(t *testing.T) {
 			name:  "service error",
 			param: uuid.New().String(),
 			f: func(msi *mock_handler.MockServiceI) {
-				msi.EXPECT().DeleteNote(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("service error"))
+				msi.EXPECT().DeleteNote(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("service error"))
 			},
 			expectedStatusCode:   http.StatusInternalServerError,
 			expectedResponseBody: `{"error":"service error"}`,
@@ -601,3 +961,313 @@ func Test_noteH_deleteNote(t *testing.T) {
 		})
 	}
 }
+
+func Test_noteH_noteETag(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	note := dto.NoteOutput{
+		ID:      uuid.New(),
+		UserID:  userID,
+		Heading: "Test Note",
+		Version: 3,
+	}
+
+	bytes, err := json.Marshal(note)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name                 string
+		ifNoneMatch          string
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:                 "no condition",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"note":` + string(bytes) + `}`,
+		},
+		{
+			name:                 "stale etag",
+			ifNoneMatch:          `"2"`,
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"note":` + string(bytes) + `}`,
+		},
+		{
+			name:               "matching etag",
+			ifNoneMatch:        `"1", W/"3"`,
+			expectedStatusCode: http.StatusNotModified,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockNoteHandler(t, ctrl, func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().Note(gomock.Any(), userID, note.ID).Return(note, nil)
+			})
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.GET("/notes/:note_id", func(c *gin.Context) {
+				c.Set(userIDKey, userID.String())
+				handler.note(c)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/notes/"+note.ID.String(), nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func Test_noteH_updateNoteIfMatch(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	noteID := uuid.New()
+	heading := "New heading"
+
+	tests := []struct {
+		name                 string
+		ifMatch              string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:    "matching version",
+			ifMatch: `"4"`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().UpdateNote(gomock.Any(), dto.NoteUpdate{
+					ID:      noteID,
+					UserID:  userID,
+					Heading: &heading,
+					Version: &[]int{4}[0],
+				}).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"data":"ok"}`,
+		},
+		{
+			name:    "any version",
+			ifMatch: "*",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().UpdateNote(gomock.Any(), dto.NoteUpdate{
+					ID:      noteID,
+					UserID:  userID,
+					Heading: &heading,
+				}).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"data":"ok"}`,
+		},
+		{
+			name:    "version mismatch",
+			ifMatch: `"3"`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().UpdateNote(gomock.Any(), gomock.Any()).
+					Return(domain.MakeError(domain.ErrFailedToUpdate, domain.ErrVersionMismatch, "note"))
+			},
+			expectedStatusCode:   http.StatusPreconditionFailed,
+			expectedResponseBody: `{"error":"failed to update note: version mismatch"}`,
+		},
+		{
+			name:    "read-only share",
+			ifMatch: "*",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().UpdateNote(gomock.Any(), gomock.Any()).
+					Return(domain.MakeError(domain.ErrFailedToUpdate, domain.ErrForbidden, "note"))
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"error":"failed to update note: forbidden"}`,
+		},
+		{
+			name:                 "malformed etag",
+			ifMatch:              `W/"4"`,
+			expectedStatusCode:   http.StatusPreconditionFailed,
+			expectedResponseBody: `{"error":"version mismatch"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockNoteHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.PUT("/notes/:note_id", func(c *gin.Context) {
+				c.Set(userIDKey, userID.String())
+				handler.updateNote(c)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", "/notes/"+noteID.String(), strings.NewReader(`{"heading":"New heading"}`))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("If-Match", tt.ifMatch)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func Test_noteH_deleteNoteIfMatch(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	noteID := uuid.New()
+
+	tests := []struct {
+		name                 string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "matching version",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().DeleteNote(gomock.Any(), userID, noteID, &[]int{2}[0]).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"data":"ok"}`,
+		},
+		{
+			name: "version mismatch",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().DeleteNote(gomock.Any(), userID, noteID, &[]int{2}[0]).
+					Return(domain.MakeError(domain.ErrFailedToDelete, domain.ErrVersionMismatch, "note"))
+			},
+			expectedStatusCode:   http.StatusPreconditionFailed,
+			expectedResponseBody: `{"error":"failed to delete note: version mismatch"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockNoteHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.DELETE("/notes/:note_id", func(c *gin.Context) {
+				c.Set(userIDKey, userID.String())
+				handler.deleteNote(c)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", "/notes/"+noteID.String(), nil)
+			req.Header.Set("If-Match", `"2"`)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func Test_noteH_batchNotes(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	noteID := uuid.New()
+
+	tests := []struct {
+		name                 string
+		body                 string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "success",
+			body: `{"mode":"best_effort","ops":[{"op":"delete","id":"` + noteID.String() + `"}]}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().BatchNotes(gomock.Any(), userID, dto.NoteBatch{
+					Mode: domain.NoteBatchBestEffort,
+					Ops:  []dto.NoteBatchOp{{Op: "delete", ID: noteID}},
+				}).Return(dto.NoteBatchResponse{
+					Mode:      domain.NoteBatchBestEffort,
+					Committed: true,
+					Results:   []dto.NoteBatchResult{{Index: 0, ID: noteID, Op: "delete", Status: domain.NoteOpStatusApplied}},
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"batch":{"mode":"best_effort","committed":true,"results":[{"index":0,"id":"` + noteID.String() + `","op":"delete","status":"applied"}]}}`,
+		},
+		{
+			name:                 "invalid mode",
+			body:                 `{"mode":"sometimes","ops":[{"op":"delete","id":"` + noteID.String() + `"}]}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"validation failed: Field: Mode, Tag: oneof, Param: atomic best_effort"}`,
+		},
+		{
+			name:                 "empty batch",
+			body:                 `{"ops":[]}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"validation failed: Field: Ops, Tag: min, Param: 1"}`,
+		},
+		{
+			name:                 "invalid JSON",
+			body:                 `{"ops":`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"unexpected EOF"}`,
+		},
+		{
+			name: "service error",
+			body: `{"ops":[{"op":"delete","id":"` + noteID.String() + `"}]}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().BatchNotes(gomock.Any(), userID, gomock.Any()).Return(dto.NoteBatchResponse{}, errors.New("service error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"service error"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockNoteHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.POST("/notes/batch", func(c *gin.Context) {
+				c.Set(userIDKey, userID.String())
+				handler.batchNotes(c)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/notes/batch", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
@@ -0,0 +1,399 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+	"noteApp/pkg/valid"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type NotebookSI interface {
+	CreateNotebook(ctx context.Context, notebook dto.NotebookCreate) (uuid.UUID, error)
+	Notebook(ctx context.Context, userID, notebookID uuid.UUID) (dto.NotebookOutput, error)
+	Notebooks(ctx context.Context, userID uuid.UUID) ([]dto.NotebookOutput, error)
+	RenameNotebook(ctx context.Context, notebook dto.NotebookUpdate) error
+	MoveNotebook(ctx context.Context, move dto.NotebookMove) error
+	DeleteNotebook(ctx context.Context, userID, notebookID uuid.UUID, mode string) error
+}
+
+type notebookH struct {
+	service NotebookSI
+	log     *logger.Logger
+}
+
+func newNotebookHandler(service NotebookSI, log *logger.Logger) *notebookH {
+	return &notebookH{
+		service: service,
+		log:     log,
+	}
+}
+
+func (h *Handler) InitNotebookAPIs(api *gin.RouterGroup) {
+	h.log.Info("init notebooks APIs")
+	notebook := api.Group("/notebooks", h.requireScope(domain.ScopeNotesRead, domain.ScopeNotesWrite), h.authMiddleware)
+	{
+		notebook.POST("/", h.createNotebook)
+		notebook.GET("/", h.notebooks)
+		notebook.GET("/:notebook_id", h.notebook)
+		notebook.GET("/:notebook_id/notes", h.notebookNotes)
+		notebook.PUT("/:notebook_id", h.renameNotebook)
+		notebook.PUT("/:notebook_id/move", h.moveNotebook)
+		notebook.DELETE("/:notebook_id", h.deleteNotebook)
+	}
+}
+
+func (n *notebookH) createNotebook(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		n.log.Debug("unauthorized access attempt",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("method", c.Request.Method),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	var notebook dto.NotebookCreate
+	if err := c.ShouldBindJSON(&notebook); err != nil {
+		n.log.Debug("invalid JSON in create notebook request",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	notebook.UserID = userID
+
+	if err := valid.ValidateStruct(notebook); err != nil {
+		n.log.Debug("validation failed for create notebook",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	id, err := n.service.CreateNotebook(c.Request.Context(), notebook)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			newErrorResponse(c, http.StatusNotFound, err.Error())
+			return
+		}
+		n.log.Error("create notebook failed",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	n.log.Info("notebook created successfully",
+		zap.String("client_ip", c.ClientIP()),
+		zap.String("user_id", userID.String()),
+		zap.String("notebook_id", id.String()),
+	)
+
+	newSuccessResponse(c, http.StatusOK, "id", id)
+}
+
+func (n *notebookH) notebooks(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		n.log.Debug("unauthorized access attempt",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("method", c.Request.Method),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	notebooks, err := n.service.Notebooks(c.Request.Context(), userID)
+	if err != nil {
+		n.log.Error("get notebooks failed",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	n.log.Info("notebooks retrieved successfully",
+		zap.String("client_ip", c.ClientIP()),
+		zap.String("user_id", userID.String()),
+		zap.Int("count", len(notebooks)),
+	)
+
+	newSuccessResponse(c, http.StatusOK, "notebooks", notebooks)
+}
+
+func (n *notebookH) notebook(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		n.log.Debug("unauthorized access attempt",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("method", c.Request.Method),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	notebookID, err := getParamUUID(c, "notebook_id")
+	if err != nil {
+		n.log.Debug("invalid notebook_id in URL",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.String("param_value", c.Param("notebook_id")),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	notebook, err := n.service.Notebook(c.Request.Context(), userID, notebookID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			newErrorResponse(c, http.StatusNotFound, err.Error())
+			return
+		}
+		n.log.Error("failed to get notebook",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.String("notebook_id", notebookID.String()),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	n.log.Info("notebook retrieved successfully",
+		zap.String("client_ip", c.ClientIP()),
+		zap.String("user_id", userID.String()),
+		zap.String("notebook_id", notebookID.String()),
+	)
+
+	newSuccessResponse(c, http.StatusOK, "notebook", notebook)
+}
+
+func (n *notebookH) renameNotebook(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		n.log.Debug("unauthorized access attempt",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("method", c.Request.Method),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	notebookID, err := getParamUUID(c, "notebook_id")
+	if err != nil {
+		n.log.Debug("invalid notebook_id in URL",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.String("param_value", c.Param("notebook_id")),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var notebook dto.NotebookUpdate
+	if err := c.ShouldBindJSON(&notebook); err != nil {
+		n.log.Debug("invalid JSON in rename notebook request",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	notebook.ID = notebookID
+	notebook.UserID = userID
+
+	if err := valid.ValidateStruct(notebook); err != nil {
+		n.log.Debug("validation failed for rename notebook",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.String("notebook_id", notebookID.String()),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := n.service.RenameNotebook(c.Request.Context(), notebook); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			newErrorResponse(c, http.StatusNotFound, err.Error())
+			return
+		}
+		n.log.Error("failed to rename notebook",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.String("notebook_id", notebookID.String()),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	n.log.Info("notebook renamed successfully",
+		zap.String("client_ip", c.ClientIP()),
+		zap.String("user_id", userID.String()),
+		zap.String("notebook_id", notebookID.String()),
+	)
+
+	newSuccessResponse(c, http.StatusOK, "data", "ok")
+}
+
+func (n *notebookH) moveNotebook(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		n.log.Debug("unauthorized access attempt",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("method", c.Request.Method),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	notebookID, err := getParamUUID(c, "notebook_id")
+	if err != nil {
+		n.log.Debug("invalid notebook_id in URL",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.String("param_value", c.Param("notebook_id")),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var move dto.NotebookMove
+	if err := c.ShouldBindJSON(&move); err != nil {
+		n.log.Debug("invalid JSON in move notebook request",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	move.ID = notebookID
+	move.UserID = userID
+
+	if err := n.service.MoveNotebook(c.Request.Context(), move); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			newErrorResponse(c, http.StatusNotFound, err.Error())
+		case errors.Is(err, domain.ErrNotebookCycle):
+			newErrorResponse(c, http.StatusConflict, err.Error())
+		default:
+			n.log.Error("failed to move notebook",
+				zap.Error(err),
+				zap.String("client_ip", c.ClientIP()),
+				zap.String("user_id", userID.String()),
+				zap.String("notebook_id", notebookID.String()),
+			)
+			newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	n.log.Info("notebook moved successfully",
+		zap.String("client_ip", c.ClientIP()),
+		zap.String("user_id", userID.String()),
+		zap.String("notebook_id", notebookID.String()),
+	)
+
+	newSuccessResponse(c, http.StatusOK, "data", "ok")
+}
+
+func (n *notebookH) deleteNotebook(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		n.log.Debug("unauthorized access attempt in deleteNotebook",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("method", c.Request.Method),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	notebookID, err := getParamUUID(c, "notebook_id")
+	if err != nil {
+		n.log.Debug("invalid notebook_id in URL",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.String("param_value", c.Param("notebook_id")),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var params dto.NotebookDelete
+	if err := c.ShouldBindQuery(&params); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := valid.ValidateStruct(params); err != nil {
+		n.log.Debug("validation failed for delete notebook",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := n.service.DeleteNotebook(c.Request.Context(), userID, notebookID, params.Mode); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			newErrorResponse(c, http.StatusNotFound, err.Error())
+			return
+		}
+		n.log.Error("failed to delete notebook",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.String("notebook_id", notebookID.String()),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	n.log.Info("notebook deleted successfully",
+		zap.String("client_ip", c.ClientIP()),
+		zap.String("user_id", userID.String()),
+		zap.String("notebook_id", notebookID.String()),
+		zap.String("mode", params.Mode),
+	)
+
+	newSuccessResponse(c, http.StatusOK, "data", "ok")
+}
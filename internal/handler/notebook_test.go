@@ -0,0 +1,452 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	mock_handler "noteApp/internal/handler/mock"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockNotebookHandler(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock_handler.MockServiceI)) *Handler {
+	t.Helper()
+
+	service := mock_handler.NewMockServiceI(ctrl)
+
+	if setupMock != nil {
+		setupMock(service)
+	}
+
+	return &Handler{
+		noteH:     newNoteHandler(service, nil, logger.LoggerForTest()),
+		notebookH: newNotebookHandler(service, logger.LoggerForTest()),
+	}
+}
+
+func Test_notebookH_createNotebook(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	notebookID := uuid.New()
+	parentID := uuid.New()
+
+	tests := []struct {
+		name                 string
+		inputBody            string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "success",
+			inputBody: `{"name":"work","parent_id":"` + parentID.String() + `"}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().CreateNotebook(gomock.Any(), dto.NotebookCreate{
+					UserID:   userID,
+					ParentID: &parentID,
+					Name:     "work",
+				}).Return(notebookID, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"id":"` + notebookID.String() + `"}`,
+		},
+		{
+			name:                 "missing user_id in context",
+			inputBody:            `{"name":"work"}`,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"error":"user id not found in context"}`,
+		},
+		{
+			name:                 "empty name",
+			inputBody:            `{"name":""}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"validation failed: Field: Name, Tag: required, Param: "}`,
+		},
+		{
+			name:      "parent not found",
+			inputBody: `{"name":"work","parent_id":"` + parentID.String() + `"}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().CreateNotebook(gomock.Any(), gomock.Any()).Return(uuid.Nil, domain.ErrNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"not found"}`,
+		},
+		{
+			name:      "service error",
+			inputBody: `{"name":"work"}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().CreateNotebook(gomock.Any(), gomock.Any()).Return(uuid.Nil, errors.New("service error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"service error"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockNotebookHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.POST("/notebooks", func(c *gin.Context) {
+				if tt.name != "missing user_id in context" {
+					c.Set(userIDKey, userID.String())
+				}
+				handler.createNotebook(c)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/notebooks", strings.NewReader(tt.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func Test_notebookH_notebooks(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	parentID := uuid.New()
+
+	notebooks := []dto.NotebookOutput{
+		{
+			ID:        parentID,
+			Name:      "work",
+			Notes:     2,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		},
+		{
+			ID:        uuid.New(),
+			ParentID:  &parentID,
+			Name:      "archive",
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		},
+	}
+
+	bytes, err := json.Marshal(notebooks)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name                 string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "success",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().Notebooks(gomock.Any(), userID).Return(notebooks, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"notebooks":` + string(bytes) + `}`,
+		},
+		{
+			name:                 "missing user_id in context",
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"error":"user id not found in context"}`,
+		},
+		{
+			name: "service error",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().Notebooks(gomock.Any(), userID).Return(nil, errors.New("service error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"service error"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockNotebookHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.GET("/notebooks", func(c *gin.Context) {
+				if tt.name != "missing user_id in context" {
+					c.Set(userIDKey, userID.String())
+				}
+				handler.notebooks(c)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/notebooks", nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func Test_notebookH_moveNotebook(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	notebookID := uuid.New()
+	parentID := uuid.New()
+
+	tests := []struct {
+		name                 string
+		param                string
+		inputBody            string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "success",
+			param:     notebookID.String(),
+			inputBody: `{"parent_id":"` + parentID.String() + `"}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().MoveNotebook(gomock.Any(), dto.NotebookMove{
+					ID:       notebookID,
+					UserID:   userID,
+					ParentID: &parentID,
+				}).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"data":"ok"}`,
+		},
+		{
+			name:                 "invalid notebook_id param",
+			param:                "123",
+			inputBody:            `{}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"notebook_id is not uuid"}`,
+		},
+		{
+			name:      "cycle",
+			param:     notebookID.String(),
+			inputBody: `{"parent_id":"` + parentID.String() + `"}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().MoveNotebook(gomock.Any(), gomock.Any()).Return(domain.ErrNotebookCycle)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"error":"` + domain.ErrNotebookCycle.Error() + `"}`,
+		},
+		{
+			name:      "not found",
+			param:     notebookID.String(),
+			inputBody: `{}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().MoveNotebook(gomock.Any(), gomock.Any()).Return(domain.ErrNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"not found"}`,
+		},
+		{
+			name:      "service error",
+			param:     notebookID.String(),
+			inputBody: `{}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().MoveNotebook(gomock.Any(), gomock.Any()).Return(errors.New("service error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"service error"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockNotebookHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.PUT("/notebooks/:notebook_id/move", func(c *gin.Context) {
+				c.Set(userIDKey, userID.String())
+				handler.moveNotebook(c)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", "/notebooks/"+tt.param+"/move", strings.NewReader(tt.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func Test_notebookH_deleteNotebook(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	notebookID := uuid.New()
+
+	tests := []struct {
+		name                 string
+		query                string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "default mode",
+			query: "",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().DeleteNotebook(gomock.Any(), userID, notebookID, "").Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"data":"ok"}`,
+		},
+		{
+			name:  "delete mode",
+			query: "?mode=delete",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().DeleteNotebook(gomock.Any(), userID, notebookID, domain.NotebookDeleteDelete).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"data":"ok"}`,
+		},
+		{
+			name:                 "invalid mode",
+			query:                "?mode=purge",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"validation failed: Field: Mode, Tag: oneof, Param: move delete"}`,
+		},
+		{
+			name:  "not found",
+			query: "",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().DeleteNotebook(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(domain.ErrNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"not found"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockNotebookHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.DELETE("/notebooks/:notebook_id", func(c *gin.Context) {
+				c.Set(userIDKey, userID.String())
+				handler.deleteNotebook(c)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", "/notebooks/"+notebookID.String()+tt.query, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func Test_noteH_notebookNotes(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	notebookID := uuid.New()
+
+	notes := dto.MakePaginatedResponse([]dto.NoteOutput{}, 0, 0, 10)
+
+	bytes, err := json.Marshal(notes)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name                 string
+		param                string
+		query                string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "recursive",
+			param: notebookID.String(),
+			query: "?limit=10&recursive=true",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().Notes(gomock.Any(), userID, dto.NoteFilter{
+					Paginated:  dto.Paginated{Limit: 10},
+					NotebookID: notebookID.String(),
+					Recursive:  true,
+				}).Return(notes, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"notes":` + string(bytes) + `}`,
+		},
+		{
+			name:                 "invalid notebook_id param",
+			param:                "123",
+			query:                "?limit=10",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"notebook_id is not uuid"}`,
+		},
+		{
+			name:  "service error",
+			param: notebookID.String(),
+			query: "?limit=10",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().Notes(gomock.Any(), gomock.Any(), gomock.Any()).Return(dto.PaginatedResponse{}, errors.New("service error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"service error"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockNotebookHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.GET("/notebooks/:notebook_id/notes", func(c *gin.Context) {
+				c.Set(userIDKey, userID.String())
+				handler.notebookNotes(c)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/notebooks/"+tt.param+"/notes"+tt.query, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
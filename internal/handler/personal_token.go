@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+	"noteApp/pkg/valid"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type PersonalTokenSI interface {
+	CreatePersonalToken(ctx context.Context, token dto.PersonalTokenCreate) (dto.PersonalTokenOutput, error)
+	PersonalTokens(ctx context.Context, userID uuid.UUID) ([]dto.PersonalTokenOutput, error)
+	DeletePersonalToken(ctx context.Context, userID, tokenID uuid.UUID) error
+	AuthenticatePersonalToken(ctx context.Context, token string) (dto.TokenClaims, error)
+}
+
+type personalTokenH struct {
+	service PersonalTokenSI
+	log     *logger.Logger
+}
+
+func newPersonalTokenHandler(service PersonalTokenSI, log *logger.Logger) *personalTokenH {
+	return &personalTokenH{
+		service: service,
+		log:     log,
+	}
+}
+
+func (h *Handler) InitPersonalTokenAPIs(api *gin.RouterGroup) {
+	h.log.Info("init personal access token APIs")
+	token := api.Group("/profile/tokens", h.authMiddleware)
+	{
+		token.POST("/", h.requireVerified, h.createPersonalToken)
+		token.GET("/", h.personalTokens)
+		token.DELETE("/:token_id", h.deletePersonalToken)
+	}
+}
+
+func (p *personalTokenH) createPersonalToken(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		p.log.Debug("unauthorized access attempt",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("method", c.Request.Method),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	var token dto.PersonalTokenCreate
+	if err := c.ShouldBindJSON(&token); err != nil {
+		p.log.Debug("invalid JSON in create personal token request",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	token.UserID = userID
+
+	if err := valid.ValidateStruct(token); err != nil {
+		p.log.Debug("validation failed for create personal token",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	output, err := p.service.CreatePersonalToken(c.Request.Context(), token)
+	if err != nil {
+		p.log.Error("create personal token failed",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	p.log.Info("personal token created successfully",
+		zap.String("client_ip", c.ClientIP()),
+		zap.String("user_id", userID.String()),
+		zap.String("token_id", output.ID.String()),
+	)
+
+	newSuccessResponse(c, http.StatusCreated, "token", output)
+}
+
+func (p *personalTokenH) personalTokens(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		p.log.Debug("unauthorized access attempt",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("method", c.Request.Method),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	tokens, err := p.service.PersonalTokens(c.Request.Context(), userID)
+	if err != nil {
+		p.log.Error("get personal tokens failed",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	newSuccessResponse(c, http.StatusOK, "tokens", tokens)
+}
+
+func (p *personalTokenH) deletePersonalToken(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		p.log.Debug("unauthorized access attempt",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("method", c.Request.Method),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	tokenID, err := getParamUUID(c, "token_id")
+	if err != nil {
+		p.log.Debug("invalid token_id in URL",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.String("param_value", c.Param("token_id")),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := p.service.DeletePersonalToken(c.Request.Context(), userID, tokenID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			newErrorResponse(c, http.StatusNotFound, err.Error())
+			return
+		}
+		p.log.Error("failed to delete personal token",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.String("token_id", tokenID.String()),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	p.log.Info("personal token revoked successfully",
+		zap.String("client_ip", c.ClientIP()),
+		zap.String("user_id", userID.String()),
+		zap.String("token_id", tokenID.String()),
+	)
+
+	newSuccessResponse(c, http.StatusOK, "data", "ok")
+}
@@ -0,0 +1,257 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	mock_handler "noteApp/internal/handler/mock"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockPersonalTokenHandler(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock_handler.MockServiceI)) *Handler {
+	t.Helper()
+
+	service := mock_handler.NewMockServiceI(ctrl)
+
+	if setupMock != nil {
+		setupMock(service)
+	}
+
+	return &Handler{
+		personalTokenH: newPersonalTokenHandler(service, logger.LoggerForTest()),
+	}
+}
+
+func Test_personalTokenH_createPersonalToken(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	output := dto.PersonalTokenOutput{
+		ID:        uuid.New(),
+		Name:      "ci",
+		Token:     domain.PersonalTokenPrefix + "secret",
+		Scopes:    []string{domain.ScopeNotesRead},
+		CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	bytes, err := json.Marshal(output)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name                 string
+		inputBody            string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "success",
+			inputBody: `{"name":"ci","scopes":["notes:read"]}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().CreatePersonalToken(gomock.Any(), dto.PersonalTokenCreate{
+					UserID: userID,
+					Name:   "ci",
+					Scopes: []string{domain.ScopeNotesRead},
+				}).Return(output, nil)
+			},
+			expectedStatusCode:   http.StatusCreated,
+			expectedResponseBody: `{"token":` + string(bytes) + `}`,
+		},
+		{
+			name:                 "missing scopes",
+			inputBody:            `{"name":"ci"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"validation failed: Field: Scopes, Tag: required, Param: "}`,
+		},
+		{
+			name:                 "unknown scope",
+			inputBody:            `{"name":"ci","scopes":["admin"]}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"validation failed: Field: Scopes[0], Tag: oneof, Param: notes:read notes:write"}`,
+		},
+		{
+			name:                 "expiry in the past",
+			inputBody:            `{"name":"ci","scopes":["notes:read"],"expires_at":"2000-01-01T00:00:00Z"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"validation failed: Field: ExpiresAt, Tag: gt, Param: "}`,
+		},
+		{
+			name:      "service error",
+			inputBody: `{"name":"ci","scopes":["notes:read"]}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().CreatePersonalToken(gomock.Any(), gomock.Any()).Return(dto.PersonalTokenOutput{}, errors.New("service error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"service error"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockPersonalTokenHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.POST("/profile/tokens", func(c *gin.Context) {
+				c.Set(userIDKey, userID.String())
+				handler.createPersonalToken(c)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/profile/tokens", strings.NewReader(tt.inputBody))
+			req.Header.Set("Content-type", "application/json")
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func Test_personalTokenH_personalTokens(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	tokens := []dto.PersonalTokenOutput{
+		{
+			ID:        uuid.New(),
+			Name:      "ci",
+			Scopes:    []string{domain.ScopeNotesRead},
+			CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+	}
+
+	bytes, err := json.Marshal(tokens)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name                 string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "success",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().PersonalTokens(gomock.Any(), userID).Return(tokens, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"tokens":` + string(bytes) + `}`,
+		},
+		{
+			name: "service error",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().PersonalTokens(gomock.Any(), userID).Return(nil, errors.New("service error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"service error"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockPersonalTokenHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.GET("/profile/tokens", func(c *gin.Context) {
+				c.Set(userIDKey, userID.String())
+				handler.personalTokens(c)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/profile/tokens", nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func Test_personalTokenH_deletePersonalToken(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	tokenID := uuid.New()
+
+	tests := []struct {
+		name                 string
+		param                string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "success",
+			param: tokenID.String(),
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().DeletePersonalToken(gomock.Any(), userID, tokenID).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"data":"ok"}`,
+		},
+		{
+			name:                 "invalid token_id param",
+			param:                "123",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"token_id is not uuid"}`,
+		},
+		{
+			name:  "not found",
+			param: tokenID.String(),
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().DeletePersonalToken(gomock.Any(), userID, tokenID).Return(domain.ErrNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"not found"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockPersonalTokenHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.DELETE("/profile/tokens/:token_id", func(c *gin.Context) {
+				c.Set(userIDKey, userID.String())
+				handler.deletePersonalToken(c)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", "/profile/tokens/"+tt.param, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
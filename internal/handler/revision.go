@@ -0,0 +1,236 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+	"noteApp/pkg/valid"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type RevisionSI interface {
+	NoteRevisions(ctx context.Context, userID, noteID uuid.UUID) ([]dto.NoteRevisionOutput, error)
+	NoteRevision(ctx context.Context, userID, noteID uuid.UUID, revision int) (dto.NoteRevisionOutput, error)
+	DiffNoteRevisions(ctx context.Context, userID, noteID uuid.UUID, diff dto.NoteRevisionDiff) (dto.NoteDiffOutput, error)
+	RestoreNoteRevision(ctx context.Context, userID, noteID uuid.UUID, revision int, version *int) error
+}
+
+type revisionH struct {
+	service RevisionSI
+	log     *logger.Logger
+}
+
+func newRevisionHandler(service RevisionSI, log *logger.Logger) *revisionH {
+	return &revisionH{
+		service: service,
+		log:     log,
+	}
+}
+
+func (h *Handler) InitRevisionAPIs(api *gin.RouterGroup) {
+	h.log.Info("init revisions APIs")
+	revision := api.Group("/notes/:note_id/revisions", h.requireScope(domain.ScopeNotesRead, domain.ScopeNotesWrite), h.authMiddleware)
+	{
+		revision.GET("/", h.noteRevisions)
+		revision.GET("/diff", h.diffNoteRevisions)
+		revision.GET("/:rev", h.noteRevision)
+		revision.POST("/:rev/restore", h.restoreNoteRevision)
+	}
+}
+
+func (r *revisionH) noteRevisions(c *gin.Context) {
+	userID, noteID, ok := r.noteParams(c)
+	if !ok {
+		return
+	}
+
+	revisions, err := r.service.NoteRevisions(c.Request.Context(), userID, noteID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			newErrorResponse(c, http.StatusNotFound, err.Error())
+			return
+		}
+		r.log.Error("failed to get note revisions",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.String("note_id", noteID.String()),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	r.log.Info("note revisions retrieved successfully",
+		zap.String("client_ip", c.ClientIP()),
+		zap.String("user_id", userID.String()),
+		zap.String("note_id", noteID.String()),
+		zap.Int("count", len(revisions)),
+	)
+
+	newSuccessResponse(c, http.StatusOK, "revisions", revisions)
+}
+
+func (r *revisionH) noteRevision(c *gin.Context) {
+	userID, noteID, ok := r.noteParams(c)
+	if !ok {
+		return
+	}
+
+	revision, err := getParamRevision(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	rev, err := r.service.NoteRevision(c.Request.Context(), userID, noteID, revision)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			newErrorResponse(c, http.StatusNotFound, err.Error())
+			return
+		}
+		r.log.Error("failed to get note revision",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.String("note_id", noteID.String()),
+			zap.Int("revision", revision),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	newSuccessResponse(c, http.StatusOK, "revision", rev)
+}
+
+func (r *revisionH) diffNoteRevisions(c *gin.Context) {
+	userID, noteID, ok := r.noteParams(c)
+	if !ok {
+		return
+	}
+
+	var diff dto.NoteRevisionDiff
+	if err := c.ShouldBindQuery(&diff); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := valid.ValidateStruct(diff); err != nil {
+		r.log.Debug("validation failed for revision diff",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := r.service.DiffNoteRevisions(c.Request.Context(), userID, noteID, diff)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			newErrorResponse(c, http.StatusNotFound, err.Error())
+			return
+		}
+		r.log.Error("failed to diff note revisions",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.String("note_id", noteID.String()),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	newSuccessResponse(c, http.StatusOK, "diff", result)
+}
+
+func (r *revisionH) restoreNoteRevision(c *gin.Context) {
+	userID, noteID, ok := r.noteParams(c)
+	if !ok {
+		return
+	}
+
+	revision, err := getParamRevision(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusPreconditionFailed, err.Error())
+		return
+	}
+
+	if err := r.service.RestoreNoteRevision(c.Request.Context(), userID, noteID, revision, version); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			newErrorResponse(c, http.StatusNotFound, err.Error())
+			return
+		}
+		if errors.Is(err, domain.ErrVersionMismatch) {
+			newErrorResponse(c, http.StatusPreconditionFailed, err.Error())
+			return
+		}
+		r.log.Error("failed to restore note revision",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.String("note_id", noteID.String()),
+			zap.Int("revision", revision),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	r.log.Info("note revision restored successfully",
+		zap.String("client_ip", c.ClientIP()),
+		zap.String("user_id", userID.String()),
+		zap.String("note_id", noteID.String()),
+		zap.Int("revision", revision),
+	)
+
+	newSuccessResponse(c, http.StatusOK, "data", "ok")
+}
+
+func (r *revisionH) noteParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	userID, err := getUserID(c)
+	if err != nil {
+		r.log.Debug("unauthorized access attempt",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("method", c.Request.Method),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	noteID, err := getParamUUID(c, "note_id")
+	if err != nil {
+		r.log.Debug("invalid note_id in URL",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.String("param_value", c.Param("note_id")),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return userID, noteID, true
+}
+
+func getParamRevision(c *gin.Context) (int, error) {
+	revision, err := strconv.Atoi(c.Param("rev"))
+	if err != nil || revision < 1 {
+		return 0, fmt.Errorf("rev is not a positive number")
+	}
+	return revision, nil
+}
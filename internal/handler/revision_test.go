@@ -0,0 +1,315 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	mock_handler "noteApp/internal/handler/mock"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockRevisionHandler(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock_handler.MockServiceI)) *Handler {
+	t.Helper()
+
+	service := mock_handler.NewMockServiceI(ctrl)
+
+	if setupMock != nil {
+		setupMock(service)
+	}
+
+	return &Handler{
+		revisionH: newRevisionHandler(service, logger.LoggerForTest()),
+	}
+}
+
+func Test_revisionH_noteRevisions(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	noteID := uuid.New()
+
+	revisions := []dto.NoteRevisionOutput{
+		{
+			Revision:  1,
+			Heading:   "heading",
+			Content:   "content",
+			CreatedAt: time.Now(),
+		},
+	}
+
+	bytes, err := json.Marshal(revisions)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name                 string
+		param                string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "success",
+			param: noteID.String(),
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().NoteRevisions(gomock.Any(), userID, noteID).Return(revisions, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"revisions":` + string(bytes) + `}`,
+		},
+		{
+			name:                 "missing user_id in context",
+			param:                noteID.String(),
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"error":"user id not found in context"}`,
+		},
+		{
+			name:                 "invalid note_id param",
+			param:                "123",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"note_id is not uuid"}`,
+		},
+		{
+			name:  "not found",
+			param: noteID.String(),
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().NoteRevisions(gomock.Any(), userID, noteID).Return(nil, domain.ErrNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"not found"}`,
+		},
+		{
+			name:  "service error",
+			param: noteID.String(),
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().NoteRevisions(gomock.Any(), userID, noteID).Return(nil, errors.New("service error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"service error"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockRevisionHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.GET("/notes/:note_id/revisions", func(c *gin.Context) {
+				if tt.name != "missing user_id in context" {
+					c.Set(userIDKey, userID.String())
+				}
+				handler.noteRevisions(c)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/notes/"+tt.param+"/revisions", nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func Test_revisionH_diffNoteRevisions(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	noteID := uuid.New()
+
+	diff := dto.NoteDiffOutput{
+		From: 1,
+		To:   2,
+		Diff: "--- revision 1\n+++ revision 2\n",
+	}
+
+	bytes, err := json.Marshal(diff)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name                 string
+		query                string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "success",
+			query: "from=1&to=2",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().DiffNoteRevisions(gomock.Any(), userID, noteID, dto.NoteRevisionDiff{From: 1, To: 2}).Return(diff, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"diff":` + string(bytes) + `}`,
+		},
+		{
+			name:                 "missing from",
+			query:                "to=2",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"validation failed: Field: From, Tag: required, Param: "}`,
+		},
+		{
+			name:  "not found",
+			query: "from=1",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().DiffNoteRevisions(gomock.Any(), userID, noteID, dto.NoteRevisionDiff{From: 1}).Return(dto.NoteDiffOutput{}, domain.ErrNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"not found"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockRevisionHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.GET("/notes/:note_id/revisions/diff", func(c *gin.Context) {
+				c.Set(userIDKey, userID.String())
+				handler.diffNoteRevisions(c)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/notes/"+noteID.String()+"/revisions/diff?"+tt.query, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func Test_revisionH_restoreNoteRevision(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	noteID := uuid.New()
+
+	tests := []struct {
+		name                 string
+		rev                  string
+		ifMatch              string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "success",
+			rev:  "2",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().RestoreNoteRevision(gomock.Any(), userID, noteID, 2, nil).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"data":"ok"}`,
+		},
+		{
+			name:    "with If-Match",
+			rev:     "2",
+			ifMatch: `"5"`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().RestoreNoteRevision(gomock.Any(), userID, noteID, 2, &[]int{5}[0]).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"data":"ok"}`,
+		},
+		{
+			name:                 "malformed If-Match",
+			rev:                  "2",
+			ifMatch:              "5",
+			expectedStatusCode:   http.StatusPreconditionFailed,
+			expectedResponseBody: `{"error":"version mismatch"}`,
+		},
+		{
+			name:    "version mismatch",
+			rev:     "2",
+			ifMatch: `"4"`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().RestoreNoteRevision(gomock.Any(), userID, noteID, 2, &[]int{4}[0]).
+					Return(domain.MakeError(domain.ErrFailedToUpdate, domain.ErrVersionMismatch, "note"))
+			},
+			expectedStatusCode:   http.StatusPreconditionFailed,
+			expectedResponseBody: `{"error":"failed to update note: version mismatch"}`,
+		},
+		{
+			name:                 "invalid rev param",
+			rev:                  "abc",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"rev is not a positive number"}`,
+		},
+		{
+			name:                 "zero rev param",
+			rev:                  "0",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"rev is not a positive number"}`,
+		},
+		{
+			name: "not found",
+			rev:  "7",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().RestoreNoteRevision(gomock.Any(), userID, noteID, 7, nil).Return(domain.ErrNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"not found"}`,
+		},
+		{
+			name: "service error",
+			rev:  "2",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().RestoreNoteRevision(gomock.Any(), userID, noteID, 2, nil).Return(errors.New("service error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"service error"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockRevisionHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.POST("/notes/:note_id/revisions/:rev/restore", func(c *gin.Context) {
+				c.Set(userIDKey, userID.String())
+				handler.restoreNoteRevision(c)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/notes/"+noteID.String()+"/revisions/"+tt.rev+"/restore", nil)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
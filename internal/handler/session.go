@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type SessionSI interface {
+	Sessions(ctx context.Context, userID uuid.UUID, tokenID string) ([]dto.SessionOutput, error)
+	RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error
+	RevokeOtherSessions(ctx context.Context, userID uuid.UUID, tokenID string) error
+}
+
+type sessionH struct {
+	service SessionSI
+	log     *logger.Logger
+}
+
+func newSessionHandler(service SessionSI, log *logger.Logger) *sessionH {
+	return &sessionH{
+		service: service,
+		log:     log,
+	}
+}
+
+func (h *Handler) InitSessionAPIs(api *gin.RouterGroup) {
+	h.log.Info("init session APIs")
+	session := api.Group("/profile/sessions", h.authMiddleware)
+	{
+		session.GET("/", h.sessions)
+		session.DELETE("/", h.revokeOtherSessions)
+		session.DELETE("/:session_id", h.revokeSession)
+	}
+}
+
+func (s *sessionH) sessions(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		s.log.Debug("unauthorized access attempt",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("method", c.Request.Method),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	tokenID, _ := getRefreshToken(c)
+
+	sessions, err := s.service.Sessions(c.Request.Context(), userID, tokenID)
+	if err != nil {
+		s.log.Error("failed to get sessions",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	newSuccessResponse(c, http.StatusOK, "sessions", sessions)
+}
+
+func (s *sessionH) revokeSession(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		s.log.Debug("unauthorized access attempt",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("method", c.Request.Method),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	sessionID, err := getParamUUID(c, "session_id")
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := s.service.RevokeSession(c.Request.Context(), userID, sessionID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			newErrorResponse(c, http.StatusNotFound, err.Error())
+			return
+		}
+		s.log.Error("failed to revoke session",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.String("session_id", sessionID.String()),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	s.log.Info("session revoked",
+		zap.String("client_ip", c.ClientIP()),
+		zap.String("user_id", userID.String()),
+		zap.String("session_id", sessionID.String()),
+	)
+
+	newSuccessResponse(c, http.StatusOK, "data", "ok")
+}
+
+func (s *sessionH) revokeOtherSessions(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		s.log.Debug("unauthorized access attempt",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("method", c.Request.Method),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	tokenID, err := getRefreshToken(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	if err := s.service.RevokeOtherSessions(c.Request.Context(), userID, tokenID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			newErrorResponse(c, http.StatusUnauthorized, err.Error())
+			return
+		}
+		s.log.Error("failed to revoke other sessions",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	s.log.Info("other sessions revoked",
+		zap.String("client_ip", c.ClientIP()),
+		zap.String("user_id", userID.String()),
+	)
+
+	newSuccessResponse(c, http.StatusOK, "data", "ok")
+}
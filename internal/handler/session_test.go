@@ -0,0 +1,253 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	mock_handler "noteApp/internal/handler/mock"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func mockSessionHandler(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock_handler.MockServiceI)) *Handler {
+	t.Helper()
+
+	service := mock_handler.NewMockServiceI(ctrl)
+
+	if setupMock != nil {
+		setupMock(service)
+	}
+
+	return &Handler{
+		sessionH: newSessionHandler(service, logger.LoggerForTest()),
+	}
+}
+
+func Test_sessionH_sessions(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	sessionID := uuid.New()
+	usedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name                 string
+		cookie               *http.Cookie
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:   "success",
+			cookie: &http.Cookie{Name: refreshToken, Value: "current"},
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().Sessions(gomock.Any(), userID, "current").Return([]dto.SessionOutput{
+					{ID: sessionID, UserAgent: "curl", IP: "10.0.0.1", CreatedAt: usedAt, LastUsedAt: usedAt, Current: true},
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"sessions":[{"id":"` + sessionID.String() + `","user_agent":"curl","ip":"10.0.0.1","created_at":"2024-01-02T03:04:05Z","last_used_at":"2024-01-02T03:04:05Z","current":true}]}`,
+		},
+		{
+			name: "without cookie",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().Sessions(gomock.Any(), userID, "").Return([]dto.SessionOutput{}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"sessions":[]}`,
+		},
+		{
+			name: "service error",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().Sessions(gomock.Any(), userID, "").Return(nil, errors.New("service error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"service error"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockSessionHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.GET("/sessions", func(c *gin.Context) {
+				c.Set(userIDKey, userID.String())
+				handler.sessions(c)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/sessions", nil)
+			if tt.cookie != nil {
+				req.AddCookie(tt.cookie)
+			}
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func Test_sessionH_revokeSession(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	sessionID := uuid.New()
+
+	tests := []struct {
+		name                 string
+		sessionID            string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "success",
+			sessionID: sessionID.String(),
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().RevokeSession(gomock.Any(), userID, sessionID).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"data":"ok"}`,
+		},
+		{
+			name:                 "invalid session id",
+			sessionID:            "invalid",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"session_id is not uuid"}`,
+		},
+		{
+			name:      "not found",
+			sessionID: sessionID.String(),
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().RevokeSession(gomock.Any(), userID, sessionID).Return(domain.ErrNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"not found"}`,
+		},
+		{
+			name:      "service error",
+			sessionID: sessionID.String(),
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().RevokeSession(gomock.Any(), userID, sessionID).Return(errors.New("service error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"service error"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockSessionHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.DELETE("/sessions/:session_id", func(c *gin.Context) {
+				c.Set(userIDKey, userID.String())
+				handler.revokeSession(c)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", "/sessions/"+tt.sessionID, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func Test_sessionH_revokeOtherSessions(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+
+	tests := []struct {
+		name                 string
+		cookie               *http.Cookie
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:   "success",
+			cookie: &http.Cookie{Name: refreshToken, Value: "current"},
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().RevokeOtherSessions(gomock.Any(), userID, "current").Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"data":"ok"}`,
+		},
+		{
+			name:                 "without cookie",
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"error":"token ID not found in cookie: http: named cookie not present"}`,
+		},
+		{
+			name:   "unknown session",
+			cookie: &http.Cookie{Name: refreshToken, Value: "stale"},
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().RevokeOtherSessions(gomock.Any(), userID, "stale").Return(domain.ErrNotFound)
+			},
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"error":"not found"}`,
+		},
+		{
+			name:   "service error",
+			cookie: &http.Cookie{Name: refreshToken, Value: "current"},
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().RevokeOtherSessions(gomock.Any(), userID, "current").Return(errors.New("service error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"service error"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockSessionHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.DELETE("/sessions", func(c *gin.Context) {
+				c.Set(userIDKey, userID.String())
+				handler.revokeOtherSessions(c)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", "/sessions", nil)
+			if tt.cookie != nil {
+				req.AddCookie(tt.cookie)
+			}
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, w.Body.String())
+		})
+	}
+}
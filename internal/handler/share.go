@@ -0,0 +1,248 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+	"noteApp/pkg/valid"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type ShareSI interface {
+	ShareNote(ctx context.Context, share dto.NoteShareCreate) error
+	UnshareNote(ctx context.Context, share dto.NoteShareDelete) error
+	NoteShares(ctx context.Context, ownerID, noteID uuid.UUID) ([]dto.NoteShareOutput, error)
+	SharedNotes(ctx context.Context, userID uuid.UUID, p dto.Paginated) (dto.PaginatedResponse, error)
+}
+
+type shareH struct {
+	service ShareSI
+	log     *logger.Logger
+}
+
+func newShareHandler(service ShareSI, log *logger.Logger) *shareH {
+	return &shareH{
+		service: service,
+		log:     log,
+	}
+}
+
+func (h *Handler) InitShareAPIs(api *gin.RouterGroup) {
+	h.log.Info("init shares APIs")
+	share := api.Group("/notes/:note_id/shares", h.requireScope(domain.ScopeNotesRead, domain.ScopeNotesWrite), h.authMiddleware)
+	{
+		share.POST("/", h.requireVerified, h.shareNote)
+		share.GET("/", h.noteShares)
+		share.DELETE("/", h.unshareNote)
+	}
+
+	shared := api.Group("/shared", h.requireScope(domain.ScopeNotesRead, domain.ScopeNotesWrite), h.authMiddleware)
+	{
+		shared.GET("/", h.sharedNotes)
+	}
+}
+
+func (s *shareH) shareNote(c *gin.Context) {
+	userID, noteID, ok := s.noteParams(c)
+	if !ok {
+		return
+	}
+
+	var share dto.NoteShareCreate
+	if err := c.ShouldBindJSON(&share); err != nil {
+		s.log.Debug("invalid JSON in share note request",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	share.NoteID = noteID
+	share.OwnerID = userID
+
+	if err := valid.ValidateStruct(share); err != nil {
+		s.log.Debug("validation failed for share note",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := s.service.ShareNote(c.Request.Context(), share); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			newErrorResponse(c, http.StatusNotFound, err.Error())
+			return
+		}
+		s.log.Error("failed to share note",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.String("note_id", noteID.String()),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	s.log.Info("note shared successfully",
+		zap.String("client_ip", c.ClientIP()),
+		zap.String("user_id", userID.String()),
+		zap.String("note_id", noteID.String()),
+	)
+
+	newSuccessResponse(c, http.StatusOK, "data", "ok")
+}
+
+func (s *shareH) unshareNote(c *gin.Context) {
+	userID, noteID, ok := s.noteParams(c)
+	if !ok {
+		return
+	}
+
+	var share dto.NoteShareDelete
+	if err := c.ShouldBindQuery(&share); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	share.NoteID = noteID
+	share.OwnerID = userID
+
+	if err := valid.ValidateStruct(share); err != nil {
+		s.log.Debug("validation failed for unshare note",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := s.service.UnshareNote(c.Request.Context(), share); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			newErrorResponse(c, http.StatusNotFound, err.Error())
+			return
+		}
+		s.log.Error("failed to unshare note",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.String("note_id", noteID.String()),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	s.log.Info("note unshared successfully",
+		zap.String("client_ip", c.ClientIP()),
+		zap.String("user_id", userID.String()),
+		zap.String("note_id", noteID.String()),
+	)
+
+	newSuccessResponse(c, http.StatusOK, "data", "ok")
+}
+
+func (s *shareH) noteShares(c *gin.Context) {
+	userID, noteID, ok := s.noteParams(c)
+	if !ok {
+		return
+	}
+
+	shares, err := s.service.NoteShares(c.Request.Context(), userID, noteID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			newErrorResponse(c, http.StatusNotFound, err.Error())
+			return
+		}
+		s.log.Error("failed to get note shares",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.String("note_id", noteID.String()),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	newSuccessResponse(c, http.StatusOK, "shares", shares)
+}
+
+func (s *shareH) sharedNotes(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		s.log.Debug("unauthorized access attempt",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("method", c.Request.Method),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	var p dto.Paginated
+	if err := c.ShouldBindQuery(&p); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := valid.ValidateStruct(p); err != nil {
+		s.log.Debug("validation failed for shared notes request",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	notes, err := s.service.SharedNotes(c.Request.Context(), userID, p)
+	if err != nil {
+		s.log.Error("get shared notes failed",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	newSuccessResponse(c, http.StatusOK, "notes", notes)
+}
+
+func (s *shareH) noteParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	userID, err := getUserID(c)
+	if err != nil {
+		s.log.Debug("unauthorized access attempt",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("method", c.Request.Method),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	noteID, err := getParamUUID(c, "note_id")
+	if err != nil {
+		s.log.Debug("invalid note_id in URL",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.String("param_value", c.Param("note_id")),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return userID, noteID, true
+}
@@ -0,0 +1,298 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	mock_handler "noteApp/internal/handler/mock"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockShareHandler(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock_handler.MockServiceI)) *Handler {
+	t.Helper()
+
+	service := mock_handler.NewMockServiceI(ctrl)
+
+	if setupMock != nil {
+		setupMock(service)
+	}
+
+	return &Handler{
+		shareH: newShareHandler(service, logger.LoggerForTest()),
+	}
+}
+
+func Test_shareH_shareNote(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	noteID := uuid.New()
+
+	tests := []struct {
+		name                 string
+		param                string
+		body                 string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "success",
+			param: noteID.String(),
+			body:  `{"username":"bob","permission":"write"}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().ShareNote(gomock.Any(), dto.NoteShareCreate{
+					NoteID:     noteID,
+					OwnerID:    userID,
+					Username:   "bob",
+					Permission: "write",
+				}).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"data":"ok"}`,
+		},
+		{
+			name:                 "missing grantee",
+			param:                noteID.String(),
+			body:                 `{"permission":"read"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"validation failed: Field: Username, Tag: required_without, Param: Email; Field: Email, Tag: required_without, Param: Username"}`,
+		},
+		{
+			name:                 "invalid permission",
+			param:                noteID.String(),
+			body:                 `{"email":"bob@example.com","permission":"admin"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"validation failed: Field: Permission, Tag: oneof, Param: read write"}`,
+		},
+		{
+			name:                 "invalid note_id param",
+			param:                "123",
+			body:                 `{"username":"bob","permission":"read"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"note_id is not uuid"}`,
+		},
+		{
+			name:  "note or user not found",
+			param: noteID.String(),
+			body:  `{"email":"bob@example.com","permission":"read"}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().ShareNote(gomock.Any(), gomock.Any()).Return(domain.ErrNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"not found"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockShareHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.POST("/notes/:note_id/shares", func(c *gin.Context) {
+				c.Set(userIDKey, userID.String())
+				handler.shareNote(c)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/notes/"+tt.param+"/shares", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func Test_shareH_unshareNote(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	noteID := uuid.New()
+
+	tests := []struct {
+		name                 string
+		query                string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "success",
+			query: "email=bob@example.com",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().UnshareNote(gomock.Any(), dto.NoteShareDelete{
+					NoteID:  noteID,
+					OwnerID: userID,
+					Email:   "bob@example.com",
+				}).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"data":"ok"}`,
+		},
+		{
+			name:                 "missing grantee",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"validation failed: Field: Username, Tag: required_without, Param: Email; Field: Email, Tag: required_without, Param: Username"}`,
+		},
+		{
+			name:  "share not found",
+			query: "username=bob",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().UnshareNote(gomock.Any(), gomock.Any()).Return(domain.ErrNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"not found"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockShareHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.DELETE("/notes/:note_id/shares", func(c *gin.Context) {
+				c.Set(userIDKey, userID.String())
+				handler.unshareNote(c)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", "/notes/"+noteID.String()+"/shares?"+tt.query, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func Test_shareH_noteShares(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	noteID := uuid.New()
+
+	shares := []dto.NoteShareOutput{
+		{UserID: uuid.New(), Username: "bob", Email: "bob@example.com", Permission: "read", CreatedAt: time.Now()},
+	}
+
+	bytes, err := json.Marshal(shares)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name                 string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "success",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().NoteShares(gomock.Any(), userID, noteID).Return(shares, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"shares":` + string(bytes) + `}`,
+		},
+		{
+			name: "not found",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().NoteShares(gomock.Any(), userID, noteID).Return(nil, domain.ErrNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"not found"}`,
+		},
+		{
+			name: "service error",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().NoteShares(gomock.Any(), userID, noteID).Return(nil, errors.New("service error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"service error"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockShareHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.GET("/notes/:note_id/shares", func(c *gin.Context) {
+				c.Set(userIDKey, userID.String())
+				handler.noteShares(c)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/notes/"+noteID.String()+"/shares", nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func Test_shareH_sharedNotes(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+
+	notes := dto.MakePaginatedResponse([]dto.SharedNoteOutput{
+		{NoteOutput: dto.NoteOutput{ID: uuid.New(), UserID: uuid.New()}, Permission: "read"},
+	}, 1, 0, 10)
+
+	bytes, err := json.Marshal(notes)
+	require.NoError(t, err)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := mockShareHandler(t, ctrl, func(msi *mock_handler.MockServiceI) {
+		msi.EXPECT().SharedNotes(gomock.Any(), userID, dto.Paginated{Limit: 10}).Return(notes, nil)
+	})
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/shared", func(c *gin.Context) {
+		c.Set(userIDKey, userID.String())
+		handler.sharedNotes(c)
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/shared?limit=10", nil)
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `{"notes":`+string(bytes)+`}`, strings.TrimSpace(w.Body.String()))
+}
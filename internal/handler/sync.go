@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+	"noteApp/pkg/valid"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type SyncSI interface {
+	SyncChanges(ctx context.Context, userID uuid.UUID, p dto.SyncPull) (dto.SyncPullResponse, error)
+	ApplySyncChanges(ctx context.Context, userID uuid.UUID, push dto.SyncPush) dto.SyncPushResponse
+}
+
+type syncH struct {
+	service SyncSI
+	log     *logger.Logger
+}
+
+func newSyncHandler(service SyncSI, log *logger.Logger) *syncH {
+	return &syncH{
+		service: service,
+		log:     log,
+	}
+}
+
+func (h *Handler) InitSyncAPIs(api *gin.RouterGroup) {
+	h.log.Info("init sync APIs")
+	sync := api.Group("/sync", h.requireScope(domain.ScopeNotesRead, domain.ScopeNotesWrite), h.authMiddleware)
+	{
+		sync.GET("/", h.syncChanges)
+		sync.POST("/", h.applySyncChanges)
+	}
+}
+
+func (s *syncH) syncChanges(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		s.log.Debug("unauthorized access attempt",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("method", c.Request.Method),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	var p dto.SyncPull
+	if err := c.ShouldBindQuery(&p); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := valid.ValidateStruct(p); err != nil {
+		s.log.Debug("validation failed for sync request",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	changes, err := s.service.SyncChanges(c.Request.Context(), userID, p)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidSyncToken) {
+			newErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		s.log.Error("failed to get sync changes",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	newSuccessResponse(c, http.StatusOK, "sync", changes)
+}
+
+func (s *syncH) applySyncChanges(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		s.log.Debug("unauthorized access attempt",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("method", c.Request.Method),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	var push dto.SyncPush
+	if err := c.ShouldBindJSON(&push); err != nil {
+		s.log.Debug("invalid JSON in sync request",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := valid.ValidateStruct(push); err != nil {
+		s.log.Debug("validation failed for sync request",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	results := s.service.ApplySyncChanges(c.Request.Context(), userID, push)
+
+	s.log.Info("sync changes processed",
+		zap.String("client_ip", c.ClientIP()),
+		zap.String("user_id", userID.String()),
+		zap.Int("changes", len(push.Changes)),
+	)
+
+	newSuccessResponse(c, http.StatusOK, "sync", results)
+}
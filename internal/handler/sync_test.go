@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	mock_handler "noteApp/internal/handler/mock"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func mockSyncHandler(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock_handler.MockServiceI)) *Handler {
+	t.Helper()
+
+	service := mock_handler.NewMockServiceI(ctrl)
+
+	if setupMock != nil {
+		setupMock(service)
+	}
+
+	return &Handler{
+		syncH: newSyncHandler(service, logger.LoggerForTest()),
+	}
+}
+
+func Test_syncH_syncChanges(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	deletedID := uuid.New()
+
+	tests := []struct {
+		name                 string
+		query                string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "success",
+			query: "?since=MTA&limit=50",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().SyncChanges(gomock.Any(), userID, dto.SyncPull{Since: "MTA", Limit: 50}).Return(dto.SyncPullResponse{
+					Notes:   []dto.NoteOutput{},
+					Deleted: []uuid.UUID{deletedID},
+					Token:   "MTE",
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"sync":{"notes":[],"deleted":["` + deletedID.String() + `"],"token":"MTE","has_more":false}}`,
+		},
+		{
+			name:                 "invalid limit",
+			query:                "?limit=1000",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"validation failed: Field: Limit, Tag: max, Param: 500"}`,
+		},
+		{
+			name:  "invalid token",
+			query: "?since=bad",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().SyncChanges(gomock.Any(), userID, dto.SyncPull{Since: "bad"}).
+					Return(dto.SyncPullResponse{}, domain.ErrInvalidSyncToken)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"invalid sync token"}`,
+		},
+		{
+			name: "service error",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().SyncChanges(gomock.Any(), userID, dto.SyncPull{}).
+					Return(dto.SyncPullResponse{}, errors.New("db error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"db error"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockSyncHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.GET("/sync", func(c *gin.Context) {
+				c.Set(userIDKey, userID.String())
+				handler.syncChanges(c)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/sync"+tt.query, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func Test_syncH_applySyncChanges(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	noteID := uuid.New()
+	version := 2
+
+	tests := []struct {
+		name                 string
+		body                 string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "success",
+			body: `{"changes":[{"op":"delete","id":"` + noteID.String() + `","version":2}]}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().ApplySyncChanges(gomock.Any(), userID, dto.SyncPush{Changes: []dto.SyncChange{
+					{Op: "delete", ID: noteID, Version: &version},
+				}}).Return(dto.SyncPushResponse{Results: []dto.SyncResult{
+					{ID: noteID, Op: "delete", Status: domain.NoteOpStatusConflict, Error: "version mismatch"},
+				}})
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"sync":{"results":[{"id":"` + noteID.String() + `","op":"delete","status":"conflict","error":"version mismatch"}]}}`,
+		},
+		{
+			name:                 "empty batch",
+			body:                 `{"changes":[]}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"validation failed: Field: Changes, Tag: min, Param: 1"}`,
+		},
+		{
+			name:                 "invalid JSON",
+			body:                 `{"changes":`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"unexpected EOF"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockSyncHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.POST("/sync", func(c *gin.Context) {
+				c.Set(userIDKey, userID.String())
+				handler.applySyncChanges(c)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/sync", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
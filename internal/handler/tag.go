@@ -0,0 +1,282 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+	"noteApp/pkg/valid"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type TagSI interface {
+	Tags(ctx context.Context, userID uuid.UUID) ([]dto.TagOutput, error)
+	RenameTag(ctx context.Context, tag dto.TagUpdate) error
+	MergeTags(ctx context.Context, merge dto.TagMerge) error
+	DeleteTag(ctx context.Context, userID, tagID uuid.UUID) error
+}
+
+type tagH struct {
+	service TagSI
+	log     *logger.Logger
+}
+
+func newTagHandler(service TagSI, log *logger.Logger) *tagH {
+	return &tagH{
+		service: service,
+		log:     log,
+	}
+}
+
+func (h *Handler) InitTagAPIs(api *gin.RouterGroup) {
+	h.log.Info("init tags APIs")
+	tag := api.Group("/tags", h.requireScope(domain.ScopeNotesRead, domain.ScopeNotesWrite), h.authMiddleware)
+	{
+		tag.GET("/", h.tags)
+		tag.PUT("/:tag_id", h.renameTag)
+		tag.POST("/:tag_id/merge", h.mergeTags)
+		tag.DELETE("/:tag_id", h.deleteTag)
+	}
+}
+
+func (t *tagH) tags(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		t.log.Debug("unauthorized access attempt",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("method", c.Request.Method),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	tags, err := t.service.Tags(c.Request.Context(), userID)
+	if err != nil {
+		t.log.Error("get tags failed",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	t.log.Info("tags retrieved successfully",
+		zap.String("client_ip", c.ClientIP()),
+		zap.String("user_id", userID.String()),
+		zap.Int("count", len(tags)),
+	)
+
+	newSuccessResponse(c, http.StatusOK, "tags", tags)
+}
+
+func (t *tagH) renameTag(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		t.log.Debug("unauthorized access attempt",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("method", c.Request.Method),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	tagID, err := getParamUUID(c, "tag_id")
+	if err != nil {
+		t.log.Debug("invalid tag_id in URL",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.String("param_value", c.Param("tag_id")),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var tag dto.TagUpdate
+	if err := c.ShouldBindJSON(&tag); err != nil {
+		t.log.Debug("invalid JSON in rename tag request",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tag.ID = tagID
+	tag.UserID = userID
+
+	if err := valid.ValidateStruct(tag); err != nil {
+		t.log.Debug("validation failed for rename tag",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.String("tag_id", tagID.String()),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := t.service.RenameTag(c.Request.Context(), tag); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			newErrorResponse(c, http.StatusNotFound, err.Error())
+		case errors.Is(err, domain.ErrAlreadyExists):
+			newErrorResponse(c, http.StatusConflict, err.Error())
+		default:
+			t.log.Error("failed to rename tag",
+				zap.Error(err),
+				zap.String("client_ip", c.ClientIP()),
+				zap.String("user_id", userID.String()),
+				zap.String("tag_id", tagID.String()),
+			)
+			newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	t.log.Info("tag renamed successfully",
+		zap.String("client_ip", c.ClientIP()),
+		zap.String("user_id", userID.String()),
+		zap.String("tag_id", tagID.String()),
+	)
+
+	newSuccessResponse(c, http.StatusOK, "data", "ok")
+}
+
+func (t *tagH) mergeTags(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		t.log.Debug("unauthorized access attempt",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("method", c.Request.Method),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	tagID, err := getParamUUID(c, "tag_id")
+	if err != nil {
+		t.log.Debug("invalid tag_id in URL",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.String("param_value", c.Param("tag_id")),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var merge dto.TagMerge
+	if err := c.ShouldBindJSON(&merge); err != nil {
+		t.log.Debug("invalid JSON in merge tags request",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	merge.TargetID = tagID
+	merge.UserID = userID
+
+	if err := valid.ValidateStruct(merge); err != nil {
+		t.log.Debug("validation failed for merge tags",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.String("tag_id", tagID.String()),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := t.service.MergeTags(c.Request.Context(), merge); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			newErrorResponse(c, http.StatusNotFound, err.Error())
+			return
+		}
+		if errors.Is(err, domain.ErrTagMergeSelf) {
+			newErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		t.log.Error("failed to merge tags",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.String("tag_id", tagID.String()),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	t.log.Info("tags merged successfully",
+		zap.String("client_ip", c.ClientIP()),
+		zap.String("user_id", userID.String()),
+		zap.String("tag_id", tagID.String()),
+	)
+
+	newSuccessResponse(c, http.StatusOK, "data", "ok")
+}
+
+func (t *tagH) deleteTag(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		t.log.Debug("unauthorized access attempt in deleteTag",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("method", c.Request.Method),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	tagID, err := getParamUUID(c, "tag_id")
+	if err != nil {
+		t.log.Debug("invalid tag_id in URL",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.String("param_value", c.Param("tag_id")),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := t.service.DeleteTag(c.Request.Context(), userID, tagID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			newErrorResponse(c, http.StatusNotFound, err.Error())
+			return
+		}
+		t.log.Error("failed to delete tag",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.String("tag_id", tagID.String()),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	t.log.Info("tag deleted successfully",
+		zap.String("client_ip", c.ClientIP()),
+		zap.String("user_id", userID.String()),
+		zap.String("tag_id", tagID.String()),
+	)
+
+	newSuccessResponse(c, http.StatusOK, "data", "ok")
+}
@@ -0,0 +1,416 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	mock_handler "noteApp/internal/handler/mock"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockTagHandler(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock_handler.MockServiceI)) *Handler {
+	t.Helper()
+
+	service := mock_handler.NewMockServiceI(ctrl)
+
+	if setupMock != nil {
+		setupMock(service)
+	}
+
+	return &Handler{
+		tagH: newTagHandler(service, logger.LoggerForTest()),
+	}
+}
+
+func Test_tagH_tags(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+
+	tags := []dto.TagOutput{
+		{
+			ID:        uuid.New(),
+			Name:      "work",
+			Notes:     3,
+			CreatedAt: time.Now(),
+		},
+	}
+
+	bytes, err := json.Marshal(tags)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name                 string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "success",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().Tags(gomock.Any(), userID).Return(tags, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"tags":` + string(bytes) + `}`,
+		},
+		{
+			name:                 "missing user_id in context",
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"error":"user id not found in context"}`,
+		},
+		{
+			name: "service error",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().Tags(gomock.Any(), userID).Return(nil, errors.New("service error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"service error"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockTagHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.GET("/tags", func(c *gin.Context) {
+				if tt.name != "missing user_id in context" {
+					c.Set(userIDKey, userID.String())
+				}
+				handler.tags(c)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/tags", nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func Test_tagH_renameTag(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	tagID := uuid.New()
+
+	tests := []struct {
+		name                 string
+		param                string
+		inputBody            string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "success",
+			param:     tagID.String(),
+			inputBody: `{"name":"personal"}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().RenameTag(gomock.Any(), dto.TagUpdate{
+					ID:     tagID,
+					UserID: userID,
+					Name:   "personal",
+				}).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"data":"ok"}`,
+		},
+		{
+			name:                 "missing user_id in context",
+			param:                tagID.String(),
+			inputBody:            `{"name":"personal"}`,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"error":"user id not found in context"}`,
+		},
+		{
+			name:                 "invalid tag_id param",
+			param:                "123",
+			inputBody:            `{"name":"personal"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"tag_id is not uuid"}`,
+		},
+		{
+			name:                 "empty body",
+			param:                tagID.String(),
+			inputBody:            ``,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"EOF"}`,
+		},
+		{
+			name:                 "empty name",
+			param:                tagID.String(),
+			inputBody:            `{"name":""}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"validation failed: Field: Name, Tag: required, Param: "}`,
+		},
+		{
+			name:      "not found",
+			param:     tagID.String(),
+			inputBody: `{"name":"personal"}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().RenameTag(gomock.Any(), gomock.Any()).Return(domain.ErrNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"not found"}`,
+		},
+		{
+			name:      "already exists",
+			param:     tagID.String(),
+			inputBody: `{"name":"personal"}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().RenameTag(gomock.Any(), gomock.Any()).Return(domain.ErrAlreadyExists)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"error":"already exists"}`,
+		},
+		{
+			name:      "service error",
+			param:     tagID.String(),
+			inputBody: `{"name":"personal"}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().RenameTag(gomock.Any(), gomock.Any()).Return(errors.New("service error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"service error"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockTagHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.PUT("/tags/:tag_id", func(c *gin.Context) {
+				if tt.name != "missing user_id in context" {
+					c.Set(userIDKey, userID.String())
+				}
+				handler.renameTag(c)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", "/tags/"+tt.param, strings.NewReader(tt.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func Test_tagH_mergeTags(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	tagID := uuid.New()
+	sourceID := uuid.New()
+
+	tests := []struct {
+		name                 string
+		param                string
+		inputBody            string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "success",
+			param:     tagID.String(),
+			inputBody: `{"tag_ids":["` + sourceID.String() + `"]}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().MergeTags(gomock.Any(), dto.TagMerge{
+					TargetID:  tagID,
+					UserID:    userID,
+					SourceIDs: []uuid.UUID{sourceID},
+				}).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"data":"ok"}`,
+		},
+		{
+			name:                 "missing user_id in context",
+			param:                tagID.String(),
+			inputBody:            `{"tag_ids":["` + sourceID.String() + `"]}`,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"error":"user id not found in context"}`,
+		},
+		{
+			name:                 "invalid tag_id param",
+			param:                "123",
+			inputBody:            `{"tag_ids":["` + sourceID.String() + `"]}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"tag_id is not uuid"}`,
+		},
+		{
+			name:                 "empty tag ids",
+			param:                tagID.String(),
+			inputBody:            `{"tag_ids":[]}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"validation failed: Field: SourceIDs, Tag: min, Param: 1"}`,
+		},
+		{
+			name:      "not found",
+			param:     tagID.String(),
+			inputBody: `{"tag_ids":["` + sourceID.String() + `"]}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().MergeTags(gomock.Any(), gomock.Any()).Return(domain.ErrNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"not found"}`,
+		},
+		{
+			name:      "merge into itself",
+			param:     tagID.String(),
+			inputBody: `{"tag_ids":["` + tagID.String() + `"]}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().MergeTags(gomock.Any(), gomock.Any()).Return(domain.ErrTagMergeSelf)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"tag cannot be merged into itself"}`,
+		},
+		{
+			name:      "service error",
+			param:     tagID.String(),
+			inputBody: `{"tag_ids":["` + sourceID.String() + `"]}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().MergeTags(gomock.Any(), gomock.Any()).Return(errors.New("service error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"service error"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockTagHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.POST("/tags/:tag_id/merge", func(c *gin.Context) {
+				if tt.name != "missing user_id in context" {
+					c.Set(userIDKey, userID.String())
+				}
+				handler.mergeTags(c)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/tags/"+tt.param+"/merge", strings.NewReader(tt.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func Test_tagH_deleteTag(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+
+	tests := []struct {
+		name                 string
+		param                string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "success",
+			param: uuid.New().String(),
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().DeleteTag(gomock.Any(), userID, gomock.Any()).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"data":"ok"}`,
+		},
+		{
+			name:                 "missing user_id in context",
+			param:                uuid.New().String(),
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"error":"user id not found in context"}`,
+		},
+		{
+			name:                 "invalid tag_id param",
+			param:                "123",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"tag_id is not uuid"}`,
+		},
+		{
+			name:  "not found",
+			param: uuid.New().String(),
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().DeleteTag(gomock.Any(), gomock.Any(), gomock.Any()).Return(domain.ErrNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"not found"}`,
+		},
+		{
+			name:  "service error",
+			param: uuid.New().String(),
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().DeleteTag(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("service error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"service error"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockTagHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.DELETE("/tags/:tag_id", func(c *gin.Context) {
+				if tt.name != "missing user_id in context" {
+					c.Set(userIDKey, userID.String())
+				}
+				handler.deleteTag(c)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", "/tags/"+tt.param, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+	"noteApp/pkg/valid"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type TrashSI interface {
+	TrashedNotes(ctx context.Context, userID uuid.UUID, p dto.Paginated) (dto.PaginatedResponse, error)
+	RestoreNote(ctx context.Context, userID, noteID uuid.UUID) error
+	PurgeNote(ctx context.Context, userID, noteID uuid.UUID) error
+	EmptyTrash(ctx context.Context, userID uuid.UUID) (int64, error)
+}
+
+type trashH struct {
+	service TrashSI
+	log     *logger.Logger
+}
+
+func newTrashHandler(service TrashSI, log *logger.Logger) *trashH {
+	return &trashH{
+		service: service,
+		log:     log,
+	}
+}
+
+func (h *Handler) InitTrashAPIs(api *gin.RouterGroup) {
+	h.log.Info("init trash APIs")
+	trash := api.Group("/trash", h.requireScope(domain.ScopeNotesRead, domain.ScopeNotesWrite), h.authMiddleware)
+	{
+		trash.GET("/", h.trashedNotes)
+		trash.DELETE("/", h.emptyTrash)
+		trash.POST("/:note_id/restore", h.restoreNote)
+		trash.DELETE("/:note_id", h.purgeNote)
+	}
+}
+
+func (t *trashH) trashedNotes(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		t.log.Debug("unauthorized access attempt",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("method", c.Request.Method),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	var p dto.Paginated
+	if err := c.ShouldBindQuery(&p); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := valid.ValidateStruct(p); err != nil {
+		t.log.Debug("validation failed for trash request",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	notes, err := t.service.TrashedNotes(c.Request.Context(), userID, p)
+	if err != nil {
+		t.log.Error("get trashed notes failed",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	newSuccessResponse(c, http.StatusOK, "notes", notes)
+}
+
+func (t *trashH) restoreNote(c *gin.Context) {
+	userID, noteID, ok := t.noteParams(c)
+	if !ok {
+		return
+	}
+
+	if err := t.service.RestoreNote(c.Request.Context(), userID, noteID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			newErrorResponse(c, http.StatusNotFound, err.Error())
+			return
+		}
+		t.log.Error("failed to restore note",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.String("note_id", noteID.String()),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	t.log.Info("note restored successfully",
+		zap.String("client_ip", c.ClientIP()),
+		zap.String("user_id", userID.String()),
+		zap.String("note_id", noteID.String()),
+	)
+
+	newSuccessResponse(c, http.StatusOK, "data", "ok")
+}
+
+func (t *trashH) purgeNote(c *gin.Context) {
+	userID, noteID, ok := t.noteParams(c)
+	if !ok {
+		return
+	}
+
+	if err := t.service.PurgeNote(c.Request.Context(), userID, noteID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			newErrorResponse(c, http.StatusNotFound, err.Error())
+			return
+		}
+		t.log.Error("failed to purge note",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.String("note_id", noteID.String()),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	t.log.Info("note purged successfully",
+		zap.String("client_ip", c.ClientIP()),
+		zap.String("user_id", userID.String()),
+		zap.String("note_id", noteID.String()),
+	)
+
+	newSuccessResponse(c, http.StatusOK, "data", "ok")
+}
+
+func (t *trashH) emptyTrash(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		t.log.Debug("unauthorized access attempt",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("method", c.Request.Method),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	purged, err := t.service.EmptyTrash(c.Request.Context(), userID)
+	if err != nil {
+		t.log.Error("failed to empty trash",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	t.log.Info("trash emptied successfully",
+		zap.String("client_ip", c.ClientIP()),
+		zap.String("user_id", userID.String()),
+		zap.Int64("purged", purged),
+	)
+
+	newSuccessResponse(c, http.StatusOK, "purged", purged)
+}
+
+func (t *trashH) noteParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	userID, err := getUserID(c)
+	if err != nil {
+		t.log.Debug("unauthorized access attempt",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("method", c.Request.Method),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	noteID, err := getParamUUID(c, "note_id")
+	if err != nil {
+		t.log.Debug("invalid note_id in URL",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.String("param_value", c.Param("note_id")),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return userID, noteID, true
+}
@@ -0,0 +1,274 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	mock_handler "noteApp/internal/handler/mock"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockTrashHandler(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock_handler.MockServiceI)) *Handler {
+	t.Helper()
+
+	service := mock_handler.NewMockServiceI(ctrl)
+
+	if setupMock != nil {
+		setupMock(service)
+	}
+
+	return &Handler{
+		trashH: newTrashHandler(service, logger.LoggerForTest()),
+	}
+}
+
+func Test_trashH_trashedNotes(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+
+	notes := dto.MakePaginatedResponse([]dto.TrashedNoteOutput{
+		{
+			NoteOutput: dto.NoteOutput{ID: uuid.New(), UserID: userID, Heading: "heading"},
+			DeletedAt:  time.Now(),
+		},
+	}, 1, 0, 10)
+
+	bytes, err := json.Marshal(notes)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name                 string
+		query                string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "success",
+			query: "limit=10&offset=0",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().TrashedNotes(gomock.Any(), userID, dto.Paginated{Limit: 10}).Return(notes, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"notes":` + string(bytes) + `}`,
+		},
+		{
+			name:                 "invalid limit",
+			query:                "limit=1",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"validation failed: Field: Limit, Tag: gte, Param: 10"}`,
+		},
+		{
+			name:  "service error",
+			query: "limit=10",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().TrashedNotes(gomock.Any(), userID, dto.Paginated{Limit: 10}).Return(dto.PaginatedResponse{}, errors.New("service error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"service error"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockTrashHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.GET("/trash", func(c *gin.Context) {
+				c.Set(userIDKey, userID.String())
+				handler.trashedNotes(c)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/trash?"+tt.query, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func Test_trashH_restoreNote(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	noteID := uuid.New()
+
+	tests := []struct {
+		name                 string
+		param                string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "success",
+			param: noteID.String(),
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().RestoreNote(gomock.Any(), userID, noteID).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"data":"ok"}`,
+		},
+		{
+			name:                 "missing user_id in context",
+			param:                noteID.String(),
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"error":"user id not found in context"}`,
+		},
+		{
+			name:                 "invalid note_id param",
+			param:                "123",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"note_id is not uuid"}`,
+		},
+		{
+			name:  "not found",
+			param: noteID.String(),
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().RestoreNote(gomock.Any(), userID, noteID).Return(domain.ErrNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"not found"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockTrashHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.POST("/trash/:note_id/restore", func(c *gin.Context) {
+				if tt.name != "missing user_id in context" {
+					c.Set(userIDKey, userID.String())
+				}
+				handler.restoreNote(c)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/trash/"+tt.param+"/restore", nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func Test_trashH_purgeNote(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	noteID := uuid.New()
+
+	tests := []struct {
+		name                 string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "success",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().PurgeNote(gomock.Any(), userID, noteID).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"data":"ok"}`,
+		},
+		{
+			name: "not found",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().PurgeNote(gomock.Any(), userID, noteID).Return(domain.ErrNotFound)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"error":"not found"}`,
+		},
+		{
+			name: "service error",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().PurgeNote(gomock.Any(), userID, noteID).Return(errors.New("service error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"service error"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockTrashHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.DELETE("/trash/:note_id", func(c *gin.Context) {
+				c.Set(userIDKey, userID.String())
+				handler.purgeNote(c)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", "/trash/"+noteID.String(), nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func Test_trashH_emptyTrash(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := mockTrashHandler(t, ctrl, func(msi *mock_handler.MockServiceI) {
+		msi.EXPECT().EmptyTrash(gomock.Any(), userID).Return(int64(4), nil)
+	})
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.DELETE("/trash", func(c *gin.Context) {
+		c.Set(userIDKey, userID.String())
+		handler.emptyTrash(c)
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("DELETE", "/trash", nil)
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `{"purged":4}`, strings.TrimSpace(w.Body.String()))
+}
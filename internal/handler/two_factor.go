@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+	"noteApp/pkg/valid"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type TwoFactorSI interface {
+	EnrollTwoFactor(ctx context.Context, userID uuid.UUID) (dto.TwoFactorEnrollment, error)
+	ConfirmTwoFactor(ctx context.Context, data dto.TwoFactorCode) ([]string, error)
+	DisableTwoFactor(ctx context.Context, data dto.TwoFactorCode) error
+}
+
+type twoFactorH struct {
+	service TwoFactorSI
+	log     *logger.Logger
+}
+
+func newTwoFactorHandler(service TwoFactorSI, log *logger.Logger) *twoFactorH {
+	return &twoFactorH{
+		service: service,
+		log:     log,
+	}
+}
+
+func (h *Handler) InitTwoFactorAPIs(api *gin.RouterGroup) {
+	h.log.Info("init two-factor APIs")
+	twoFactor := api.Group("/profile/2fa", h.authMiddleware)
+	{
+		twoFactor.POST("/enroll", h.enrollTwoFactor)
+		twoFactor.POST("/confirm", h.confirmTwoFactor)
+		twoFactor.POST("/disable", h.disableTwoFactor)
+	}
+}
+
+func (t *twoFactorH) enrollTwoFactor(c *gin.Context) {
+	userID, err := getUserID(c)
+	if err != nil {
+		t.log.Debug("unauthorized access attempt",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("method", c.Request.Method),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	enrollment, err := t.service.EnrollTwoFactor(c.Request.Context(), userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrTwoFactorEnabled) {
+			newErrorResponse(c, http.StatusConflict, domain.ErrTwoFactorEnabled.Error())
+			return
+		}
+		t.log.Error("two-factor enrollment failed",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	newSuccessResponse(c, http.StatusOK, "two_factor", enrollment)
+}
+
+func (t *twoFactorH) confirmTwoFactor(c *gin.Context) {
+	data, ok := t.bindCode(c)
+	if !ok {
+		return
+	}
+
+	codes, err := t.service.ConfirmTwoFactor(c.Request.Context(), data)
+	if err != nil {
+		t.codeError(c, data.UserID, err)
+		return
+	}
+
+	t.log.Info("two-factor enabled successfully",
+		zap.String("client_ip", c.ClientIP()),
+		zap.String("user_id", data.UserID.String()),
+	)
+
+	newSuccessResponse(c, http.StatusOK, "recovery_codes", codes)
+}
+
+func (t *twoFactorH) disableTwoFactor(c *gin.Context) {
+	data, ok := t.bindCode(c)
+	if !ok {
+		return
+	}
+
+	if err := t.service.DisableTwoFactor(c.Request.Context(), data); err != nil {
+		t.codeError(c, data.UserID, err)
+		return
+	}
+
+	t.log.Info("two-factor disabled successfully",
+		zap.String("client_ip", c.ClientIP()),
+		zap.String("user_id", data.UserID.String()),
+	)
+
+	newSuccessResponse(c, http.StatusOK, "data", "ok")
+}
+
+func (t *twoFactorH) bindCode(c *gin.Context) (dto.TwoFactorCode, bool) {
+	userID, err := getUserID(c)
+	if err != nil {
+		t.log.Debug("unauthorized access attempt",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("method", c.Request.Method),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusUnauthorized, err.Error())
+		return dto.TwoFactorCode{}, false
+	}
+
+	var data dto.TwoFactorCode
+	if err := c.ShouldBindJSON(&data); err != nil {
+		t.log.Debug("invalid JSON in two-factor request",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return dto.TwoFactorCode{}, false
+	}
+
+	data.UserID = userID
+
+	if err := valid.ValidateStruct(data); err != nil {
+		t.log.Debug("validation failed for two-factor request",
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return dto.TwoFactorCode{}, false
+	}
+
+	return data, true
+}
+
+func (t *twoFactorH) codeError(c *gin.Context, userID uuid.UUID, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidOTP):
+		newErrorResponse(c, http.StatusBadRequest, domain.ErrInvalidOTP.Error())
+	case errors.Is(err, domain.ErrTwoFactorEnabled), errors.Is(err, domain.ErrNoTwoFactor):
+		newErrorResponse(c, http.StatusConflict, err.Error())
+	default:
+		t.log.Error("two-factor request failed",
+			zap.Error(err),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_id", userID.String()),
+		)
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+}
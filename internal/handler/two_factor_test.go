@@ -0,0 +1,264 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	mock_handler "noteApp/internal/handler/mock"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func mockTwoFactorHandler(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock_handler.MockServiceI)) *Handler {
+	t.Helper()
+
+	service := mock_handler.NewMockServiceI(ctrl)
+
+	if setupMock != nil {
+		setupMock(service)
+	}
+
+	return &Handler{
+		twoFactorH: newTwoFactorHandler(service, logger.LoggerForTest()),
+	}
+}
+
+func Test_twoFactorH_enrollTwoFactor(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+
+	tests := []struct {
+		name                 string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "success",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().EnrollTwoFactor(gomock.Any(), userID).Return(dto.TwoFactorEnrollment{
+					Secret: "SECRET",
+					URI:    "otpauth://totp/NoteApp:alice@example.com?secret=SECRET",
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"two_factor":{"secret":"SECRET","uri":"otpauth://totp/NoteApp:alice@example.com?secret=SECRET"}}`,
+		},
+		{
+			name: "already enabled",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().EnrollTwoFactor(gomock.Any(), userID).Return(dto.TwoFactorEnrollment{}, domain.MakeError(domain.ErrFailedToCreate, domain.ErrTwoFactorEnabled, "two-factor"))
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"error":"two-factor authentication already enabled"}`,
+		},
+		{
+			name: "service error",
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().EnrollTwoFactor(gomock.Any(), userID).Return(dto.TwoFactorEnrollment{}, errors.New("service error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"service error"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockTwoFactorHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.POST("/profile/2fa/enroll", func(c *gin.Context) {
+				c.Set(userIDKey, userID.String())
+				handler.enrollTwoFactor(c)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/profile/2fa/enroll", nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func Test_twoFactorH_confirmTwoFactor(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+
+	tests := []struct {
+		name                 string
+		inputBody            string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "success",
+			inputBody: `{"code":"123456"}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().ConfirmTwoFactor(gomock.Any(), dto.TwoFactorCode{UserID: userID, Code: "123456"}).Return([]string{"aaaaa-bbbbb", "ccccc-ddddd"}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"recovery_codes":["aaaaa-bbbbb","ccccc-ddddd"]}`,
+		},
+		{
+			name:                 "missing code",
+			inputBody:            `{}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"validation failed: Field: Code, Tag: required, Param: "}`,
+		},
+		{
+			name:                 "empty body",
+			inputBody:            ``,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"EOF"}`,
+		},
+		{
+			name:      "invalid code",
+			inputBody: `{"code":"000000"}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().ConfirmTwoFactor(gomock.Any(), gomock.Any()).Return(nil, domain.ErrInvalidOTP)
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"invalid two-factor code"}`,
+		},
+		{
+			name:      "not enrolled",
+			inputBody: `{"code":"123456"}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().ConfirmTwoFactor(gomock.Any(), gomock.Any()).Return(nil, domain.ErrNoTwoFactor)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"error":"two-factor authentication not enabled"}`,
+		},
+		{
+			name:      "service error",
+			inputBody: `{"code":"123456"}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().ConfirmTwoFactor(gomock.Any(), gomock.Any()).Return(nil, errors.New("service error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"service error"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockTwoFactorHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.POST("/profile/2fa/confirm", func(c *gin.Context) {
+				c.Set(userIDKey, userID.String())
+				handler.confirmTwoFactor(c)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/profile/2fa/confirm", strings.NewReader(tt.inputBody))
+			req.Header.Set("Content-type", "application/json")
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, w.Body.String())
+		})
+	}
+}
+
+func Test_twoFactorH_disableTwoFactor(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+
+	tests := []struct {
+		name                 string
+		inputBody            string
+		f                    func(*mock_handler.MockServiceI)
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "success",
+			inputBody: `{"code":"aaaaa-bbbbb"}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().DisableTwoFactor(gomock.Any(), dto.TwoFactorCode{UserID: userID, Code: "aaaaa-bbbbb"}).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"data":"ok"}`,
+		},
+		{
+			name:      "invalid code",
+			inputBody: `{"code":"000000"}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().DisableTwoFactor(gomock.Any(), gomock.Any()).Return(domain.MakeError(domain.ErrFailedToUpdate, domain.ErrInvalidOTP, "two-factor"))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"error":"invalid two-factor code"}`,
+		},
+		{
+			name:      "not enabled",
+			inputBody: `{"code":"123456"}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().DisableTwoFactor(gomock.Any(), gomock.Any()).Return(domain.ErrNoTwoFactor)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"error":"two-factor authentication not enabled"}`,
+		},
+		{
+			name:      "service error",
+			inputBody: `{"code":"123456"}`,
+			f: func(msi *mock_handler.MockServiceI) {
+				msi.EXPECT().DisableTwoFactor(gomock.Any(), gomock.Any()).Return(errors.New("service error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"error":"service error"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := mockTwoFactorHandler(t, ctrl, tt.f)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.POST("/profile/2fa/disable", func(c *gin.Context) {
+				c.Set(userIDKey, userID.String())
+				handler.disableTwoFactor(c)
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/profile/2fa/disable", strings.NewReader(tt.inputBody))
+			req.Header.Set("Content-type", "application/json")
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package domain
+
+import "github.com/google/uuid"
+
+const (
+	NoteOpCreate = "create"
+	NoteOpUpdate = "update"
+	NoteOpDelete = "delete"
+)
+
+const (
+	NoteOpStatusApplied   = "applied"
+	NoteOpStatusConflict  = "conflict"
+	NoteOpStatusNotFound  = "not_found"
+	NoteOpStatusForbidden = "forbidden"
+	NoteOpStatusInvalid   = "invalid"
+	NoteOpStatusAborted   = "aborted"
+	NoteOpStatusFailed    = "failed"
+)
+
+const (
+	NoteBatchAtomic     = "atomic"
+	NoteBatchBestEffort = "best_effort"
+)
+
+type NoteBatchOp struct {
+	Op      string
+	NoteID  uuid.UUID
+	Create  Note
+	Update  NoteUpdate
+	Version *int
+}
@@ -14,6 +14,24 @@ var (
 	ErrNoFieldsToUpdate  = errors.New("no fields to update")
 	ErrInvalidUUID       = errors.New("invalid UUID")
 	ErrIncorrectPassword = errors.New("incorrect password")
+	ErrAlreadyExists     = errors.New("already exists")
+	ErrNotebookCycle     = errors.New("notebook cannot be moved into itself or its descendants")
+	ErrTagMergeSelf      = errors.New("tag cannot be merged into itself")
+	ErrEmptySearchQuery  = errors.New("search query has no searchable words")
+	ErrInvalidCursor     = errors.New("invalid cursor")
+	ErrVersionMismatch   = errors.New("version mismatch")
+	ErrForbidden         = errors.New("forbidden")
+	ErrInvalidSyncToken  = errors.New("invalid sync token")
+	ErrBatchAborted      = errors.New("batch aborted")
+	ErrTokenReused       = errors.New("refresh token reused")
+	ErrAccountDisabled   = errors.New("account disabled")
+	ErrTwoFactorEnabled  = errors.New("two-factor authentication already enabled")
+	ErrNoTwoFactor       = errors.New("two-factor authentication not enabled")
+	ErrInvalidOTP        = errors.New("invalid two-factor code")
+	ErrEmailNotVerified  = errors.New("email not verified")
+	ErrEmailVerified     = errors.New("email already verified")
+	ErrInvalidEmailToken = errors.New("invalid or expired token")
+	ErrTooManyAttempts   = errors.New("too many sign-in attempts, try again later")
 )
 
 func MakeError(dErr, err error, object string) error {
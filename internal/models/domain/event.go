@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	NoteEventCreated = "created"
+	NoteEventUpdated = "updated"
+	NoteEventDeleted = "deleted"
+	NoteEventRevoked = "revoked"
+)
+
+type NoteEvent struct {
+	ID        int64
+	UserID    uuid.UUID
+	NoteID    uuid.UUID
+	ActorID   uuid.UUID
+	Type      string
+	CreatedAt time.Time
+}
+
+func NoteTopic(noteID uuid.UUID) string {
+	return "notes:" + noteID.String()
+}
+
+func UserTopic(userID uuid.UUID) string {
+	return "users:" + userID.String()
+}
@@ -0,0 +1,17 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type NoteLink struct {
+	ID           uuid.UUID
+	NoteID       uuid.UUID
+	OwnerID      uuid.UUID
+	TokenHash    string
+	PasswordHash *string
+	ExpiresAt    *time.Time
+	CreatedAt    time.Time
+}
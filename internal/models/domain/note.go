@@ -8,12 +8,38 @@ import (
 )
 
 type Note struct {
-	ID        uuid.UUID
-	UserID    uuid.UUID
-	Heading   string
-	Content   string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	NotebookID *uuid.UUID
+	Heading    string
+	Content    string
+	Done       bool
+	Tags       []string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	Version    int
+}
+
+const (
+	NoteSearchFullText = "fulltext"
+	NoteSearchFuzzy    = "fuzzy"
+
+	DefaultSimilarityThreshold = 0.3
+)
+
+type NoteCursor struct {
+	Sort     string
+	Order    string
+	Value    string
+	ID       uuid.UUID
+	Backward bool
+}
+
+type NoteSearchResult struct {
+	Note
+	Rank       float64
+	Headline   string
+	Similarity float64
 }
 
 type NoteUpdate struct {
@@ -21,6 +47,9 @@ type NoteUpdate struct {
 	UserID  uuid.UUID
 	Heading *string
 	Content *string
+	Done    *bool
+	Tags    *[]string
+	Version *int
 }
 
 func (n *Note) Validate() error {
@@ -40,6 +69,10 @@ func (n *Note) Validate() error {
 		return fmt.Errorf("empty content")
 	}
 
+	if err := validateTags(n.Tags); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -52,13 +85,19 @@ func (n *NoteUpdate) Validate() error {
 		return fmt.Errorf("invalid note user ID")
 	}
 
-	if *n.Heading == "" {
+	if n.Heading != nil && *n.Heading == "" {
 		return fmt.Errorf("empty heading")
 	}
 
-	if *n.Content == "" {
+	if n.Content != nil && *n.Content == "" {
 		return fmt.Errorf("empty content")
 	}
 
+	if n.Tags != nil {
+		if err := validateTags(*n.Tags); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	NotebookDeleteMove   = "move"
+	NotebookDeleteDelete = "delete"
+)
+
+type Notebook struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	ParentID  *uuid.UUID
+	Name      string
+	Notes     int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type NotebookUpdate struct {
+	ID     uuid.UUID
+	UserID uuid.UUID
+	Name   string
+}
+
+func (n *Notebook) Validate() error {
+	if n.ID == uuid.Nil {
+		return fmt.Errorf("invalid notebook ID")
+	}
+
+	if n.UserID == uuid.Nil {
+		return fmt.Errorf("invalid notebook user ID")
+	}
+
+	if n.Name == "" {
+		return fmt.Errorf("empty notebook name")
+	}
+
+	if n.ParentID != nil && *n.ParentID == n.ID {
+		return ErrNotebookCycle
+	}
+
+	return nil
+}
+
+func (n *NotebookUpdate) Validate() error {
+	if n.ID == uuid.Nil {
+		return fmt.Errorf("invalid notebook ID")
+	}
+
+	if n.UserID == uuid.Nil {
+		return fmt.Errorf("invalid notebook user ID")
+	}
+
+	if n.Name == "" {
+		return fmt.Errorf("empty notebook name")
+	}
+
+	return nil
+}
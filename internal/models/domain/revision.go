@@ -0,0 +1,15 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type NoteRevision struct {
+	NoteID    uuid.UUID
+	Revision  int
+	Heading   string
+	Content   string
+	CreatedAt time.Time
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	NotePermissionRead  = "read"
+	NotePermissionWrite = "write"
+)
+
+type NoteShare struct {
+	NoteID     uuid.UUID
+	OwnerID    uuid.UUID
+	UserID     uuid.UUID
+	Username   string
+	Email      string
+	Permission string
+	CreatedAt  time.Time
+}
+
+type NoteShareTarget struct {
+	NoteID     uuid.UUID
+	OwnerID    uuid.UUID
+	Username   string
+	Email      string
+	Permission string
+}
+
+type SharedNote struct {
+	Note
+	Permission string
+}
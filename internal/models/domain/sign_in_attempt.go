@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"time"
+)
+
+const (
+	SignInScopeAccount = "account"
+	SignInScopeIP      = "ip"
+)
+
+type SignInAttempt struct {
+	Scope        string
+	Subject      string
+	Failures     int
+	LastFailedAt time.Time
+}
+
+type SignInLimit struct {
+	Threshold  int
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+	ResetAfter time.Duration
+}
+
+type LockoutError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LockoutError) Error() string {
+	return ErrTooManyAttempts.Error()
+}
+
+func (e *LockoutError) Unwrap() error {
+	return ErrTooManyAttempts
+}
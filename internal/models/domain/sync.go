@@ -0,0 +1,7 @@
+package domain
+
+type NoteChange struct {
+	Note
+	Seq     int64
+	Deleted bool
+}
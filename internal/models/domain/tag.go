@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	TagMatchAny = "any"
+	TagMatchAll = "all"
+)
+
+type Tag struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Name      string
+	Notes     int
+	CreatedAt time.Time
+}
+
+type TagUpdate struct {
+	ID     uuid.UUID
+	UserID uuid.UUID
+	Name   string
+}
+
+func (t *TagUpdate) Validate() error {
+	if t.ID == uuid.Nil {
+		return fmt.Errorf("invalid tag ID")
+	}
+
+	if t.UserID == uuid.Nil {
+		return fmt.Errorf("invalid tag user ID")
+	}
+
+	if t.Name == "" {
+		return fmt.Errorf("empty tag name")
+	}
+
+	return nil
+}
+
+func validateTags(tags []string) error {
+	for _, tag := range tags {
+		if tag == "" {
+			return fmt.Errorf("empty tag name")
+		}
+	}
+
+	return nil
+}
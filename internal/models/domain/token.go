@@ -9,9 +9,15 @@ import (
 )
 
 type Token struct {
-	UserID    uuid.UUID
-	TokenID   string
-	ExpiresAt time.Time
+	UserID     uuid.UUID
+	TokenID    string
+	FamilyID   uuid.UUID
+	UserAgent  string
+	IP         string
+	ExpiresAt  time.Time
+	RotatedAt  *time.Time
+	CreatedAt  time.Time
+	LastUsedAt time.Time
 }
 
 type TokenClaims struct {
@@ -0,0 +1,8 @@
+package domain
+
+import "time"
+
+type TrashedNote struct {
+	Note
+	DeletedAt time.Time
+}
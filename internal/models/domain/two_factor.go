@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	TwoFactorIssuer      = "NoteApp"
+	TwoFactorMaxAttempts = 5
+	RecoveryCodeCount    = 10
+)
+
+type TwoFactor struct {
+	UserID      uuid.UUID
+	Secret      string
+	LastStep    *int64
+	ConfirmedAt *time.Time
+	CreatedAt   time.Time
+}
+
+func (t TwoFactor) Enabled() bool {
+	return t.ConfirmedAt != nil
+}
+
+type TwoFactorChallenge struct {
+	TokenHash string
+	UserID    uuid.UUID
+	Attempts  int
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+func (c TwoFactorChallenge) Locked() bool {
+	return c.Attempts >= TwoFactorMaxAttempts
+}
@@ -7,14 +7,22 @@ import (
 	"github.com/google/uuid"
 )
 
+const (
+	UserRoleUser  = "user"
+	UserRoleAdmin = "admin"
+)
+
 type User struct {
-	ID        uuid.UUID
-	Username  string
-	Email     string
-	Password  string
-	ImageURL  string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID         uuid.UUID
+	Username   string
+	Email      string
+	Password   string
+	ImageURL   string
+	Role       string
+	DisabledAt *time.Time
+	VerifiedAt *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
 }
 
 type UserUpdate struct {
@@ -0,0 +1,18 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AdminUserOutput struct {
+	UserOutput
+	Role       string     `json:"role"`
+	DisabledAt *time.Time `json:"disabled_at,omitempty"`
+}
+
+type AdminPasswordReset struct {
+	UserID      uuid.UUID `json:"user_id" validate:"required"`
+	NewPassword string    `json:"new_password" validate:"required,min=8,max=72"`
+}
@@ -0,0 +1,33 @@
+package dto
+
+import "github.com/google/uuid"
+
+type NoteBatch struct {
+	Mode string        `json:"mode" validate:"omitempty,oneof=atomic best_effort"`
+	Ops  []NoteBatchOp `json:"ops" validate:"required,min=1,max=100"`
+}
+
+type NoteBatchOp struct {
+	Op         string     `json:"op" validate:"required,oneof=create update delete"`
+	ID         uuid.UUID  `json:"id" validate:"required_unless=Op create"`
+	NotebookID *uuid.UUID `json:"notebook_id"`
+	Heading    *string    `json:"heading" validate:"required_if=Op create,omitempty,min=1,max=255"`
+	Content    *string    `json:"content" validate:"required_if=Op create,omitempty,min=1,max=255"`
+	Done       *bool      `json:"done"`
+	Tags       *[]string  `json:"tags" validate:"omitempty,max=20,dive,min=1,max=64"`
+	Version    *int       `json:"version" validate:"omitempty,min=1"`
+}
+
+type NoteBatchResult struct {
+	Index  int       `json:"index"`
+	ID     uuid.UUID `json:"id"`
+	Op     string    `json:"op"`
+	Status string    `json:"status"`
+	Error  string    `json:"error,omitempty"`
+}
+
+type NoteBatchResponse struct {
+	Mode      string            `json:"mode"`
+	Committed bool              `json:"committed"`
+	Results   []NoteBatchResult `json:"results"`
+}
@@ -38,3 +38,27 @@ func MakePaginatedResponse(data interface{}, total int, offset int, limit int) P
 		},
 	}
 }
+
+type CursorPaginatedResponse struct {
+	Data       interface{} `json:"data"`
+	Pagination struct {
+		Limit      int    `json:"limit"`
+		NextCursor string `json:"next_cursor,omitempty"`
+		PrevCursor string `json:"prev_cursor,omitempty"`
+	} `json:"pagination"`
+}
+
+func MakeCursorPaginatedResponse(data interface{}, limit int, nextCursor, prevCursor string) CursorPaginatedResponse {
+	return CursorPaginatedResponse{
+		Data: data,
+		Pagination: struct {
+			Limit      int    `json:"limit"`
+			NextCursor string `json:"next_cursor,omitempty"`
+			PrevCursor string `json:"prev_cursor,omitempty"`
+		}{
+			Limit:      limit,
+			NextCursor: nextCursor,
+			PrevCursor: prevCursor,
+		},
+	}
+}
@@ -0,0 +1,14 @@
+package dto
+
+type EmailVerify struct {
+	Token string `json:"token" validate:"required,max=64"`
+}
+
+type ForgotPassword struct {
+	Email string `json:"email" validate:"required,email,max=255"`
+}
+
+type PasswordReset struct {
+	Token    string `json:"token" validate:"required,max=64"`
+	Password string `json:"password" validate:"required,min=8,max=72"`
+}
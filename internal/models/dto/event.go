@@ -0,0 +1,15 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type NoteEventOutput struct {
+	ID         int64     `json:"id,omitempty"`
+	Type       string    `json:"type"`
+	NoteID     uuid.UUID `json:"note_id"`
+	UserID     uuid.UUID `json:"user_id"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
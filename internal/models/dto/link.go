@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type NoteLinkCreate struct {
+	NoteID    uuid.UUID  `json:"note_id"`
+	UserID    uuid.UUID  `json:"user_id"`
+	Password  string     `json:"password" validate:"omitempty,min=4,max=72"`
+	ExpiresAt *time.Time `json:"expires_at" validate:"omitempty,gt"`
+}
+
+type NoteLinkOutput struct {
+	ID          uuid.UUID  `json:"id"`
+	Token       string     `json:"token,omitempty"`
+	HasPassword bool       `json:"has_password"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type PublicNoteOutput struct {
+	Heading   string    `json:"heading"`
+	Content   string    `json:"content"`
+	Done      bool      `json:"done"`
+	Tags      []string  `json:"tags"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
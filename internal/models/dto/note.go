@@ -7,11 +7,13 @@ import (
 )
 
 type NoteCreate struct {
-	ID      uuid.UUID `json:"id"`
-	UserID  uuid.UUID `json:"user_id" validate:"required"`
-	Heading string    `json:"heading" validate:"required,min=1,max=255"`
-	Content string    `json:"content" validate:"required,min=1,max=255"`
-	Done    bool      `json:"done"`
+	ID         uuid.UUID  `json:"id"`
+	UserID     uuid.UUID  `json:"user_id" validate:"required"`
+	NotebookID *uuid.UUID `json:"notebook_id"`
+	Heading    string     `json:"heading" validate:"required,min=1,max=255"`
+	Content    string     `json:"content" validate:"required,min=1,max=255"`
+	Done       bool       `json:"done"`
+	Tags       []string   `json:"tags" validate:"omitempty,max=20,dive,min=1,max=64"`
 }
 
 type NoteUpdate struct {
@@ -20,14 +22,58 @@ type NoteUpdate struct {
 	Heading *string   `json:"heading" validate:"omitempty,min=1,max=255"`
 	Content *string   `json:"content" validate:"omitempty,min=1,max=255"`
 	Done    *bool     `json:"done"`
+	Tags    *[]string `json:"tags" validate:"omitempty,max=20,dive,min=1,max=64"`
+	Version *int      `json:"-"`
+}
+
+type NoteMove struct {
+	ID         uuid.UUID  `json:"id" validate:"required"`
+	UserID     uuid.UUID  `json:"user_id" validate:"required"`
+	NotebookID *uuid.UUID `json:"notebook_id"`
+}
+
+type NoteFilter struct {
+	Paginated
+	Done          *bool      `form:"done"`
+	Tags          []string   `form:"tag" validate:"omitempty,max=20,dive,min=1,max=64"`
+	TagMatch      string     `form:"tag_match" validate:"omitempty,oneof=any all"`
+	NotebookID    string     `form:"notebook_id" validate:"omitempty,uuid"`
+	Recursive     bool       `form:"recursive"`
+	Sort          string     `form:"sort" validate:"omitempty,oneof=created_at updated_at heading"`
+	Order         string     `form:"order" validate:"omitempty,oneof=asc desc"`
+	CreatedAfter  *time.Time `form:"created_after" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedBefore *time.Time `form:"created_before" time_format:"2006-01-02T15:04:05Z07:00"`
+	UpdatedAfter  *time.Time `form:"updated_after" time_format:"2006-01-02T15:04:05Z07:00"`
+	UpdatedBefore *time.Time `form:"updated_before" time_format:"2006-01-02T15:04:05Z07:00"`
+	Paging        string     `form:"paging" validate:"omitempty,oneof=offset cursor"`
+	After         string     `form:"after" validate:"omitempty,excluded_with=Before,max=512"`
+	Before        string     `form:"before" validate:"omitempty,max=512"`
+}
+
+type NoteSearch struct {
+	Paginated
+	Query     string   `form:"q" validate:"required,max=256"`
+	Mode      string   `form:"mode" validate:"omitempty,oneof=fulltext fuzzy"`
+	Threshold *float64 `form:"threshold" validate:"omitempty,gt=0,lte=1"`
+	Content   bool     `form:"content"`
 }
 
 type NoteOutput struct {
-	ID        uuid.UUID `json:"id"`
-	UserID    uuid.UUID `json:"user_id"`
-	Heading   string    `json:"heading"`
-	Content   string    `json:"content"`
-	Done      bool      `json:"done"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID         uuid.UUID  `json:"id"`
+	UserID     uuid.UUID  `json:"user_id"`
+	NotebookID *uuid.UUID `json:"notebook_id"`
+	Heading    string     `json:"heading"`
+	Content    string     `json:"content"`
+	Done       bool       `json:"done"`
+	Tags       []string   `json:"tags"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	Version    int        `json:"version"`
+}
+
+type NoteSearchOutput struct {
+	NoteOutput
+	Rank       float64 `json:"rank,omitempty"`
+	Headline   string  `json:"headline,omitempty"`
+	Similarity float64 `json:"similarity,omitempty"`
 }
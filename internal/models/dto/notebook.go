@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type NotebookCreate struct {
+	ID       uuid.UUID  `json:"id"`
+	UserID   uuid.UUID  `json:"user_id" validate:"required"`
+	ParentID *uuid.UUID `json:"parent_id"`
+	Name     string     `json:"name" validate:"required,min=1,max=255"`
+}
+
+type NotebookUpdate struct {
+	ID     uuid.UUID `json:"id" validate:"required"`
+	UserID uuid.UUID `json:"user_id" validate:"required"`
+	Name   string    `json:"name" validate:"required,min=1,max=255"`
+}
+
+type NotebookMove struct {
+	ID       uuid.UUID  `json:"id" validate:"required"`
+	UserID   uuid.UUID  `json:"user_id" validate:"required"`
+	ParentID *uuid.UUID `json:"parent_id"`
+}
+
+type NotebookDelete struct {
+	Mode string `form:"mode" validate:"omitempty,oneof=move delete"`
+}
+
+type NotebookOutput struct {
+	ID        uuid.UUID  `json:"id"`
+	ParentID  *uuid.UUID `json:"parent_id"`
+	Name      string     `json:"name"`
+	Notes     int        `json:"notes"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,21 @@
+package dto
+
+import "time"
+
+type NoteRevisionDiff struct {
+	From int `form:"from" validate:"required,gte=1"`
+	To   int `form:"to" validate:"omitempty,gte=1"`
+}
+
+type NoteRevisionOutput struct {
+	Revision  int       `json:"revision"`
+	Heading   string    `json:"heading"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type NoteDiffOutput struct {
+	From int    `json:"from"`
+	To   int    `json:"to,omitempty"`
+	Diff string `json:"diff"`
+}
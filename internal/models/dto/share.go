@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type NoteShareCreate struct {
+	NoteID     uuid.UUID `json:"note_id"`
+	OwnerID    uuid.UUID `json:"owner_id"`
+	Username   string    `json:"username" validate:"required_without=Email,omitempty,min=3,max=255"`
+	Email      string    `json:"email" validate:"required_without=Username,omitempty,email,max=255"`
+	Permission string    `json:"permission" validate:"required,oneof=read write"`
+}
+
+type NoteShareDelete struct {
+	NoteID   uuid.UUID `form:"-"`
+	OwnerID  uuid.UUID `form:"-"`
+	Username string    `form:"username" validate:"required_without=Email,omitempty,min=3,max=255"`
+	Email    string    `form:"email" validate:"required_without=Username,omitempty,email,max=255"`
+}
+
+type NoteShareOutput struct {
+	UserID     uuid.UUID `json:"user_id"`
+	Username   string    `json:"username"`
+	Email      string    `json:"email"`
+	Permission string    `json:"permission"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type SharedNoteOutput struct {
+	NoteOutput
+	Permission string `json:"permission"`
+}
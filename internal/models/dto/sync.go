@@ -0,0 +1,42 @@
+package dto
+
+import "github.com/google/uuid"
+
+type SyncPull struct {
+	Since string `form:"since" validate:"omitempty,max=64"`
+	Limit int    `form:"limit" validate:"omitempty,min=1,max=500"`
+}
+
+type SyncPullResponse struct {
+	Notes   []NoteOutput `json:"notes"`
+	Deleted []uuid.UUID  `json:"deleted"`
+	Token   string       `json:"token"`
+	HasMore bool         `json:"has_more"`
+}
+
+type SyncChange struct {
+	Op         string     `json:"op" validate:"required,oneof=create update delete"`
+	ID         uuid.UUID  `json:"id" validate:"required"`
+	NotebookID *uuid.UUID `json:"notebook_id"`
+	Heading    *string    `json:"heading" validate:"required_if=Op create,omitempty,min=1,max=255"`
+	Content    *string    `json:"content" validate:"required_if=Op create,omitempty,min=1,max=255"`
+	Done       *bool      `json:"done"`
+	Tags       *[]string  `json:"tags" validate:"omitempty,max=20,dive,min=1,max=64"`
+	Version    *int       `json:"version" validate:"omitempty,min=1"`
+}
+
+type SyncPush struct {
+	Changes []SyncChange `json:"changes" validate:"required,min=1,max=100"`
+}
+
+type SyncResult struct {
+	ID     uuid.UUID   `json:"id"`
+	Op     string      `json:"op"`
+	Status string      `json:"status"`
+	Error  string      `json:"error,omitempty"`
+	Note   *NoteOutput `json:"note,omitempty"`
+}
+
+type SyncPushResponse struct {
+	Results []SyncResult `json:"results"`
+}
@@ -0,0 +1,26 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TagUpdate struct {
+	ID     uuid.UUID `json:"id" validate:"required"`
+	UserID uuid.UUID `json:"user_id" validate:"required"`
+	Name   string    `json:"name" validate:"required,min=1,max=64"`
+}
+
+type TagMerge struct {
+	TargetID  uuid.UUID   `json:"target_id" validate:"required"`
+	UserID    uuid.UUID   `json:"user_id" validate:"required"`
+	SourceIDs []uuid.UUID `json:"tag_ids" validate:"required,min=1,max=100,dive,required"`
+}
+
+type TagOutput struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Notes     int       `json:"notes"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -1,6 +1,15 @@
 package dto
 
+import "github.com/google/uuid"
+
 type TokenOutput struct {
-	AccessToken  string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
+	AccessToken    string `json:"access_token"`
+	RefreshToken   string `json:"refresh_token"`
+	ChallengeToken string `json:"challenge_token,omitempty"`
+}
+
+type TokenClaims struct {
+	UserID uuid.UUID
+	Role   string
+	Scopes []string
 }
@@ -0,0 +1,8 @@
+package dto
+
+import "time"
+
+type TrashedNoteOutput struct {
+	NoteOutput
+	DeletedAt time.Time `json:"deleted_at"`
+}
@@ -0,0 +1,18 @@
+package dto
+
+import "github.com/google/uuid"
+
+type TwoFactorEnrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+type TwoFactorCode struct {
+	UserID uuid.UUID `json:"user_id"`
+	Code   string    `json:"code" validate:"required,max=32"`
+}
+
+type TwoFactorSignIn struct {
+	ChallengeToken string `json:"challenge_token" validate:"required,max=64"`
+	Code           string `json:"code" validate:"required,max=32"`
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type AdminR struct {
+	db  query
+	log *logger.Logger
+}
+
+func NewAdminRepository(db query, log *logger.Logger) *AdminR {
+	return &AdminR{
+		db:  db,
+		log: log,
+	}
+}
+
+func (a *AdminR) Users(ctx context.Context, p dto.Paginated) ([]domain.User, int, error) {
+	var total int
+	query := `SELECT COUNT(*) FROM users`
+	if err := a.db.QueryRowContext(ctx, query).Scan(&total); err != nil {
+		a.log.Error("failed to execute COUNT query in Users",
+			zap.Error(err),
+		)
+		return nil, 0, domain.MakeError(domain.ErrReceiving, err, "users")
+	}
+
+	if total == 0 || total <= p.Offset {
+		return nil, total, nil
+	}
+
+	query = `
+		SELECT id, username, email, image_url, role, disabled_at, created_at, updated_at
+		FROM users
+		ORDER BY created_at ASC, id ASC
+		LIMIT $1 OFFSET $2`
+
+	rows, err := a.db.QueryContext(ctx, query, p.Limit, p.Offset)
+	if err != nil {
+		a.log.Error("failed to execute SELECT query in Users",
+			zap.Error(err),
+		)
+		return nil, 0, domain.MakeError(domain.ErrReceiving, err, "users")
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			a.log.Error("failed to close rows", zap.Error(closeErr))
+		}
+	}()
+
+	users := make([]domain.User, 0, p.Limit)
+	for rows.Next() {
+		var user domain.User
+		if err := rows.Scan(
+			&user.ID,
+			&user.Username,
+			&user.Email,
+			&user.ImageURL,
+			&user.Role,
+			&user.DisabledAt,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+		); err != nil {
+			return nil, 0, domain.MakeError(domain.ErrReceiving, err, "users")
+		}
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		a.log.Error("error during row iteration",
+			zap.Error(err),
+		)
+		return nil, 0, domain.MakeError(domain.ErrReceiving, err, "users")
+	}
+
+	return users, total, nil
+}
+
+func (a *AdminR) SetUserDisabled(ctx context.Context, userID uuid.UUID, disabled bool) error {
+	query := `
+		UPDATE users
+		SET disabled_at = CASE WHEN $2 THEN COALESCE(disabled_at, NOW()) ELSE NULL END, updated_at=NOW()
+		WHERE id=$1`
+
+	result, err := a.db.ExecContext(ctx, query, userID, disabled)
+	if err != nil {
+		a.log.Error("failed to execute UPDATE query in SetUserDisabled",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+			zap.Bool("disabled", disabled),
+		)
+		return domain.MakeError(domain.ErrFailedToUpdate, err, "user")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		a.log.Error("failed to get rows affected after UPDATE",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return domain.MakeError(domain.ErrFailedToUpdate, err, "user")
+	}
+
+	if rowsAffected == 0 {
+		return domain.MakeError(domain.ErrFailedToUpdate, domain.ErrNotFound, "user")
+	}
+
+	return nil
+}
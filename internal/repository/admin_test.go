@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminR_Users(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	createNamedTestUser(t, tx, "admin_users_listed")
+
+	repo := NewAdminRepository(tx, logger.LoggerForTest())
+
+	users, total, err := repo.Users(context.Background(), dto.Paginated{Limit: 100})
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, total, 1)
+	require.NotEmpty(t, users)
+
+	for _, user := range users {
+		assert.NotEqual(t, uuid.Nil, user.ID)
+		assert.NotEmpty(t, user.Role)
+		assert.Empty(t, user.Password)
+	}
+
+	users, _, err = repo.Users(context.Background(), dto.Paginated{Limit: 10, Offset: total})
+	require.NoError(t, err)
+	assert.Empty(t, users)
+}
+
+func TestAdminR_SetUserDisabled(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	userID := createNamedTestUser(t, tx, "admin_disabled")
+
+	repo := NewAdminRepository(tx, logger.LoggerForTest())
+	repoUser := NewUserRepository(tx, logger.LoggerForTest())
+
+	user, err := repoUser.UserByID(context.Background(), userID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.UserRoleUser, user.Role)
+	assert.Nil(t, user.DisabledAt)
+
+	require.NoError(t, repo.SetUserDisabled(context.Background(), userID, true))
+
+	user, err = repoUser.UserByID(context.Background(), userID)
+	require.NoError(t, err)
+	require.NotNil(t, user.DisabledAt)
+
+	require.NoError(t, repo.SetUserDisabled(context.Background(), userID, false))
+
+	user, err = repoUser.UserByID(context.Background(), userID)
+	require.NoError(t, err)
+	assert.Nil(t, user.DisabledAt)
+
+	err = repo.SetUserDisabled(context.Background(), uuid.New(), true)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+}
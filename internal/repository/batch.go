@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"noteApp/internal/models/domain"
+	"noteApp/pkg/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type BatchR struct {
+	db  query
+	tx  *TxManager
+	log *logger.Logger
+}
+
+func NewBatchRepository(db query, log *logger.Logger) *BatchR {
+	return &BatchR{
+		db:  txQuery{db: db},
+		tx:  NewTxManager(db, log),
+		log: log,
+	}
+}
+
+func (b *BatchR) BatchNotes(ctx context.Context, userID uuid.UUID, ops []domain.NoteBatchOp, atomic bool) ([]error, error) {
+	errs := make([]error, len(ops))
+
+	err := b.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if _, err := b.db.ExecContext(ctx, "SAVEPOINT batch"); err != nil {
+			return err
+		}
+
+		notes := NewNoteRepository(b.db, b.log)
+		for i, op := range ops {
+			if !atomic {
+				if _, err := b.db.ExecContext(ctx, "SAVEPOINT batch_op"); err != nil {
+					return err
+				}
+			}
+
+			if errs[i] = applyNoteBatchOp(ctx, notes, userID, op); errs[i] == nil {
+				continue
+			}
+
+			if atomic {
+				for j := range errs {
+					if j != i {
+						errs[j] = domain.ErrBatchAborted
+					}
+				}
+				_, err := b.db.ExecContext(ctx, "ROLLBACK TO SAVEPOINT batch")
+				return err
+			}
+
+			if _, err := b.db.ExecContext(ctx, "ROLLBACK TO SAVEPOINT batch_op"); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		b.log.Error("failed to execute notes batch",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return nil, domain.MakeError(domain.ErrFailedToUpdate, err, "notes batch")
+	}
+
+	return errs, nil
+}
+
+func applyNoteBatchOp(ctx context.Context, notes *NoteR, userID uuid.UUID, op domain.NoteBatchOp) error {
+	switch op.Op {
+	case domain.NoteOpCreate:
+		return notes.CreateNote(ctx, op.Create)
+	case domain.NoteOpUpdate:
+		return notes.UpdateNote(ctx, op.Update)
+	case domain.NoteOpDelete:
+		return notes.DeleteNote(ctx, userID, op.NoteID, op.Version)
+	default:
+		return domain.MakeError(domain.ErrFailedToUpdate, errors.New("unknown operation "+op.Op), "note")
+	}
+}
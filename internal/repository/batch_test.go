@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"noteApp/internal/models/domain"
+	"noteApp/pkg/logger"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createCommittedTestUser(t *testing.T) uuid.UUID {
+	t.Helper()
+
+	userID := uuid.New()
+	repoUser := NewUserRepository(globalTestDB, logger.LoggerForTest())
+	err := repoUser.CreateUser(context.Background(), domain.User{
+		ID:       userID,
+		Username: userID.String(),
+		Email:    userID.String() + "@example.com",
+		Password: "test",
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, _ = globalTestDB.Exec(`DELETE FROM users WHERE id=$1`, userID)
+	})
+
+	return userID
+}
+
+func batchCreateOp(userID uuid.UUID) domain.NoteBatchOp {
+	noteID := uuid.New()
+	return domain.NoteBatchOp{
+		Op:     domain.NoteOpCreate,
+		NoteID: noteID,
+		Create: domain.Note{
+			ID:      noteID,
+			UserID:  userID,
+			Heading: "test_heading",
+			Content: "test_content",
+		},
+	}
+}
+
+func TestBatchR_BatchNotes(t *testing.T) {
+	t.Parallel()
+
+	userID := createCommittedTestUser(t)
+	repo := NewBatchRepository(globalTestDB, logger.LoggerForTest())
+	notes := NewNoteRepository(globalTestDB, logger.LoggerForTest())
+
+	created := batchCreateOp(userID)
+	heading := "batch heading"
+	errs, err := repo.BatchNotes(context.Background(), userID, []domain.NoteBatchOp{
+		created,
+		{
+			Op:     domain.NoteOpUpdate,
+			NoteID: created.NoteID,
+			Update: domain.NoteUpdate{ID: created.NoteID, UserID: userID, Heading: &heading},
+		},
+	}, true)
+	require.NoError(t, err)
+	assert.Equal(t, []error{nil, nil}, errs)
+
+	note, err := notes.Note(context.Background(), userID, created.NoteID)
+	require.NoError(t, err)
+	assert.Equal(t, heading, note.Heading)
+
+	t.Run("atomic rollback", func(t *testing.T) {
+		op := batchCreateOp(userID)
+		errs, err := repo.BatchNotes(context.Background(), userID, []domain.NoteBatchOp{
+			op,
+			{Op: domain.NoteOpDelete, NoteID: uuid.New()},
+		}, true)
+		require.NoError(t, err)
+		require.Len(t, errs, 2)
+		assert.ErrorIs(t, errs[0], domain.ErrBatchAborted)
+		assert.ErrorIs(t, errs[1], domain.ErrNotFound)
+
+		_, err = notes.Note(context.Background(), userID, op.NoteID)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("best effort", func(t *testing.T) {
+		op := batchCreateOp(userID)
+		version := 100
+		errs, err := repo.BatchNotes(context.Background(), userID, []domain.NoteBatchOp{
+			{Op: domain.NoteOpDelete, NoteID: created.NoteID, Version: &version},
+			op,
+		}, false)
+		require.NoError(t, err)
+		require.Len(t, errs, 2)
+		assert.ErrorIs(t, errs[0], domain.ErrVersionMismatch)
+		assert.NoError(t, errs[1])
+
+		_, err = notes.Note(context.Background(), userID, op.NoteID)
+		assert.NoError(t, err)
+		_, err = notes.Note(context.Background(), userID, created.NoteID)
+		assert.NoError(t, err)
+	})
+}
+
+func TestBatchR_BatchNotesWithinTx(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	userID := createTestUser(t, tx)
+	repo := NewBatchRepository(tx, logger.LoggerForTest())
+	notes := NewNoteRepository(tx, logger.LoggerForTest())
+
+	op := batchCreateOp(userID)
+	errs, err := repo.BatchNotes(context.Background(), userID, []domain.NoteBatchOp{
+		op,
+		{Op: domain.NoteOpDelete, NoteID: uuid.New()},
+	}, true)
+	require.NoError(t, err)
+	require.Len(t, errs, 2)
+	assert.ErrorIs(t, errs[0], domain.ErrBatchAborted)
+
+	_, err = notes.Note(context.Background(), userID, op.NoteID)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	errs, err = repo.BatchNotes(context.Background(), userID, []domain.NoteBatchOp{op}, true)
+	require.NoError(t, err)
+	assert.Equal(t, []error{nil}, errs)
+
+	_, err = notes.Note(context.Background(), userID, op.NoteID)
+	assert.NoError(t, err)
+}
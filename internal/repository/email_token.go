@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"noteApp/internal/models/domain"
+	"noteApp/pkg/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type EmailTokenR struct {
+	db  query
+	log *logger.Logger
+}
+
+func NewEmailTokenRepository(db query, log *logger.Logger) *EmailTokenR {
+	return &EmailTokenR{
+		db:  db,
+		log: log,
+	}
+}
+
+func (e *EmailTokenR) CreateEmailToken(ctx context.Context, token domain.EmailToken) error {
+	query := `
+		INSERT INTO email_tokens (token_hash, user_id, purpose, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	_, err := e.db.ExecContext(ctx, query, token.TokenHash, token.UserID, token.Purpose, token.ExpiresAt.UTC(), token.CreatedAt.UTC())
+	if err != nil {
+		e.log.Error("failed to execute INSERT query in CreateEmailToken",
+			zap.Error(err),
+			zap.String("user_id", token.UserID.String()),
+			zap.String("purpose", token.Purpose),
+		)
+		return domain.MakeError(domain.ErrFailedToCreate, err, "email token")
+	}
+
+	return nil
+}
+
+func (e *EmailTokenR) ConsumeEmailToken(ctx context.Context, tokenHash, purpose string) (domain.EmailToken, error) {
+	query := `
+		DELETE FROM email_tokens
+		WHERE token_hash=$1 AND purpose=$2
+		RETURNING token_hash, user_id, purpose, expires_at, created_at`
+
+	var token domain.EmailToken
+	err := e.db.QueryRowContext(ctx, query, tokenHash, purpose).Scan(
+		&token.TokenHash,
+		&token.UserID,
+		&token.Purpose,
+		&token.ExpiresAt,
+		&token.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.EmailToken{}, domain.MakeError(domain.ErrFailedToDelete, domain.ErrNotFound, "email token")
+		}
+		e.log.Error("failed to execute DELETE query in ConsumeEmailToken",
+			zap.Error(err),
+			zap.String("purpose", purpose),
+		)
+		return domain.EmailToken{}, domain.MakeError(domain.ErrFailedToDelete, err, "email token")
+	}
+
+	return token, nil
+}
+
+func (e *EmailTokenR) DeleteEmailTokens(ctx context.Context, userID uuid.UUID, purpose string) error {
+	query := `DELETE FROM email_tokens WHERE user_id=$1 AND purpose=$2`
+
+	_, err := e.db.ExecContext(ctx, query, userID, purpose)
+	if err != nil {
+		e.log.Error("failed to execute DELETE query in DeleteEmailTokens",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+			zap.String("purpose", purpose),
+		)
+		return domain.MakeError(domain.ErrFailedToDelete, err, "email tokens")
+	}
+
+	return nil
+}
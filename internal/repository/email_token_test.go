@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"noteApp/internal/models/domain"
+	"noteApp/pkg/logger"
+	"noteApp/pkg/utils"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailTokenR_ConsumeEmailToken(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	userID := createTestUser(t, tx)
+	repo := NewEmailTokenRepository(tx, logger.LoggerForTest())
+
+	hash := utils.HashToken(uuid.NewString())
+	require.NoError(t, repo.CreateEmailToken(context.Background(), domain.EmailToken{
+		TokenHash: hash,
+		UserID:    userID,
+		Purpose:   domain.EmailTokenVerify,
+		ExpiresAt: time.Now().Add(time.Hour),
+		CreatedAt: time.Now(),
+	}))
+
+	_, err = repo.ConsumeEmailToken(context.Background(), hash, domain.EmailTokenReset)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	token, err := repo.ConsumeEmailToken(context.Background(), hash, domain.EmailTokenVerify)
+	require.NoError(t, err)
+	assert.Equal(t, userID, token.UserID)
+	assert.Equal(t, domain.EmailTokenVerify, token.Purpose)
+
+	_, err = repo.ConsumeEmailToken(context.Background(), hash, domain.EmailTokenVerify)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestEmailTokenR_DeleteEmailTokens(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	userID := createTestUser(t, tx)
+	repo := NewEmailTokenRepository(tx, logger.LoggerForTest())
+
+	verifyHash := utils.HashToken(uuid.NewString())
+	resetHash := utils.HashToken(uuid.NewString())
+	for hash, purpose := range map[string]string{verifyHash: domain.EmailTokenVerify, resetHash: domain.EmailTokenReset} {
+		require.NoError(t, repo.CreateEmailToken(context.Background(), domain.EmailToken{
+			TokenHash: hash,
+			UserID:    userID,
+			Purpose:   purpose,
+			ExpiresAt: time.Now().Add(time.Hour),
+			CreatedAt: time.Now(),
+		}))
+	}
+
+	require.NoError(t, repo.DeleteEmailTokens(context.Background(), userID, domain.EmailTokenReset))
+
+	_, err = repo.ConsumeEmailToken(context.Background(), resetHash, domain.EmailTokenReset)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	_, err = repo.ConsumeEmailToken(context.Background(), verifyHash, domain.EmailTokenVerify)
+	require.NoError(t, err)
+}
+
+func TestUserR_MarkEmailVerified(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	userID := createTestUser(t, tx)
+	repo := NewUserRepository(tx, logger.LoggerForTest())
+
+	user, err := repo.UserByID(context.Background(), userID)
+	require.NoError(t, err)
+	assert.Nil(t, user.VerifiedAt)
+
+	require.NoError(t, repo.MarkEmailVerified(context.Background(), userID))
+
+	user, err = repo.UserByID(context.Background(), userID)
+	require.NoError(t, err)
+	require.NotNil(t, user.VerifiedAt)
+
+	sameEmail := user.Email
+	require.NoError(t, repo.UpdateUser(context.Background(), domain.UserUpdate{ID: userID, Email: &sameEmail}))
+
+	user, err = repo.UserByID(context.Background(), userID)
+	require.NoError(t, err)
+	assert.NotNil(t, user.VerifiedAt)
+
+	newEmail := "verified_changed@example.com"
+	require.NoError(t, repo.UpdateUser(context.Background(), domain.UserUpdate{ID: userID, Email: &newEmail}))
+
+	user, err = repo.UserByID(context.Background(), userID)
+	require.NoError(t, err)
+	assert.Nil(t, user.VerifiedAt)
+
+	err = repo.MarkEmailVerified(context.Background(), uuid.New())
+	require.ErrorIs(t, err, domain.ErrNotFound)
+}
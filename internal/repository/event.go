@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"noteApp/internal/models/domain"
+	"noteApp/pkg/logger"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type EventR struct {
+	db  query
+	log *logger.Logger
+}
+
+func NewEventRepository(db query, log *logger.Logger) *EventR {
+	return &EventR{
+		db:  db,
+		log: log,
+	}
+}
+
+func (e *EventR) CreateNoteEvents(ctx context.Context, event domain.NoteEvent) ([]domain.NoteEvent, error) {
+	query := `
+		INSERT INTO note_events (user_id, note_id, actor_id, type, created_at)
+		SELECT r.user_id, $1, $2, $3, NOW()
+		FROM (
+			SELECT user_id FROM notes WHERE id=$1
+			UNION
+			SELECT user_id FROM note_shares WHERE note_id=$1
+		) r
+		RETURNING id, user_id, note_id, actor_id, type, created_at`
+
+	rows, err := e.db.QueryContext(ctx, query, event.NoteID, event.ActorID, event.Type)
+	if err != nil {
+		e.log.Error("failed to execute INSERT query in CreateNoteEvents",
+			zap.Error(err),
+			zap.String("note_id", event.NoteID.String()),
+			zap.String("type", event.Type),
+		)
+		return nil, domain.MakeError(domain.ErrFailedToCreate, err, "note events")
+	}
+
+	return e.scanEvents(rows, domain.ErrFailedToCreate)
+}
+
+func (e *EventR) NoteEvents(ctx context.Context, userID uuid.UUID, afterID int64, limit int) ([]domain.NoteEvent, error) {
+	query := `
+		SELECT id, user_id, note_id, actor_id, type, created_at
+		FROM note_events
+		WHERE user_id=$1 AND id>$2
+		ORDER BY id ASC
+		LIMIT $3`
+
+	rows, err := e.db.QueryContext(ctx, query, userID, afterID, limit)
+	if err != nil {
+		e.log.Error("failed to execute SELECT query in NoteEvents",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+			zap.Int64("after_id", afterID),
+		)
+		return nil, domain.MakeError(domain.ErrReceiving, err, "note events")
+	}
+
+	return e.scanEvents(rows, domain.ErrReceiving)
+}
+
+func (e *EventR) LastNoteEventID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	query := `SELECT COALESCE(MAX(id), 0) FROM note_events WHERE user_id=$1`
+
+	var id int64
+	if err := e.db.QueryRowContext(ctx, query, userID).Scan(&id); err != nil {
+		e.log.Error("failed to execute SELECT query in LastNoteEventID",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return 0, domain.MakeError(domain.ErrReceiving, err, "note events")
+	}
+
+	return id, nil
+}
+
+func (e *EventR) PurgeNoteEvents(ctx context.Context, createdBefore time.Time) (int64, error) {
+	query := `DELETE FROM note_events WHERE created_at < $1`
+
+	result, err := e.db.ExecContext(ctx, query, createdBefore.UTC())
+	if err != nil {
+		e.log.Error("failed to execute DELETE query in PurgeNoteEvents",
+			zap.Error(err),
+			zap.Time("created_before", createdBefore),
+		)
+		return 0, domain.MakeError(domain.ErrFailedToDelete, err, "note events")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		e.log.Error("failed to get rows affected after DELETE",
+			zap.Error(err),
+			zap.Time("created_before", createdBefore),
+		)
+		return 0, domain.MakeError(domain.ErrFailedToDelete, err, "note events")
+	}
+
+	return rowsAffected, nil
+}
+
+func (e *EventR) scanEvents(rows *sql.Rows, dErr error) ([]domain.NoteEvent, error) {
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			e.log.Error("failed to close rows", zap.Error(closeErr))
+		}
+	}()
+
+	var events []domain.NoteEvent
+	for rows.Next() {
+		var event domain.NoteEvent
+		if err := rows.Scan(
+			&event.ID,
+			&event.UserID,
+			&event.NoteID,
+			&event.ActorID,
+			&event.Type,
+			&event.CreatedAt,
+		); err != nil {
+			return nil, domain.MakeError(dErr, err, "note events")
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		e.log.Error("error during row iteration", zap.Error(err))
+		return nil, domain.MakeError(dErr, err, "note events")
+	}
+
+	return events, nil
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"noteApp/internal/models/domain"
+	"noteApp/pkg/logger"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventR_NoteEvents(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	ownerID := createTestUser(t, tx)
+	granteeID := createNamedTestUser(t, tx, "event_grantee")
+	noteID := createTaggedNote(t, tx, ownerID)
+
+	require.NoError(t, NewShareRepository(tx, logger.LoggerForTest()).ShareNote(context.Background(), domain.NoteShareTarget{
+		NoteID:     noteID,
+		OwnerID:    ownerID,
+		Username:   "event_grantee",
+		Permission: domain.NotePermissionRead,
+	}))
+
+	repo := NewEventRepository(tx, logger.LoggerForTest())
+
+	created, err := repo.CreateNoteEvents(context.Background(), domain.NoteEvent{
+		NoteID:  noteID,
+		ActorID: ownerID,
+		Type:    domain.NoteEventUpdated,
+	})
+	require.NoError(t, err)
+	require.Len(t, created, 2)
+
+	assert.ElementsMatch(t, []uuid.UUID{ownerID, granteeID}, []uuid.UUID{created[0].UserID, created[1].UserID})
+
+	second, err := repo.CreateNoteEvents(context.Background(), domain.NoteEvent{
+		NoteID:  noteID,
+		ActorID: granteeID,
+		Type:    domain.NoteEventDeleted,
+	})
+	require.NoError(t, err)
+	require.Len(t, second, 2)
+
+	events, err := repo.NoteEvents(context.Background(), ownerID, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, domain.NoteEventUpdated, events[0].Type)
+	assert.Equal(t, domain.NoteEventDeleted, events[1].Type)
+	assert.Equal(t, granteeID, events[1].ActorID)
+
+	events, err = repo.NoteEvents(context.Background(), ownerID, events[0].ID, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, domain.NoteEventDeleted, events[0].Type)
+
+	events, err = repo.NoteEvents(context.Background(), granteeID, 0, 1)
+	require.NoError(t, err)
+	assert.Len(t, events, 1)
+}
+
+func TestEventR_LastNoteEventID(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	userID := createTestUser(t, tx)
+	noteID := createTaggedNote(t, tx, userID)
+	repo := NewEventRepository(tx, logger.LoggerForTest())
+
+	last, err := repo.LastNoteEventID(context.Background(), userID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), last)
+
+	created, err := repo.CreateNoteEvents(context.Background(), domain.NoteEvent{
+		NoteID:  noteID,
+		ActorID: userID,
+		Type:    domain.NoteEventUpdated,
+	})
+	require.NoError(t, err)
+	require.Len(t, created, 1)
+
+	last, err = repo.LastNoteEventID(context.Background(), userID)
+	require.NoError(t, err)
+	assert.Equal(t, created[0].ID, last)
+}
+
+func TestEventR_PurgeNoteEvents(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	userID := createTestUser(t, tx)
+	noteID := createTaggedNote(t, tx, userID)
+	repo := NewEventRepository(tx, logger.LoggerForTest())
+
+	for range 2 {
+		_, err := repo.CreateNoteEvents(context.Background(), domain.NoteEvent{
+			NoteID:  noteID,
+			ActorID: userID,
+			Type:    domain.NoteEventUpdated,
+		})
+		require.NoError(t, err)
+	}
+
+	_, err = tx.ExecContext(context.Background(),
+		`UPDATE note_events SET created_at=NOW() - INTERVAL '2 hours' WHERE id = (SELECT MIN(id) FROM note_events WHERE user_id=$1)`, userID)
+	require.NoError(t, err)
+
+	purged, err := repo.PurgeNoteEvents(context.Background(), time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, purged, int64(1))
+
+	events, err := repo.NoteEvents(context.Background(), userID, 0, 10)
+	require.NoError(t, err)
+	assert.Len(t, events, 1)
+}
@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"noteApp/internal/models/domain"
+	"noteApp/pkg/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type LinkR struct {
+	db  query
+	log *logger.Logger
+}
+
+func NewLinkRepository(db query, log *logger.Logger) *LinkR {
+	return &LinkR{
+		db:  db,
+		log: log,
+	}
+}
+
+func (l *LinkR) CreateNoteLink(ctx context.Context, link domain.NoteLink) error {
+	query := `
+		INSERT INTO note_links (id, note_id, token_hash, password_hash, expires_at, created_at)
+		SELECT $1, $2, $4, $5, $6, NOW()
+		WHERE EXISTS (SELECT 1 FROM notes WHERE id=$2 AND user_id=$3 AND deleted_at IS NULL)`
+
+	var expiresAt interface{}
+	if link.ExpiresAt != nil {
+		expiresAt = link.ExpiresAt.UTC()
+	}
+
+	result, err := l.db.ExecContext(ctx, query, link.ID, link.NoteID, link.OwnerID, link.TokenHash, link.PasswordHash, expiresAt)
+	if err != nil {
+		l.log.Error("failed to execute INSERT query in CreateNoteLink",
+			zap.Error(err),
+			zap.String("note_id", link.NoteID.String()),
+			zap.String("user_id", link.OwnerID.String()),
+		)
+		return domain.MakeError(domain.ErrFailedToCreate, err, "note link")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		l.log.Error("failed to get rows affected after INSERT",
+			zap.Error(err),
+			zap.String("note_id", link.NoteID.String()),
+			zap.String("user_id", link.OwnerID.String()),
+		)
+		return domain.MakeError(domain.ErrFailedToCreate, err, "note link")
+	}
+
+	if rowsAffected == 0 {
+		return domain.MakeError(domain.ErrFailedToCreate, domain.ErrNotFound, "note")
+	}
+
+	return nil
+}
+
+func (l *LinkR) NoteLinks(ctx context.Context, userID, noteID uuid.UUID) ([]domain.NoteLink, error) {
+	var exists bool
+	query := `SELECT EXISTS (SELECT 1 FROM notes WHERE id=$1 AND user_id=$2 AND deleted_at IS NULL)`
+	if err := l.db.QueryRowContext(ctx, query, noteID, userID).Scan(&exists); err != nil {
+		l.log.Error("failed to check note in NoteLinks",
+			zap.Error(err),
+			zap.String("note_id", noteID.String()),
+			zap.String("user_id", userID.String()),
+		)
+		return nil, domain.MakeError(domain.ErrReceiving, err, "note links")
+	}
+
+	if !exists {
+		return nil, domain.MakeError(domain.ErrReceiving, domain.ErrNotFound, "note")
+	}
+
+	query = `
+		SELECT id, note_id, token_hash, password_hash, expires_at, created_at
+		FROM note_links
+		WHERE note_id=$1
+		ORDER BY created_at DESC, id DESC`
+
+	rows, err := l.db.QueryContext(ctx, query, noteID)
+	if err != nil {
+		l.log.Error("failed to execute SELECT query in NoteLinks",
+			zap.Error(err),
+			zap.String("note_id", noteID.String()),
+			zap.String("user_id", userID.String()),
+		)
+		return nil, domain.MakeError(domain.ErrReceiving, err, "note links")
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			l.log.Error("failed to close rows", zap.Error(closeErr))
+		}
+	}()
+
+	var links []domain.NoteLink
+	for rows.Next() {
+		link := domain.NoteLink{OwnerID: userID}
+		if err := rows.Scan(
+			&link.ID,
+			&link.NoteID,
+			&link.TokenHash,
+			&link.PasswordHash,
+			&link.ExpiresAt,
+			&link.CreatedAt,
+		); err != nil {
+			return nil, domain.MakeError(domain.ErrReceiving, err, "note links")
+		}
+		links = append(links, link)
+	}
+
+	if err := rows.Err(); err != nil {
+		l.log.Error("error during row iteration",
+			zap.Error(err),
+			zap.String("note_id", noteID.String()),
+		)
+		return nil, domain.MakeError(domain.ErrReceiving, err, "note links")
+	}
+
+	return links, nil
+}
+
+func (l *LinkR) NoteLinkByToken(ctx context.Context, tokenHash string) (domain.NoteLink, error) {
+	query := `
+		SELECT nl.id, nl.note_id, n.user_id, nl.token_hash, nl.password_hash, nl.expires_at, nl.created_at
+		FROM note_links nl
+		JOIN notes n ON n.id = nl.note_id
+		WHERE nl.token_hash=$1 AND n.deleted_at IS NULL
+		AND (nl.expires_at IS NULL OR nl.expires_at > NOW())`
+
+	var link domain.NoteLink
+	err := l.db.QueryRowContext(ctx, query, tokenHash).Scan(
+		&link.ID,
+		&link.NoteID,
+		&link.OwnerID,
+		&link.TokenHash,
+		&link.PasswordHash,
+		&link.ExpiresAt,
+		&link.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.NoteLink{}, domain.MakeError(domain.ErrReceiving, domain.ErrNotFound, "note link")
+		}
+		l.log.Error("database error in NoteLinkByToken query", zap.Error(err))
+		return domain.NoteLink{}, domain.MakeError(domain.ErrReceiving, err, "note link")
+	}
+
+	return link, nil
+}
+
+func (l *LinkR) DeleteNoteLink(ctx context.Context, userID, noteID, linkID uuid.UUID) error {
+	query := `
+		DELETE FROM note_links nl
+		USING notes n
+		WHERE nl.note_id = n.id AND nl.id=$1 AND nl.note_id=$2 AND n.user_id=$3`
+
+	result, err := l.db.ExecContext(ctx, query, linkID, noteID, userID)
+	if err != nil {
+		l.log.Error("failed to execute DELETE query in DeleteNoteLink",
+			zap.Error(err),
+			zap.String("link_id", linkID.String()),
+			zap.String("note_id", noteID.String()),
+			zap.String("user_id", userID.String()),
+		)
+		return domain.MakeError(domain.ErrFailedToDelete, err, "note link")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		l.log.Error("failed to get rows affected after DELETE",
+			zap.Error(err),
+			zap.String("link_id", linkID.String()),
+			zap.String("note_id", noteID.String()),
+			zap.String("user_id", userID.String()),
+		)
+		return domain.MakeError(domain.ErrFailedToDelete, err, "note link")
+	}
+
+	if rowsAffected == 0 {
+		return domain.MakeError(domain.ErrFailedToDelete, domain.ErrNotFound, "note link")
+	}
+
+	return nil
+}
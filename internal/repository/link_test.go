@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"noteApp/internal/models/domain"
+	"noteApp/pkg/logger"
+	"noteApp/pkg/utils"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinkR_NoteLinks(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	userID := createTestUser(t, tx)
+	otherID := createNamedTestUser(t, tx, "link_other")
+	noteID := createTaggedNote(t, tx, userID)
+
+	repo := NewLinkRepository(tx, logger.LoggerForTest())
+
+	password := "hashed"
+	link := domain.NoteLink{
+		ID:           uuid.New(),
+		NoteID:       noteID,
+		OwnerID:      userID,
+		TokenHash:    utils.HashToken("link_token"),
+		PasswordHash: &password,
+	}
+	require.NoError(t, repo.CreateNoteLink(context.Background(), link))
+
+	links, err := repo.NoteLinks(context.Background(), userID, noteID)
+	require.NoError(t, err)
+	require.Len(t, links, 1)
+	assert.Equal(t, link.ID, links[0].ID)
+	require.NotNil(t, links[0].PasswordHash)
+	assert.Equal(t, password, *links[0].PasswordHash)
+
+	got, err := repo.NoteLinkByToken(context.Background(), link.TokenHash)
+	require.NoError(t, err)
+	assert.Equal(t, link.ID, got.ID)
+	assert.Equal(t, userID, got.OwnerID)
+
+	err = repo.CreateNoteLink(context.Background(), domain.NoteLink{
+		ID:        uuid.New(),
+		NoteID:    noteID,
+		OwnerID:   otherID,
+		TokenHash: utils.HashToken("other_token"),
+	})
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	_, err = repo.NoteLinks(context.Background(), otherID, noteID)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestLinkR_NoteLinkByToken(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	userID := createTestUser(t, tx)
+	noteID := createTaggedNote(t, tx, userID)
+
+	repo := NewLinkRepository(tx, logger.LoggerForTest())
+
+	expired := time.Now().Add(-time.Hour)
+	expiredLink := domain.NoteLink{
+		ID:        uuid.New(),
+		NoteID:    noteID,
+		OwnerID:   userID,
+		TokenHash: utils.HashToken("expired_token"),
+		ExpiresAt: &expired,
+	}
+	require.NoError(t, repo.CreateNoteLink(context.Background(), expiredLink))
+
+	_, err = repo.NoteLinkByToken(context.Background(), expiredLink.TokenHash)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	link := domain.NoteLink{
+		ID:        uuid.New(),
+		NoteID:    noteID,
+		OwnerID:   userID,
+		TokenHash: utils.HashToken("live_token"),
+	}
+	require.NoError(t, repo.CreateNoteLink(context.Background(), link))
+
+	noteRepo := NewNoteRepository(tx, logger.LoggerForTest())
+	require.NoError(t, noteRepo.DeleteNote(context.Background(), userID, noteID, nil))
+
+	_, err = repo.NoteLinkByToken(context.Background(), link.TokenHash)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestLinkR_DeleteNoteLink(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	userID := createTestUser(t, tx)
+	otherID := createNamedTestUser(t, tx, "unlink_other")
+	noteID := createTaggedNote(t, tx, userID)
+
+	repo := NewLinkRepository(tx, logger.LoggerForTest())
+
+	link := domain.NoteLink{
+		ID:        uuid.New(),
+		NoteID:    noteID,
+		OwnerID:   userID,
+		TokenHash: utils.HashToken("revoke_token"),
+	}
+	require.NoError(t, repo.CreateNoteLink(context.Background(), link))
+
+	err = repo.DeleteNoteLink(context.Background(), otherID, noteID, link.ID)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	require.NoError(t, repo.DeleteNoteLink(context.Background(), userID, noteID, link.ID))
+
+	_, err = repo.NoteLinkByToken(context.Background(), link.TokenHash)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	err = repo.DeleteNoteLink(context.Background(), userID, noteID, link.ID)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+}
@@ -8,30 +8,51 @@ import (
 	"noteApp/internal/models/dto"
 	"noteApp/pkg/logger"
 	"noteApp/pkg/utils"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"go.uber.org/zap"
 )
 
+const noteTagsColumn = `ARRAY(
+			SELECT t.name FROM note_tags nt JOIN tags t ON t.id = nt.tag_id
+			WHERE nt.note_id = notes.id ORDER BY t.name)`
+
 type NoteR struct {
 	db  query
+	tx  *TxManager
 	log *logger.Logger
 }
 
 func NewNoteRepository(db query, log *logger.Logger) *NoteR {
 	return &NoteR{
-		db:  db,
+		db:  txQuery{db: db},
+		tx:  NewTxManager(db, log),
 		log: log,
 	}
 }
 
 func (n *NoteR) CreateNote(ctx context.Context, note domain.Note) error {
-	query := `INSERT INTO notes (id, user_id, heading, content, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6)`
+	return n.tx.WithinTx(ctx, func(ctx context.Context) error {
+		return n.createNote(ctx, note)
+	})
+}
 
-	_, err := n.db.ExecContext(ctx, query, note.ID, note.UserID, note.Heading, note.Content, time.Now().UTC(), time.Now().UTC())
+func (n *NoteR) createNote(ctx context.Context, note domain.Note) error {
+	query := `
+		INSERT INTO notes (id, user_id, notebook_id, heading, content, done, created_at, updated_at)
+		SELECT $1, $2, $3, $4, $5, $6, $7, $8
+		WHERE $3::uuid IS NULL OR EXISTS (SELECT 1 FROM notebooks WHERE id=$3 AND user_id=$2)`
+
+	result, err := n.db.ExecContext(ctx, query, note.ID, note.UserID, note.NotebookID, note.Heading, note.Content, note.Done, time.Now().UTC(), time.Now().UTC())
 	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.MakeError(domain.ErrFailedToCreate, domain.ErrAlreadyExists, "note")
+		}
 		n.log.Error("failed to execute INSERT query in CreateNote",
 			zap.Error(err),
 			zap.String("note_id", note.ID.String()),
@@ -40,29 +61,57 @@ func (n *NoteR) CreateNote(ctx context.Context, note domain.Note) error {
 		return domain.MakeError(domain.ErrFailedToCreate, err, "note")
 	}
 
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		n.log.Error("failed to get rows affected after INSERT",
+			zap.Error(err),
+			zap.String("note_id", note.ID.String()),
+			zap.String("user_id", note.UserID.String()),
+		)
+		return domain.MakeError(domain.ErrFailedToCreate, err, "note")
+	}
+
+	if rowsAffected == 0 {
+		return domain.MakeError(domain.ErrFailedToCreate, domain.ErrNotFound, "notebook")
+	}
+
+	if len(note.Tags) > 0 {
+		if err := n.setNoteTags(ctx, note.UserID, note.ID, note.Tags); err != nil {
+			return domain.MakeError(domain.ErrFailedToCreate, err, "note")
+		}
+	}
+
 	return nil
 }
 
 func (n *NoteR) Note(ctx context.Context, userID, noteID uuid.UUID) (domain.Note, error) {
-	query := `
+	query := fmt.Sprintf(`
 		SELECT
 			id,
 			user_id,
+			notebook_id,
 			heading,
 			content,
+			done,
+			%v,
 			created_at,
-			updated_at
+			updated_at,
+			version
 		FROM notes
-		WHERE id=$1 AND user_id=$2`
+		WHERE id=$1 AND user_id=$2 AND deleted_at IS NULL`, noteTagsColumn)
 
 	var note domain.Note
 	err := n.db.QueryRowContext(ctx, query, noteID, userID).Scan(
 		&note.ID,
 		&note.UserID,
+		&note.NotebookID,
 		&note.Heading,
 		&note.Content,
+		&note.Done,
+		(*pq.StringArray)(&note.Tags),
 		&note.CreatedAt,
 		&note.UpdatedAt,
+		&note.Version,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -79,26 +128,30 @@ func (n *NoteR) Note(ctx context.Context, userID, noteID uuid.UUID) (domain.Note
 	return note, nil
 }
 
-func (n *NoteR) Notes(ctx context.Context, userID uuid.UUID, p dto.Paginated) ([]domain.Note, int, error) {
+func (n *NoteR) Notes(ctx context.Context, userID uuid.UUID, f dto.NoteFilter) ([]domain.Note, int, error) {
+	where, args := noteFilterConditions(userID, f)
+
 	var total int
-	query := "SELECT COUNT(*) FROM notes WHERE user_id=$1"
-	err := n.db.QueryRowContext(ctx, query, userID).Scan(&total)
+	query := fmt.Sprintf("SELECT COUNT(*) FROM notes WHERE %v", where)
+	err := n.db.QueryRowContext(ctx, query, args...).Scan(&total)
 	if err != nil {
 		return nil, 0, domain.MakeError(domain.ErrReceiving, err, "notes")
 	}
 
-	if total == 0 || total <= p.Offset {
+	if total == 0 || total <= f.Offset {
 		return nil, 0, nil
 	}
 
-	query = `
-        SELECT id, user_id, heading, content, created_at, updated_at
+	query = fmt.Sprintf(`
+        SELECT id, user_id, notebook_id, heading, content, done, %v, created_at, updated_at, version
         FROM notes
-        WHERE user_id=$1
-		ORDER BY created_at ASC
-        LIMIT $2 OFFSET $3`
+        WHERE %v
+		ORDER BY %v
+        LIMIT $%v OFFSET $%v`, noteTagsColumn, where, noteOrderBy(f), len(args)+1, len(args)+2)
+
+	args = append(args, f.Limit, f.Offset)
 
-	rows, err := n.db.QueryContext(ctx, query, userID, p.Limit, p.Offset)
+	rows, err := n.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		n.log.Error("failed to execute SELECT query in Notes",
 			zap.Error(err),
@@ -113,20 +166,24 @@ func (n *NoteR) Notes(ctx context.Context, userID uuid.UUID, p dto.Paginated) ([
 		}
 	}()
 
-	if p.Offset+p.Limit > total {
-		p.Limit = total - p.Offset
+	if f.Offset+f.Limit > total {
+		f.Limit = total - f.Offset
 	}
 
-	notes := make([]domain.Note, 0, p.Limit)
+	notes := make([]domain.Note, 0, f.Limit)
 	for rows.Next() {
 		var note domain.Note
 		err := rows.Scan(
 			&note.ID,
 			&note.UserID,
+			&note.NotebookID,
 			&note.Heading,
 			&note.Content,
+			&note.Done,
+			(*pq.StringArray)(&note.Tags),
 			&note.CreatedAt,
 			&note.UpdatedAt,
+			&note.Version,
 		)
 		if err != nil {
 			return nil, 0, domain.MakeError(domain.ErrReceiving, err, "notes")
@@ -145,7 +202,382 @@ func (n *NoteR) Notes(ctx context.Context, userID uuid.UUID, p dto.Paginated) ([
 	return notes, total, nil
 }
 
+func (n *NoteR) NotesByCursor(ctx context.Context, userID uuid.UUID, f dto.NoteFilter, cursor *domain.NoteCursor) ([]domain.Note, bool, error) {
+	where, args := noteFilterConditions(userID, f)
+
+	backward := cursor != nil && cursor.Backward
+	if backward {
+		if f.Order == "desc" {
+			f.Order = "asc"
+		} else {
+			f.Order = "desc"
+		}
+	}
+
+	if cursor != nil {
+		column, ok := noteSortColumns[cursor.Sort]
+		if !ok {
+			return nil, false, domain.MakeError(domain.ErrReceiving, domain.ErrInvalidCursor, "notes")
+		}
+
+		operator := ">"
+		if f.Order == "desc" {
+			operator = "<"
+		}
+
+		args = append(args, cursor.Value, cursor.ID)
+		where += fmt.Sprintf(" AND (%v, id) %v ($%v, $%v)", column, operator, len(args)-1, len(args))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, user_id, notebook_id, heading, content, done, %v, created_at, updated_at, version
+		FROM notes
+		WHERE %v
+		ORDER BY %v
+		LIMIT $%v`, noteTagsColumn, where, noteOrderBy(f), len(args)+1)
+
+	args = append(args, f.Limit+1)
+
+	rows, err := n.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		n.log.Error("failed to execute SELECT query in NotesByCursor",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return nil, false, domain.MakeError(domain.ErrReceiving, err, "notes")
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			n.log.Error("failed to close rows", zap.Error(closeErr))
+		}
+	}()
+
+	notes := make([]domain.Note, 0, f.Limit+1)
+	for rows.Next() {
+		var note domain.Note
+		err := rows.Scan(
+			&note.ID,
+			&note.UserID,
+			&note.NotebookID,
+			&note.Heading,
+			&note.Content,
+			&note.Done,
+			(*pq.StringArray)(&note.Tags),
+			&note.CreatedAt,
+			&note.UpdatedAt,
+			&note.Version,
+		)
+		if err != nil {
+			return nil, false, domain.MakeError(domain.ErrReceiving, err, "notes")
+		}
+		notes = append(notes, note)
+	}
+
+	if err = rows.Err(); err != nil {
+		n.log.Error("error during row iteration",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return nil, false, domain.MakeError(domain.ErrReceiving, err, "notes")
+	}
+
+	hasMore := len(notes) > f.Limit
+	if hasMore {
+		notes = notes[:f.Limit]
+	}
+
+	if backward {
+		slices.Reverse(notes)
+	}
+
+	return notes, hasMore, nil
+}
+
+func (n *NoteR) SearchNotes(ctx context.Context, userID uuid.UUID, tsQuery string, p dto.Paginated) ([]domain.NoteSearchResult, int, error) {
+	var total int
+	query := `
+		SELECT COUNT(*)
+		FROM notes, to_tsquery('english', $2) AS q
+		WHERE user_id=$1 AND deleted_at IS NULL AND search_vector @@ q`
+	err := n.db.QueryRowContext(ctx, query, userID, tsQuery).Scan(&total)
+	if err != nil {
+		n.log.Error("failed to execute COUNT query in SearchNotes",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return nil, 0, domain.MakeError(domain.ErrReceiving, err, "notes")
+	}
+
+	if total == 0 || total <= p.Offset {
+		return nil, total, nil
+	}
+
+	query = fmt.Sprintf(`
+		SELECT
+			id, user_id, notebook_id, heading, content, done, %v, created_at, updated_at, version,
+			ts_rank_cd(search_vector, q) AS rank,
+			ts_headline('english', heading || ' ' || content, q,
+				'StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15, MaxFragments=2')
+		FROM notes, to_tsquery('english', $2) AS q
+		WHERE user_id=$1 AND deleted_at IS NULL AND search_vector @@ q
+		ORDER BY rank DESC, updated_at DESC
+		LIMIT $3 OFFSET $4`, noteTagsColumn)
+
+	rows, err := n.db.QueryContext(ctx, query, userID, tsQuery, p.Limit, p.Offset)
+	if err != nil {
+		n.log.Error("failed to execute SELECT query in SearchNotes",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return nil, 0, domain.MakeError(domain.ErrReceiving, err, "notes")
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			n.log.Error("failed to close rows", zap.Error(closeErr))
+		}
+	}()
+
+	results := make([]domain.NoteSearchResult, 0, p.Limit)
+	for rows.Next() {
+		var result domain.NoteSearchResult
+		err := rows.Scan(
+			&result.ID,
+			&result.UserID,
+			&result.NotebookID,
+			&result.Heading,
+			&result.Content,
+			&result.Done,
+			(*pq.StringArray)(&result.Tags),
+			&result.CreatedAt,
+			&result.UpdatedAt,
+			&result.Version,
+			&result.Rank,
+			&result.Headline,
+		)
+		if err != nil {
+			return nil, 0, domain.MakeError(domain.ErrReceiving, err, "notes")
+		}
+		results = append(results, result)
+	}
+
+	if err = rows.Err(); err != nil {
+		n.log.Error("error during row iteration",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return nil, 0, domain.MakeError(domain.ErrReceiving, err, "notes")
+	}
+
+	return results, total, nil
+}
+
+func (n *NoteR) FuzzySearchNotes(ctx context.Context, userID uuid.UUID, s dto.NoteSearch) ([]domain.NoteSearchResult, int, error) {
+	var (
+		results []domain.NoteSearchResult
+		total   int
+	)
+	err := n.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		results, total, err = n.fuzzySearchNotes(ctx, userID, s)
+		return err
+	})
+
+	return results, total, err
+}
+
+func (n *NoteR) fuzzySearchNotes(ctx context.Context, userID uuid.UUID, s dto.NoteSearch) ([]domain.NoteSearchResult, int, error) {
+	similarity := "word_similarity($2, heading)"
+	match := "$2 <% heading"
+	if s.Content {
+		similarity = "GREATEST(word_similarity($2, heading), word_similarity($2, content))"
+		match = "($2 <% heading OR $2 <% content)"
+	}
+
+	threshold := domain.DefaultSimilarityThreshold
+	if s.Threshold != nil {
+		threshold = *s.Threshold
+	}
+
+	_, err := n.db.ExecContext(ctx, `SELECT set_config('pg_trgm.word_similarity_threshold', $1, true)`, strconv.FormatFloat(threshold, 'f', -1, 64))
+	if err != nil {
+		n.log.Error("failed to set similarity threshold in FuzzySearchNotes",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return nil, 0, domain.MakeError(domain.ErrReceiving, err, "notes")
+	}
+
+	var total int
+	query := fmt.Sprintf("SELECT COUNT(*) FROM notes WHERE user_id=$1 AND deleted_at IS NULL AND %v", match)
+	err = n.db.QueryRowContext(ctx, query, userID, s.Query).Scan(&total)
+	if err != nil {
+		n.log.Error("failed to execute COUNT query in FuzzySearchNotes",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return nil, 0, domain.MakeError(domain.ErrReceiving, err, "notes")
+	}
+
+	if total == 0 || total <= s.Offset {
+		return nil, total, nil
+	}
+
+	query = fmt.Sprintf(`
+		SELECT
+			id, user_id, notebook_id, heading, content, done, %v, created_at, updated_at, version,
+			%v AS similarity
+		FROM notes
+		WHERE user_id=$1 AND deleted_at IS NULL AND %v
+		ORDER BY similarity DESC, updated_at DESC
+		LIMIT $3 OFFSET $4`, noteTagsColumn, similarity, match)
+
+	rows, err := n.db.QueryContext(ctx, query, userID, s.Query, s.Limit, s.Offset)
+	if err != nil {
+		n.log.Error("failed to execute SELECT query in FuzzySearchNotes",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return nil, 0, domain.MakeError(domain.ErrReceiving, err, "notes")
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			n.log.Error("failed to close rows", zap.Error(closeErr))
+		}
+	}()
+
+	results := make([]domain.NoteSearchResult, 0, s.Limit)
+	for rows.Next() {
+		var result domain.NoteSearchResult
+		err := rows.Scan(
+			&result.ID,
+			&result.UserID,
+			&result.NotebookID,
+			&result.Heading,
+			&result.Content,
+			&result.Done,
+			(*pq.StringArray)(&result.Tags),
+			&result.CreatedAt,
+			&result.UpdatedAt,
+			&result.Version,
+			&result.Similarity,
+		)
+		if err != nil {
+			return nil, 0, domain.MakeError(domain.ErrReceiving, err, "notes")
+		}
+		results = append(results, result)
+	}
+
+	if err = rows.Err(); err != nil {
+		n.log.Error("error during row iteration",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return nil, 0, domain.MakeError(domain.ErrReceiving, err, "notes")
+	}
+
+	return results, total, nil
+}
+
+func noteFilterConditions(userID uuid.UUID, f dto.NoteFilter) (string, []interface{}) {
+	conditions := []string{"user_id=$1", "deleted_at IS NULL"}
+	args := []interface{}{userID}
+
+	if f.Done != nil {
+		args = append(args, *f.Done)
+		conditions = append(conditions, fmt.Sprintf("done=$%v", len(args)))
+	}
+
+	if len(f.Tags) > 0 {
+		tags := uniqueTags(f.Tags)
+		args = append(args, pq.StringArray(tags))
+		tagQuery := fmt.Sprintf(`
+			SELECT nt.note_id FROM note_tags nt JOIN tags t ON t.id = nt.tag_id
+			WHERE t.user_id=$1 AND t.name = ANY($%v)`, len(args))
+
+		if f.TagMatch == domain.TagMatchAll {
+			args = append(args, len(tags))
+			tagQuery += fmt.Sprintf(" GROUP BY nt.note_id HAVING COUNT(DISTINCT t.id)=$%v", len(args))
+		}
+
+		conditions = append(conditions, fmt.Sprintf("id IN (%v)", tagQuery))
+	}
+
+	if f.NotebookID != "" {
+		args = append(args, f.NotebookID)
+		if f.Recursive {
+			conditions = append(conditions, fmt.Sprintf(`notebook_id IN (
+				WITH RECURSIVE tree AS (
+					SELECT id FROM notebooks WHERE id=$%v AND user_id=$1
+					UNION
+					SELECT nb.id FROM notebooks nb JOIN tree t ON nb.parent_id = t.id
+				)
+				SELECT id FROM tree)`, len(args)))
+		} else {
+			conditions = append(conditions, fmt.Sprintf("notebook_id=$%v", len(args)))
+		}
+	}
+
+	ranges := []struct {
+		condition string
+		value     *time.Time
+	}{
+		{"created_at >= $%v", f.CreatedAfter},
+		{"created_at < $%v", f.CreatedBefore},
+		{"updated_at >= $%v", f.UpdatedAfter},
+		{"updated_at < $%v", f.UpdatedBefore},
+	}
+	for _, r := range ranges {
+		if r.value == nil {
+			continue
+		}
+		args = append(args, r.value.UTC())
+		conditions = append(conditions, fmt.Sprintf(r.condition, len(args)))
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+var noteSortColumns = map[string]string{
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+	"heading":    "heading",
+}
+
+func noteOrderBy(f dto.NoteFilter) string {
+	column, ok := noteSortColumns[f.Sort]
+	if !ok {
+		column = "created_at"
+	}
+
+	direction := "ASC"
+	if f.Order == "desc" {
+		direction = "DESC"
+	}
+
+	return fmt.Sprintf("%v %v, id %v", column, direction, direction)
+}
+
+func uniqueTags(tags []string) []string {
+	seen := make(map[string]struct{}, len(tags))
+	result := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		result = append(result, tag)
+	}
+
+	return result
+}
+
 func (n *NoteR) UpdateNote(ctx context.Context, note domain.NoteUpdate) error {
+	return n.tx.WithinTx(ctx, func(ctx context.Context) error {
+		return n.updateNote(ctx, note)
+	})
+}
+
+func (n *NoteR) updateNote(ctx context.Context, note domain.NoteUpdate) error {
 	var (
 		fields []string
 		args   []interface{}
@@ -154,17 +586,37 @@ func (n *NoteR) UpdateNote(ctx context.Context, note domain.NoteUpdate) error {
 
 	utils.AddFieldsToQuery("heading", note.Heading, &fields, &args, &argIdx)
 	utils.AddFieldsToQuery("content", note.Content, &fields, &args, &argIdx)
+	utils.AddFieldsToQuery("done", note.Done, &fields, &args, &argIdx)
 
-	if len(fields) == 0 {
+	if len(fields) == 0 && note.Tags == nil {
 		return domain.MakeError(domain.ErrFailedToUpdate, domain.ErrNoFieldsToUpdate, "note")
 	}
 
-	fields = append(fields, "updated_at=NOW()")
-
-	query := fmt.Sprintf(`UPDATE notes SET %v WHERE id=$%v AND user_id=$%v`, strings.Join(fields, ", "), argIdx+1, argIdx+2)
+	fields = append(fields, "updated_at=NOW()", "version=version+1")
 
+	where := fmt.Sprintf("id=$%v AND user_id=$%v AND deleted_at IS NULL", argIdx+1, argIdx+2)
 	args = append(args, note.ID, note.UserID)
 
+	if note.Version != nil {
+		args = append(args, *note.Version)
+		where += fmt.Sprintf(" AND version=$%v", len(args))
+	}
+
+	query := fmt.Sprintf(`UPDATE notes SET %v WHERE %v`, strings.Join(fields, ", "), where)
+
+	if note.Heading != nil || note.Content != nil {
+		query = fmt.Sprintf(`
+			WITH previous AS (
+				SELECT id, heading, content, version FROM notes WHERE %[1]v FOR UPDATE
+			),
+			revision AS (
+				INSERT INTO note_revisions (note_id, revision, heading, content, created_at)
+				SELECT id, version, heading, content, NOW()
+				FROM previous
+			)
+			%[2]v`, where, query)
+	}
+
 	result, err := n.db.ExecContext(ctx, query, args...)
 	if err != nil {
 		n.log.Error("failed to execute UPDATE query",
@@ -185,6 +637,92 @@ func (n *NoteR) UpdateNote(ctx context.Context, note domain.NoteUpdate) error {
 		return domain.MakeError(domain.ErrFailedToUpdate, err, "note")
 	}
 
+	if rowsAffected == 0 {
+		return n.noteWriteConflict(ctx, domain.ErrFailedToUpdate, note.UserID, note.ID, note.Version)
+	}
+
+	if note.Tags != nil {
+		if err := n.setNoteTags(ctx, note.UserID, note.ID, *note.Tags); err != nil {
+			return domain.MakeError(domain.ErrFailedToUpdate, err, "note")
+		}
+	}
+
+	return nil
+}
+
+func (n *NoteR) setNoteTags(ctx context.Context, userID, noteID uuid.UUID, tags []string) error {
+	query := `DELETE FROM note_tags WHERE note_id=$1`
+
+	if _, err := n.db.ExecContext(ctx, query, noteID); err != nil {
+		n.log.Error("failed to execute DELETE query in setNoteTags",
+			zap.Error(err),
+			zap.String("note_id", noteID.String()),
+			zap.String("user_id", userID.String()),
+		)
+		return err
+	}
+
+	if len(tags) == 0 {
+		return nil
+	}
+
+	query = `
+		WITH input AS (
+			SELECT DISTINCT unnest($3::varchar[]) AS name
+		),
+		created AS (
+			INSERT INTO tags (id, user_id, name, created_at)
+			SELECT gen_random_uuid(), $1, name, NOW() FROM input
+			ON CONFLICT (user_id, name) DO NOTHING
+			RETURNING id
+		),
+		matched AS (
+			SELECT id FROM created
+			UNION ALL
+			SELECT t.id FROM tags t JOIN input i ON i.name = t.name WHERE t.user_id=$1
+		)
+		INSERT INTO note_tags (note_id, tag_id)
+		SELECT $2, id FROM matched
+		ON CONFLICT DO NOTHING`
+
+	if _, err := n.db.ExecContext(ctx, query, userID, noteID, pq.StringArray(tags)); err != nil {
+		n.log.Error("failed to execute INSERT query in setNoteTags",
+			zap.Error(err),
+			zap.String("note_id", noteID.String()),
+			zap.String("user_id", userID.String()),
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (n *NoteR) MoveNote(ctx context.Context, userID, noteID uuid.UUID, notebookID *uuid.UUID) error {
+	query := `
+		UPDATE notes SET notebook_id=$1, updated_at=NOW(), version=version+1
+		WHERE id=$2 AND user_id=$3 AND deleted_at IS NULL
+		AND ($1::uuid IS NULL OR EXISTS (SELECT 1 FROM notebooks WHERE id=$1 AND user_id=$3))`
+
+	result, err := n.db.ExecContext(ctx, query, notebookID, noteID, userID)
+	if err != nil {
+		n.log.Error("failed to execute UPDATE query in MoveNote",
+			zap.Error(err),
+			zap.String("note_id", noteID.String()),
+			zap.String("user_id", userID.String()),
+		)
+		return domain.MakeError(domain.ErrFailedToUpdate, err, "note")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		n.log.Error("failed to get rows affected after UPDATE",
+			zap.Error(err),
+			zap.String("note_id", noteID.String()),
+			zap.String("user_id", userID.String()),
+		)
+		return domain.MakeError(domain.ErrFailedToUpdate, err, "note")
+	}
+
 	if rowsAffected == 0 {
 		return domain.MakeError(domain.ErrFailedToUpdate, domain.ErrNotFound, "note")
 	}
@@ -192,12 +730,14 @@ func (n *NoteR) UpdateNote(ctx context.Context, note domain.NoteUpdate) error {
 	return nil
 }
 
-func (n *NoteR) DeleteNote(ctx context.Context, userID, noteID uuid.UUID) error {
-	query := `DELETE FROM notes WHERE id=$1 AND user_id=$2`
+func (n *NoteR) DeleteNote(ctx context.Context, userID, noteID uuid.UUID, version *int) error {
+	query := `
+		UPDATE notes SET deleted_at=NOW(), version=version+1
+		WHERE id=$1 AND user_id=$2 AND deleted_at IS NULL AND ($3::int IS NULL OR version=$3)`
 
-	result, err := n.db.ExecContext(ctx, query, noteID, userID)
+	result, err := n.db.ExecContext(ctx, query, noteID, userID, version)
 	if err != nil {
-		n.log.Error("failed to execute DELETE query",
+		n.log.Error("failed to execute UPDATE query in DeleteNote",
 			zap.Error(err),
 			zap.String("note_id", noteID.String()),
 			zap.String("user_id", userID.String()),
@@ -207,7 +747,7 @@ func (n *NoteR) DeleteNote(ctx context.Context, userID, noteID uuid.UUID) error
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		n.log.Error("failed to get rows affected after DELETE",
+		n.log.Error("failed to get rows affected after UPDATE",
 			zap.Error(err),
 			zap.String("note_id", noteID.String()),
 			zap.String("user_id", userID.String()),
@@ -216,8 +756,31 @@ func (n *NoteR) DeleteNote(ctx context.Context, userID, noteID uuid.UUID) error
 	}
 
 	if rowsAffected == 0 {
-		return domain.MakeError(domain.ErrFailedToDelete, sql.ErrNoRows, "note")
+		return n.noteWriteConflict(ctx, domain.ErrFailedToDelete, userID, noteID, version)
 	}
 
 	return nil
 }
+
+func (n *NoteR) noteWriteConflict(ctx context.Context, dErr error, userID, noteID uuid.UUID, version *int) error {
+	if version == nil {
+		return domain.MakeError(dErr, domain.ErrNotFound, "note")
+	}
+
+	var exists bool
+	query := `SELECT EXISTS (SELECT 1 FROM notes WHERE id=$1 AND user_id=$2 AND deleted_at IS NULL)`
+	if err := n.db.QueryRowContext(ctx, query, noteID, userID).Scan(&exists); err != nil {
+		n.log.Error("failed to check note after version conflict",
+			zap.Error(err),
+			zap.String("note_id", noteID.String()),
+			zap.String("user_id", userID.String()),
+		)
+		return domain.MakeError(dErr, err, "note")
+	}
+
+	if !exists {
+		return domain.MakeError(dErr, domain.ErrNotFound, "note")
+	}
+
+	return domain.MakeError(dErr, domain.ErrVersionMismatch, "note")
+}
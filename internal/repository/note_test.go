@@ -5,6 +5,7 @@ import (
 	"noteApp/internal/models/domain"
 	"noteApp/internal/models/dto"
 	"noteApp/pkg/logger"
+	"strings"
 	"testing"
 	"time"
 
@@ -39,6 +40,20 @@ func TestNoteR_CreateNote(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "success with done",
+			args: args{
+				context.Background(),
+				domain.Note{
+					ID:      uuid.New(),
+					UserID:  uuid.New(),
+					Heading: "test_heading",
+					Content: "test_content",
+					Done:    true,
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "without user",
 			args: args{
@@ -115,12 +130,35 @@ func TestNoteR_CreateNote(t *testing.T) {
 			require.NoError(t, err)
 			assert.Equal(t, got.Heading, tt.args.note.Heading)
 			assert.Equal(t, got.Content, tt.args.note.Content)
+			assert.Equal(t, got.Done, tt.args.note.Done)
 			require.WithinDuration(t, time.Now(), got.CreatedAt, time.Second)
 			require.WithinDuration(t, time.Now(), got.UpdatedAt, time.Second)
 		})
 	}
 }
 
+func TestNoteR_CreateNoteTakenID(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	repoUser := NewUserRepository(tx, logger.LoggerForTest())
+	repoNote := NewNoteRepository(tx, logger.LoggerForTest())
+
+	owner := domain.User{ID: uuid.New(), Username: "owner", Email: "owner", Password: "test", ImageURL: "test"}
+	other := domain.User{ID: uuid.New(), Username: "other", Email: "other", Password: "test", ImageURL: "test"}
+	require.NoError(t, repoUser.CreateUser(context.Background(), owner))
+	require.NoError(t, repoUser.CreateUser(context.Background(), other))
+
+	noteID := uuid.New()
+	require.NoError(t, repoNote.CreateNote(context.Background(), domain.Note{ID: noteID, UserID: owner.ID, Heading: "test_heading", Content: "test_content"}))
+
+	err = repoNote.CreateNote(context.Background(), domain.Note{ID: noteID, UserID: other.ID, Heading: "test_heading", Content: "test_content"})
+	require.ErrorIs(t, err, domain.ErrAlreadyExists)
+}
+
 func TestNoteR_Note(t *testing.T) {
 	t.Parallel()
 
@@ -228,7 +266,7 @@ func TestNoteR_Notes(t *testing.T) {
 	type args struct {
 		ctx    context.Context
 		userID uuid.UUID
-		p      dto.Paginated
+		f      dto.NoteFilter
 	}
 	tests := []struct {
 		name       string
@@ -243,9 +281,11 @@ func TestNoteR_Notes(t *testing.T) {
 			args: args{
 				ctx:    context.Background(),
 				userID: uuid.New(),
-				p: dto.Paginated{
-					Limit:  10,
-					Offset: 0,
+				f: dto.NoteFilter{
+					Paginated: dto.Paginated{
+						Limit:  10,
+						Offset: 0,
+					},
 				},
 			},
 			want1:   5,
@@ -256,9 +296,11 @@ func TestNoteR_Notes(t *testing.T) {
 			args: args{
 				ctx:    context.Background(),
 				userID: uuid.New(),
-				p: dto.Paginated{
-					Limit:  3,
-					Offset: 0,
+				f: dto.NoteFilter{
+					Paginated: dto.Paginated{
+						Limit:  3,
+						Offset: 0,
+					},
 				},
 			},
 			want1:   3,
@@ -269,22 +311,91 @@ func TestNoteR_Notes(t *testing.T) {
 			args: args{
 				ctx:    context.Background(),
 				userID: uuid.New(),
-				p: dto.Paginated{
-					Limit:  10,
-					Offset: 2,
+				f: dto.NoteFilter{
+					Paginated: dto.Paginated{
+						Limit:  10,
+						Offset: 2,
+					},
+				},
+			},
+			want1:   3,
+			wantErr: false,
+		},
+		{
+			name: "success with done filter",
+			args: args{
+				ctx:    context.Background(),
+				userID: uuid.New(),
+				f: dto.NoteFilter{
+					Paginated: dto.Paginated{
+						Limit:  10,
+						Offset: 0,
+					},
+					Done: &[]bool{true}[0],
+				},
+			},
+			want1:   2,
+			wantErr: false,
+		},
+		{
+			name: "success with not done filter",
+			args: args{
+				ctx:    context.Background(),
+				userID: uuid.New(),
+				f: dto.NoteFilter{
+					Paginated: dto.Paginated{
+						Limit:  10,
+						Offset: 0,
+					},
+					Done: &[]bool{false}[0],
 				},
 			},
 			want1:   3,
 			wantErr: false,
 		},
+		{
+			name: "success with any tag filter",
+			args: args{
+				ctx:    context.Background(),
+				userID: uuid.New(),
+				f: dto.NoteFilter{
+					Paginated: dto.Paginated{
+						Limit:  10,
+						Offset: 0,
+					},
+					Tags: []string{"work", "home"},
+				},
+			},
+			want1:   2,
+			wantErr: false,
+		},
+		{
+			name: "success with all tags filter",
+			args: args{
+				ctx:    context.Background(),
+				userID: uuid.New(),
+				f: dto.NoteFilter{
+					Paginated: dto.Paginated{
+						Limit:  10,
+						Offset: 0,
+					},
+					Tags:     []string{"work", "home"},
+					TagMatch: domain.TagMatchAll,
+				},
+			},
+			want1:   1,
+			wantErr: false,
+		},
 		{
 			name: "no notes for user",
 			args: args{
 				ctx:    context.Background(),
 				userID: uuid.New(),
-				p: dto.Paginated{
-					Limit:  10,
-					Offset: 0,
+				f: dto.NoteFilter{
+					Paginated: dto.Paginated{
+						Limit:  10,
+						Offset: 0,
+					},
 				},
 			},
 			want1:   0,
@@ -295,9 +406,11 @@ func TestNoteR_Notes(t *testing.T) {
 			args: args{
 				ctx:    context.Background(),
 				userID: uuid.New(),
-				p: dto.Paginated{
-					Limit:  10,
-					Offset: 10,
+				f: dto.NoteFilter{
+					Paginated: dto.Paginated{
+						Limit:  10,
+						Offset: 10,
+					},
 				},
 			},
 			want:    nil,
@@ -309,9 +422,11 @@ func TestNoteR_Notes(t *testing.T) {
 			args: args{
 				ctx:    context.Background(),
 				userID: uuid.New(),
-				p: dto.Paginated{
-					Limit:  -1,
-					Offset: 0,
+				f: dto.NoteFilter{
+					Paginated: dto.Paginated{
+						Limit:  -1,
+						Offset: 0,
+					},
 				},
 			},
 			want:       nil,
@@ -324,9 +439,11 @@ func TestNoteR_Notes(t *testing.T) {
 			args: args{
 				ctx:    context.Background(),
 				userID: uuid.New(),
-				p: dto.Paginated{
-					Limit:  10,
-					Offset: -1,
+				f: dto.NoteFilter{
+					Paginated: dto.Paginated{
+						Limit:  10,
+						Offset: -1,
+					},
 				},
 			},
 			want:       nil,
@@ -357,12 +474,19 @@ func TestNoteR_Notes(t *testing.T) {
 			require.NoError(t, err)
 
 			if tt.name != "no notes for user" {
-				for range 5 {
+				for i := range 5 {
 					note := domain.Note{
 						ID:      uuid.New(),
 						UserID:  tt.args.userID,
 						Heading: "test_heading",
 						Content: "test_content",
+						Done:    i < 2,
+					}
+					switch i {
+					case 0:
+						note.Tags = []string{"work", "home"}
+					case 1:
+						note.Tags = []string{"work"}
 					}
 
 					err := repo.CreateNote(context.Background(), note)
@@ -370,7 +494,7 @@ func TestNoteR_Notes(t *testing.T) {
 				}
 			}
 
-			got, got1, err := repo.Notes(tt.args.ctx, tt.args.userID, tt.args.p)
+			got, got1, err := repo.Notes(tt.args.ctx, tt.args.userID, tt.args.f)
 			if tt.wantErr {
 				require.ErrorContains(t, err, tt.wantErrMsg)
 				return
@@ -381,6 +505,9 @@ func TestNoteR_Notes(t *testing.T) {
 			require.LessOrEqual(t, tt.want1, got1)
 			for _, note := range got {
 				assert.Equal(t, note.UserID, tt.args.userID)
+				if tt.args.f.Done != nil {
+					assert.Equal(t, note.Done, *tt.args.f.Done)
+				}
 				assert.Equal(t, note.Heading, "test_heading")
 				assert.Equal(t, note.Content, "test_content")
 				require.WithinDuration(t, time.Now(), note.CreatedAt, time.Second)
@@ -390,6 +517,169 @@ func TestNoteR_Notes(t *testing.T) {
 	}
 }
 
+func TestNoteR_NotesSortAndRange(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	userID := createTestUser(t, tx)
+	repo := NewNoteRepository(tx, logger.LoggerForTest())
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	notes := []struct {
+		heading   string
+		createdAt time.Time
+		updatedAt time.Time
+	}{
+		{"banana", base, base.Add(72 * time.Hour)},
+		{"apple", base.Add(24 * time.Hour), base.Add(24 * time.Hour)},
+		{"cherry", base.Add(48 * time.Hour), base.Add(96 * time.Hour)},
+	}
+	for _, v := range notes {
+		noteID := uuid.New()
+		err := repo.CreateNote(context.Background(), domain.Note{
+			ID:      noteID,
+			UserID:  userID,
+			Heading: v.heading,
+			Content: "test_content",
+		})
+		require.NoError(t, err)
+
+		_, err = tx.ExecContext(context.Background(),
+			"UPDATE notes SET created_at=$1, updated_at=$2 WHERE id=$3", v.createdAt, v.updatedAt, noteID)
+		require.NoError(t, err)
+	}
+
+	after := base.Add(12 * time.Hour)
+	before := base.Add(80 * time.Hour)
+
+	tests := []struct {
+		name string
+		f    dto.NoteFilter
+		want []string
+	}{
+		{
+			name: "default order",
+			f:    dto.NoteFilter{},
+			want: []string{"banana", "apple", "cherry"},
+		},
+		{
+			name: "recently updated",
+			f:    dto.NoteFilter{Sort: "updated_at", Order: "desc"},
+			want: []string{"cherry", "banana", "apple"},
+		},
+		{
+			name: "by heading",
+			f:    dto.NoteFilter{Sort: "heading"},
+			want: []string{"apple", "banana", "cherry"},
+		},
+		{
+			name: "created after",
+			f:    dto.NoteFilter{CreatedAfter: &after},
+			want: []string{"apple", "cherry"},
+		},
+		{
+			name: "updated before",
+			f:    dto.NoteFilter{UpdatedBefore: &before, Sort: "heading", Order: "desc"},
+			want: []string{"banana", "apple"},
+		},
+		{
+			name: "created after and updated before",
+			f:    dto.NoteFilter{CreatedAfter: &after, UpdatedBefore: &before},
+			want: []string{"apple"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.f.Paginated = dto.Paginated{Limit: 10}
+
+			got, total, err := repo.Notes(context.Background(), userID, tt.f)
+			require.NoError(t, err)
+			require.Equal(t, len(tt.want), total)
+
+			headings := make([]string, 0, len(got))
+			for _, note := range got {
+				headings = append(headings, note.Heading)
+			}
+			require.Equal(t, tt.want, headings)
+		})
+	}
+}
+
+func TestNoteR_NotesByCursor(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	userID := createTestUser(t, tx)
+	repo := NewNoteRepository(tx, logger.LoggerForTest())
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ids := make([]uuid.UUID, 5)
+	for i := range ids {
+		ids[i] = uuid.New()
+		err := repo.CreateNote(context.Background(), domain.Note{
+			ID:      ids[i],
+			UserID:  userID,
+			Heading: "test_heading",
+			Content: "test_content",
+		})
+		require.NoError(t, err)
+
+		_, err = tx.ExecContext(context.Background(),
+			"UPDATE notes SET created_at=$1 WHERE id=$2", base.Add(time.Duration(i)*time.Minute), ids[i])
+		require.NoError(t, err)
+	}
+
+	cursorOf := func(note domain.Note, backward bool) *domain.NoteCursor {
+		return &domain.NoteCursor{
+			Sort:     "created_at",
+			Order:    "asc",
+			Value:    note.CreatedAt.Format(time.RFC3339Nano),
+			ID:       note.ID,
+			Backward: backward,
+		}
+	}
+
+	f := dto.NoteFilter{Paginated: dto.Paginated{Limit: 2}}
+
+	page, hasMore, err := repo.NotesByCursor(context.Background(), userID, f, nil)
+	require.NoError(t, err)
+	require.True(t, hasMore)
+	require.Equal(t, []uuid.UUID{ids[0], ids[1]}, []uuid.UUID{page[0].ID, page[1].ID})
+
+	page, hasMore, err = repo.NotesByCursor(context.Background(), userID, f, cursorOf(page[1], false))
+	require.NoError(t, err)
+	require.True(t, hasMore)
+	require.Equal(t, []uuid.UUID{ids[2], ids[3]}, []uuid.UUID{page[0].ID, page[1].ID})
+
+	last, hasMore, err := repo.NotesByCursor(context.Background(), userID, f, cursorOf(page[1], false))
+	require.NoError(t, err)
+	require.False(t, hasMore)
+	require.Len(t, last, 1)
+	require.Equal(t, ids[4], last[0].ID)
+
+	page, hasMore, err = repo.NotesByCursor(context.Background(), userID, f, cursorOf(page[0], true))
+	require.NoError(t, err)
+	require.False(t, hasMore)
+	require.Equal(t, []uuid.UUID{ids[0], ids[1]}, []uuid.UUID{page[0].ID, page[1].ID})
+
+	f.Order = "desc"
+	page, hasMore, err = repo.NotesByCursor(context.Background(), userID, f, &domain.NoteCursor{
+		Sort:  "created_at",
+		Order: "desc",
+		Value: last[0].CreatedAt.Format(time.RFC3339Nano),
+		ID:    last[0].ID,
+	})
+	require.NoError(t, err)
+	require.True(t, hasMore)
+	require.Equal(t, []uuid.UUID{ids[3], ids[2]}, []uuid.UUID{page[0].ID, page[1].ID})
+}
+
 func TestNoteR_UpdateNote(t *testing.T) {
 	t.Parallel()
 
@@ -416,6 +706,18 @@ func TestNoteR_UpdateNote(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "success with done only",
+			args: args{
+				ctx: context.Background(),
+				note: domain.NoteUpdate{
+					ID:     uuid.New(),
+					UserID: uuid.New(),
+					Done:   &[]bool{true}[0],
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "nil fields",
 			args: args{
@@ -542,6 +844,12 @@ func TestNoteR_UpdateNote(t *testing.T) {
 				assert.Equal(t, noteDB.Content, testNote.Content)
 			}
 
+			if tt.args.note.Done != nil {
+				assert.Equal(t, noteDB.Done, *tt.args.note.Done)
+			} else {
+				assert.Equal(t, noteDB.Done, testNote.Done)
+			}
+
 			require.WithinDuration(t, time.Now(), noteDB.CreatedAt, time.Second)
 
 			require.WithinDuration(t, time.Now(), noteDB.UpdatedAt, time.Second)
@@ -550,6 +858,312 @@ func TestNoteR_UpdateNote(t *testing.T) {
 	}
 }
 
+func TestNoteR_TagFailureRollsBack(t *testing.T) {
+	t.Parallel()
+
+	name := "tag_rollback_" + uuid.NewString()[:8]
+	userID := uuid.New()
+	require.NoError(t, NewUserRepository(globalTestDB, logger.LoggerForTest()).CreateUser(context.Background(), domain.User{
+		ID:       userID,
+		Username: name,
+		Email:    name + "@example.com",
+		Password: "test",
+	}))
+	t.Cleanup(func() {
+		_, _ = globalTestDB.ExecContext(context.Background(), `DELETE FROM users WHERE id=$1`, userID)
+	})
+
+	repo := NewNoteRepository(globalTestDB, logger.LoggerForTest())
+	tooLong := strings.Repeat("t", 65)
+
+	failedID := uuid.New()
+	err := repo.CreateNote(context.Background(), domain.Note{
+		ID:      failedID,
+		UserID:  userID,
+		Heading: "test_heading",
+		Content: "test_content",
+		Tags:    []string{tooLong},
+	})
+	require.Error(t, err)
+
+	_, err = repo.Note(context.Background(), userID, failedID)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	noteID := uuid.New()
+	require.NoError(t, repo.CreateNote(context.Background(), domain.Note{
+		ID:      noteID,
+		UserID:  userID,
+		Heading: "test_heading",
+		Content: "test_content",
+		Tags:    []string{"work"},
+	}))
+
+	heading := "new_heading"
+	err = repo.UpdateNote(context.Background(), domain.NoteUpdate{
+		ID:      noteID,
+		UserID:  userID,
+		Heading: &heading,
+		Tags:    &[]string{tooLong},
+	})
+	require.Error(t, err)
+
+	note, err := repo.Note(context.Background(), userID, noteID)
+	require.NoError(t, err)
+	assert.Equal(t, "test_heading", note.Heading)
+	assert.Equal(t, []string{"work"}, note.Tags)
+	assert.Equal(t, 1, note.Version)
+
+	revisions, err := NewRevisionRepository(globalTestDB, logger.LoggerForTest()).NoteRevisions(context.Background(), userID, noteID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(revisions))
+}
+
+func TestNoteR_SearchNotes(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	userID := createTestUser(t, tx)
+	repo := NewNoteRepository(tx, logger.LoggerForTest())
+
+	notes := []domain.Note{
+		{Heading: "Grocery list", Content: "buy milk and bread"},
+		{Heading: "Weekend plans", Content: "visit the grocery market with friends"},
+		{Heading: "Reading", Content: "the quick brown fox jumps over the lazy dog"},
+	}
+	ids := make([]uuid.UUID, len(notes))
+	for i, note := range notes {
+		ids[i] = uuid.New()
+		note.ID = ids[i]
+		note.UserID = userID
+		require.NoError(t, repo.CreateNote(context.Background(), note))
+	}
+
+	tests := []struct {
+		name      string
+		tsQuery   string
+		userID    uuid.UUID
+		wantIDs   []uuid.UUID
+		wantTotal int
+	}{
+		{
+			name:      "heading ranks first",
+			tsQuery:   "'grocery'",
+			userID:    userID,
+			wantIDs:   []uuid.UUID{ids[0], ids[1]},
+			wantTotal: 2,
+		},
+		{
+			name:      "prefix",
+			tsQuery:   "'groc':*",
+			userID:    userID,
+			wantIDs:   []uuid.UUID{ids[0], ids[1]},
+			wantTotal: 2,
+		},
+		{
+			name:      "phrase",
+			tsQuery:   "('brown' <-> 'fox')",
+			userID:    userID,
+			wantIDs:   []uuid.UUID{ids[2]},
+			wantTotal: 1,
+		},
+		{
+			name:      "phrase order matters",
+			tsQuery:   "('fox' <-> 'brown')",
+			userID:    userID,
+			wantTotal: 0,
+		},
+		{
+			name:      "other user",
+			tsQuery:   "'grocery'",
+			userID:    uuid.New(),
+			wantTotal: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, total, err := repo.SearchNotes(context.Background(), tt.userID, tt.tsQuery, dto.Paginated{Limit: 10})
+			require.NoError(t, err)
+			require.Equal(t, tt.wantTotal, total)
+			require.Len(t, got, len(tt.wantIDs))
+			for i, id := range tt.wantIDs {
+				require.Equal(t, id, got[i].ID)
+				require.Greater(t, got[i].Rank, 0.0)
+				require.Contains(t, got[i].Headline, "<mark>")
+			}
+		})
+	}
+}
+
+func TestNoteR_FuzzySearchNotes(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	userID := createTestUser(t, tx)
+	repo := NewNoteRepository(tx, logger.LoggerForTest())
+
+	notes := []domain.Note{
+		{Heading: "Grocery list", Content: "milk"},
+		{Heading: "Groceries for party", Content: "chips"},
+		{Heading: "Workout", Content: "grocery run after gym"},
+	}
+	ids := make([]uuid.UUID, len(notes))
+	for i, note := range notes {
+		ids[i] = uuid.New()
+		note.ID = ids[i]
+		note.UserID = userID
+		require.NoError(t, repo.CreateNote(context.Background(), note))
+	}
+
+	high := 0.9
+
+	tests := []struct {
+		name      string
+		search    dto.NoteSearch
+		wantIDs   []uuid.UUID
+		wantTotal int
+	}{
+		{
+			name: "typo in heading",
+			search: dto.NoteSearch{
+				Paginated: dto.Paginated{Limit: 10},
+				Query:     "grocry",
+			},
+			wantIDs:   []uuid.UUID{ids[0], ids[1]},
+			wantTotal: 2,
+		},
+		{
+			name: "with content",
+			search: dto.NoteSearch{
+				Paginated: dto.Paginated{Limit: 10},
+				Query:     "grocery",
+				Content:   true,
+			},
+			wantTotal: 3,
+		},
+		{
+			name: "high threshold",
+			search: dto.NoteSearch{
+				Paginated: dto.Paginated{Limit: 10},
+				Query:     "grocry",
+				Threshold: &high,
+			},
+			wantTotal: 0,
+		},
+		{
+			name: "default threshold after high",
+			search: dto.NoteSearch{
+				Paginated: dto.Paginated{Limit: 10},
+				Query:     "grocry",
+			},
+			wantIDs:   []uuid.UUID{ids[0], ids[1]},
+			wantTotal: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, total, err := repo.FuzzySearchNotes(context.Background(), userID, tt.search)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantTotal, total)
+			require.Len(t, got, tt.wantTotal)
+			for i, id := range tt.wantIDs {
+				require.Equal(t, id, got[i].ID)
+			}
+			for i := range got {
+				require.Greater(t, got[i].Similarity, 0.0)
+				if i > 0 {
+					require.GreaterOrEqual(t, got[i-1].Similarity, got[i].Similarity)
+				}
+			}
+		})
+	}
+}
+
+func TestNoteR_MoveNote(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		wantErr    bool
+		wantErrMsg string
+	}{
+		{
+			name:    "success",
+			wantErr: false,
+		},
+		{
+			name:    "to root",
+			wantErr: false,
+		},
+		{
+			name:       "foreign notebook",
+			wantErr:    true,
+			wantErrMsg: domain.ErrNotFound.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = tx.Rollback() })
+
+			userID := createTestUser(t, tx)
+			rootID := createTestNotebook(t, tx, userID, nil, "root")
+			childID := createTestNotebook(t, tx, userID, &rootID, "child")
+			noteID := createTaggedNote(t, tx, userID)
+
+			notebookID := &childID
+			switch tt.name {
+			case "to root":
+				notebookID = nil
+			case "foreign notebook":
+				foreignID := createTestNotebook(t, tx, createTestUser(t, tx), nil, "foreign")
+				notebookID = &foreignID
+			}
+
+			repo := NewNoteRepository(tx, logger.LoggerForTest())
+
+			err = repo.MoveNote(context.Background(), userID, noteID, notebookID)
+			if tt.wantErr {
+				require.ErrorContains(t, err, tt.wantErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+
+			note, err := repo.Note(context.Background(), userID, noteID)
+			require.NoError(t, err)
+			require.Equal(t, notebookID, note.NotebookID)
+
+			f := dto.NoteFilter{
+				Paginated:  dto.Paginated{Limit: 10},
+				NotebookID: rootID.String(),
+			}
+			notes, _, err := repo.Notes(context.Background(), userID, f)
+			require.NoError(t, err)
+			require.Empty(t, notes)
+
+			f.Recursive = true
+			notes, total, err := repo.Notes(context.Background(), userID, f)
+			require.NoError(t, err)
+			if notebookID == nil {
+				require.Empty(t, notes)
+				return
+			}
+			require.Equal(t, 1, total)
+			require.Len(t, notes, 1)
+			require.Equal(t, noteID, notes[0].ID)
+		})
+	}
+}
+
 func TestNoteR_DeleteNote(t *testing.T) {
 	t.Parallel()
 
@@ -633,7 +1247,7 @@ func TestNoteR_DeleteNote(t *testing.T) {
 			err = repo.CreateNote(context.Background(), testNote)
 			require.NoError(t, err)
 
-			err = repo.DeleteNote(tt.args.ctx, tt.args.userID, tt.args.noteID)
+			err = repo.DeleteNote(tt.args.ctx, tt.args.userID, tt.args.noteID, nil)
 			if tt.wantErr {
 				require.ErrorContains(t, err, tt.wantErrMsg)
 				return
@@ -646,3 +1260,57 @@ func TestNoteR_DeleteNote(t *testing.T) {
 		})
 	}
 }
+
+func TestNoteR_NoteVersion(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	userID := createTestUser(t, tx)
+	noteID := createTaggedNote(t, tx, userID)
+	repo := NewNoteRepository(tx, logger.LoggerForTest())
+
+	note, err := repo.Note(context.Background(), userID, noteID)
+	require.NoError(t, err)
+	require.Equal(t, 1, note.Version)
+
+	err = repo.UpdateNote(context.Background(), domain.NoteUpdate{
+		ID:      noteID,
+		UserID:  userID,
+		Heading: &[]string{"new_heading"}[0],
+		Version: &[]int{1}[0],
+	})
+	require.NoError(t, err)
+
+	note, err = repo.Note(context.Background(), userID, noteID)
+	require.NoError(t, err)
+	require.Equal(t, 2, note.Version)
+
+	err = repo.UpdateNote(context.Background(), domain.NoteUpdate{
+		ID:      noteID,
+		UserID:  userID,
+		Heading: &[]string{"stale_heading"}[0],
+		Version: &[]int{1}[0],
+	})
+	require.ErrorIs(t, err, domain.ErrVersionMismatch)
+
+	revisions, err := NewRevisionRepository(tx, logger.LoggerForTest()).NoteRevisions(context.Background(), userID, noteID)
+	require.NoError(t, err)
+	require.Len(t, revisions, 1)
+
+	err = repo.UpdateNote(context.Background(), domain.NoteUpdate{
+		ID:      uuid.New(),
+		UserID:  userID,
+		Done:    &[]bool{true}[0],
+		Version: &[]int{1}[0],
+	})
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	err = repo.DeleteNote(context.Background(), userID, noteID, &[]int{1}[0])
+	require.ErrorIs(t, err, domain.ErrVersionMismatch)
+
+	err = repo.DeleteNote(context.Background(), userID, noteID, &[]int{2}[0])
+	require.NoError(t, err)
+}
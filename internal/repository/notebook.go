@@ -0,0 +1,320 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"noteApp/internal/models/domain"
+	"noteApp/pkg/logger"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type NotebookR struct {
+	db  query
+	tx  *TxManager
+	log *logger.Logger
+}
+
+func NewNotebookRepository(db query, log *logger.Logger) *NotebookR {
+	return &NotebookR{
+		db:  txQuery{db: db},
+		tx:  NewTxManager(db, log),
+		log: log,
+	}
+}
+
+func (n *NotebookR) CreateNotebook(ctx context.Context, notebook domain.Notebook) error {
+	query := `
+		INSERT INTO notebooks (id, user_id, parent_id, name, created_at, updated_at)
+		SELECT $1, $2, $3, $4, $5, $6
+		WHERE $3::uuid IS NULL OR EXISTS (SELECT 1 FROM notebooks WHERE id=$3 AND user_id=$2)`
+
+	result, err := n.db.ExecContext(ctx, query, notebook.ID, notebook.UserID, notebook.ParentID, notebook.Name, time.Now().UTC(), time.Now().UTC())
+	if err != nil {
+		n.log.Error("failed to execute INSERT query in CreateNotebook",
+			zap.Error(err),
+			zap.String("notebook_id", notebook.ID.String()),
+			zap.String("user_id", notebook.UserID.String()),
+		)
+		return domain.MakeError(domain.ErrFailedToCreate, err, "notebook")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		n.log.Error("failed to get rows affected after INSERT",
+			zap.Error(err),
+			zap.String("notebook_id", notebook.ID.String()),
+			zap.String("user_id", notebook.UserID.String()),
+		)
+		return domain.MakeError(domain.ErrFailedToCreate, err, "notebook")
+	}
+
+	if rowsAffected == 0 {
+		return domain.MakeError(domain.ErrFailedToCreate, domain.ErrNotFound, "parent notebook")
+	}
+
+	return nil
+}
+
+func (n *NotebookR) Notebook(ctx context.Context, userID, notebookID uuid.UUID) (domain.Notebook, error) {
+	query := `
+		SELECT
+			nb.id,
+			nb.user_id,
+			nb.parent_id,
+			nb.name,
+			(SELECT COUNT(*) FROM notes WHERE notebook_id = nb.id AND deleted_at IS NULL),
+			nb.created_at,
+			nb.updated_at
+		FROM notebooks nb
+		WHERE nb.id=$1 AND nb.user_id=$2`
+
+	var notebook domain.Notebook
+	err := n.db.QueryRowContext(ctx, query, notebookID, userID).Scan(
+		&notebook.ID,
+		&notebook.UserID,
+		&notebook.ParentID,
+		&notebook.Name,
+		&notebook.Notes,
+		&notebook.CreatedAt,
+		&notebook.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.Notebook{}, domain.MakeError(domain.ErrReceiving, domain.ErrNotFound, "notebook")
+		}
+		n.log.Error("database error in Notebook query",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+			zap.String("notebook_id", notebookID.String()),
+		)
+		return domain.Notebook{}, domain.MakeError(domain.ErrReceiving, err, "notebook")
+	}
+
+	return notebook, nil
+}
+
+func (n *NotebookR) Notebooks(ctx context.Context, userID uuid.UUID) ([]domain.Notebook, error) {
+	query := `
+		SELECT
+			nb.id,
+			nb.user_id,
+			nb.parent_id,
+			nb.name,
+			(SELECT COUNT(*) FROM notes WHERE notebook_id = nb.id AND deleted_at IS NULL),
+			nb.created_at,
+			nb.updated_at
+		FROM notebooks nb
+		WHERE nb.user_id=$1
+		ORDER BY nb.name ASC`
+
+	rows, err := n.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		n.log.Error("failed to execute SELECT query in Notebooks",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return nil, domain.MakeError(domain.ErrReceiving, err, "notebooks")
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			n.log.Error("failed to close rows", zap.Error(closeErr))
+		}
+	}()
+
+	var notebooks []domain.Notebook
+	for rows.Next() {
+		var notebook domain.Notebook
+		if err := rows.Scan(
+			&notebook.ID,
+			&notebook.UserID,
+			&notebook.ParentID,
+			&notebook.Name,
+			&notebook.Notes,
+			&notebook.CreatedAt,
+			&notebook.UpdatedAt,
+		); err != nil {
+			return nil, domain.MakeError(domain.ErrReceiving, err, "notebooks")
+		}
+		notebooks = append(notebooks, notebook)
+	}
+
+	if err := rows.Err(); err != nil {
+		n.log.Error("error during row iteration",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return nil, domain.MakeError(domain.ErrReceiving, err, "notebooks")
+	}
+
+	return notebooks, nil
+}
+
+func (n *NotebookR) RenameNotebook(ctx context.Context, notebook domain.NotebookUpdate) error {
+	query := `UPDATE notebooks SET name=$1, updated_at=NOW() WHERE id=$2 AND user_id=$3`
+
+	result, err := n.db.ExecContext(ctx, query, notebook.Name, notebook.ID, notebook.UserID)
+	if err != nil {
+		n.log.Error("failed to execute UPDATE query in RenameNotebook",
+			zap.Error(err),
+			zap.String("notebook_id", notebook.ID.String()),
+			zap.String("user_id", notebook.UserID.String()),
+		)
+		return domain.MakeError(domain.ErrFailedToUpdate, err, "notebook")
+	}
+
+	return n.checkAffected(result, domain.ErrFailedToUpdate, notebook.UserID, notebook.ID)
+}
+
+func (n *NotebookR) MoveNotebook(ctx context.Context, userID, notebookID uuid.UUID, parentID *uuid.UUID) error {
+	return n.tx.WithinTx(ctx, func(ctx context.Context) error {
+		return n.moveNotebook(ctx, userID, notebookID, parentID)
+	})
+}
+
+func (n *NotebookR) moveNotebook(ctx context.Context, userID, notebookID uuid.UUID, parentID *uuid.UUID) error {
+	if parentID != nil {
+		if err := n.lockNotebooks(ctx, userID); err != nil {
+			return domain.MakeError(domain.ErrFailedToUpdate, err, "notebook")
+		}
+
+		ancestors, err := n.ancestors(ctx, userID, *parentID)
+		if err != nil {
+			return domain.MakeError(domain.ErrFailedToUpdate, err, "notebook")
+		}
+
+		if len(ancestors) == 0 {
+			return domain.MakeError(domain.ErrFailedToUpdate, domain.ErrNotFound, "parent notebook")
+		}
+
+		for _, id := range ancestors {
+			if id == notebookID {
+				return domain.MakeError(domain.ErrFailedToUpdate, domain.ErrNotebookCycle, "notebook")
+			}
+		}
+	}
+
+	query := `UPDATE notebooks SET parent_id=$1, updated_at=NOW() WHERE id=$2 AND user_id=$3`
+
+	result, err := n.db.ExecContext(ctx, query, parentID, notebookID, userID)
+	if err != nil {
+		n.log.Error("failed to execute UPDATE query in MoveNotebook",
+			zap.Error(err),
+			zap.String("notebook_id", notebookID.String()),
+			zap.String("user_id", userID.String()),
+		)
+		return domain.MakeError(domain.ErrFailedToUpdate, err, "notebook")
+	}
+
+	return n.checkAffected(result, domain.ErrFailedToUpdate, userID, notebookID)
+}
+
+func (n *NotebookR) DeleteNotebook(ctx context.Context, userID, notebookID uuid.UUID, mode string) error {
+	query := `
+		WITH detached_notes AS (
+			UPDATE notes SET notebook_id=NULL, version=version+1
+			WHERE notebook_id=$1 AND user_id=$2
+		),
+		detached_notebooks AS (
+			UPDATE notebooks SET parent_id=NULL, updated_at=NOW()
+			WHERE parent_id=$1 AND user_id=$2
+		)
+		DELETE FROM notebooks WHERE id=$1 AND user_id=$2`
+
+	if mode == domain.NotebookDeleteDelete {
+		query = `
+			WITH RECURSIVE tree AS (
+				SELECT id FROM notebooks WHERE id=$1 AND user_id=$2
+				UNION
+				SELECT nb.id FROM notebooks nb JOIN tree t ON nb.parent_id = t.id
+			),
+			trashed_notes AS (
+				UPDATE notes SET deleted_at=NOW(), version=version+1
+				WHERE notebook_id IN (SELECT id FROM tree) AND deleted_at IS NULL
+			)
+			DELETE FROM notebooks WHERE id IN (SELECT id FROM tree)`
+	}
+
+	result, err := n.db.ExecContext(ctx, query, notebookID, userID)
+	if err != nil {
+		n.log.Error("failed to execute DELETE query",
+			zap.Error(err),
+			zap.String("notebook_id", notebookID.String()),
+			zap.String("user_id", userID.String()),
+			zap.String("mode", mode),
+		)
+		return domain.MakeError(domain.ErrFailedToDelete, err, "notebook")
+	}
+
+	return n.checkAffected(result, domain.ErrFailedToDelete, userID, notebookID)
+}
+
+func (n *NotebookR) lockNotebooks(ctx context.Context, userID uuid.UUID) error {
+	query := `SELECT id FROM notebooks WHERE user_id=$1 ORDER BY id FOR UPDATE`
+
+	if _, err := n.db.ExecContext(ctx, query, userID); err != nil {
+		n.log.Error("failed to execute SELECT query in lockNotebooks",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (n *NotebookR) ancestors(ctx context.Context, userID, notebookID uuid.UUID) ([]uuid.UUID, error) {
+	query := `
+		WITH RECURSIVE ancestors AS (
+			SELECT id, parent_id FROM notebooks WHERE id=$1 AND user_id=$2
+			UNION
+			SELECT nb.id, nb.parent_id FROM notebooks nb JOIN ancestors a ON nb.id = a.parent_id
+		)
+		SELECT id FROM ancestors`
+
+	rows, err := n.db.QueryContext(ctx, query, notebookID, userID)
+	if err != nil {
+		n.log.Error("failed to execute SELECT query in ancestors",
+			zap.Error(err),
+			zap.String("notebook_id", notebookID.String()),
+			zap.String("user_id", userID.String()),
+		)
+		return nil, err
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			n.log.Error("failed to close rows", zap.Error(closeErr))
+		}
+	}()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+func (n *NotebookR) checkAffected(result sql.Result, dErr error, userID, notebookID uuid.UUID) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		n.log.Error("failed to get rows affected",
+			zap.Error(err),
+			zap.String("notebook_id", notebookID.String()),
+			zap.String("user_id", userID.String()),
+		)
+		return domain.MakeError(dErr, err, "notebook")
+	}
+
+	if rowsAffected == 0 {
+		return domain.MakeError(dErr, domain.ErrNotFound, "notebook")
+	}
+
+	return nil
+}
@@ -0,0 +1,364 @@
+package repository
+
+import (
+	"context"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestNotebook(t *testing.T, tx *sqlx.Tx, userID uuid.UUID, parentID *uuid.UUID, name string) uuid.UUID {
+	t.Helper()
+
+	notebookID := uuid.New()
+	repo := NewNotebookRepository(tx, logger.LoggerForTest())
+	err := repo.CreateNotebook(context.Background(), domain.Notebook{
+		ID:       notebookID,
+		UserID:   userID,
+		ParentID: parentID,
+		Name:     name,
+	})
+	require.NoError(t, err)
+
+	return notebookID
+}
+
+func TestNotebookR_CreateNotebook(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		wantErr    bool
+		wantErrMsg string
+	}{
+		{
+			name:    "success",
+			wantErr: false,
+		},
+		{
+			name:       "foreign parent",
+			wantErr:    true,
+			wantErrMsg: domain.ErrNotFound.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = tx.Rollback() })
+
+			userID := createTestUser(t, tx)
+			parentOwner := userID
+			if tt.name == "foreign parent" {
+				parentOwner = createTestUser(t, tx)
+			}
+			parentID := createTestNotebook(t, tx, parentOwner, nil, "parent")
+
+			repo := NewNotebookRepository(tx, logger.LoggerForTest())
+			notebook := domain.Notebook{
+				ID:       uuid.New(),
+				UserID:   userID,
+				ParentID: &parentID,
+				Name:     "child",
+			}
+
+			err = repo.CreateNotebook(context.Background(), notebook)
+			if tt.wantErr {
+				require.ErrorContains(t, err, tt.wantErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+
+			got, err := repo.Notebook(context.Background(), userID, notebook.ID)
+			require.NoError(t, err)
+			assert.Equal(t, "child", got.Name)
+			assert.Equal(t, &parentID, got.ParentID)
+		})
+	}
+}
+
+func TestNotebookR_Notebooks(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	userID := createTestUser(t, tx)
+	workID := createTestNotebook(t, tx, userID, nil, "work")
+	createTestNotebook(t, tx, userID, &workID, "archive")
+
+	noteRepo := NewNoteRepository(tx, logger.LoggerForTest())
+	err = noteRepo.CreateNote(context.Background(), domain.Note{
+		ID:         uuid.New(),
+		UserID:     userID,
+		NotebookID: &workID,
+		Heading:    "test_heading",
+		Content:    "test_content",
+	})
+	require.NoError(t, err)
+
+	repo := NewNotebookRepository(tx, logger.LoggerForTest())
+
+	got, err := repo.Notebooks(context.Background(), userID)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "archive", got[0].Name)
+	assert.Equal(t, &workID, got[0].ParentID)
+	assert.Equal(t, 0, got[0].Notes)
+	assert.Equal(t, "work", got[1].Name)
+	assert.Equal(t, 1, got[1].Notes)
+}
+
+func TestNotebookR_MoveNotebook(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		wantErr    bool
+		wantErrMsg string
+	}{
+		{
+			name:    "success",
+			wantErr: false,
+		},
+		{
+			name:    "to root",
+			wantErr: false,
+		},
+		{
+			name:       "into descendant",
+			wantErr:    true,
+			wantErrMsg: domain.ErrNotebookCycle.Error(),
+		},
+		{
+			name:       "unknown parent",
+			wantErr:    true,
+			wantErrMsg: domain.ErrNotFound.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = tx.Rollback() })
+
+			userID := createTestUser(t, tx)
+			rootID := createTestNotebook(t, tx, userID, nil, "root")
+			childID := createTestNotebook(t, tx, userID, &rootID, "child")
+			otherID := createTestNotebook(t, tx, userID, nil, "other")
+
+			notebookID, parentID := childID, &otherID
+			switch tt.name {
+			case "to root":
+				parentID = nil
+			case "into descendant":
+				notebookID, parentID = rootID, &childID
+			case "unknown parent":
+				unknown := uuid.New()
+				parentID = &unknown
+			}
+
+			repo := NewNotebookRepository(tx, logger.LoggerForTest())
+
+			err = repo.MoveNotebook(context.Background(), userID, notebookID, parentID)
+			if tt.wantErr {
+				require.ErrorContains(t, err, tt.wantErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+
+			got, err := repo.Notebook(context.Background(), userID, notebookID)
+			require.NoError(t, err)
+			assert.Equal(t, parentID, got.ParentID)
+		})
+	}
+}
+
+func TestNotebookR_MoveNotebookExistingCycle(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	userID := createTestUser(t, tx)
+	firstID := createTestNotebook(t, tx, userID, nil, "first")
+	secondID := createTestNotebook(t, tx, userID, &firstID, "second")
+
+	_, err = tx.ExecContext(context.Background(), `UPDATE notebooks SET parent_id=$1 WHERE id=$2`, secondID, firstID)
+	require.NoError(t, err)
+
+	repo := NewNotebookRepository(tx, logger.LoggerForTest())
+
+	err = repo.MoveNotebook(context.Background(), userID, secondID, &firstID)
+	require.ErrorContains(t, err, domain.ErrNotebookCycle.Error())
+
+	_, total, err := NewNoteRepository(tx, logger.LoggerForTest()).Notes(context.Background(), userID, dto.NoteFilter{
+		Paginated:  dto.Paginated{Limit: 10},
+		NotebookID: firstID.String(),
+		Recursive:  true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, total)
+
+	require.NoError(t, repo.DeleteNotebook(context.Background(), userID, firstID, domain.NotebookDeleteDelete))
+}
+
+func TestNotebookR_MoveNotebookConcurrent(t *testing.T) {
+	t.Parallel()
+
+	name := "notebook_move_" + uuid.NewString()[:8]
+	userID := uuid.New()
+	require.NoError(t, NewUserRepository(globalTestDB, logger.LoggerForTest()).CreateUser(context.Background(), domain.User{
+		ID:       userID,
+		Username: name,
+		Email:    name + "@example.com",
+		Password: "test",
+	}))
+	t.Cleanup(func() {
+		_, _ = globalTestDB.ExecContext(context.Background(), `DELETE FROM notebooks WHERE user_id=$1`, userID)
+		_, _ = globalTestDB.ExecContext(context.Background(), `DELETE FROM users WHERE id=$1`, userID)
+	})
+
+	repo := NewNotebookRepository(globalTestDB, logger.LoggerForTest())
+	firstID, secondID := uuid.New(), uuid.New()
+	for _, id := range []uuid.UUID{firstID, secondID} {
+		require.NoError(t, repo.CreateNotebook(context.Background(), domain.Notebook{ID: id, UserID: userID, Name: "notebook"}))
+	}
+
+	errs := make(chan error, 2)
+	for _, move := range [][2]uuid.UUID{{firstID, secondID}, {secondID, firstID}} {
+		go func() {
+			errs <- repo.MoveNotebook(context.Background(), userID, move[0], &move[1])
+		}()
+	}
+
+	var cycles int
+	for range 2 {
+		if err := <-errs; err != nil {
+			require.ErrorIs(t, err, domain.ErrNotebookCycle)
+			cycles++
+		}
+	}
+	assert.Equal(t, 1, cycles)
+}
+
+func TestNotebookR_DeleteNotebook(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		mode       string
+		wantErr    bool
+		wantErrMsg string
+	}{
+		{
+			name:    "move contents to root",
+			mode:    domain.NotebookDeleteMove,
+			wantErr: false,
+		},
+		{
+			name:    "delete contents",
+			mode:    domain.NotebookDeleteDelete,
+			wantErr: false,
+		},
+		{
+			name:       "wrong user ID",
+			mode:       domain.NotebookDeleteMove,
+			wantErr:    true,
+			wantErrMsg: domain.ErrNotFound.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = tx.Rollback() })
+
+			userID := createTestUser(t, tx)
+			rootID := createTestNotebook(t, tx, userID, nil, "root")
+			childID := createTestNotebook(t, tx, userID, &rootID, "child")
+
+			noteRepo := NewNoteRepository(tx, logger.LoggerForTest())
+			noteID := uuid.New()
+			err = noteRepo.CreateNote(context.Background(), domain.Note{
+				ID:         noteID,
+				UserID:     userID,
+				NotebookID: &childID,
+				Heading:    "test_heading",
+				Content:    "test_content",
+			})
+			require.NoError(t, err)
+
+			rootNoteID := uuid.New()
+			err = noteRepo.CreateNote(context.Background(), domain.Note{
+				ID:         rootNoteID,
+				UserID:     userID,
+				NotebookID: &rootID,
+				Heading:    "root_heading",
+				Content:    "root_content",
+			})
+			require.NoError(t, err)
+
+			deleteUserID := userID
+			if tt.name == "wrong user ID" {
+				deleteUserID = uuid.New()
+			}
+
+			repo := NewNotebookRepository(tx, logger.LoggerForTest())
+
+			err = repo.DeleteNotebook(context.Background(), deleteUserID, rootID, tt.mode)
+			if tt.wantErr {
+				require.ErrorContains(t, err, tt.wantErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+
+			_, err = repo.Notebook(context.Background(), userID, rootID)
+			require.ErrorContains(t, err, domain.ErrNotFound.Error())
+
+			note, err := noteRepo.Note(context.Background(), userID, noteID)
+			if tt.mode == domain.NotebookDeleteDelete {
+				require.Error(t, err)
+				_, err = repo.Notebook(context.Background(), userID, childID)
+				require.ErrorContains(t, err, domain.ErrNotFound.Error())
+
+				trashed, total, err := NewTrashRepository(tx, logger.LoggerForTest()).
+					TrashedNotes(context.Background(), userID, dto.Paginated{Limit: 10})
+				require.NoError(t, err)
+				assert.Equal(t, 2, total)
+				assert.ElementsMatch(t, []uuid.UUID{noteID, rootNoteID}, []uuid.UUID{trashed[0].ID, trashed[1].ID})
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, &childID, note.NotebookID)
+			assert.Equal(t, 1, note.Version)
+
+			rootNote, err := noteRepo.Note(context.Background(), userID, rootNoteID)
+			require.NoError(t, err)
+			assert.Nil(t, rootNote.NotebookID)
+			assert.Equal(t, 2, rootNote.Version)
+
+			child, err := repo.Notebook(context.Background(), userID, childID)
+			require.NoError(t, err)
+			assert.Nil(t, child.ParentID)
+		})
+	}
+}
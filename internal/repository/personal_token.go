@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"noteApp/internal/models/domain"
+	"noteApp/pkg/logger"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+type PersonalTokenR struct {
+	db  query
+	log *logger.Logger
+}
+
+func NewPersonalTokenRepository(db query, log *logger.Logger) *PersonalTokenR {
+	return &PersonalTokenR{
+		db:  db,
+		log: log,
+	}
+}
+
+func (p *PersonalTokenR) CreatePersonalToken(ctx context.Context, token domain.PersonalToken) error {
+	query := `
+		INSERT INTO personal_access_tokens (id, user_id, name, token_hash, scopes, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	var expiresAt interface{}
+	if token.ExpiresAt != nil {
+		expiresAt = token.ExpiresAt.UTC()
+	}
+
+	_, err := p.db.ExecContext(ctx, query, token.ID, token.UserID, token.Name, token.TokenHash, pq.StringArray(token.Scopes), expiresAt, token.CreatedAt.UTC())
+	if err != nil {
+		p.log.Error("failed to execute INSERT query in CreatePersonalToken",
+			zap.Error(err),
+			zap.String("user_id", token.UserID.String()),
+			zap.String("token_id", token.ID.String()),
+		)
+		return domain.MakeError(domain.ErrFailedToCreate, err, "personal access token")
+	}
+
+	return nil
+}
+
+func (p *PersonalTokenR) PersonalTokens(ctx context.Context, userID uuid.UUID) ([]domain.PersonalToken, error) {
+	query := `
+		SELECT id, user_id, name, token_hash, scopes, expires_at, last_used_at, created_at
+		FROM personal_access_tokens
+		WHERE user_id=$1
+		ORDER BY created_at DESC, id DESC`
+
+	rows, err := p.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		p.log.Error("failed to execute SELECT query in PersonalTokens",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return nil, domain.MakeError(domain.ErrReceiving, err, "personal access tokens")
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			p.log.Error("failed to close rows", zap.Error(closeErr))
+		}
+	}()
+
+	var tokens []domain.PersonalToken
+	for rows.Next() {
+		var token domain.PersonalToken
+		if err := rows.Scan(
+			&token.ID,
+			&token.UserID,
+			&token.Name,
+			&token.TokenHash,
+			(*pq.StringArray)(&token.Scopes),
+			&token.ExpiresAt,
+			&token.LastUsedAt,
+			&token.CreatedAt,
+		); err != nil {
+			return nil, domain.MakeError(domain.ErrReceiving, err, "personal access tokens")
+		}
+		tokens = append(tokens, token)
+	}
+
+	if err := rows.Err(); err != nil {
+		p.log.Error("error during row iteration",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return nil, domain.MakeError(domain.ErrReceiving, err, "personal access tokens")
+	}
+
+	return tokens, nil
+}
+
+func (p *PersonalTokenR) TouchPersonalToken(ctx context.Context, tokenHash string) (domain.PersonalToken, error) {
+	query := `
+		UPDATE personal_access_tokens pat SET last_used_at=NOW()
+		FROM users u
+		WHERE pat.token_hash=$1 AND u.id = pat.user_id AND u.disabled_at IS NULL
+		AND (pat.expires_at IS NULL OR pat.expires_at > NOW())
+		RETURNING pat.id, pat.user_id, pat.name, pat.token_hash, pat.scopes, pat.expires_at, pat.last_used_at, pat.created_at`
+
+	var token domain.PersonalToken
+	err := p.db.QueryRowContext(ctx, query, tokenHash).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.Name,
+		&token.TokenHash,
+		(*pq.StringArray)(&token.Scopes),
+		&token.ExpiresAt,
+		&token.LastUsedAt,
+		&token.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.PersonalToken{}, domain.MakeError(domain.ErrReceiving, domain.ErrNotFound, "personal access token")
+		}
+		p.log.Error("database error in TouchPersonalToken query",
+			zap.Error(err),
+		)
+		return domain.PersonalToken{}, domain.MakeError(domain.ErrReceiving, err, "personal access token")
+	}
+
+	return token, nil
+}
+
+func (p *PersonalTokenR) DeletePersonalToken(ctx context.Context, userID, tokenID uuid.UUID) error {
+	query := `DELETE FROM personal_access_tokens WHERE id=$1 AND user_id=$2`
+
+	result, err := p.db.ExecContext(ctx, query, tokenID, userID)
+	if err != nil {
+		p.log.Error("failed to execute DELETE query in DeletePersonalToken",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+			zap.String("token_id", tokenID.String()),
+		)
+		return domain.MakeError(domain.ErrFailedToDelete, err, "personal access token")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		p.log.Error("failed to get rows affected after DELETE",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+			zap.String("token_id", tokenID.String()),
+		)
+		return domain.MakeError(domain.ErrFailedToDelete, err, "personal access token")
+	}
+
+	if rowsAffected == 0 {
+		return domain.MakeError(domain.ErrFailedToDelete, domain.ErrNotFound, "personal access token")
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"noteApp/internal/models/domain"
+	"noteApp/pkg/logger"
+	"noteApp/pkg/utils"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createPersonalToken(t *testing.T, tx *sqlx.Tx, userID uuid.UUID, hash string, expiresAt *time.Time) uuid.UUID {
+	t.Helper()
+
+	tokenID := uuid.New()
+	repo := NewPersonalTokenRepository(tx, logger.LoggerForTest())
+	err := repo.CreatePersonalToken(context.Background(), domain.PersonalToken{
+		ID:        tokenID,
+		UserID:    userID,
+		Name:      "ci",
+		TokenHash: hash,
+		Scopes:    []string{domain.ScopeNotesRead, domain.ScopeNotesWrite},
+		ExpiresAt: expiresAt,
+		CreatedAt: time.Now(),
+	})
+	require.NoError(t, err)
+
+	return tokenID
+}
+
+func TestPersonalTokenR_PersonalTokens(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	userID := createTestUser(t, tx)
+	otherID := createNamedTestUser(t, tx, "pat_other")
+	tokenID := createPersonalToken(t, tx, userID, utils.HashToken(uuid.NewString()), nil)
+	createPersonalToken(t, tx, otherID, utils.HashToken(uuid.NewString()), nil)
+
+	repo := NewPersonalTokenRepository(tx, logger.LoggerForTest())
+
+	tokens, err := repo.PersonalTokens(context.Background(), userID)
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+	assert.Equal(t, tokenID, tokens[0].ID)
+	assert.Equal(t, "ci", tokens[0].Name)
+	assert.Equal(t, []string{domain.ScopeNotesRead, domain.ScopeNotesWrite}, tokens[0].Scopes)
+	assert.Nil(t, tokens[0].LastUsedAt)
+}
+
+func TestPersonalTokenR_TouchPersonalToken(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	userID := createTestUser(t, tx)
+	hash := utils.HashToken(uuid.NewString())
+	tokenID := createPersonalToken(t, tx, userID, hash, nil)
+
+	expired := time.Now().Add(-time.Hour)
+	expiredHash := utils.HashToken(uuid.NewString())
+	createPersonalToken(t, tx, userID, expiredHash, &expired)
+
+	repo := NewPersonalTokenRepository(tx, logger.LoggerForTest())
+
+	token, err := repo.TouchPersonalToken(context.Background(), hash)
+	require.NoError(t, err)
+	assert.Equal(t, tokenID, token.ID)
+	assert.Equal(t, userID, token.UserID)
+	assert.NotNil(t, token.LastUsedAt)
+
+	_, err = repo.TouchPersonalToken(context.Background(), expiredHash)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	_, err = repo.TouchPersonalToken(context.Background(), "unknown")
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	require.NoError(t, NewAdminRepository(tx, logger.LoggerForTest()).SetUserDisabled(context.Background(), userID, true))
+
+	_, err = repo.TouchPersonalToken(context.Background(), hash)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestPersonalTokenR_DeletePersonalToken(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	userID := createTestUser(t, tx)
+	otherID := createNamedTestUser(t, tx, "pat_intruder")
+	tokenID := createPersonalToken(t, tx, userID, utils.HashToken(uuid.NewString()), nil)
+
+	repo := NewPersonalTokenRepository(tx, logger.LoggerForTest())
+
+	err = repo.DeletePersonalToken(context.Background(), otherID, tokenID)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	require.NoError(t, repo.DeletePersonalToken(context.Background(), userID, tokenID))
+
+	err = repo.DeletePersonalToken(context.Background(), userID, tokenID)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+}
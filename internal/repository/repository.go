@@ -13,15 +13,49 @@ type query interface {
 }
 
 type repository struct {
+	*AdminR
+	*BatchR
+	*EmailTokenR
+	*EventR
+	*LinkR
 	*NoteR
+	*NotebookR
+	*PersonalTokenR
+	*RevisionR
+	*SessionR
+	*SignInAttemptR
+	*ShareR
+	*SyncR
+	*TagR
 	*TokenR
+	*TrashR
+	*TwoFactorR
+	*TxManager
 	*UserR
 }
 
-func NewRepository(q query, log *logger.Logger) repository {
+func NewRepository(db query, log *logger.Logger) repository {
+	q := txQuery{db: db}
+
 	return repository{
-		NoteR:  NewNoteRepository(q, log),
-		TokenR: NewTokenRepository(q, log),
-		UserR:  NewUserRepository(q, log),
+		AdminR:         NewAdminRepository(q, log),
+		BatchR:         NewBatchRepository(db, log),
+		EmailTokenR:    NewEmailTokenRepository(q, log),
+		EventR:         NewEventRepository(q, log),
+		LinkR:          NewLinkRepository(q, log),
+		NoteR:          NewNoteRepository(db, log),
+		NotebookR:      NewNotebookRepository(db, log),
+		PersonalTokenR: NewPersonalTokenRepository(q, log),
+		RevisionR:      NewRevisionRepository(q, log),
+		SessionR:       NewSessionRepository(q, log),
+		SignInAttemptR: NewSignInAttemptRepository(q, log),
+		ShareR:         NewShareRepository(q, log),
+		SyncR:          NewSyncRepository(q, log),
+		TagR:           NewTagRepository(q, log),
+		TokenR:         NewTokenRepository(q, log),
+		TrashR:         NewTrashRepository(q, log),
+		TwoFactorR:     NewTwoFactorRepository(q, log),
+		TxManager:      NewTxManager(db, log),
+		UserR:          NewUserRepository(q, log),
 	}
 }
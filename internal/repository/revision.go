@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"noteApp/internal/models/domain"
+	"noteApp/pkg/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type RevisionR struct {
+	db  query
+	log *logger.Logger
+}
+
+func NewRevisionRepository(db query, log *logger.Logger) *RevisionR {
+	return &RevisionR{
+		db:  db,
+		log: log,
+	}
+}
+
+func (r *RevisionR) NoteRevisions(ctx context.Context, userID, noteID uuid.UUID) ([]domain.NoteRevision, error) {
+	var exists bool
+	query := `SELECT EXISTS (SELECT 1 FROM notes WHERE id=$1 AND user_id=$2 AND deleted_at IS NULL)`
+	if err := r.db.QueryRowContext(ctx, query, noteID, userID).Scan(&exists); err != nil {
+		r.log.Error("failed to check note in NoteRevisions",
+			zap.Error(err),
+			zap.String("note_id", noteID.String()),
+			zap.String("user_id", userID.String()),
+		)
+		return nil, domain.MakeError(domain.ErrReceiving, err, "revisions")
+	}
+
+	if !exists {
+		return nil, domain.MakeError(domain.ErrReceiving, domain.ErrNotFound, "note")
+	}
+
+	query = `
+		SELECT note_id, revision, heading, content, created_at
+		FROM note_revisions
+		WHERE note_id=$1
+		ORDER BY revision DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, noteID)
+	if err != nil {
+		r.log.Error("failed to execute SELECT query in NoteRevisions",
+			zap.Error(err),
+			zap.String("note_id", noteID.String()),
+			zap.String("user_id", userID.String()),
+		)
+		return nil, domain.MakeError(domain.ErrReceiving, err, "revisions")
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			r.log.Error("failed to close rows", zap.Error(closeErr))
+		}
+	}()
+
+	var revisions []domain.NoteRevision
+	for rows.Next() {
+		var revision domain.NoteRevision
+		if err := rows.Scan(
+			&revision.NoteID,
+			&revision.Revision,
+			&revision.Heading,
+			&revision.Content,
+			&revision.CreatedAt,
+		); err != nil {
+			return nil, domain.MakeError(domain.ErrReceiving, err, "revisions")
+		}
+		revisions = append(revisions, revision)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.log.Error("error during row iteration",
+			zap.Error(err),
+			zap.String("note_id", noteID.String()),
+		)
+		return nil, domain.MakeError(domain.ErrReceiving, err, "revisions")
+	}
+
+	return revisions, nil
+}
+
+func (r *RevisionR) NoteRevision(ctx context.Context, userID, noteID uuid.UUID, revision int) (domain.NoteRevision, error) {
+	query := `
+		SELECT r.note_id, r.revision, r.heading, r.content, r.created_at
+		FROM note_revisions r
+		JOIN notes n ON n.id = r.note_id
+		WHERE r.note_id=$1 AND n.user_id=$2 AND n.deleted_at IS NULL AND r.revision=$3`
+
+	var rev domain.NoteRevision
+	err := r.db.QueryRowContext(ctx, query, noteID, userID, revision).Scan(
+		&rev.NoteID,
+		&rev.Revision,
+		&rev.Heading,
+		&rev.Content,
+		&rev.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.NoteRevision{}, domain.MakeError(domain.ErrReceiving, domain.ErrNotFound, "revision")
+		}
+		r.log.Error("database error in NoteRevision query",
+			zap.Error(err),
+			zap.String("note_id", noteID.String()),
+			zap.String("user_id", userID.String()),
+			zap.Int("revision", revision),
+		)
+		return domain.NoteRevision{}, domain.MakeError(domain.ErrReceiving, err, "revision")
+	}
+
+	return rev, nil
+}
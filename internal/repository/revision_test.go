@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"noteApp/internal/models/domain"
+	"noteApp/pkg/logger"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevisionR_NoteRevisions(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	userID := createTestUser(t, tx)
+	noteID := createTaggedNote(t, tx, userID)
+
+	noteRepo := NewNoteRepository(tx, logger.LoggerForTest())
+	repo := NewRevisionRepository(tx, logger.LoggerForTest())
+
+	revisions, err := repo.NoteRevisions(context.Background(), userID, noteID)
+	require.NoError(t, err)
+	require.Empty(t, revisions)
+
+	err = noteRepo.UpdateNote(context.Background(), domain.NoteUpdate{
+		ID:      noteID,
+		UserID:  userID,
+		Content: &[]string{"second_content"}[0],
+	})
+	require.NoError(t, err)
+
+	err = noteRepo.UpdateNote(context.Background(), domain.NoteUpdate{
+		ID:      noteID,
+		UserID:  userID,
+		Heading: &[]string{"third_heading"}[0],
+	})
+	require.NoError(t, err)
+
+	err = noteRepo.UpdateNote(context.Background(), domain.NoteUpdate{
+		ID:     noteID,
+		UserID: userID,
+		Done:   &[]bool{true}[0],
+	})
+	require.NoError(t, err)
+
+	revisions, err = repo.NoteRevisions(context.Background(), userID, noteID)
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	assert.Equal(t, 2, revisions[0].Revision)
+	assert.Equal(t, "test_heading", revisions[0].Heading)
+	assert.Equal(t, "second_content", revisions[0].Content)
+	assert.Equal(t, 1, revisions[1].Revision)
+	assert.Equal(t, "test_content", revisions[1].Content)
+
+	_, err = repo.NoteRevisions(context.Background(), uuid.New(), noteID)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestRevisionR_RevisionFollowsVersion(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	userID := createTestUser(t, tx)
+	noteID := createTaggedNote(t, tx, userID)
+
+	noteRepo := NewNoteRepository(tx, logger.LoggerForTest())
+	repo := NewRevisionRepository(tx, logger.LoggerForTest())
+
+	err = noteRepo.UpdateNote(context.Background(), domain.NoteUpdate{
+		ID:     noteID,
+		UserID: userID,
+		Done:   &[]bool{true}[0],
+	})
+	require.NoError(t, err)
+
+	err = noteRepo.UpdateNote(context.Background(), domain.NoteUpdate{
+		ID:      noteID,
+		UserID:  userID,
+		Content: &[]string{"second_content"}[0],
+		Version: &[]int{2}[0],
+	})
+	require.NoError(t, err)
+
+	revisions, err := repo.NoteRevisions(context.Background(), userID, noteID)
+	require.NoError(t, err)
+	require.Len(t, revisions, 1)
+	assert.Equal(t, 2, revisions[0].Revision)
+	assert.Equal(t, "test_content", revisions[0].Content)
+}
+
+func TestRevisionR_NoteRevision(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		revision   int
+		otherUser  bool
+		wantErr    bool
+		wantErrMsg string
+	}{
+		{
+			name:     "success",
+			revision: 1,
+			wantErr:  false,
+		},
+		{
+			name:       "unknown revision",
+			revision:   2,
+			wantErr:    true,
+			wantErrMsg: domain.ErrNotFound.Error(),
+		},
+		{
+			name:       "wrong user ID",
+			revision:   1,
+			otherUser:  true,
+			wantErr:    true,
+			wantErrMsg: domain.ErrNotFound.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = tx.Rollback() })
+
+			userID := createTestUser(t, tx)
+			noteID := createTaggedNote(t, tx, userID)
+
+			err = NewNoteRepository(tx, logger.LoggerForTest()).UpdateNote(context.Background(), domain.NoteUpdate{
+				ID:      noteID,
+				UserID:  userID,
+				Heading: &[]string{"new_heading"}[0],
+			})
+			require.NoError(t, err)
+
+			readerID := userID
+			if tt.otherUser {
+				readerID = uuid.New()
+			}
+
+			repo := NewRevisionRepository(tx, logger.LoggerForTest())
+
+			got, err := repo.NoteRevision(context.Background(), readerID, noteID, tt.revision)
+			if tt.wantErr {
+				require.ErrorContains(t, err, tt.wantErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, noteID, got.NoteID)
+			assert.Equal(t, "test_heading", got.Heading)
+			assert.Equal(t, "test_content", got.Content)
+		})
+	}
+}
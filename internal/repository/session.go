@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"noteApp/internal/models/domain"
+	"noteApp/pkg/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type SessionR struct {
+	db  query
+	log *logger.Logger
+}
+
+func NewSessionRepository(db query, log *logger.Logger) *SessionR {
+	return &SessionR{
+		db:  db,
+		log: log,
+	}
+}
+
+func (s *SessionR) Sessions(ctx context.Context, userID uuid.UUID) ([]domain.Session, error) {
+	query := `
+		SELECT
+			family_id,
+			user_id,
+			(ARRAY_AGG(user_agent ORDER BY created_at DESC))[1],
+			(ARRAY_AGG(ip ORDER BY created_at DESC))[1],
+			MIN(created_at),
+			MAX(last_used_at)
+		FROM tokens
+		WHERE user_id=$1
+		GROUP BY family_id, user_id
+		HAVING BOOL_OR(rotated_at IS NULL AND expired_at > NOW())
+		ORDER BY MAX(last_used_at) DESC`
+
+	rows, err := s.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		s.log.Error("failed to execute SELECT query in Sessions",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return nil, domain.MakeError(domain.ErrReceiving, err, "sessions")
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			s.log.Error("failed to close rows", zap.Error(closeErr))
+		}
+	}()
+
+	var sessions []domain.Session
+	for rows.Next() {
+		var session domain.Session
+		if err := rows.Scan(
+			&session.ID,
+			&session.UserID,
+			&session.UserAgent,
+			&session.IP,
+			&session.CreatedAt,
+			&session.LastUsedAt,
+		); err != nil {
+			return nil, domain.MakeError(domain.ErrReceiving, err, "sessions")
+		}
+		sessions = append(sessions, session)
+	}
+
+	if err := rows.Err(); err != nil {
+		s.log.Error("error during row iteration",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return nil, domain.MakeError(domain.ErrReceiving, err, "sessions")
+	}
+
+	return sessions, nil
+}
+
+func (s *SessionR) DeleteSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	query := `DELETE FROM tokens WHERE user_id=$1 AND family_id=$2`
+
+	result, err := s.db.ExecContext(ctx, query, userID, sessionID)
+	if err != nil {
+		s.log.Error("failed to execute DELETE query in DeleteSession",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+			zap.String("session_id", sessionID.String()),
+		)
+		return domain.MakeError(domain.ErrFailedToDelete, err, "session")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		s.log.Error("failed to get rows affected after DELETE",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+			zap.String("session_id", sessionID.String()),
+		)
+		return domain.MakeError(domain.ErrFailedToDelete, err, "session")
+	}
+
+	if rowsAffected == 0 {
+		return domain.MakeError(domain.ErrFailedToDelete, domain.ErrNotFound, "session")
+	}
+
+	return nil
+}
+
+func (s *SessionR) DeleteOtherSessions(ctx context.Context, userID, keepID uuid.UUID) error {
+	query := `DELETE FROM tokens WHERE user_id=$1 AND family_id<>$2`
+
+	if _, err := s.db.ExecContext(ctx, query, userID, keepID); err != nil {
+		s.log.Error("failed to execute DELETE query in DeleteOtherSessions",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+			zap.String("session_id", keepID.String()),
+		)
+		return domain.MakeError(domain.ErrFailedToDelete, err, "sessions")
+	}
+
+	return nil
+}
+
+func (s *SessionR) DeleteSessions(ctx context.Context, userID uuid.UUID) error {
+	query := `DELETE FROM tokens WHERE user_id=$1`
+
+	if _, err := s.db.ExecContext(ctx, query, userID); err != nil {
+		s.log.Error("failed to execute DELETE query in DeleteSessions",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return domain.MakeError(domain.ErrFailedToDelete, err, "sessions")
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"noteApp/internal/models/domain"
+	"noteApp/pkg/logger"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createSessionToken(t *testing.T, repo *TokenR, userID, familyID uuid.UUID, userAgent string) string {
+	t.Helper()
+
+	tokenID := uuid.New().String()
+	require.NoError(t, repo.CreateToken(context.Background(), domain.Token{
+		UserID:    userID,
+		TokenID:   tokenID,
+		FamilyID:  familyID,
+		UserAgent: userAgent,
+		IP:        "10.0.0.1",
+		ExpiresAt: time.Now().Add(time.Hour).UTC(),
+	}))
+
+	return tokenID
+}
+
+func TestSessionR_Sessions(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	userID := createTestUser(t, tx)
+	tokens := NewTokenRepository(tx, logger.LoggerForTest())
+	repo := NewSessionRepository(tx, logger.LoggerForTest())
+
+	laptop := uuid.New()
+	phone := uuid.New()
+	revoked := uuid.New()
+
+	rotated := createSessionToken(t, tokens, userID, laptop, "firefox")
+	require.NoError(t, tokens.RotateToken(context.Background(), rotated))
+	createSessionToken(t, tokens, userID, laptop, "firefox")
+	createSessionToken(t, tokens, userID, phone, "safari")
+	gone := createSessionToken(t, tokens, userID, revoked, "curl")
+	require.NoError(t, tokens.RotateToken(context.Background(), gone))
+
+	sessions, err := repo.Sessions(context.Background(), userID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+
+	byID := map[uuid.UUID]domain.Session{}
+	for _, v := range sessions {
+		byID[v.ID] = v
+	}
+	assert.Equal(t, "firefox", byID[laptop].UserAgent)
+	assert.Equal(t, "safari", byID[phone].UserAgent)
+	assert.Equal(t, "10.0.0.1", byID[phone].IP)
+	assert.False(t, byID[laptop].LastUsedAt.Before(byID[laptop].CreatedAt))
+}
+
+func TestSessionR_DeleteSession(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	userID := createTestUser(t, tx)
+	tokens := NewTokenRepository(tx, logger.LoggerForTest())
+	repo := NewSessionRepository(tx, logger.LoggerForTest())
+
+	familyID := uuid.New()
+	tokenID := createSessionToken(t, tokens, userID, familyID, "firefox")
+
+	err = repo.DeleteSession(context.Background(), uuid.New(), familyID)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	require.NoError(t, repo.DeleteSession(context.Background(), userID, familyID))
+
+	_, err = tokens.Token(context.Background(), tokenID)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestSessionR_DeleteOtherSessions(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	userID := createTestUser(t, tx)
+	tokens := NewTokenRepository(tx, logger.LoggerForTest())
+	repo := NewSessionRepository(tx, logger.LoggerForTest())
+
+	current := uuid.New()
+	keep := createSessionToken(t, tokens, userID, current, "firefox")
+	other := createSessionToken(t, tokens, userID, uuid.New(), "safari")
+
+	require.NoError(t, repo.DeleteOtherSessions(context.Background(), userID, current))
+
+	_, err = tokens.Token(context.Background(), keep)
+	require.NoError(t, err)
+	_, err = tokens.Token(context.Background(), other)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	require.NoError(t, repo.DeleteSessions(context.Background(), userID))
+
+	_, err = tokens.Token(context.Background(), keep)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+}
@@ -0,0 +1,267 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+type ShareR struct {
+	db  query
+	log *logger.Logger
+}
+
+func NewShareRepository(db query, log *logger.Logger) *ShareR {
+	return &ShareR{
+		db:  db,
+		log: log,
+	}
+}
+
+func (s *ShareR) ShareNote(ctx context.Context, target domain.NoteShareTarget) error {
+	query := `
+		INSERT INTO note_shares (note_id, user_id, permission, created_at)
+		SELECT n.id, u.id, $5, NOW()
+		FROM notes n, users u
+		WHERE n.id=$1 AND n.user_id=$2 AND n.deleted_at IS NULL AND u.id <> n.user_id
+		AND (($3 <> '' AND u.username=$3) OR ($4 <> '' AND u.email=$4))
+		ON CONFLICT (note_id, user_id) DO UPDATE SET permission=EXCLUDED.permission`
+
+	result, err := s.db.ExecContext(ctx, query, target.NoteID, target.OwnerID, target.Username, target.Email, target.Permission)
+	if err != nil {
+		s.log.Error("failed to execute INSERT query in ShareNote",
+			zap.Error(err),
+			zap.String("note_id", target.NoteID.String()),
+			zap.String("user_id", target.OwnerID.String()),
+		)
+		return domain.MakeError(domain.ErrFailedToCreate, err, "note share")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		s.log.Error("failed to get rows affected after INSERT",
+			zap.Error(err),
+			zap.String("note_id", target.NoteID.String()),
+			zap.String("user_id", target.OwnerID.String()),
+		)
+		return domain.MakeError(domain.ErrFailedToCreate, err, "note share")
+	}
+
+	if rowsAffected == 0 {
+		return domain.MakeError(domain.ErrFailedToCreate, domain.ErrNotFound, "note or user")
+	}
+
+	return nil
+}
+
+func (s *ShareR) UnshareNote(ctx context.Context, target domain.NoteShareTarget) error {
+	query := `
+		DELETE FROM note_shares ns
+		USING notes n, users u
+		WHERE ns.note_id=n.id AND ns.user_id=u.id AND n.id=$1 AND n.user_id=$2
+		AND (($3 <> '' AND u.username=$3) OR ($4 <> '' AND u.email=$4))`
+
+	result, err := s.db.ExecContext(ctx, query, target.NoteID, target.OwnerID, target.Username, target.Email)
+	if err != nil {
+		s.log.Error("failed to execute DELETE query in UnshareNote",
+			zap.Error(err),
+			zap.String("note_id", target.NoteID.String()),
+			zap.String("user_id", target.OwnerID.String()),
+		)
+		return domain.MakeError(domain.ErrFailedToDelete, err, "note share")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		s.log.Error("failed to get rows affected after DELETE",
+			zap.Error(err),
+			zap.String("note_id", target.NoteID.String()),
+			zap.String("user_id", target.OwnerID.String()),
+		)
+		return domain.MakeError(domain.ErrFailedToDelete, err, "note share")
+	}
+
+	if rowsAffected == 0 {
+		return domain.MakeError(domain.ErrFailedToDelete, domain.ErrNotFound, "note share")
+	}
+
+	return nil
+}
+
+func (s *ShareR) NoteShares(ctx context.Context, ownerID, noteID uuid.UUID) ([]domain.NoteShare, error) {
+	var exists bool
+	query := `SELECT EXISTS (SELECT 1 FROM notes WHERE id=$1 AND user_id=$2 AND deleted_at IS NULL)`
+	if err := s.db.QueryRowContext(ctx, query, noteID, ownerID).Scan(&exists); err != nil {
+		s.log.Error("failed to check note in NoteShares",
+			zap.Error(err),
+			zap.String("note_id", noteID.String()),
+			zap.String("user_id", ownerID.String()),
+		)
+		return nil, domain.MakeError(domain.ErrReceiving, err, "note shares")
+	}
+
+	if !exists {
+		return nil, domain.MakeError(domain.ErrReceiving, domain.ErrNotFound, "note")
+	}
+
+	query = `
+		SELECT ns.note_id, ns.user_id, u.username, u.email, ns.permission, ns.created_at
+		FROM note_shares ns
+		JOIN users u ON u.id = ns.user_id
+		WHERE ns.note_id=$1
+		ORDER BY u.username ASC`
+
+	rows, err := s.db.QueryContext(ctx, query, noteID)
+	if err != nil {
+		s.log.Error("failed to execute SELECT query in NoteShares",
+			zap.Error(err),
+			zap.String("note_id", noteID.String()),
+			zap.String("user_id", ownerID.String()),
+		)
+		return nil, domain.MakeError(domain.ErrReceiving, err, "note shares")
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			s.log.Error("failed to close rows", zap.Error(closeErr))
+		}
+	}()
+
+	var shares []domain.NoteShare
+	for rows.Next() {
+		share := domain.NoteShare{OwnerID: ownerID}
+		if err := rows.Scan(
+			&share.NoteID,
+			&share.UserID,
+			&share.Username,
+			&share.Email,
+			&share.Permission,
+			&share.CreatedAt,
+		); err != nil {
+			return nil, domain.MakeError(domain.ErrReceiving, err, "note shares")
+		}
+		shares = append(shares, share)
+	}
+
+	if err := rows.Err(); err != nil {
+		s.log.Error("error during row iteration",
+			zap.Error(err),
+			zap.String("note_id", noteID.String()),
+		)
+		return nil, domain.MakeError(domain.ErrReceiving, err, "note shares")
+	}
+
+	return shares, nil
+}
+
+func (s *ShareR) NoteShare(ctx context.Context, userID, noteID uuid.UUID) (domain.NoteShare, error) {
+	query := `
+		SELECT ns.note_id, n.user_id, ns.user_id, ns.permission, ns.created_at
+		FROM note_shares ns
+		JOIN notes n ON n.id = ns.note_id
+		WHERE ns.note_id=$1 AND ns.user_id=$2 AND n.deleted_at IS NULL`
+
+	var share domain.NoteShare
+	err := s.db.QueryRowContext(ctx, query, noteID, userID).Scan(
+		&share.NoteID,
+		&share.OwnerID,
+		&share.UserID,
+		&share.Permission,
+		&share.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.NoteShare{}, domain.MakeError(domain.ErrReceiving, domain.ErrNotFound, "note share")
+		}
+		s.log.Error("database error in NoteShare query",
+			zap.Error(err),
+			zap.String("note_id", noteID.String()),
+			zap.String("user_id", userID.String()),
+		)
+		return domain.NoteShare{}, domain.MakeError(domain.ErrReceiving, err, "note share")
+	}
+
+	return share, nil
+}
+
+func (s *ShareR) SharedNotes(ctx context.Context, userID uuid.UUID, p dto.Paginated) ([]domain.SharedNote, int, error) {
+	var total int
+	query := `
+		SELECT COUNT(*)
+		FROM note_shares ns
+		JOIN notes ON notes.id = ns.note_id
+		WHERE ns.user_id=$1 AND notes.deleted_at IS NULL`
+	if err := s.db.QueryRowContext(ctx, query, userID).Scan(&total); err != nil {
+		s.log.Error("failed to execute COUNT query in SharedNotes",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return nil, 0, domain.MakeError(domain.ErrReceiving, err, "shared notes")
+	}
+
+	if total == 0 || total <= p.Offset {
+		return nil, total, nil
+	}
+
+	query = fmt.Sprintf(`
+		SELECT
+			notes.id, notes.user_id, notes.notebook_id, notes.heading, notes.content, notes.done, %v,
+			notes.created_at, notes.updated_at, notes.version, ns.permission
+		FROM note_shares ns
+		JOIN notes ON notes.id = ns.note_id
+		WHERE ns.user_id=$1 AND notes.deleted_at IS NULL
+		ORDER BY notes.updated_at DESC, notes.id DESC
+		LIMIT $2 OFFSET $3`, noteTagsColumn)
+
+	rows, err := s.db.QueryContext(ctx, query, userID, p.Limit, p.Offset)
+	if err != nil {
+		s.log.Error("failed to execute SELECT query in SharedNotes",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return nil, 0, domain.MakeError(domain.ErrReceiving, err, "shared notes")
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			s.log.Error("failed to close rows", zap.Error(closeErr))
+		}
+	}()
+
+	notes := make([]domain.SharedNote, 0, p.Limit)
+	for rows.Next() {
+		var note domain.SharedNote
+		err := rows.Scan(
+			&note.ID,
+			&note.UserID,
+			&note.NotebookID,
+			&note.Heading,
+			&note.Content,
+			&note.Done,
+			(*pq.StringArray)(&note.Tags),
+			&note.CreatedAt,
+			&note.UpdatedAt,
+			&note.Version,
+			&note.Permission,
+		)
+		if err != nil {
+			return nil, 0, domain.MakeError(domain.ErrReceiving, err, "shared notes")
+		}
+		notes = append(notes, note)
+	}
+
+	if err = rows.Err(); err != nil {
+		s.log.Error("error during row iteration",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return nil, 0, domain.MakeError(domain.ErrReceiving, err, "shared notes")
+	}
+
+	return notes, total, nil
+}
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createNamedTestUser(t *testing.T, tx *sqlx.Tx, name string) uuid.UUID {
+	t.Helper()
+
+	userID := uuid.New()
+	repoUser := NewUserRepository(tx, logger.LoggerForTest())
+	err := repoUser.CreateUser(context.Background(), domain.User{
+		ID:       userID,
+		Username: name,
+		Email:    name + "@example.com",
+		Password: "test",
+	})
+	require.NoError(t, err)
+
+	return userID
+}
+
+func TestShareR_ShareNote(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	ownerID := createTestUser(t, tx)
+	granteeID := createNamedTestUser(t, tx, "share_grantee")
+	noteID := createTaggedNote(t, tx, ownerID)
+
+	repo := NewShareRepository(tx, logger.LoggerForTest())
+
+	err = repo.ShareNote(context.Background(), domain.NoteShareTarget{
+		NoteID:     noteID,
+		OwnerID:    ownerID,
+		Username:   "share_grantee",
+		Permission: domain.NotePermissionRead,
+	})
+	require.NoError(t, err)
+
+	err = repo.ShareNote(context.Background(), domain.NoteShareTarget{
+		NoteID:     noteID,
+		OwnerID:    ownerID,
+		Email:      "share_grantee@example.com",
+		Permission: domain.NotePermissionWrite,
+	})
+	require.NoError(t, err)
+
+	shares, err := repo.NoteShares(context.Background(), ownerID, noteID)
+	require.NoError(t, err)
+	require.Len(t, shares, 1)
+	assert.Equal(t, granteeID, shares[0].UserID)
+	assert.Equal(t, domain.NotePermissionWrite, shares[0].Permission)
+
+	share, err := repo.NoteShare(context.Background(), granteeID, noteID)
+	require.NoError(t, err)
+	assert.Equal(t, ownerID, share.OwnerID)
+
+	err = repo.ShareNote(context.Background(), domain.NoteShareTarget{
+		NoteID:     noteID,
+		OwnerID:    granteeID,
+		Username:   "test",
+		Permission: domain.NotePermissionRead,
+	})
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	err = repo.ShareNote(context.Background(), domain.NoteShareTarget{
+		NoteID:     noteID,
+		OwnerID:    ownerID,
+		Username:   "nobody",
+		Permission: domain.NotePermissionRead,
+	})
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	_, err = repo.NoteShares(context.Background(), granteeID, noteID)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestShareR_UnshareNote(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	ownerID := createTestUser(t, tx)
+	granteeID := createNamedTestUser(t, tx, "unshare_grantee")
+	noteID := createTaggedNote(t, tx, ownerID)
+
+	repo := NewShareRepository(tx, logger.LoggerForTest())
+
+	require.NoError(t, repo.ShareNote(context.Background(), domain.NoteShareTarget{
+		NoteID:     noteID,
+		OwnerID:    ownerID,
+		Username:   "unshare_grantee",
+		Permission: domain.NotePermissionRead,
+	}))
+
+	target := domain.NoteShareTarget{NoteID: noteID, OwnerID: ownerID, Email: "unshare_grantee@example.com"}
+	require.NoError(t, repo.UnshareNote(context.Background(), target))
+
+	_, err = repo.NoteShare(context.Background(), granteeID, noteID)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	err = repo.UnshareNote(context.Background(), target)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestShareR_SharedNotes(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	ownerID := createTestUser(t, tx)
+	granteeID := createNamedTestUser(t, tx, "shared_grantee")
+	sharedID := createTaggedNote(t, tx, ownerID, "work")
+	trashedID := createTaggedNote(t, tx, ownerID)
+	createTaggedNote(t, tx, ownerID)
+
+	repo := NewShareRepository(tx, logger.LoggerForTest())
+	for _, id := range []uuid.UUID{sharedID, trashedID} {
+		require.NoError(t, repo.ShareNote(context.Background(), domain.NoteShareTarget{
+			NoteID:     id,
+			OwnerID:    ownerID,
+			Username:   "shared_grantee",
+			Permission: domain.NotePermissionRead,
+		}))
+	}
+
+	require.NoError(t, NewNoteRepository(tx, logger.LoggerForTest()).DeleteNote(context.Background(), ownerID, trashedID, nil))
+
+	notes, total, err := repo.SharedNotes(context.Background(), granteeID, dto.Paginated{Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, 1, total)
+	assert.Equal(t, sharedID, notes[0].ID)
+	assert.Equal(t, ownerID, notes[0].UserID)
+	assert.Equal(t, []string{"work"}, notes[0].Tags)
+	assert.Equal(t, domain.NotePermissionRead, notes[0].Permission)
+
+	_, err = repo.NoteShare(context.Background(), granteeID, trashedID)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"noteApp/internal/models/domain"
+	"noteApp/pkg/logger"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type SignInAttemptR struct {
+	db  query
+	log *logger.Logger
+}
+
+func NewSignInAttemptRepository(db query, log *logger.Logger) *SignInAttemptR {
+	return &SignInAttemptR{
+		db:  db,
+		log: log,
+	}
+}
+
+func (s *SignInAttemptR) SignInAttempt(ctx context.Context, scope, subject string) (domain.SignInAttempt, error) {
+	query := `SELECT scope, subject, failures, last_failed_at FROM sign_in_attempts WHERE scope=$1 AND subject=$2`
+
+	var attempt domain.SignInAttempt
+	err := s.db.QueryRowContext(ctx, query, scope, subject).Scan(
+		&attempt.Scope,
+		&attempt.Subject,
+		&attempt.Failures,
+		&attempt.LastFailedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.SignInAttempt{}, domain.MakeError(domain.ErrReceiving, domain.ErrNotFound, "sign-in attempt")
+		}
+		s.log.Error("database error in SignInAttempt query",
+			zap.Error(err),
+			zap.String("scope", scope),
+		)
+		return domain.SignInAttempt{}, domain.MakeError(domain.ErrReceiving, err, "sign-in attempt")
+	}
+
+	return attempt, nil
+}
+
+func (s *SignInAttemptR) ReserveSignInAttempt(ctx context.Context, scope, subject string, at time.Time, limit domain.SignInLimit) (domain.SignInAttempt, bool, error) {
+	query := `
+		INSERT INTO sign_in_attempts (scope, subject, failures, last_failed_at)
+		VALUES ($1, $2, 1, $3)
+		ON CONFLICT (scope, subject) DO UPDATE SET
+			failures = CASE WHEN sign_in_attempts.last_failed_at < $4 THEN 1 ELSE sign_in_attempts.failures + 1 END,
+			last_failed_at = EXCLUDED.last_failed_at
+		WHERE sign_in_attempts.failures < $5
+			OR sign_in_attempts.last_failed_at < $4
+			OR sign_in_attempts.last_failed_at
+				+ LEAST($6 * POWER(2, LEAST(sign_in_attempts.failures - $5, 32)), $7) * INTERVAL '1 microsecond' <= $3
+		RETURNING scope, subject, failures, last_failed_at`
+
+	var attempt domain.SignInAttempt
+	err := s.db.QueryRowContext(ctx, query,
+		scope,
+		subject,
+		at.UTC(),
+		at.Add(-limit.ResetAfter).UTC(),
+		limit.Threshold,
+		limit.BaseDelay.Microseconds(),
+		limit.MaxDelay.Microseconds(),
+	).Scan(
+		&attempt.Scope,
+		&attempt.Subject,
+		&attempt.Failures,
+		&attempt.LastFailedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			attempt, err = s.SignInAttempt(ctx, scope, subject)
+			return attempt, false, err
+		}
+		s.log.Error("failed to execute UPSERT query in ReserveSignInAttempt",
+			zap.Error(err),
+			zap.String("scope", scope),
+		)
+		return domain.SignInAttempt{}, false, domain.MakeError(domain.ErrFailedToUpdate, err, "sign-in attempt")
+	}
+
+	return attempt, true, nil
+}
+
+func (s *SignInAttemptR) ReleaseSignInAttempt(ctx context.Context, scope, subject string) error {
+	query := `UPDATE sign_in_attempts SET failures=failures-1 WHERE scope=$1 AND subject=$2 AND failures > 0`
+
+	_, err := s.db.ExecContext(ctx, query, scope, subject)
+	if err != nil {
+		s.log.Error("failed to execute UPDATE query in ReleaseSignInAttempt",
+			zap.Error(err),
+			zap.String("scope", scope),
+		)
+		return domain.MakeError(domain.ErrFailedToUpdate, err, "sign-in attempt")
+	}
+
+	return nil
+}
+
+func (s *SignInAttemptR) ResetSignInAttempts(ctx context.Context, scope, subject string) error {
+	query := `DELETE FROM sign_in_attempts WHERE scope=$1 AND subject=$2`
+
+	_, err := s.db.ExecContext(ctx, query, scope, subject)
+	if err != nil {
+		s.log.Error("failed to execute DELETE query in ResetSignInAttempts",
+			zap.Error(err),
+			zap.String("scope", scope),
+		)
+		return domain.MakeError(domain.ErrFailedToDelete, err, "sign-in attempt")
+	}
+
+	return nil
+}
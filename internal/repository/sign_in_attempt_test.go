@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"noteApp/internal/models/domain"
+	"noteApp/pkg/logger"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignInAttemptR_ReserveSignInAttempt(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	repo := NewSignInAttemptRepository(tx, logger.LoggerForTest())
+	subject := uuid.NewString() + "@example.com"
+	now := time.Now()
+	limit := domain.SignInLimit{Threshold: 2, BaseDelay: time.Minute, MaxDelay: time.Hour, ResetAfter: time.Hour}
+
+	_, err = repo.SignInAttempt(context.Background(), domain.SignInScopeAccount, subject)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	attempt, ok, err := repo.ReserveSignInAttempt(context.Background(), domain.SignInScopeAccount, subject, now, limit)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 1, attempt.Failures)
+
+	attempt, ok, err = repo.ReserveSignInAttempt(context.Background(), domain.SignInScopeAccount, subject, now.Add(time.Second), limit)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 2, attempt.Failures)
+
+	attempt, ok, err = repo.ReserveSignInAttempt(context.Background(), domain.SignInScopeAccount, subject, now.Add(2*time.Second), limit)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, 2, attempt.Failures)
+	assert.WithinDuration(t, now.Add(time.Second), attempt.LastFailedAt, time.Second)
+
+	_, err = repo.SignInAttempt(context.Background(), domain.SignInScopeIP, subject)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	attempt, ok, err = repo.ReserveSignInAttempt(context.Background(), domain.SignInScopeAccount, subject, now.Add(2*time.Minute), limit)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 3, attempt.Failures)
+
+	attempt, ok, err = repo.ReserveSignInAttempt(context.Background(), domain.SignInScopeAccount, subject, now.Add(3*time.Minute), limit)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, 3, attempt.Failures)
+
+	attempt, ok, err = repo.ReserveSignInAttempt(context.Background(), domain.SignInScopeAccount, subject, now.Add(3*time.Hour), limit)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 1, attempt.Failures)
+}
+
+func TestSignInAttemptR_ReserveSignInAttemptConcurrent(t *testing.T) {
+	t.Parallel()
+
+	repo := NewSignInAttemptRepository(globalTestDB, logger.LoggerForTest())
+	subject := uuid.NewString() + "@example.com"
+	limit := domain.SignInLimit{Threshold: 3, BaseDelay: time.Minute, MaxDelay: time.Hour, ResetAfter: time.Hour}
+	t.Cleanup(func() {
+		_ = repo.ResetSignInAttempts(context.Background(), domain.SignInScopeAccount, subject)
+	})
+
+	const attempts = 10
+	results := make(chan bool, attempts)
+	for range attempts {
+		go func() {
+			_, ok, err := repo.ReserveSignInAttempt(context.Background(), domain.SignInScopeAccount, subject, time.Now(), limit)
+			assert.NoError(t, err)
+			results <- ok
+		}()
+	}
+
+	var reserved int
+	for range attempts {
+		if <-results {
+			reserved++
+		}
+	}
+	assert.Equal(t, limit.Threshold, reserved)
+}
+
+func TestSignInAttemptR_ReleaseSignInAttempt(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	repo := NewSignInAttemptRepository(tx, logger.LoggerForTest())
+	ip := "198.51.100.24"
+	now := time.Now()
+	limit := domain.SignInLimit{Threshold: 1, BaseDelay: time.Minute, MaxDelay: time.Hour, ResetAfter: time.Hour}
+
+	_, ok, err := repo.ReserveSignInAttempt(context.Background(), domain.SignInScopeIP, ip, now, limit)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	require.NoError(t, repo.ReleaseSignInAttempt(context.Background(), domain.SignInScopeIP, ip))
+
+	attempt, err := repo.SignInAttempt(context.Background(), domain.SignInScopeIP, ip)
+	require.NoError(t, err)
+	assert.Equal(t, 0, attempt.Failures)
+
+	_, ok, err = repo.ReserveSignInAttempt(context.Background(), domain.SignInScopeIP, ip, now.Add(time.Second), limit)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	require.NoError(t, repo.ReleaseSignInAttempt(context.Background(), domain.SignInScopeIP, "198.51.100.25"))
+}
+
+func TestSignInAttemptR_ResetSignInAttempts(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	repo := NewSignInAttemptRepository(tx, logger.LoggerForTest())
+	ip := "198.51.100.23"
+	now := time.Now()
+
+	_, _, err = repo.ReserveSignInAttempt(context.Background(), domain.SignInScopeIP, ip, now, domain.SignInLimit{Threshold: 1, ResetAfter: time.Hour})
+	require.NoError(t, err)
+
+	require.NoError(t, repo.ResetSignInAttempts(context.Background(), domain.SignInScopeIP, ip))
+
+	_, err = repo.SignInAttempt(context.Background(), domain.SignInScopeIP, ip)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	require.NoError(t, repo.ResetSignInAttempts(context.Background(), domain.SignInScopeIP, ip))
+}
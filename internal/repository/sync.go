@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"noteApp/internal/models/domain"
+	"noteApp/pkg/logger"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+type SyncR struct {
+	db  query
+	log *logger.Logger
+}
+
+func NewSyncRepository(db query, log *logger.Logger) *SyncR {
+	return &SyncR{
+		db:  db,
+		log: log,
+	}
+}
+
+func (s *SyncR) NoteChanges(ctx context.Context, userID uuid.UUID, since int64, limit int) ([]domain.NoteChange, error) {
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			user_id,
+			notebook_id,
+			heading,
+			content,
+			done,
+			%v,
+			created_at,
+			updated_at,
+			version,
+			sync_seq,
+			deleted_at IS NOT NULL
+		FROM notes
+		WHERE user_id=$1 AND sync_seq>$2
+		ORDER BY sync_seq ASC
+		LIMIT $3`, noteTagsColumn)
+
+	rows, err := s.db.QueryContext(ctx, query, userID, since, limit)
+	if err != nil {
+		s.log.Error("failed to execute SELECT query in NoteChanges",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+			zap.Int64("since", since),
+		)
+		return nil, domain.MakeError(domain.ErrReceiving, err, "note changes")
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			s.log.Error("failed to close rows", zap.Error(closeErr))
+		}
+	}()
+
+	var changes []domain.NoteChange
+	for rows.Next() {
+		var change domain.NoteChange
+		if err := rows.Scan(
+			&change.ID,
+			&change.UserID,
+			&change.NotebookID,
+			&change.Heading,
+			&change.Content,
+			&change.Done,
+			(*pq.StringArray)(&change.Tags),
+			&change.CreatedAt,
+			&change.UpdatedAt,
+			&change.Version,
+			&change.Seq,
+			&change.Deleted,
+		); err != nil {
+			return nil, domain.MakeError(domain.ErrReceiving, err, "note changes")
+		}
+		changes = append(changes, change)
+	}
+
+	if err := rows.Err(); err != nil {
+		s.log.Error("error during row iteration",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return nil, domain.MakeError(domain.ErrReceiving, err, "note changes")
+	}
+
+	return changes, nil
+}
+
+func (s *SyncR) NoteTombstones(ctx context.Context, userID uuid.UUID, since int64, limit int) ([]domain.NoteChange, error) {
+	query := `
+		SELECT note_id, user_id, sync_seq
+		FROM note_tombstones
+		WHERE user_id=$1 AND sync_seq>$2
+		ORDER BY sync_seq ASC
+		LIMIT $3`
+
+	rows, err := s.db.QueryContext(ctx, query, userID, since, limit)
+	if err != nil {
+		s.log.Error("failed to execute SELECT query in NoteTombstones",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+			zap.Int64("since", since),
+		)
+		return nil, domain.MakeError(domain.ErrReceiving, err, "note tombstones")
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			s.log.Error("failed to close rows", zap.Error(closeErr))
+		}
+	}()
+
+	var tombstones []domain.NoteChange
+	for rows.Next() {
+		tombstone := domain.NoteChange{Deleted: true}
+		if err := rows.Scan(&tombstone.ID, &tombstone.UserID, &tombstone.Seq); err != nil {
+			return nil, domain.MakeError(domain.ErrReceiving, err, "note tombstones")
+		}
+		tombstones = append(tombstones, tombstone)
+	}
+
+	if err := rows.Err(); err != nil {
+		s.log.Error("error during row iteration",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return nil, domain.MakeError(domain.ErrReceiving, err, "note tombstones")
+	}
+
+	return tombstones, nil
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"noteApp/internal/models/domain"
+	"noteApp/pkg/logger"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncR_NoteChanges(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	userID := createTestUser(t, tx)
+	first := createTaggedNote(t, tx, userID, "work")
+	second := createTaggedNote(t, tx, userID)
+
+	repo := NewSyncRepository(tx, logger.LoggerForTest())
+
+	changes, err := repo.NoteChanges(context.Background(), userID, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	assert.Equal(t, []string{"work"}, changes[0].Tags)
+	assert.Less(t, changes[0].Seq, changes[1].Seq)
+	since := changes[1].Seq
+
+	noteRepo := NewNoteRepository(tx, logger.LoggerForTest())
+	heading := "synced heading"
+	require.NoError(t, noteRepo.UpdateNote(context.Background(), domain.NoteUpdate{ID: first, UserID: userID, Heading: &heading}))
+	require.NoError(t, noteRepo.DeleteNote(context.Background(), userID, second, nil))
+
+	changes, err = repo.NoteChanges(context.Background(), userID, since, 10)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	assert.Equal(t, first, changes[0].ID)
+	assert.Equal(t, heading, changes[0].Heading)
+	assert.False(t, changes[0].Deleted)
+	assert.Equal(t, second, changes[1].ID)
+	assert.True(t, changes[1].Deleted)
+
+	changes, err = repo.NoteChanges(context.Background(), userID, since, 1)
+	require.NoError(t, err)
+	assert.Len(t, changes, 1)
+}
+
+func TestSyncR_NoteTombstones(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	userID := createTestUser(t, tx)
+	noteID := createTaggedNote(t, tx, userID)
+
+	repo := NewSyncRepository(tx, logger.LoggerForTest())
+
+	changes, err := repo.NoteChanges(context.Background(), userID, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	since := changes[0].Seq
+
+	trashRepo := NewTrashRepository(tx, logger.LoggerForTest())
+	noteRepo := NewNoteRepository(tx, logger.LoggerForTest())
+	require.NoError(t, noteRepo.DeleteNote(context.Background(), userID, noteID, nil))
+	require.NoError(t, trashRepo.PurgeNote(context.Background(), userID, noteID))
+
+	tombstones, err := repo.NoteTombstones(context.Background(), userID, since, 10)
+	require.NoError(t, err)
+	require.Len(t, tombstones, 1)
+	assert.Equal(t, noteID, tombstones[0].ID)
+	assert.True(t, tombstones[0].Deleted)
+	assert.Greater(t, tombstones[0].Seq, since)
+
+	tombstones, err = repo.NoteTombstones(context.Background(), uuid.New(), 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, tombstones)
+}
+
+func TestSyncR_SyncSeqSerializedPerUser(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	userID := createTestUser(t, tx)
+	createTaggedNote(t, tx, userID)
+
+	other, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = other.Rollback() })
+
+	query := `SELECT pg_try_advisory_xact_lock(hashtext('notes_sync_seq'), hashtext($1::text))`
+
+	var locked bool
+	require.NoError(t, other.QueryRowContext(context.Background(), query, userID).Scan(&locked))
+	assert.False(t, locked)
+
+	require.NoError(t, other.QueryRowContext(context.Background(), query, uuid.New()).Scan(&locked))
+	assert.True(t, locked)
+}
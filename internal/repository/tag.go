@@ -0,0 +1,218 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"noteApp/internal/models/domain"
+	"noteApp/pkg/logger"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+const uniqueViolationCode = "23505"
+
+type TagR struct {
+	db  query
+	log *logger.Logger
+}
+
+func NewTagRepository(db query, log *logger.Logger) *TagR {
+	return &TagR{
+		db:  db,
+		log: log,
+	}
+}
+
+func (t *TagR) Tags(ctx context.Context, userID uuid.UUID) ([]domain.Tag, error) {
+	query := `
+		SELECT t.id, t.user_id, t.name, COUNT(n.id), t.created_at
+		FROM tags t
+		LEFT JOIN note_tags nt ON nt.tag_id = t.id
+		LEFT JOIN notes n ON n.id = nt.note_id AND n.deleted_at IS NULL
+		WHERE t.user_id=$1
+		GROUP BY t.id
+		ORDER BY t.name ASC`
+
+	rows, err := t.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		t.log.Error("failed to execute SELECT query in Tags",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return nil, domain.MakeError(domain.ErrReceiving, err, "tags")
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			t.log.Error("failed to close rows", zap.Error(closeErr))
+		}
+	}()
+
+	var tags []domain.Tag
+	for rows.Next() {
+		var tag domain.Tag
+		if err := rows.Scan(
+			&tag.ID,
+			&tag.UserID,
+			&tag.Name,
+			&tag.Notes,
+			&tag.CreatedAt,
+		); err != nil {
+			return nil, domain.MakeError(domain.ErrReceiving, err, "tags")
+		}
+		tags = append(tags, tag)
+	}
+
+	if err := rows.Err(); err != nil {
+		t.log.Error("error during row iteration",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return nil, domain.MakeError(domain.ErrReceiving, err, "tags")
+	}
+
+	return tags, nil
+}
+
+func (t *TagR) RenameTag(ctx context.Context, tag domain.TagUpdate) error {
+	query := `
+		WITH bumped AS (
+			UPDATE notes SET version=version+1
+			WHERE id IN (
+				SELECT nt.note_id FROM note_tags nt
+				JOIN tags t ON t.id = nt.tag_id
+				WHERE t.id=$2 AND t.user_id=$3
+			)
+		)
+		UPDATE tags SET name=$1 WHERE id=$2 AND user_id=$3`
+
+	result, err := t.db.ExecContext(ctx, query, tag.Name, tag.ID, tag.UserID)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.MakeError(domain.ErrFailedToUpdate, domain.ErrAlreadyExists, "tag")
+		}
+		t.log.Error("failed to execute UPDATE query in RenameTag",
+			zap.Error(err),
+			zap.String("tag_id", tag.ID.String()),
+			zap.String("user_id", tag.UserID.String()),
+		)
+		return domain.MakeError(domain.ErrFailedToUpdate, err, "tag")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		t.log.Error("failed to get rows affected after UPDATE",
+			zap.Error(err),
+			zap.String("tag_id", tag.ID.String()),
+			zap.String("user_id", tag.UserID.String()),
+		)
+		return domain.MakeError(domain.ErrFailedToUpdate, err, "tag")
+	}
+
+	if rowsAffected == 0 {
+		return domain.MakeError(domain.ErrFailedToUpdate, domain.ErrNotFound, "tag")
+	}
+
+	return nil
+}
+
+func (t *TagR) MergeTags(ctx context.Context, userID, targetID uuid.UUID, sourceIDs []uuid.UUID) error {
+	query := `
+		WITH target AS (
+			SELECT id FROM tags WHERE id=$1 AND user_id=$2
+		),
+		sources AS (
+			SELECT id FROM tags WHERE id = ANY($3::uuid[]) AND user_id=$2 AND id <> $1
+		),
+		moved AS (
+			INSERT INTO note_tags (note_id, tag_id)
+			SELECT nt.note_id, target.id
+			FROM note_tags nt
+			JOIN sources s ON s.id = nt.tag_id
+			CROSS JOIN target
+			ON CONFLICT DO NOTHING
+		),
+		bumped AS (
+			UPDATE notes SET version=version+1
+			WHERE id IN (SELECT nt.note_id FROM note_tags nt JOIN sources s ON s.id = nt.tag_id)
+				AND EXISTS (SELECT 1 FROM target)
+		)
+		DELETE FROM tags
+		WHERE id IN (SELECT id FROM sources) AND EXISTS (SELECT 1 FROM target)`
+
+	ids := make(pq.StringArray, 0, len(sourceIDs))
+	for _, id := range sourceIDs {
+		ids = append(ids, id.String())
+	}
+
+	result, err := t.db.ExecContext(ctx, query, targetID, userID, ids)
+	if err != nil {
+		t.log.Error("failed to execute merge query in MergeTags",
+			zap.Error(err),
+			zap.String("tag_id", targetID.String()),
+			zap.String("user_id", userID.String()),
+		)
+		return domain.MakeError(domain.ErrFailedToUpdate, err, "tag")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		t.log.Error("failed to get rows affected after merge",
+			zap.Error(err),
+			zap.String("tag_id", targetID.String()),
+			zap.String("user_id", userID.String()),
+		)
+		return domain.MakeError(domain.ErrFailedToUpdate, err, "tag")
+	}
+
+	if rowsAffected == 0 {
+		return domain.MakeError(domain.ErrFailedToUpdate, domain.ErrNotFound, "tag")
+	}
+
+	return nil
+}
+
+func (t *TagR) DeleteTag(ctx context.Context, userID, tagID uuid.UUID) error {
+	query := `
+		WITH bumped AS (
+			UPDATE notes SET version=version+1
+			WHERE id IN (
+				SELECT nt.note_id FROM note_tags nt
+				JOIN tags t ON t.id = nt.tag_id
+				WHERE t.id=$1 AND t.user_id=$2
+			)
+		)
+		DELETE FROM tags WHERE id=$1 AND user_id=$2`
+
+	result, err := t.db.ExecContext(ctx, query, tagID, userID)
+	if err != nil {
+		t.log.Error("failed to execute DELETE query",
+			zap.Error(err),
+			zap.String("tag_id", tagID.String()),
+			zap.String("user_id", userID.String()),
+		)
+		return domain.MakeError(domain.ErrFailedToDelete, err, "tag")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		t.log.Error("failed to get rows affected after DELETE",
+			zap.Error(err),
+			zap.String("tag_id", tagID.String()),
+			zap.String("user_id", userID.String()),
+		)
+		return domain.MakeError(domain.ErrFailedToDelete, err, "tag")
+	}
+
+	if rowsAffected == 0 {
+		return domain.MakeError(domain.ErrFailedToDelete, domain.ErrNotFound, "tag")
+	}
+
+	return nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolationCode
+}
@@ -0,0 +1,279 @@
+package repository
+
+import (
+	"context"
+	"noteApp/internal/models/domain"
+	"noteApp/pkg/logger"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTaggedNote(t *testing.T, tx *sqlx.Tx, userID uuid.UUID, tags ...string) uuid.UUID {
+	t.Helper()
+
+	noteID := uuid.New()
+	repo := NewNoteRepository(tx, logger.LoggerForTest())
+	err := repo.CreateNote(context.Background(), domain.Note{
+		ID:      noteID,
+		UserID:  userID,
+		Heading: "test_heading",
+		Content: "test_content",
+		Tags:    tags,
+	})
+	require.NoError(t, err)
+
+	return noteID
+}
+
+func createTestUser(t *testing.T, tx *sqlx.Tx) uuid.UUID {
+	t.Helper()
+
+	userID := uuid.New()
+	repoUser := NewUserRepository(tx, logger.LoggerForTest())
+	err := repoUser.CreateUser(context.Background(), domain.User{
+		ID:       userID,
+		Username: "test",
+		Email:    "test",
+		Password: "test",
+		ImageURL: "test",
+	})
+	require.NoError(t, err)
+
+	return userID
+}
+
+func tagIDByName(t *testing.T, tags []domain.Tag, name string) uuid.UUID {
+	t.Helper()
+
+	for _, tag := range tags {
+		if tag.Name == name {
+			return tag.ID
+		}
+	}
+	require.FailNow(t, "tag not found", name)
+	return uuid.Nil
+}
+
+func TestTagR_Tags(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	userID := createTestUser(t, tx)
+	createTaggedNote(t, tx, userID, "work", "urgent")
+	createTaggedNote(t, tx, userID, "work")
+
+	repo := NewTagRepository(tx, logger.LoggerForTest())
+
+	got, err := repo.Tags(context.Background(), userID)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "urgent", got[0].Name)
+	assert.Equal(t, 1, got[0].Notes)
+	assert.Equal(t, "work", got[1].Name)
+	assert.Equal(t, 2, got[1].Notes)
+
+	got, err = repo.Tags(context.Background(), uuid.New())
+	require.NoError(t, err)
+	require.Empty(t, got)
+}
+
+func TestTagR_RenameTag(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		newName    string
+		wantErr    bool
+		wantErrMsg string
+	}{
+		{
+			name:    "success",
+			newName: "job",
+			wantErr: false,
+		},
+		{
+			name:       "name already used",
+			newName:    "home",
+			wantErr:    true,
+			wantErrMsg: domain.ErrAlreadyExists.Error(),
+		},
+		{
+			name:       "wrong user ID",
+			newName:    "job",
+			wantErr:    true,
+			wantErrMsg: domain.ErrNotFound.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = tx.Rollback() })
+
+			userID := createTestUser(t, tx)
+			noteID := createTaggedNote(t, tx, userID, "work", "home")
+
+			repo := NewTagRepository(tx, logger.LoggerForTest())
+			tags, err := repo.Tags(context.Background(), userID)
+			require.NoError(t, err)
+
+			update := domain.TagUpdate{
+				ID:     tagIDByName(t, tags, "work"),
+				UserID: userID,
+				Name:   tt.newName,
+			}
+			if tt.name == "wrong user ID" {
+				update.UserID = uuid.New()
+			}
+
+			err = repo.RenameTag(context.Background(), update)
+			if tt.wantErr {
+				require.ErrorContains(t, err, tt.wantErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+
+			tags, err = repo.Tags(context.Background(), userID)
+			require.NoError(t, err)
+			tagIDByName(t, tags, tt.newName)
+
+			note, err := NewNoteRepository(tx, logger.LoggerForTest()).Note(context.Background(), userID, noteID)
+			require.NoError(t, err)
+			assert.Equal(t, 2, note.Version)
+		})
+	}
+}
+
+func TestTagR_MergeTags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		wantErr    bool
+		wantErrMsg string
+	}{
+		{
+			name:    "success",
+			wantErr: false,
+		},
+		{
+			name:       "wrong user ID",
+			wantErr:    true,
+			wantErrMsg: domain.ErrNotFound.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = tx.Rollback() })
+
+			userID := createTestUser(t, tx)
+			noteID := createTaggedNote(t, tx, userID, "todo", "tasks")
+			createTaggedNote(t, tx, userID, "tasks")
+			untouchedID := createTaggedNote(t, tx, userID, "todo")
+
+			repo := NewTagRepository(tx, logger.LoggerForTest())
+			tags, err := repo.Tags(context.Background(), userID)
+			require.NoError(t, err)
+
+			targetID := tagIDByName(t, tags, "todo")
+			sourceID := tagIDByName(t, tags, "tasks")
+
+			mergeUserID := userID
+			if tt.name == "wrong user ID" {
+				mergeUserID = uuid.New()
+			}
+
+			err = repo.MergeTags(context.Background(), mergeUserID, targetID, []uuid.UUID{sourceID})
+			if tt.wantErr {
+				require.ErrorContains(t, err, tt.wantErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+
+			tags, err = repo.Tags(context.Background(), userID)
+			require.NoError(t, err)
+			require.Len(t, tags, 1)
+			assert.Equal(t, "todo", tags[0].Name)
+			assert.Equal(t, 3, tags[0].Notes)
+
+			noteRepo := NewNoteRepository(tx, logger.LoggerForTest())
+			note, err := noteRepo.Note(context.Background(), userID, noteID)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"todo"}, note.Tags)
+			assert.Equal(t, 2, note.Version)
+
+			untouched, err := noteRepo.Note(context.Background(), userID, untouchedID)
+			require.NoError(t, err)
+			assert.Equal(t, 1, untouched.Version)
+		})
+	}
+}
+
+func TestTagR_DeleteTag(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		wantErr    bool
+		wantErrMsg string
+	}{
+		{
+			name:    "success",
+			wantErr: false,
+		},
+		{
+			name:       "wrong user ID",
+			wantErr:    true,
+			wantErrMsg: domain.ErrFailedToDelete.Error(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = tx.Rollback() })
+
+			userID := createTestUser(t, tx)
+			noteID := createTaggedNote(t, tx, userID, "work")
+
+			repo := NewTagRepository(tx, logger.LoggerForTest())
+			tags, err := repo.Tags(context.Background(), userID)
+			require.NoError(t, err)
+
+			deleteUserID := userID
+			if tt.name == "wrong user ID" {
+				deleteUserID = uuid.New()
+			}
+
+			err = repo.DeleteTag(context.Background(), deleteUserID, tagIDByName(t, tags, "work"))
+			if tt.wantErr {
+				require.ErrorContains(t, err, tt.wantErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+
+			note, err := NewNoteRepository(tx, logger.LoggerForTest()).Note(context.Background(), userID, noteID)
+			require.NoError(t, err)
+			assert.Empty(t, note.Tags)
+			assert.Equal(t, 2, note.Version)
+		})
+	}
+}
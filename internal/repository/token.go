@@ -5,7 +5,9 @@ import (
 	"database/sql"
 	"noteApp/internal/models/domain"
 	"noteApp/pkg/logger"
+	"time"
 
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
@@ -22,9 +24,11 @@ func NewTokenRepository(db query, log *logger.Logger) *TokenR {
 }
 
 func (t *TokenR) CreateToken(ctx context.Context, token domain.Token) error {
-	query := `INSERT INTO tokens (user_id, token_id, expired_at) VALUES ($1, $2, $3)`
+	query := `
+		INSERT INTO tokens (user_id, token_id, family_id, user_agent, ip, expired_at, created_at, last_used_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW())`
 
-	_, err := t.db.ExecContext(ctx, query, token.UserID, token.TokenID, token.ExpiresAt)
+	_, err := t.db.ExecContext(ctx, query, token.UserID, token.TokenID, token.FamilyID, token.UserAgent, token.IP, token.ExpiresAt)
 	if err != nil {
 		t.log.Error("failed to execute INSERT query in CreateToken",
 			zap.Error(err),
@@ -38,7 +42,10 @@ func (t *TokenR) CreateToken(ctx context.Context, token domain.Token) error {
 }
 
 func (t *TokenR) Token(ctx context.Context, tokenID string) (domain.Token, error) {
-	query := `SELECT user_id, token_id, expired_at FROM tokens WHERE token_id=$1`
+	query := `
+		SELECT user_id, token_id, family_id, user_agent, ip, expired_at, rotated_at, created_at, last_used_at
+		FROM tokens
+		WHERE token_id=$1`
 
 	row := t.db.QueryRowContext(ctx, query, tokenID)
 
@@ -46,7 +53,13 @@ func (t *TokenR) Token(ctx context.Context, tokenID string) (domain.Token, error
 	if err := row.Scan(
 		&token.UserID,
 		&token.TokenID,
+		&token.FamilyID,
+		&token.UserAgent,
+		&token.IP,
 		&token.ExpiresAt,
+		&token.RotatedAt,
+		&token.CreatedAt,
+		&token.LastUsedAt,
 	); err != nil {
 		if err == sql.ErrNoRows {
 			return domain.Token{}, domain.MakeError(domain.ErrReceiving, domain.ErrNotFound, "token")
@@ -88,3 +101,69 @@ func (t *TokenR) DeleteToken(ctx context.Context, tokenID string) error {
 
 	return nil
 }
+
+func (t *TokenR) RotateToken(ctx context.Context, tokenID string) error {
+	query := `UPDATE tokens SET rotated_at=NOW(), last_used_at=NOW() WHERE token_id=$1 AND rotated_at IS NULL`
+
+	result, err := t.db.ExecContext(ctx, query, tokenID)
+	if err != nil {
+		t.log.Error("failed to execute UPDATE query in RotateToken",
+			zap.Error(err),
+			zap.String("token_id", tokenID),
+		)
+		return domain.MakeError(domain.ErrFailedToUpdate, err, "token")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		t.log.Error("failed to get rows affected after UPDATE",
+			zap.Error(err),
+			zap.String("token_id", tokenID),
+		)
+		return domain.MakeError(domain.ErrFailedToUpdate, err, "token")
+	}
+
+	if rowsAffected == 0 {
+		return domain.MakeError(domain.ErrFailedToUpdate, domain.ErrNotFound, "token")
+	}
+
+	return nil
+}
+
+func (t *TokenR) DeleteTokenFamily(ctx context.Context, familyID uuid.UUID) error {
+	query := `DELETE FROM tokens WHERE family_id=$1`
+
+	if _, err := t.db.ExecContext(ctx, query, familyID); err != nil {
+		t.log.Error("failed to execute DELETE query in DeleteTokenFamily",
+			zap.Error(err),
+			zap.String("family_id", familyID.String()),
+		)
+		return domain.MakeError(domain.ErrFailedToDelete, err, "token family")
+	}
+
+	return nil
+}
+
+func (t *TokenR) PurgeExpiredTokens(ctx context.Context, expiredBefore time.Time) (int64, error) {
+	query := `DELETE FROM tokens WHERE expired_at < $1`
+
+	result, err := t.db.ExecContext(ctx, query, expiredBefore.UTC())
+	if err != nil {
+		t.log.Error("failed to execute DELETE query in PurgeExpiredTokens",
+			zap.Error(err),
+			zap.Time("expired_before", expiredBefore),
+		)
+		return 0, domain.MakeError(domain.ErrFailedToDelete, err, "token")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		t.log.Error("failed to get rows affected after DELETE",
+			zap.Error(err),
+			zap.Time("expired_before", expiredBefore),
+		)
+		return 0, domain.MakeError(domain.ErrFailedToDelete, err, "token")
+	}
+
+	return rowsAffected, nil
+}
@@ -265,3 +265,105 @@ func TestTokenR_DeleteToken(t *testing.T) {
 		})
 	}
 }
+
+func TestTokenR_RotateToken(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	userID := createTestUser(t, tx)
+	repo := NewTokenRepository(tx, logger.LoggerForTest())
+
+	token := domain.Token{
+		UserID:    userID,
+		TokenID:   uuid.New().String(),
+		FamilyID:  uuid.New(),
+		ExpiresAt: time.Now().Add(time.Hour).UTC(),
+	}
+	require.NoError(t, repo.CreateToken(context.Background(), token))
+
+	require.NoError(t, repo.RotateToken(context.Background(), token.TokenID))
+
+	got, err := repo.Token(context.Background(), token.TokenID)
+	require.NoError(t, err)
+	assert.Equal(t, token.FamilyID, got.FamilyID)
+	require.NotNil(t, got.RotatedAt)
+
+	err = repo.RotateToken(context.Background(), token.TokenID)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestTokenR_DeleteTokenFamily(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	userID := createTestUser(t, tx)
+	repo := NewTokenRepository(tx, logger.LoggerForTest())
+
+	familyID := uuid.New()
+	other := uuid.New().String()
+	ids := []string{uuid.New().String(), uuid.New().String()}
+	for _, id := range ids {
+		require.NoError(t, repo.CreateToken(context.Background(), domain.Token{
+			UserID:    userID,
+			TokenID:   id,
+			FamilyID:  familyID,
+			ExpiresAt: time.Now().Add(time.Hour).UTC(),
+		}))
+	}
+	require.NoError(t, repo.CreateToken(context.Background(), domain.Token{
+		UserID:    userID,
+		TokenID:   other,
+		FamilyID:  uuid.New(),
+		ExpiresAt: time.Now().Add(time.Hour).UTC(),
+	}))
+
+	require.NoError(t, repo.DeleteTokenFamily(context.Background(), familyID))
+
+	for _, id := range ids {
+		_, err := repo.Token(context.Background(), id)
+		require.ErrorIs(t, err, domain.ErrNotFound)
+	}
+	_, err = repo.Token(context.Background(), other)
+	require.NoError(t, err)
+}
+
+func TestTokenR_PurgeExpiredTokens(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	userID := createTestUser(t, tx)
+	repo := NewTokenRepository(tx, logger.LoggerForTest())
+
+	expired := uuid.New().String()
+	rotated := uuid.New().String()
+	active := uuid.New().String()
+	familyID := uuid.New()
+	for _, token := range []domain.Token{
+		{UserID: userID, TokenID: rotated, FamilyID: familyID, ExpiresAt: time.Now().Add(-2 * time.Hour).UTC()},
+		{UserID: userID, TokenID: expired, FamilyID: familyID, ExpiresAt: time.Now().Add(-time.Hour).UTC()},
+		{UserID: userID, TokenID: active, FamilyID: uuid.New(), ExpiresAt: time.Now().Add(time.Hour).UTC()},
+	} {
+		require.NoError(t, repo.CreateToken(context.Background(), token))
+	}
+	require.NoError(t, repo.RotateToken(context.Background(), rotated))
+
+	purged, err := repo.PurgeExpiredTokens(context.Background(), time.Now())
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, purged, int64(2))
+
+	for _, id := range []string{rotated, expired} {
+		_, err := repo.Token(context.Background(), id)
+		require.ErrorIs(t, err, domain.ErrNotFound)
+	}
+	_, err = repo.Token(context.Background(), active)
+	require.NoError(t, err)
+}
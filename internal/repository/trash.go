@@ -0,0 +1,246 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+type TrashR struct {
+	db  query
+	log *logger.Logger
+}
+
+func NewTrashRepository(db query, log *logger.Logger) *TrashR {
+	return &TrashR{
+		db:  db,
+		log: log,
+	}
+}
+
+func (t *TrashR) TrashedNotes(ctx context.Context, userID uuid.UUID, p dto.Paginated) ([]domain.TrashedNote, int, error) {
+	var total int
+	query := `SELECT COUNT(*) FROM notes WHERE user_id=$1 AND deleted_at IS NOT NULL`
+	if err := t.db.QueryRowContext(ctx, query, userID).Scan(&total); err != nil {
+		t.log.Error("failed to execute COUNT query in TrashedNotes",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return nil, 0, domain.MakeError(domain.ErrReceiving, err, "trash")
+	}
+
+	if total == 0 || total <= p.Offset {
+		return nil, total, nil
+	}
+
+	query = fmt.Sprintf(`
+		SELECT id, user_id, notebook_id, heading, content, done, %v, created_at, updated_at, version, deleted_at
+		FROM notes
+		WHERE user_id=$1 AND deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC, id DESC
+		LIMIT $2 OFFSET $3`, noteTagsColumn)
+
+	rows, err := t.db.QueryContext(ctx, query, userID, p.Limit, p.Offset)
+	if err != nil {
+		t.log.Error("failed to execute SELECT query in TrashedNotes",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return nil, 0, domain.MakeError(domain.ErrReceiving, err, "trash")
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			t.log.Error("failed to close rows", zap.Error(closeErr))
+		}
+	}()
+
+	notes := make([]domain.TrashedNote, 0, p.Limit)
+	for rows.Next() {
+		var note domain.TrashedNote
+		err := rows.Scan(
+			&note.ID,
+			&note.UserID,
+			&note.NotebookID,
+			&note.Heading,
+			&note.Content,
+			&note.Done,
+			(*pq.StringArray)(&note.Tags),
+			&note.CreatedAt,
+			&note.UpdatedAt,
+			&note.Version,
+			&note.DeletedAt,
+		)
+		if err != nil {
+			return nil, 0, domain.MakeError(domain.ErrReceiving, err, "trash")
+		}
+		notes = append(notes, note)
+	}
+
+	if err = rows.Err(); err != nil {
+		t.log.Error("error during row iteration",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return nil, 0, domain.MakeError(domain.ErrReceiving, err, "trash")
+	}
+
+	return notes, total, nil
+}
+
+func (t *TrashR) RestoreNote(ctx context.Context, userID, noteID uuid.UUID) error {
+	query := `
+		UPDATE notes SET deleted_at=NULL, updated_at=NOW(), version=version+1
+		WHERE id=$1 AND user_id=$2 AND deleted_at IS NOT NULL`
+
+	result, err := t.db.ExecContext(ctx, query, noteID, userID)
+	if err != nil {
+		t.log.Error("failed to execute UPDATE query in RestoreNote",
+			zap.Error(err),
+			zap.String("note_id", noteID.String()),
+			zap.String("user_id", userID.String()),
+		)
+		return domain.MakeError(domain.ErrFailedToUpdate, err, "note")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		t.log.Error("failed to get rows affected after UPDATE",
+			zap.Error(err),
+			zap.String("note_id", noteID.String()),
+			zap.String("user_id", userID.String()),
+		)
+		return domain.MakeError(domain.ErrFailedToUpdate, err, "note")
+	}
+
+	if rowsAffected == 0 {
+		return domain.MakeError(domain.ErrFailedToUpdate, domain.ErrNotFound, "note")
+	}
+
+	return nil
+}
+
+func (t *TrashR) TrashedNoteIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	query := `SELECT id FROM notes WHERE user_id=$1 AND deleted_at IS NOT NULL ORDER BY deleted_at FOR UPDATE`
+
+	rows, err := t.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		t.log.Error("failed to execute SELECT query in TrashedNoteIDs",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return nil, domain.MakeError(domain.ErrReceiving, err, "trash")
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			t.log.Error("failed to close rows", zap.Error(closeErr))
+		}
+	}()
+
+	var noteIDs []uuid.UUID
+	for rows.Next() {
+		var noteID uuid.UUID
+		if err := rows.Scan(&noteID); err != nil {
+			t.log.Error("failed to scan row in TrashedNoteIDs",
+				zap.Error(err),
+				zap.String("user_id", userID.String()),
+			)
+			return nil, domain.MakeError(domain.ErrReceiving, err, "trash")
+		}
+		noteIDs = append(noteIDs, noteID)
+	}
+
+	if err := rows.Err(); err != nil {
+		t.log.Error("rows iteration error in TrashedNoteIDs",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return nil, domain.MakeError(domain.ErrReceiving, err, "trash")
+	}
+
+	return noteIDs, nil
+}
+
+func (t *TrashR) PurgeNote(ctx context.Context, userID, noteID uuid.UUID) error {
+	query := `DELETE FROM notes WHERE id=$1 AND user_id=$2 AND deleted_at IS NOT NULL`
+
+	result, err := t.db.ExecContext(ctx, query, noteID, userID)
+	if err != nil {
+		t.log.Error("failed to execute DELETE query in PurgeNote",
+			zap.Error(err),
+			zap.String("note_id", noteID.String()),
+			zap.String("user_id", userID.String()),
+		)
+		return domain.MakeError(domain.ErrFailedToDelete, err, "note")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		t.log.Error("failed to get rows affected after DELETE",
+			zap.Error(err),
+			zap.String("note_id", noteID.String()),
+			zap.String("user_id", userID.String()),
+		)
+		return domain.MakeError(domain.ErrFailedToDelete, err, "note")
+	}
+
+	if rowsAffected == 0 {
+		return domain.MakeError(domain.ErrFailedToDelete, domain.ErrNotFound, "note")
+	}
+
+	return nil
+}
+
+func (t *TrashR) EmptyTrash(ctx context.Context, userID uuid.UUID) (int64, error) {
+	query := `DELETE FROM notes WHERE user_id=$1 AND deleted_at IS NOT NULL`
+
+	result, err := t.db.ExecContext(ctx, query, userID)
+	if err != nil {
+		t.log.Error("failed to execute DELETE query in EmptyTrash",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return 0, domain.MakeError(domain.ErrFailedToDelete, err, "trash")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		t.log.Error("failed to get rows affected after DELETE",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return 0, domain.MakeError(domain.ErrFailedToDelete, err, "trash")
+	}
+
+	return rowsAffected, nil
+}
+
+func (t *TrashR) PurgeTrash(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	query := `DELETE FROM notes WHERE deleted_at IS NOT NULL AND deleted_at < $1`
+
+	result, err := t.db.ExecContext(ctx, query, deletedBefore.UTC())
+	if err != nil {
+		t.log.Error("failed to execute DELETE query in PurgeTrash",
+			zap.Error(err),
+			zap.Time("deleted_before", deletedBefore),
+		)
+		return 0, domain.MakeError(domain.ErrFailedToDelete, err, "trash")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		t.log.Error("failed to get rows affected after DELETE",
+			zap.Error(err),
+			zap.Time("deleted_before", deletedBefore),
+		)
+		return 0, domain.MakeError(domain.ErrFailedToDelete, err, "trash")
+	}
+
+	return rowsAffected, nil
+}
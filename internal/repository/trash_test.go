@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrashR_TrashedNotes(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	userID := createTestUser(t, tx)
+	trashedID := createTaggedNote(t, tx, userID, "work")
+	keptID := createTaggedNote(t, tx, userID, "work")
+
+	noteRepo := NewNoteRepository(tx, logger.LoggerForTest())
+	tagRepo := NewTagRepository(tx, logger.LoggerForTest())
+	repo := NewTrashRepository(tx, logger.LoggerForTest())
+
+	require.NoError(t, noteRepo.DeleteNote(context.Background(), userID, trashedID, nil))
+
+	_, err = noteRepo.Note(context.Background(), userID, trashedID)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	notes, total, err := noteRepo.Notes(context.Background(), userID, dto.NoteFilter{Paginated: dto.Paginated{Limit: 10}})
+	require.NoError(t, err)
+	require.Equal(t, 1, total)
+	assert.Equal(t, keptID, notes[0].ID)
+
+	tags, err := tagRepo.Tags(context.Background(), userID)
+	require.NoError(t, err)
+	require.Len(t, tags, 1)
+	assert.Equal(t, 1, tags[0].Notes)
+
+	trashed, total, err := repo.TrashedNotes(context.Background(), userID, dto.Paginated{Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, 1, total)
+	assert.Equal(t, trashedID, trashed[0].ID)
+	assert.Equal(t, []string{"work"}, trashed[0].Tags)
+	assert.False(t, trashed[0].DeletedAt.IsZero())
+
+	err = noteRepo.DeleteNote(context.Background(), userID, trashedID, nil)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestTrashR_RestoreNote(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	userID := createTestUser(t, tx)
+	noteID := createTaggedNote(t, tx, userID)
+
+	noteRepo := NewNoteRepository(tx, logger.LoggerForTest())
+	repo := NewTrashRepository(tx, logger.LoggerForTest())
+
+	err = repo.RestoreNote(context.Background(), userID, noteID)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	require.NoError(t, noteRepo.DeleteNote(context.Background(), userID, noteID, nil))
+
+	err = repo.RestoreNote(context.Background(), uuid.New(), noteID)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	require.NoError(t, repo.RestoreNote(context.Background(), userID, noteID))
+
+	_, err = noteRepo.Note(context.Background(), userID, noteID)
+	require.NoError(t, err)
+}
+
+func TestTrashR_PurgeNote(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	userID := createTestUser(t, tx)
+	noteID := createTaggedNote(t, tx, userID)
+
+	noteRepo := NewNoteRepository(tx, logger.LoggerForTest())
+	repo := NewTrashRepository(tx, logger.LoggerForTest())
+
+	err = repo.PurgeNote(context.Background(), userID, noteID)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	require.NoError(t, noteRepo.DeleteNote(context.Background(), userID, noteID, nil))
+	require.NoError(t, repo.PurgeNote(context.Background(), userID, noteID))
+
+	err = repo.RestoreNote(context.Background(), userID, noteID)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestTrashR_EmptyTrash(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	userID := createTestUser(t, tx)
+	noteRepo := NewNoteRepository(tx, logger.LoggerForTest())
+	repo := NewTrashRepository(tx, logger.LoggerForTest())
+
+	for range 2 {
+		noteID := createTaggedNote(t, tx, userID)
+		require.NoError(t, noteRepo.DeleteNote(context.Background(), userID, noteID, nil))
+	}
+	keptID := createTaggedNote(t, tx, userID)
+
+	noteIDs, err := repo.TrashedNoteIDs(context.Background(), userID)
+	require.NoError(t, err)
+	assert.Len(t, noteIDs, 2)
+	assert.NotContains(t, noteIDs, keptID)
+
+	purged, err := repo.EmptyTrash(context.Background(), userID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), purged)
+
+	_, err = noteRepo.Note(context.Background(), userID, keptID)
+	require.NoError(t, err)
+}
+
+func TestTrashR_PurgeTrash(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	userID := createTestUser(t, tx)
+	expiredID := createTaggedNote(t, tx, userID)
+	recentID := createTaggedNote(t, tx, userID)
+
+	_, err = tx.Exec(`UPDATE notes SET deleted_at=$1 WHERE id=$2`, time.Now().UTC().Add(-48*time.Hour), expiredID)
+	require.NoError(t, err)
+	_, err = tx.Exec(`UPDATE notes SET deleted_at=$1 WHERE id=$2`, time.Now().UTC(), recentID)
+	require.NoError(t, err)
+
+	repo := NewTrashRepository(tx, logger.LoggerForTest())
+
+	_, err = repo.PurgeTrash(context.Background(), time.Now().Add(-24*time.Hour))
+	require.NoError(t, err)
+
+	trashed, total, err := repo.TrashedNotes(context.Background(), userID, dto.Paginated{Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, 1, total)
+	assert.Equal(t, recentID, trashed[0].ID)
+}
@@ -0,0 +1,280 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"noteApp/internal/models/domain"
+	"noteApp/pkg/logger"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+type TwoFactorR struct {
+	db  query
+	log *logger.Logger
+}
+
+func NewTwoFactorRepository(db query, log *logger.Logger) *TwoFactorR {
+	return &TwoFactorR{
+		db:  db,
+		log: log,
+	}
+}
+
+func (t *TwoFactorR) TwoFactor(ctx context.Context, userID uuid.UUID) (domain.TwoFactor, error) {
+	query := `SELECT user_id, secret, last_step, confirmed_at, created_at FROM user_totp WHERE user_id=$1`
+
+	var tf domain.TwoFactor
+	err := t.db.QueryRowContext(ctx, query, userID).Scan(
+		&tf.UserID,
+		&tf.Secret,
+		&tf.LastStep,
+		&tf.ConfirmedAt,
+		&tf.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.TwoFactor{}, domain.MakeError(domain.ErrReceiving, domain.ErrNotFound, "two-factor")
+		}
+		t.log.Error("database error in TwoFactor query",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return domain.TwoFactor{}, domain.MakeError(domain.ErrReceiving, err, "two-factor")
+	}
+
+	return tf, nil
+}
+
+func (t *TwoFactorR) SaveTwoFactor(ctx context.Context, tf domain.TwoFactor) error {
+	query := `
+		INSERT INTO user_totp (user_id, secret, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE SET secret=EXCLUDED.secret, last_step=NULL, created_at=EXCLUDED.created_at
+		WHERE user_totp.confirmed_at IS NULL`
+
+	result, err := t.db.ExecContext(ctx, query, tf.UserID, tf.Secret, tf.CreatedAt.UTC())
+	if err != nil {
+		t.log.Error("failed to execute INSERT query in SaveTwoFactor",
+			zap.Error(err),
+			zap.String("user_id", tf.UserID.String()),
+		)
+		return domain.MakeError(domain.ErrFailedToCreate, err, "two-factor")
+	}
+
+	return t.checkAffected(result, domain.ErrFailedToCreate, domain.ErrTwoFactorEnabled, "two-factor")
+}
+
+func (t *TwoFactorR) ConfirmTwoFactor(ctx context.Context, userID uuid.UUID, step int64) error {
+	query := `UPDATE user_totp SET confirmed_at=NOW(), last_step=$2 WHERE user_id=$1 AND confirmed_at IS NULL`
+
+	result, err := t.db.ExecContext(ctx, query, userID, step)
+	if err != nil {
+		t.log.Error("failed to execute UPDATE query in ConfirmTwoFactor",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return domain.MakeError(domain.ErrFailedToUpdate, err, "two-factor")
+	}
+
+	return t.checkAffected(result, domain.ErrFailedToUpdate, domain.ErrNotFound, "two-factor")
+}
+
+func (t *TwoFactorR) UseTwoFactorStep(ctx context.Context, userID uuid.UUID, step int64) error {
+	query := `
+		UPDATE user_totp SET last_step=$2
+		WHERE user_id=$1 AND confirmed_at IS NOT NULL AND (last_step IS NULL OR last_step < $2)`
+
+	result, err := t.db.ExecContext(ctx, query, userID, step)
+	if err != nil {
+		t.log.Error("failed to execute UPDATE query in UseTwoFactorStep",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return domain.MakeError(domain.ErrFailedToUpdate, err, "two-factor")
+	}
+
+	return t.checkAffected(result, domain.ErrFailedToUpdate, domain.ErrInvalidOTP, "two-factor")
+}
+
+func (t *TwoFactorR) DeleteTwoFactor(ctx context.Context, userID uuid.UUID) error {
+	query := `
+		WITH codes AS (
+			DELETE FROM recovery_codes WHERE user_id=$1
+		)
+		DELETE FROM user_totp WHERE user_id=$1`
+
+	result, err := t.db.ExecContext(ctx, query, userID)
+	if err != nil {
+		t.log.Error("failed to execute DELETE query in DeleteTwoFactor",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return domain.MakeError(domain.ErrFailedToDelete, err, "two-factor")
+	}
+
+	return t.checkAffected(result, domain.ErrFailedToDelete, domain.ErrNotFound, "two-factor")
+}
+
+func (t *TwoFactorR) ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string) error {
+	query := `
+		WITH deleted AS (
+			DELETE FROM recovery_codes WHERE user_id=$1
+		)
+		INSERT INTO recovery_codes (user_id, code_hash, created_at)
+		SELECT $1, code_hash, NOW() FROM unnest($2::text[]) AS code_hash`
+
+	_, err := t.db.ExecContext(ctx, query, userID, pq.StringArray(codeHashes))
+	if err != nil {
+		t.log.Error("failed to execute INSERT query in ReplaceRecoveryCodes",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return domain.MakeError(domain.ErrFailedToCreate, err, "recovery codes")
+	}
+
+	return nil
+}
+
+func (t *TwoFactorR) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) error {
+	query := `UPDATE recovery_codes SET used_at=NOW() WHERE user_id=$1 AND code_hash=$2 AND used_at IS NULL`
+
+	result, err := t.db.ExecContext(ctx, query, userID, codeHash)
+	if err != nil {
+		t.log.Error("failed to execute UPDATE query in UseRecoveryCode",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return domain.MakeError(domain.ErrFailedToUpdate, err, "recovery code")
+	}
+
+	return t.checkAffected(result, domain.ErrFailedToUpdate, domain.ErrNotFound, "recovery code")
+}
+
+func (t *TwoFactorR) CreateTwoFactorChallenge(ctx context.Context, challenge domain.TwoFactorChallenge) error {
+	query := `
+		WITH replaced AS (
+			DELETE FROM two_factor_challenges WHERE user_id=$2
+		)
+		INSERT INTO two_factor_challenges (token_hash, user_id, attempts, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	_, err := t.db.ExecContext(ctx, query, challenge.TokenHash, challenge.UserID, challenge.Attempts, challenge.ExpiresAt.UTC(), challenge.CreatedAt.UTC())
+	if err != nil {
+		t.log.Error("failed to execute INSERT query in CreateTwoFactorChallenge",
+			zap.Error(err),
+			zap.String("user_id", challenge.UserID.String()),
+		)
+		return domain.MakeError(domain.ErrFailedToCreate, err, "two-factor challenge")
+	}
+
+	return nil
+}
+
+func (t *TwoFactorR) TwoFactorChallenge(ctx context.Context, tokenHash string) (domain.TwoFactorChallenge, error) {
+	query := `
+		SELECT token_hash, user_id, attempts, expires_at, created_at
+		FROM two_factor_challenges
+		WHERE token_hash=$1`
+
+	var challenge domain.TwoFactorChallenge
+	err := t.db.QueryRowContext(ctx, query, tokenHash).Scan(
+		&challenge.TokenHash,
+		&challenge.UserID,
+		&challenge.Attempts,
+		&challenge.ExpiresAt,
+		&challenge.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.TwoFactorChallenge{}, domain.MakeError(domain.ErrReceiving, domain.ErrNotFound, "two-factor challenge")
+		}
+		t.log.Error("database error in TwoFactorChallenge query",
+			zap.Error(err),
+		)
+		return domain.TwoFactorChallenge{}, domain.MakeError(domain.ErrReceiving, err, "two-factor challenge")
+	}
+
+	return challenge, nil
+}
+
+func (t *TwoFactorR) UserTwoFactorChallenge(ctx context.Context, userID uuid.UUID) (domain.TwoFactorChallenge, error) {
+	query := `
+		SELECT token_hash, user_id, attempts, expires_at, created_at
+		FROM two_factor_challenges
+		WHERE user_id=$1
+		ORDER BY created_at DESC
+		LIMIT 1`
+
+	var challenge domain.TwoFactorChallenge
+	err := t.db.QueryRowContext(ctx, query, userID).Scan(
+		&challenge.TokenHash,
+		&challenge.UserID,
+		&challenge.Attempts,
+		&challenge.ExpiresAt,
+		&challenge.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.TwoFactorChallenge{}, domain.MakeError(domain.ErrReceiving, domain.ErrNotFound, "two-factor challenge")
+		}
+		t.log.Error("database error in UserTwoFactorChallenge query",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return domain.TwoFactorChallenge{}, domain.MakeError(domain.ErrReceiving, err, "two-factor challenge")
+	}
+
+	return challenge, nil
+}
+
+func (t *TwoFactorR) FailTwoFactorChallenge(ctx context.Context, tokenHash string) (int, error) {
+	query := `UPDATE two_factor_challenges SET attempts=attempts+1 WHERE token_hash=$1 RETURNING attempts`
+
+	var attempts int
+	err := t.db.QueryRowContext(ctx, query, tokenHash).Scan(&attempts)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, domain.MakeError(domain.ErrFailedToUpdate, domain.ErrNotFound, "two-factor challenge")
+		}
+		t.log.Error("failed to execute UPDATE query in FailTwoFactorChallenge",
+			zap.Error(err),
+		)
+		return 0, domain.MakeError(domain.ErrFailedToUpdate, err, "two-factor challenge")
+	}
+
+	return attempts, nil
+}
+
+func (t *TwoFactorR) DeleteTwoFactorChallenge(ctx context.Context, tokenHash string) error {
+	query := `DELETE FROM two_factor_challenges WHERE token_hash=$1`
+
+	result, err := t.db.ExecContext(ctx, query, tokenHash)
+	if err != nil {
+		t.log.Error("failed to execute DELETE query in DeleteTwoFactorChallenge",
+			zap.Error(err),
+		)
+		return domain.MakeError(domain.ErrFailedToDelete, err, "two-factor challenge")
+	}
+
+	return t.checkAffected(result, domain.ErrFailedToDelete, domain.ErrNotFound, "two-factor challenge")
+}
+
+func (t *TwoFactorR) checkAffected(result sql.Result, dErr, zeroErr error, object string) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		t.log.Error("failed to get rows affected",
+			zap.Error(err),
+			zap.String("object", object),
+		)
+		return domain.MakeError(dErr, err, object)
+	}
+
+	if rowsAffected == 0 {
+		return domain.MakeError(dErr, zeroErr, object)
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"noteApp/internal/models/domain"
+	"noteApp/pkg/logger"
+	"noteApp/pkg/utils"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTwoFactorR_EnrollAndConfirm(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	userID := createTestUser(t, tx)
+	repo := NewTwoFactorRepository(tx, logger.LoggerForTest())
+
+	_, err = repo.TwoFactor(context.Background(), userID)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	require.NoError(t, repo.SaveTwoFactor(context.Background(), domain.TwoFactor{UserID: userID, Secret: "FIRST", CreatedAt: time.Now()}))
+	require.NoError(t, repo.SaveTwoFactor(context.Background(), domain.TwoFactor{UserID: userID, Secret: "SECOND", CreatedAt: time.Now()}))
+
+	tf, err := repo.TwoFactor(context.Background(), userID)
+	require.NoError(t, err)
+	assert.Equal(t, "SECOND", tf.Secret)
+	assert.False(t, tf.Enabled())
+
+	require.NoError(t, repo.ConfirmTwoFactor(context.Background(), userID, 100))
+
+	tf, err = repo.TwoFactor(context.Background(), userID)
+	require.NoError(t, err)
+	assert.True(t, tf.Enabled())
+	require.NotNil(t, tf.LastStep)
+	assert.Equal(t, int64(100), *tf.LastStep)
+
+	err = repo.SaveTwoFactor(context.Background(), domain.TwoFactor{UserID: userID, Secret: "THIRD", CreatedAt: time.Now()})
+	require.ErrorIs(t, err, domain.ErrTwoFactorEnabled)
+
+	err = repo.UseTwoFactorStep(context.Background(), userID, 100)
+	require.ErrorIs(t, err, domain.ErrInvalidOTP)
+
+	require.NoError(t, repo.UseTwoFactorStep(context.Background(), userID, 101))
+
+	require.NoError(t, repo.DeleteTwoFactor(context.Background(), userID))
+
+	_, err = repo.TwoFactor(context.Background(), userID)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestTwoFactorR_RecoveryCodes(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	userID := createTestUser(t, tx)
+	repo := NewTwoFactorRepository(tx, logger.LoggerForTest())
+
+	oldHash := utils.HashToken("old")
+	newHash := utils.HashToken("new")
+
+	require.NoError(t, repo.ReplaceRecoveryCodes(context.Background(), userID, []string{oldHash}))
+	require.NoError(t, repo.ReplaceRecoveryCodes(context.Background(), userID, []string{newHash}))
+
+	err = repo.UseRecoveryCode(context.Background(), userID, oldHash)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	require.NoError(t, repo.UseRecoveryCode(context.Background(), userID, newHash))
+
+	err = repo.UseRecoveryCode(context.Background(), userID, newHash)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestTwoFactorR_Challenges(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	userID := createTestUser(t, tx)
+	repo := NewTwoFactorRepository(tx, logger.LoggerForTest())
+
+	hash := utils.HashToken(uuid.NewString())
+	require.NoError(t, repo.CreateTwoFactorChallenge(context.Background(), domain.TwoFactorChallenge{
+		TokenHash: hash,
+		UserID:    userID,
+		ExpiresAt: time.Now().Add(time.Minute),
+		CreatedAt: time.Now(),
+	}))
+
+	challenge, err := repo.TwoFactorChallenge(context.Background(), hash)
+	require.NoError(t, err)
+	assert.Equal(t, userID, challenge.UserID)
+	assert.Equal(t, 0, challenge.Attempts)
+
+	attempts, err := repo.FailTwoFactorChallenge(context.Background(), hash)
+	require.NoError(t, err)
+	assert.Equal(t, 1, attempts)
+
+	require.NoError(t, repo.DeleteTwoFactorChallenge(context.Background(), hash))
+
+	_, err = repo.TwoFactorChallenge(context.Background(), hash)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	_, err = repo.FailTwoFactorChallenge(context.Background(), hash)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	err = repo.DeleteTwoFactorChallenge(context.Background(), hash)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestTwoFactorR_ReplaceChallenge(t *testing.T) {
+	t.Parallel()
+
+	tx, err := globalTestDB.BeginTxx(context.Background(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = tx.Rollback() })
+
+	userID := createTestUser(t, tx)
+	repo := NewTwoFactorRepository(tx, logger.LoggerForTest())
+
+	_, err = repo.UserTwoFactorChallenge(context.Background(), userID)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	first := utils.HashToken(uuid.NewString())
+	require.NoError(t, repo.CreateTwoFactorChallenge(context.Background(), domain.TwoFactorChallenge{
+		TokenHash: first,
+		UserID:    userID,
+		ExpiresAt: time.Now().Add(time.Minute),
+		CreatedAt: time.Now(),
+	}))
+
+	second := utils.HashToken(uuid.NewString())
+	require.NoError(t, repo.CreateTwoFactorChallenge(context.Background(), domain.TwoFactorChallenge{
+		TokenHash: second,
+		UserID:    userID,
+		Attempts:  3,
+		ExpiresAt: time.Now().Add(time.Minute),
+		CreatedAt: time.Now(),
+	}))
+
+	_, err = repo.TwoFactorChallenge(context.Background(), first)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	challenge, err := repo.UserTwoFactorChallenge(context.Background(), userID)
+	require.NoError(t, err)
+	assert.Equal(t, second, challenge.TokenHash)
+	assert.Equal(t, 3, challenge.Attempts)
+}
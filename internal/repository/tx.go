@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"noteApp/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+type txKey struct{}
+
+type txBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+type TxManager struct {
+	db  query
+	log *logger.Logger
+}
+
+func NewTxManager(db query, log *logger.Logger) *TxManager {
+	return &TxManager{
+		db:  db,
+		log: log,
+	}
+}
+
+func (m *TxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	beginner, ok := m.db.(txBeginner)
+	if !ok {
+		return fn(ctx)
+	}
+
+	tx, err := beginner.BeginTx(ctx, nil)
+	if err != nil {
+		m.log.Error("failed to begin transaction", zap.Error(err))
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			m.rollback(tx)
+			panic(p)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		m.rollback(tx)
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		m.log.Error("failed to commit transaction", zap.Error(err))
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (m *TxManager) rollback(tx *sql.Tx) {
+	if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+		m.log.Error("failed to rollback transaction", zap.Error(err))
+	}
+}
+
+type txQuery struct {
+	db query
+}
+
+func (q txQuery) conn(ctx context.Context) query {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return q.db
+}
+
+func (q txQuery) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return q.conn(ctx).ExecContext(ctx, query, args...)
+}
+
+func (q txQuery) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return q.conn(ctx).QueryRowContext(ctx, query, args...)
+}
+
+func (q txQuery) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return q.conn(ctx).QueryContext(ctx, query, args...)
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"noteApp/internal/models/domain"
+	"noteApp/pkg/logger"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestToken(t *testing.T, repo repository, userID uuid.UUID) string {
+	t.Helper()
+
+	tokenID := uuid.New().String()
+	require.NoError(t, repo.CreateToken(context.Background(), domain.Token{
+		UserID:    userID,
+		TokenID:   tokenID,
+		ExpiresAt: time.Now().Add(time.Hour).UTC(),
+	}))
+
+	return tokenID
+}
+
+func rotateToken(ctx context.Context, repo repository, userID uuid.UUID, oldID, newID string) error {
+	if err := repo.DeleteToken(ctx, oldID); err != nil {
+		return err
+	}
+	return repo.CreateToken(ctx, domain.Token{
+		UserID:    userID,
+		TokenID:   newID,
+		ExpiresAt: time.Now().Add(time.Hour).UTC(),
+	})
+}
+
+func TestTxManager_WithinTx(t *testing.T) {
+	t.Parallel()
+
+	userID := createCommittedTestUser(t)
+	repo := NewRepository(globalTestDB, logger.LoggerForTest())
+	errRotate := errors.New("rotate failed")
+
+	t.Run("commit", func(t *testing.T) {
+		oldID := createTestToken(t, repo, userID)
+		newID := uuid.New().String()
+
+		err := repo.WithinTx(context.Background(), func(ctx context.Context) error {
+			return rotateToken(ctx, repo, userID, oldID, newID)
+		})
+		require.NoError(t, err)
+
+		_, err = repo.Token(context.Background(), oldID)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		_, err = repo.Token(context.Background(), newID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("rollback on error", func(t *testing.T) {
+		oldID := createTestToken(t, repo, userID)
+		newID := uuid.New().String()
+
+		err := repo.WithinTx(context.Background(), func(ctx context.Context) error {
+			if err := rotateToken(ctx, repo, userID, oldID, newID); err != nil {
+				return err
+			}
+			return errRotate
+		})
+		require.ErrorIs(t, err, errRotate)
+
+		_, err = repo.Token(context.Background(), oldID)
+		assert.NoError(t, err)
+		_, err = repo.Token(context.Background(), newID)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("rollback on panic", func(t *testing.T) {
+		oldID := createTestToken(t, repo, userID)
+		newID := uuid.New().String()
+
+		assert.Panics(t, func() {
+			_ = repo.WithinTx(context.Background(), func(ctx context.Context) error {
+				if err := rotateToken(ctx, repo, userID, oldID, newID); err != nil {
+					return err
+				}
+				panic("rotate panicked")
+			})
+		})
+
+		_, err := repo.Token(context.Background(), oldID)
+		assert.NoError(t, err)
+		_, err = repo.Token(context.Background(), newID)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("nested joins outer transaction", func(t *testing.T) {
+		oldID := createTestToken(t, repo, userID)
+		newID := uuid.New().String()
+
+		err := repo.WithinTx(context.Background(), func(ctx context.Context) error {
+			if err := repo.WithinTx(ctx, func(ctx context.Context) error {
+				return rotateToken(ctx, repo, userID, oldID, newID)
+			}); err != nil {
+				return err
+			}
+			return errRotate
+		})
+		require.ErrorIs(t, err, errRotate)
+
+		_, err = repo.Token(context.Background(), oldID)
+		assert.NoError(t, err)
+	})
+}
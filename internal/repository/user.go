@@ -42,7 +42,7 @@ func (u *UserR) CreateUser(ctx context.Context, user domain.User) error {
 }
 
 func (u *UserR) UserByID(ctx context.Context, userID uuid.UUID) (domain.User, error) {
-	query := `SELECT id, username, email, image_url, created_at, updated_at FROM users WHERE id = $1`
+	query := `SELECT id, username, email, image_url, role, disabled_at, verified_at, created_at, updated_at FROM users WHERE id = $1`
 
 	var user domain.User
 	row := u.db.QueryRowContext(ctx, query, userID)
@@ -51,6 +51,9 @@ func (u *UserR) UserByID(ctx context.Context, userID uuid.UUID) (domain.User, er
 		&user.Username,
 		&user.Email,
 		&user.ImageURL,
+		&user.Role,
+		&user.DisabledAt,
+		&user.VerifiedAt,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	); err != nil {
@@ -98,6 +101,7 @@ func (u *UserR) UpdateUser(ctx context.Context, user domain.UserUpdate) error {
 
 	utils.AddFieldsToQuery("username", user.Username, &fields, &args, &argIdx)
 	utils.AddFieldsToQuery("email", user.Email, &fields, &args, &argIdx)
+	emailIdx := argIdx
 	utils.AddFieldsToQuery("password", user.Password, &fields, &args, &argIdx)
 	utils.AddFieldsToQuery("image_url", user.ImageURL, &fields, &args, &argIdx)
 
@@ -105,6 +109,10 @@ func (u *UserR) UpdateUser(ctx context.Context, user domain.UserUpdate) error {
 		return domain.MakeError(domain.ErrFailedToUpdate, domain.ErrNoFieldsToUpdate, "user")
 	}
 
+	if user.Email != nil {
+		fields = append(fields, fmt.Sprintf("verified_at=CASE WHEN email=$%v THEN verified_at END", emailIdx))
+	}
+
 	fields = append(fields, "updated_at=NOW()")
 
 	query := fmt.Sprintf(`UPDATE users SET %v WHERE id=$%v`, strings.Join(fields, ", "), argIdx+1)
@@ -162,3 +170,31 @@ func (u *UserR) DeleteUser(ctx context.Context, userID uuid.UUID) error {
 
 	return nil
 }
+
+func (u *UserR) MarkEmailVerified(ctx context.Context, userID uuid.UUID) error {
+	query := `UPDATE users SET verified_at=COALESCE(verified_at, NOW()) WHERE id=$1`
+
+	result, err := u.db.ExecContext(ctx, query, userID)
+	if err != nil {
+		u.log.Error("failed to execute UPDATE query in MarkEmailVerified",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return domain.MakeError(domain.ErrFailedToUpdate, err, "user")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		u.log.Error("failed to get rows affected after UPDATE",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return domain.MakeError(domain.ErrFailedToUpdate, err, "user")
+	}
+
+	if rowsAffected == 0 {
+		return domain.MakeError(domain.ErrFailedToUpdate, domain.ErrNotFound, "user")
+	}
+
+	return nil
+}
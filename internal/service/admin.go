@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type AdminRI interface {
+	Users(ctx context.Context, p dto.Paginated) ([]domain.User, int, error)
+	SetUserDisabled(ctx context.Context, userID uuid.UUID, disabled bool) error
+	UpdateUser(ctx context.Context, user domain.UserUpdate) error
+	DeleteSessions(ctx context.Context, userID uuid.UUID) error
+}
+
+type AdminS struct {
+	repo   AdminRI
+	tx     TxManagerI
+	hasher HasherI
+	log    *logger.Logger
+}
+
+func NewAdminService(repo AdminRI, tx TxManagerI, hasher HasherI, log *logger.Logger) *AdminS {
+	return &AdminS{
+		repo:   repo,
+		tx:     tx,
+		hasher: hasher,
+		log:    log,
+	}
+}
+
+func (a *AdminS) Users(ctx context.Context, p dto.Paginated) (dto.PaginatedResponse, error) {
+	usersDB, total, err := a.repo.Users(ctx, p)
+	if err != nil {
+		a.log.Error("failed to get users from repository",
+			zap.Error(err),
+			zap.Int("limit", p.Limit),
+			zap.Int("offset", p.Offset),
+		)
+		return dto.PaginatedResponse{}, err
+	}
+
+	if total == 0 {
+		return dto.PaginatedResponse{}, nil
+	}
+
+	users := make([]dto.AdminUserOutput, 0, len(usersDB))
+	for _, v := range usersDB {
+		users = append(users, adminUserDomainToDTO(v))
+	}
+
+	return dto.MakePaginatedResponse(users, total, p.Offset, p.Limit), nil
+}
+
+func (a *AdminS) DisableUser(ctx context.Context, adminID, userID uuid.UUID) error {
+	if adminID == userID {
+		a.log.Warn("admin attempted to disable own account",
+			zap.String("admin_id", adminID.String()),
+		)
+		return domain.ErrForbidden
+	}
+
+	err := a.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := a.repo.SetUserDisabled(ctx, userID, true); err != nil {
+			return err
+		}
+
+		return a.repo.DeleteSessions(ctx, userID)
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			a.log.Warn("user not found during disable",
+				zap.String("admin_id", adminID.String()),
+				zap.String("user_id", userID.String()),
+			)
+		} else {
+			a.log.Error("failed to disable user",
+				zap.Error(err),
+				zap.String("admin_id", adminID.String()),
+				zap.String("user_id", userID.String()),
+			)
+		}
+		return err
+	}
+
+	a.log.Info("user disabled by admin",
+		zap.String("admin_id", adminID.String()),
+		zap.String("user_id", userID.String()),
+	)
+
+	return nil
+}
+
+func (a *AdminS) EnableUser(ctx context.Context, adminID, userID uuid.UUID) error {
+	if err := a.repo.SetUserDisabled(ctx, userID, false); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			a.log.Warn("user not found during enable",
+				zap.String("admin_id", adminID.String()),
+				zap.String("user_id", userID.String()),
+			)
+		} else {
+			a.log.Error("failed to enable user",
+				zap.Error(err),
+				zap.String("admin_id", adminID.String()),
+				zap.String("user_id", userID.String()),
+			)
+		}
+		return err
+	}
+
+	a.log.Info("user enabled by admin",
+		zap.String("admin_id", adminID.String()),
+		zap.String("user_id", userID.String()),
+	)
+
+	return nil
+}
+
+func (a *AdminS) ResetUserPassword(ctx context.Context, adminID uuid.UUID, reset dto.AdminPasswordReset) error {
+	hashPass, err := a.hasher.GenerateHash(reset.NewPassword)
+	if err != nil {
+		a.log.Error("failed to hash password during admin reset",
+			zap.Error(err),
+			zap.String("user_id", reset.UserID.String()),
+		)
+		return err
+	}
+
+	err = a.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := a.repo.UpdateUser(ctx, domain.UserUpdate{
+			ID:       reset.UserID,
+			Password: &hashPass,
+		}); err != nil {
+			return err
+		}
+
+		return a.repo.DeleteSessions(ctx, reset.UserID)
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			a.log.Warn("user not found during password reset",
+				zap.String("admin_id", adminID.String()),
+				zap.String("user_id", reset.UserID.String()),
+			)
+		} else {
+			a.log.Error("failed to reset user password",
+				zap.Error(err),
+				zap.String("admin_id", adminID.String()),
+				zap.String("user_id", reset.UserID.String()),
+			)
+		}
+		return err
+	}
+
+	a.log.Info("user password reset by admin",
+		zap.String("admin_id", adminID.String()),
+		zap.String("user_id", reset.UserID.String()),
+	)
+
+	return nil
+}
+
+func adminUserDomainToDTO(user domain.User) dto.AdminUserOutput {
+	return dto.AdminUserOutput{
+		UserOutput: dto.UserOutput{
+			ID:        user.ID,
+			Username:  user.Username,
+			Email:     user.Email,
+			ImageURL:  user.ImageURL,
+			CreatedAt: user.CreatedAt,
+			UpdatedAt: user.UpdatedAt,
+		},
+		Role:       user.Role,
+		DisabledAt: user.DisabledAt,
+	}
+}
@@ -0,0 +1,294 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	mock_service "noteApp/internal/service/mock"
+	"noteApp/pkg/logger"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockAdminService(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock_service.MockRepositoryI, *mock_service.MockHasherI)) *AdminS {
+	t.Helper()
+
+	repo := mock_service.NewMockRepositoryI(ctrl)
+	hasher := mock_service.NewMockHasherI(ctrl)
+	if setupMock != nil {
+		setupMock(repo, hasher)
+	}
+
+	return NewAdminService(repo, &fakeTx{}, hasher, logger.LoggerForTest())
+}
+
+func TestAdminS_Users(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	disabledAt := time.Now()
+	p := dto.Paginated{Limit: 10}
+
+	tests := []struct {
+		name    string
+		f       func(*mock_service.MockRepositoryI, *mock_service.MockHasherI)
+		want    dto.PaginatedResponse
+		wantErr bool
+	}{
+		{
+			name: "success",
+			f: func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+				mri.EXPECT().Users(gomock.Any(), p).Return([]domain.User{
+					{ID: userID, Username: "alice", Email: "alice@example.com", Role: domain.UserRoleUser, DisabledAt: &disabledAt},
+				}, 1, nil)
+			},
+			want: dto.MakePaginatedResponse([]dto.AdminUserOutput{
+				{
+					UserOutput: dto.UserOutput{ID: userID, Username: "alice", Email: "alice@example.com"},
+					Role:       domain.UserRoleUser,
+					DisabledAt: &disabledAt,
+				},
+			}, 1, 0, 10),
+		},
+		{
+			name: "no users",
+			f: func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+				mri.EXPECT().Users(gomock.Any(), p).Return(nil, 0, nil)
+			},
+			want: dto.PaginatedResponse{},
+		},
+		{
+			name: "repository error",
+			f: func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+				mri.EXPECT().Users(gomock.Any(), p).Return(nil, 0, errors.New("db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			a := mockAdminService(t, ctrl, tt.f)
+
+			got, err := a.Users(context.Background(), p)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAdminS_DisableUser(t *testing.T) {
+	t.Parallel()
+
+	adminID := uuid.New()
+	userID := uuid.New()
+
+	tests := []struct {
+		name          string
+		userID        uuid.UUID
+		f             func(*mock_service.MockRepositoryI, *mock_service.MockHasherI)
+		wantErr       error
+		wantCommits   int
+		wantRollbacks int
+	}{
+		{
+			name:   "success",
+			userID: userID,
+			f: func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+				mri.EXPECT().SetUserDisabled(gomock.Any(), userID, true).DoAndReturn(func(ctx context.Context, _ uuid.UUID, _ bool) error {
+					assert.True(t, inFakeTx(ctx))
+					return nil
+				})
+				mri.EXPECT().DeleteSessions(gomock.Any(), userID).DoAndReturn(func(ctx context.Context, _ uuid.UUID) error {
+					assert.True(t, inFakeTx(ctx))
+					return nil
+				})
+			},
+			wantCommits: 1,
+		},
+		{
+			name:    "own account",
+			userID:  adminID,
+			wantErr: domain.ErrForbidden,
+		},
+		{
+			name:   "user not found",
+			userID: userID,
+			f: func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+				mri.EXPECT().SetUserDisabled(gomock.Any(), userID, true).Return(domain.MakeError(domain.ErrFailedToUpdate, domain.ErrNotFound, "user"))
+			},
+			wantErr:       domain.ErrNotFound,
+			wantRollbacks: 1,
+		},
+		{
+			name:   "failed to revoke sessions",
+			userID: userID,
+			f: func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+				mri.EXPECT().SetUserDisabled(gomock.Any(), userID, true).Return(nil)
+				mri.EXPECT().DeleteSessions(gomock.Any(), userID).Return(domain.ErrFailedToDelete)
+			},
+			wantErr:       domain.ErrFailedToDelete,
+			wantRollbacks: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			a := mockAdminService(t, ctrl, tt.f)
+
+			err := a.DisableUser(context.Background(), adminID, tt.userID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			tx := a.tx.(*fakeTx)
+			assert.Equal(t, tt.wantCommits, tx.commits)
+			assert.Equal(t, tt.wantRollbacks, tx.rollbacks)
+		})
+	}
+}
+
+func TestAdminS_EnableUser(t *testing.T) {
+	t.Parallel()
+
+	adminID := uuid.New()
+	userID := uuid.New()
+
+	tests := []struct {
+		name    string
+		f       func(*mock_service.MockRepositoryI, *mock_service.MockHasherI)
+		wantErr error
+	}{
+		{
+			name: "success",
+			f: func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+				mri.EXPECT().SetUserDisabled(gomock.Any(), userID, false).Return(nil)
+			},
+		},
+		{
+			name: "user not found",
+			f: func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+				mri.EXPECT().SetUserDisabled(gomock.Any(), userID, false).Return(domain.MakeError(domain.ErrFailedToUpdate, domain.ErrNotFound, "user"))
+			},
+			wantErr: domain.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			a := mockAdminService(t, ctrl, tt.f)
+
+			err := a.EnableUser(context.Background(), adminID, userID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestAdminS_ResetUserPassword(t *testing.T) {
+	t.Parallel()
+
+	adminID := uuid.New()
+	userID := uuid.New()
+	reset := dto.AdminPasswordReset{UserID: userID, NewPassword: "new_password"}
+
+	tests := []struct {
+		name          string
+		f             func(*mock_service.MockRepositoryI, *mock_service.MockHasherI)
+		wantErr       bool
+		wantCommits   int
+		wantRollbacks int
+	}{
+		{
+			name: "success",
+			f: func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+				mhi.EXPECT().GenerateHash("new_password").Return("hashed", nil)
+				mri.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, user domain.UserUpdate) error {
+					assert.True(t, inFakeTx(ctx))
+					assert.Equal(t, userID, user.ID)
+					require.NotNil(t, user.Password)
+					assert.Equal(t, "hashed", *user.Password)
+					return nil
+				})
+				mri.EXPECT().DeleteSessions(gomock.Any(), userID).Return(nil)
+			},
+			wantCommits: 1,
+		},
+		{
+			name: "hash error",
+			f: func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+				mhi.EXPECT().GenerateHash("new_password").Return("", errors.New("hash error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "user not found",
+			f: func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+				mhi.EXPECT().GenerateHash("new_password").Return("hashed", nil)
+				mri.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Return(domain.MakeError(domain.ErrFailedToUpdate, domain.ErrNotFound, "user"))
+			},
+			wantErr:       true,
+			wantRollbacks: 1,
+		},
+		{
+			name: "failed to revoke sessions",
+			f: func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+				mhi.EXPECT().GenerateHash("new_password").Return("hashed", nil)
+				mri.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Return(nil)
+				mri.EXPECT().DeleteSessions(gomock.Any(), userID).Return(domain.ErrFailedToDelete)
+			},
+			wantErr:       true,
+			wantRollbacks: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			a := mockAdminService(t, ctrl, tt.f)
+
+			err := a.ResetUserPassword(context.Background(), adminID, reset)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			tx := a.tx.(*fakeTx)
+			assert.Equal(t, tt.wantCommits, tx.commits)
+			assert.Equal(t, tt.wantRollbacks, tx.rollbacks)
+		})
+	}
+}
@@ -8,40 +8,74 @@ import (
 	"noteApp/internal/models/domain"
 	"noteApp/internal/models/dto"
 	"noteApp/pkg/logger"
+	"noteApp/pkg/utils"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
-	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
 	"github.com/lestrrat-go/jwx/v2/jwt"
 	"go.uber.org/zap"
 )
 
+const (
+	challengeTokenSize = 32
+)
+
 type AuthRI interface {
 	CreateUser(ctx context.Context, user domain.User) error
 	CreateToken(ctx context.Context, token domain.Token) error
 	UserCredentials(ctx context.Context, email string) (uuid.UUID, string, error)
+	UserByID(ctx context.Context, userID uuid.UUID) (domain.User, error)
 	Token(ctx context.Context, tokenID string) (domain.Token, error)
 	DeleteToken(ctx context.Context, tokenID string) error
+	RotateToken(ctx context.Context, tokenID string) error
+	DeleteTokenFamily(ctx context.Context, familyID uuid.UUID) error
+	PurgeExpiredTokens(ctx context.Context, expiredBefore time.Time) (int64, error)
+	TwoFactor(ctx context.Context, userID uuid.UUID) (domain.TwoFactor, error)
+	UseTwoFactorStep(ctx context.Context, userID uuid.UUID, step int64) error
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) error
+	CreateTwoFactorChallenge(ctx context.Context, challenge domain.TwoFactorChallenge) error
+	TwoFactorChallenge(ctx context.Context, tokenHash string) (domain.TwoFactorChallenge, error)
+	UserTwoFactorChallenge(ctx context.Context, userID uuid.UUID) (domain.TwoFactorChallenge, error)
+	FailTwoFactorChallenge(ctx context.Context, tokenHash string) (int, error)
+	DeleteTwoFactorChallenge(ctx context.Context, tokenHash string) error
+	ReserveSignInAttempt(ctx context.Context, scope, subject string, at time.Time, limit domain.SignInLimit) (domain.SignInAttempt, bool, error)
+	ReleaseSignInAttempt(ctx context.Context, scope, subject string) error
+	ResetSignInAttempts(ctx context.Context, scope, subject string) error
+}
+
+type VerifierI interface {
+	SendVerificationEmail(ctx context.Context, userID uuid.UUID) error
 }
 
 type AuthS struct {
-	repo   AuthRI
-	token  config.AuthCfg
-	hasher HasherI
-	log    *logger.Logger
+	repo     AuthRI
+	tx       TxManagerI
+	token    config.AuthCfg
+	hasher   HasherI
+	signer   SignerI
+	verifier VerifierI
+	log      *logger.Logger
 }
 
 func NewAuthService(
 	repo AuthRI,
+	tx TxManagerI,
 	hasher HasherI,
+	signer SignerI,
+	verifier VerifierI,
 	token config.AuthCfg,
 	log *logger.Logger,
 ) *AuthS {
 	return &AuthS{
-		repo:   repo,
-		hasher: hasher,
-		token:  token,
-		log:    log,
+		repo:     repo,
+		tx:       tx,
+		hasher:   hasher,
+		signer:   signer,
+		verifier: verifier,
+		token:    token,
+		log:      log,
 	}
 }
 
@@ -85,12 +119,35 @@ func (a *AuthS) SignUp(ctx context.Context, user dto.UserCreate) (uuid.UUID, err
 		zap.String("email", user.Email),
 	)
 
+	if err := a.verifier.SendVerificationEmail(ctx, input.ID); err != nil {
+		a.log.Warn("failed to send verification email after sign-up",
+			zap.String("user_id", input.ID.String()),
+			zap.Error(err),
+		)
+	}
+
 	return input.ID, nil
 }
 
-func (a *AuthS) SignIn(ctx context.Context, data dto.UserSignIn) (dto.TokenOutput, error) {
+func (a *AuthS) SignIn(ctx context.Context, data dto.UserSignIn, device dto.Device) (dto.TokenOutput, error) {
+	attempts, err := a.reserveAttempts(ctx, signInAttempts(data.Email, device.IP))
+	if err != nil {
+		return dto.TokenOutput{}, err
+	}
+
+	settled := false
+	defer func() {
+		if !settled {
+			a.releaseAttempts(ctx, attempts)
+		}
+	}()
+
 	userID, err := a.checkUser(ctx, data.Email, data.Password)
 	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) || errors.Is(err, domain.ErrIncorrectPassword) {
+			settled = true
+			a.logFailure(attempts)
+		}
 		if errors.Is(err, domain.ErrNotFound) {
 			a.log.Warn("invalid credentials during sign-in",
 				zap.String("email", data.Email),
@@ -104,7 +161,25 @@ func (a *AuthS) SignIn(ctx context.Context, data dto.UserSignIn) (dto.TokenOutpu
 		return dto.TokenOutput{}, err
 	}
 
-	token, err := a.generateAndSaveTokens(ctx, userID)
+	user, err := a.activeUser(ctx, userID)
+	if err != nil {
+		return dto.TokenOutput{}, err
+	}
+
+	tf, err := a.repo.TwoFactor(ctx, userID)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		a.log.Error("failed to get two-factor settings during sign-in",
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+		return dto.TokenOutput{}, err
+	}
+
+	if err == nil && tf.Enabled() {
+		return a.createChallenge(ctx, userID)
+	}
+
+	token, err := a.generateAndSaveTokens(ctx, user, uuid.New(), device)
 	if err != nil {
 		a.log.Error("failed to generate or save tokens",
 			zap.String("user_id", userID.String()),
@@ -113,9 +188,313 @@ func (a *AuthS) SignIn(ctx context.Context, data dto.UserSignIn) (dto.TokenOutpu
 		return dto.TokenOutput{}, err
 	}
 
+	settled = true
+	a.resetAttempts(ctx, attempts)
+
 	return token, nil
 }
 
+func (a *AuthS) SignInTwoFactor(ctx context.Context, data dto.TwoFactorSignIn, device dto.Device) (dto.TokenOutput, error) {
+	tokenHash := utils.HashToken(data.ChallengeToken)
+
+	challenge, err := a.repo.TwoFactorChallenge(ctx, tokenHash)
+	if err != nil {
+		if !errors.Is(err, domain.ErrNotFound) {
+			a.log.Error("failed to get two-factor challenge", zap.Error(err))
+		}
+		return dto.TokenOutput{}, err
+	}
+
+	if challenge.ExpiresAt.Before(time.Now()) {
+		a.log.Warn("attempt to complete expired two-factor challenge",
+			zap.String("user_id", challenge.UserID.String()),
+		)
+		if err := a.repo.DeleteTwoFactorChallenge(ctx, tokenHash); err != nil && !errors.Is(err, domain.ErrNotFound) {
+			a.log.Error("failed to delete expired two-factor challenge",
+				zap.String("user_id", challenge.UserID.String()),
+				zap.Error(err),
+			)
+		}
+		return dto.TokenOutput{}, domain.MakeError(domain.ErrReceiving, domain.ErrNotFound, "two-factor challenge")
+	}
+
+	if challenge.Locked() {
+		a.log.Warn("attempt to use locked two-factor challenge",
+			zap.String("user_id", challenge.UserID.String()),
+		)
+		return dto.TokenOutput{}, &domain.LockoutError{RetryAfter: time.Until(challenge.ExpiresAt)}
+	}
+
+	user, err := a.activeUser(ctx, challenge.UserID)
+	if err != nil {
+		return dto.TokenOutput{}, err
+	}
+
+	attempts, err := a.reserveAttempts(ctx, signInAttempts(user.Email, device.IP))
+	if err != nil {
+		return dto.TokenOutput{}, err
+	}
+
+	settled := false
+	defer func() {
+		if !settled {
+			a.releaseAttempts(ctx, attempts)
+		}
+	}()
+
+	tf, err := a.repo.TwoFactor(ctx, challenge.UserID)
+	if err != nil {
+		if !errors.Is(err, domain.ErrNotFound) {
+			a.log.Error("failed to get two-factor settings",
+				zap.String("user_id", challenge.UserID.String()),
+				zap.Error(err),
+			)
+		}
+		return dto.TokenOutput{}, err
+	}
+
+	err = a.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := a.repo.DeleteTwoFactorChallenge(ctx, tokenHash); err != nil {
+			return err
+		}
+
+		return verifyOTP(ctx, a.repo, tf, data.Code)
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidOTP) {
+			settled = true
+			a.failChallenge(ctx, challenge)
+			a.logFailure(attempts)
+		} else if !errors.Is(err, domain.ErrNotFound) {
+			a.log.Error("failed to complete two-factor challenge",
+				zap.String("user_id", challenge.UserID.String()),
+				zap.Error(err),
+			)
+		}
+		return dto.TokenOutput{}, err
+	}
+
+	token, err := a.generateAndSaveTokens(ctx, user, uuid.New(), device)
+	if err != nil {
+		a.log.Error("failed to generate or save tokens",
+			zap.String("user_id", challenge.UserID.String()),
+			zap.Error(err),
+		)
+		return dto.TokenOutput{}, err
+	}
+
+	settled = true
+	a.resetAttempts(ctx, attempts)
+
+	a.log.Info("two-factor sign-in completed",
+		zap.String("user_id", challenge.UserID.String()),
+	)
+
+	return token, nil
+}
+
+func signInAttempts(email, ip string) []domain.SignInAttempt {
+	attempts := []domain.SignInAttempt{{
+		Scope:   domain.SignInScopeAccount,
+		Subject: strings.ToLower(strings.TrimSpace(email)),
+	}}
+	if ip != "" {
+		attempts = append(attempts, domain.SignInAttempt{
+			Scope:   domain.SignInScopeIP,
+			Subject: ip,
+		})
+	}
+
+	return attempts
+}
+
+func (a *AuthS) reserveAttempts(ctx context.Context, attempts []domain.SignInAttempt) ([]domain.SignInAttempt, error) {
+	now := time.Now()
+	reserved := make([]domain.SignInAttempt, 0, len(attempts))
+	for _, key := range attempts {
+		attempt, ok, err := a.repo.ReserveSignInAttempt(ctx, key.Scope, key.Subject, now, a.signInLimit(key.Scope))
+		if err != nil {
+			a.log.Error("failed to reserve sign-in attempt",
+				zap.String("scope", key.Scope),
+				zap.String("subject", key.Subject),
+				zap.Error(err),
+			)
+			a.releaseAttempts(ctx, reserved)
+			return nil, err
+		}
+
+		if !ok {
+			until := a.lockedUntil(attempt)
+			a.log.Warn("sign-in rejected: too many failed attempts",
+				zap.String("scope", attempt.Scope),
+				zap.String("subject", attempt.Subject),
+				zap.Int("failures", attempt.Failures),
+				zap.Time("locked_until", until),
+			)
+			a.releaseAttempts(ctx, reserved)
+			return nil, &domain.LockoutError{RetryAfter: until.Sub(now)}
+		}
+
+		reserved = append(reserved, attempt)
+	}
+
+	return reserved, nil
+}
+
+func (a *AuthS) logFailure(attempts []domain.SignInAttempt) {
+	now := time.Now()
+	for _, attempt := range attempts {
+		if until := a.lockedUntil(attempt); until.After(now) {
+			a.log.Warn("security event: sign-in locked after repeated failures",
+				zap.String("event", "sign_in_lockout"),
+				zap.String("scope", attempt.Scope),
+				zap.String("subject", attempt.Subject),
+				zap.Int("failures", attempt.Failures),
+				zap.Time("locked_until", until),
+			)
+		}
+	}
+}
+
+func (a *AuthS) releaseAttempts(ctx context.Context, attempts []domain.SignInAttempt) {
+	for _, key := range attempts {
+		if err := a.repo.ReleaseSignInAttempt(ctx, key.Scope, key.Subject); err != nil {
+			a.log.Error("failed to release sign-in attempt",
+				zap.String("scope", key.Scope),
+				zap.String("subject", key.Subject),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+func (a *AuthS) resetAttempts(ctx context.Context, attempts []domain.SignInAttempt) {
+	var released []domain.SignInAttempt
+	for _, key := range attempts {
+		if key.Scope != domain.SignInScopeAccount {
+			released = append(released, key)
+			continue
+		}
+		if err := a.repo.ResetSignInAttempts(ctx, key.Scope, key.Subject); err != nil {
+			a.log.Error("failed to reset sign-in attempts",
+				zap.String("scope", key.Scope),
+				zap.String("subject", key.Subject),
+				zap.Error(err),
+			)
+		}
+	}
+
+	a.releaseAttempts(ctx, released)
+}
+
+func (a *AuthS) signInLimit(scope string) domain.SignInLimit {
+	lockout := a.token.Lockout
+
+	threshold := lockout.AccountThreshold
+	if scope == domain.SignInScopeIP {
+		threshold = lockout.IPThreshold
+	}
+
+	return domain.SignInLimit{
+		Threshold:  threshold,
+		BaseDelay:  lockout.BaseDelay,
+		MaxDelay:   lockout.MaxDelay,
+		ResetAfter: lockout.ResetAfter,
+	}
+}
+
+func (a *AuthS) lockedUntil(attempt domain.SignInAttempt) time.Time {
+	limit := a.signInLimit(attempt.Scope)
+
+	if attempt.Failures < limit.Threshold || attempt.LastFailedAt.Before(time.Now().Add(-limit.ResetAfter)) {
+		return time.Time{}
+	}
+
+	delay := limit.BaseDelay
+	for i := limit.Threshold; i < attempt.Failures && delay < limit.MaxDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, limit.MaxDelay)
+
+	return attempt.LastFailedAt.Add(delay)
+}
+
+func (a *AuthS) createChallenge(ctx context.Context, userID uuid.UUID) (dto.TokenOutput, error) {
+	now := time.Now()
+
+	previous, err := a.repo.UserTwoFactorChallenge(ctx, userID)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		a.log.Error("failed to get previous two-factor challenge",
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+		return dto.TokenOutput{}, err
+	}
+
+	attempts := 0
+	if err == nil && previous.ExpiresAt.After(now) {
+		if previous.Locked() {
+			a.log.Warn("two-factor challenge refused: too many failed attempts",
+				zap.String("user_id", userID.String()),
+				zap.Time("locked_until", previous.ExpiresAt),
+			)
+			return dto.TokenOutput{}, &domain.LockoutError{RetryAfter: previous.ExpiresAt.Sub(now)}
+		}
+		attempts = previous.Attempts
+	}
+
+	challengeToken, err := utils.RandomToken(challengeTokenSize)
+	if err != nil {
+		a.log.Error("failed to generate two-factor challenge", zap.Error(err))
+		return dto.TokenOutput{}, err
+	}
+
+	if err := a.repo.CreateTwoFactorChallenge(ctx, domain.TwoFactorChallenge{
+		TokenHash: utils.HashToken(challengeToken),
+		UserID:    userID,
+		Attempts:  attempts,
+		ExpiresAt: now.Add(a.token.ChallengeTTL),
+		CreatedAt: now,
+	}); err != nil {
+		a.log.Error("failed to save two-factor challenge",
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+		return dto.TokenOutput{}, err
+	}
+
+	a.log.Info("two-factor challenge issued",
+		zap.String("user_id", userID.String()),
+	)
+
+	return dto.TokenOutput{ChallengeToken: challengeToken}, nil
+}
+
+func (a *AuthS) failChallenge(ctx context.Context, challenge domain.TwoFactorChallenge) {
+	a.log.Warn("invalid two-factor code during sign-in",
+		zap.String("user_id", challenge.UserID.String()),
+	)
+
+	attempts, err := a.repo.FailTwoFactorChallenge(ctx, challenge.TokenHash)
+	if err != nil {
+		a.log.Error("failed to record two-factor attempt",
+			zap.String("user_id", challenge.UserID.String()),
+			zap.Error(err),
+		)
+		return
+	}
+
+	if attempts < domain.TwoFactorMaxAttempts {
+		return
+	}
+
+	a.log.Warn("too many two-factor attempts, challenge locked until it expires",
+		zap.String("user_id", challenge.UserID.String()),
+		zap.Int("attempts", attempts),
+		zap.Time("locked_until", challenge.ExpiresAt),
+	)
+}
+
 func (a *AuthS) Logout(ctx context.Context, tokenID string) error {
 	if err := a.repo.DeleteToken(ctx, tokenID); err != nil {
 		a.log.Error("failed to delete refresh token during logout",
@@ -132,6 +511,44 @@ func (a *AuthS) Logout(ctx context.Context, tokenID string) error {
 	return nil
 }
 
+func (a *AuthS) PurgeExpiredTokens(ctx context.Context) (int64, error) {
+	purged, err := a.repo.PurgeExpiredTokens(ctx, time.Now())
+	if err != nil {
+		a.log.Error("failed to purge expired refresh tokens",
+			zap.Error(err),
+		)
+		return 0, err
+	}
+
+	if purged > 0 {
+		a.log.Info("expired refresh tokens purged",
+			zap.Int64("purged", purged),
+		)
+	}
+
+	return purged, nil
+}
+
+func (a *AuthS) RunTokenPurge(ctx context.Context, interval time.Duration) {
+	a.log.Info("token purge worker started",
+		zap.Duration("interval", interval),
+	)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		_, _ = a.PurgeExpiredTokens(ctx)
+
+		select {
+		case <-ctx.Done():
+			a.log.Info("token purge worker stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 func (a *AuthS) checkUser(ctx context.Context, email, password string) (uuid.UUID, error) {
 	userID, hashedPass, err := a.repo.UserCredentials(ctx, email)
 	if err != nil {
@@ -159,11 +576,34 @@ func (a *AuthS) checkUser(ctx context.Context, email, password string) (uuid.UUI
 	return userID, nil
 }
 
-func (a *AuthS) generateAndSaveTokens(ctx context.Context, userID uuid.UUID) (dto.TokenOutput, error) {
-	accessToken, refreshToken, err := a.generateTokens(userID)
+func (a *AuthS) activeUser(ctx context.Context, userID uuid.UUID) (domain.User, error) {
+	user, err := a.repo.UserByID(ctx, userID)
+	if err != nil {
+		a.log.Error("failed to get user by ID",
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+		return domain.User{}, err
+	}
+
+	if user.DisabledAt != nil {
+		a.log.Warn("disabled account attempted to authenticate",
+			zap.String("user_id", userID.String()),
+		)
+		return domain.User{}, domain.ErrAccountDisabled
+	}
+
+	return user, nil
+}
+
+func (a *AuthS) generateAndSaveTokens(ctx context.Context, user domain.User, familyID uuid.UUID, device dto.Device) (dto.TokenOutput, error) {
+	accessToken, refreshToken, err := a.generateTokens(user.ID)
 	if err != nil {
 		return dto.TokenOutput{}, err
 	}
+	refreshToken.FamilyID = familyID
+	refreshToken.UserAgent = device.UserAgent
+	refreshToken.IP = device.IP
 
 	if err := a.repo.CreateToken(ctx, refreshToken); err != nil {
 		return dto.TokenOutput{}, err
@@ -199,7 +639,7 @@ func (a *AuthS) generateAccessToken(userID uuid.UUID) (string, error) {
 		return "", fmt.Errorf("failed to set issued at in token: %w", err)
 	}
 
-	accessToken, err := jwt.Sign(tkn, jwt.WithKey(jwa.HS256, []byte(a.token.JwtSecret)))
+	accessToken, err := a.signer.Sign(tkn)
 	if err != nil {
 		return "", fmt.Errorf("failed to sign token: %s", err)
 	}
@@ -215,25 +655,25 @@ func (a *AuthS) generateRefreshToken(userID uuid.UUID) domain.Token {
 	}
 }
 
-func (a *AuthS) ParseToken(ctx context.Context, accessToken string) (uuid.UUID, error) {
-	verified, err := jwt.Parse([]byte(accessToken), jwt.WithKey(jwa.HS256, []byte(a.token.JwtSecret)))
+func (a *AuthS) ParseToken(ctx context.Context, accessToken string) (dto.TokenClaims, error) {
+	verified, err := a.signer.Verify([]byte(accessToken))
 	if err != nil {
 		a.log.Debug("failed to parse or verify access token",
 			zap.Error(err),
 		)
-		return uuid.Nil, fmt.Errorf("invalid token")
+		return dto.TokenClaims{}, fmt.Errorf("invalid token")
 	}
 
 	subject, ok := verified.Get(jwt.SubjectKey)
 	if !ok {
 		a.log.Debug("token missing 'sub' claim")
-		return uuid.Nil, fmt.Errorf("invalid token")
+		return dto.TokenClaims{}, fmt.Errorf("invalid token")
 	}
 
 	subjectStr, ok := subject.(string)
 	if !ok {
 		a.log.Debug("token 'sub' claim is not a string")
-		return uuid.Nil, fmt.Errorf("invalid token")
+		return dto.TokenClaims{}, fmt.Errorf("invalid token")
 	}
 
 	userID, err := uuid.Parse(subjectStr)
@@ -242,13 +682,30 @@ func (a *AuthS) ParseToken(ctx context.Context, accessToken string) (uuid.UUID,
 			zap.String("subject", subjectStr),
 			zap.Error(err),
 		)
-		return uuid.Nil, domain.ErrInvalidUUID
+		return dto.TokenClaims{}, domain.ErrInvalidUUID
 	}
 
-	return userID, nil
+	user, err := a.activeUser(ctx, userID)
+	if err != nil {
+		return dto.TokenClaims{}, err
+	}
+
+	role := user.Role
+	if role == "" {
+		role = domain.UserRoleUser
+	}
+
+	return dto.TokenClaims{
+		UserID: userID,
+		Role:   role,
+	}, nil
 }
 
-func (a *AuthS) RefreshToken(ctx context.Context, tokenID string) (dto.TokenOutput, error) {
+func (a *AuthS) JWKS() jwk.Set {
+	return a.signer.PublicKeys()
+}
+
+func (a *AuthS) RefreshToken(ctx context.Context, tokenID string, device dto.Device) (dto.TokenOutput, error) {
 	tokenDB, err := a.repo.Token(ctx, tokenID)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
@@ -264,12 +721,8 @@ func (a *AuthS) RefreshToken(ctx context.Context, tokenID string) (dto.TokenOutp
 		return dto.TokenOutput{}, err
 	}
 
-	if err := a.repo.DeleteToken(ctx, tokenID); err != nil {
-		a.log.Error("failed to delete old refresh token",
-			zap.String("token_id", tokenID),
-			zap.Error(err),
-		)
-		return dto.TokenOutput{}, err
+	if tokenDB.RotatedAt != nil {
+		return dto.TokenOutput{}, a.revokeTokenFamily(ctx, tokenDB)
 	}
 
 	if tokenDB.ExpiresAt.Before(time.Now()) {
@@ -277,15 +730,47 @@ func (a *AuthS) RefreshToken(ctx context.Context, tokenID string) (dto.TokenOutp
 			zap.String("token_id", tokenID),
 			zap.Time("expires_at", tokenDB.ExpiresAt),
 		)
+		if err := a.repo.DeleteToken(ctx, tokenID); err != nil {
+			a.log.Error("failed to delete expired refresh token",
+				zap.String("token_id", tokenID),
+				zap.Error(err),
+			)
+		}
 		return dto.TokenOutput{}, fmt.Errorf("token expired")
 	}
 
-	token, err := a.generateAndSaveTokens(ctx, tokenDB.UserID)
+	user, err := a.activeUser(ctx, tokenDB.UserID)
+	if err != nil {
+		return dto.TokenOutput{}, err
+	}
+
+	var token dto.TokenOutput
+	err = a.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := a.repo.RotateToken(ctx, tokenID); err != nil {
+			if !errors.Is(err, domain.ErrNotFound) {
+				a.log.Error("failed to rotate old refresh token",
+					zap.String("token_id", tokenID),
+					zap.Error(err),
+				)
+			}
+			return err
+		}
+
+		var err error
+		if token, err = a.generateAndSaveTokens(ctx, user, tokenDB.FamilyID, device); err != nil {
+			a.log.Error("failed to generate new tokens during refresh",
+				zap.String("user_id", tokenDB.UserID.String()),
+				zap.Error(err),
+			)
+			return err
+		}
+
+		return nil
+	})
+	if errors.Is(err, domain.ErrNotFound) {
+		return dto.TokenOutput{}, a.revokeTokenFamily(ctx, tokenDB)
+	}
 	if err != nil {
-		a.log.Error("failed to generate new tokens during refresh",
-			zap.String("user_id", tokenDB.UserID.String()),
-			zap.Error(err),
-		)
 		return dto.TokenOutput{}, err
 	}
 
@@ -297,3 +782,22 @@ func (a *AuthS) RefreshToken(ctx context.Context, tokenID string) (dto.TokenOutp
 
 	return token, nil
 }
+
+func (a *AuthS) revokeTokenFamily(ctx context.Context, token domain.Token) error {
+	a.log.Warn("security event: refresh token reuse detected, revoking token family",
+		zap.String("event", "refresh_token_reuse"),
+		zap.String("user_id", token.UserID.String()),
+		zap.String("family_id", token.FamilyID.String()),
+		zap.String("token_id", token.TokenID),
+	)
+
+	if err := a.repo.DeleteTokenFamily(ctx, token.FamilyID); err != nil {
+		a.log.Error("failed to revoke refresh token family",
+			zap.String("family_id", token.FamilyID.String()),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	return domain.ErrTokenReused
+}
@@ -2,12 +2,22 @@ package service
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"fmt"
+	"noteApp/internal/config"
 	"noteApp/internal/models/domain"
 	"noteApp/internal/models/dto"
 	mock_service "noteApp/internal/service/mock"
 	"noteApp/pkg/logger"
+	"noteApp/pkg/signer"
+	"noteApp/pkg/totp"
+	"noteApp/pkg/utils"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -31,7 +41,23 @@ func mockAuthService(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock
 	cfg, err := initConfig()
 	require.NoError(t, err)
 
-	return NewAuthService(repo, hasher, cfg, logger.LoggerForTest())
+	s, err := signer.NewSigner(cfg)
+	require.NoError(t, err)
+
+	mailer := mock_service.NewMockMailerI(ctrl)
+	mailer.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	verifier := NewEmailService(repo, &fakeTx{}, mailer, hasher, testMailCfg, logger.LoggerForTest())
+
+	return NewAuthService(repo, &fakeTx{}, hasher, s, verifier, cfg, logger.LoggerForTest())
+}
+
+func expectReserveAttempt(mri *mock_service.MockRepositoryI, scope, subject string) *gomock.Call {
+	return mri.EXPECT().ReserveSignInAttempt(gomock.Any(), scope, subject, gomock.Any(), gomock.Any()).
+		Return(domain.SignInAttempt{Scope: scope, Subject: subject, Failures: 1, LastFailedAt: time.Now()}, true, nil)
+}
+
+func expectReleaseAttempt(mri *mock_service.MockRepositoryI, scope, subject string) *gomock.Call {
+	return mri.EXPECT().ReleaseSignInAttempt(gomock.Any(), scope, subject).Return(nil)
 }
 
 func TestAuthS_SignUp(t *testing.T) {
@@ -62,6 +88,33 @@ func TestAuthS_SignUp(t *testing.T) {
 			f: func(mri *mock_service.MockRepositoryI, hasher *mock_service.MockHasherI) {
 				hasher.EXPECT().GenerateHash(gomock.Any()).Return("hashed", nil)
 				mri.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(nil)
+				mri.EXPECT().UserByID(gomock.Any(), gomock.Any()).Return(domain.User{Username: "test_username", Email: "test_email"}, nil)
+				mri.EXPECT().DeleteEmailTokens(gomock.Any(), gomock.Any(), domain.EmailTokenVerify).Return(nil)
+				mri.EXPECT().CreateEmailToken(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, token domain.EmailToken) error {
+					assert.Equal(t, domain.EmailTokenVerify, token.Purpose)
+					return nil
+				})
+			},
+			wantErr: false,
+		},
+		{
+			name: "verification email failure",
+			args: args{
+				ctx: context.Background(),
+				user: dto.UserCreate{
+					ID:       uuid.New(),
+					Username: "test_username",
+					Email:    "test_email",
+					Password: "test_password",
+					ImageURL: "test_image",
+				},
+			},
+			f: func(mri *mock_service.MockRepositoryI, hasher *mock_service.MockHasherI) {
+				hasher.EXPECT().GenerateHash(gomock.Any()).Return("hashed", nil)
+				mri.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Return(nil)
+				mri.EXPECT().UserByID(gomock.Any(), gomock.Any()).Return(domain.User{Username: "test_username", Email: "test_email"}, nil)
+				mri.EXPECT().DeleteEmailTokens(gomock.Any(), gomock.Any(), domain.EmailTokenVerify).Return(nil)
+				mri.EXPECT().CreateEmailToken(gomock.Any(), gomock.Any()).Return(domain.ErrFailedToCreate)
 			},
 			wantErr: false,
 		},
@@ -180,8 +233,12 @@ func TestAuthS_SignIn(t *testing.T) {
 				},
 			},
 			f: func(mri *mock_service.MockRepositoryI, hasher *mock_service.MockHasherI) {
+				expectReserveAttempt(mri, domain.SignInScopeAccount, "test_email")
 				mri.EXPECT().UserCredentials(gomock.Any(), gomock.Any()).Return(uuid.UUID{}, "hashed_pass", nil)
 				hasher.EXPECT().ComparePassword(gomock.Any(), gomock.Any()).Return(nil)
+				mri.EXPECT().ResetSignInAttempts(gomock.Any(), domain.SignInScopeAccount, "test_email").Return(nil)
+				mri.EXPECT().UserByID(gomock.Any(), gomock.Any()).Return(domain.User{Role: domain.UserRoleUser}, nil)
+				mri.EXPECT().TwoFactor(gomock.Any(), gomock.Any()).Return(domain.TwoFactor{}, domain.ErrNotFound)
 				mri.EXPECT().CreateToken(gomock.Any(), gomock.Any()).Return(nil)
 			},
 			wantErr: false,
@@ -196,7 +253,9 @@ func TestAuthS_SignIn(t *testing.T) {
 				},
 			},
 			f: func(mri *mock_service.MockRepositoryI, hasher *mock_service.MockHasherI) {
+				expectReserveAttempt(mri, domain.SignInScopeAccount, "")
 				mri.EXPECT().UserCredentials(gomock.Any(), gomock.Any()).Return(uuid.Nil, "", domain.ErrReceiving)
+				expectReleaseAttempt(mri, domain.SignInScopeAccount, "")
 			},
 			wantErr: true,
 		},
@@ -210,7 +269,9 @@ func TestAuthS_SignIn(t *testing.T) {
 				},
 			},
 			f: func(mri *mock_service.MockRepositoryI, hasher *mock_service.MockHasherI) {
+				expectReserveAttempt(mri, domain.SignInScopeAccount, "wrong_email")
 				mri.EXPECT().UserCredentials(gomock.Any(), gomock.Any()).Return(uuid.Nil, "", domain.ErrReceiving)
+				expectReleaseAttempt(mri, domain.SignInScopeAccount, "wrong_email")
 			},
 			wantErr: true,
 		},
@@ -224,6 +285,7 @@ func TestAuthS_SignIn(t *testing.T) {
 				},
 			},
 			f: func(mri *mock_service.MockRepositoryI, hasher *mock_service.MockHasherI) {
+				expectReserveAttempt(mri, domain.SignInScopeAccount, "test_email")
 				mri.EXPECT().UserCredentials(gomock.Any(), gomock.Any()).Return(uuid.UUID{}, "", domain.ErrNotFound)
 			},
 			wantErr: true,
@@ -238,6 +300,7 @@ func TestAuthS_SignIn(t *testing.T) {
 				},
 			},
 			f: func(mri *mock_service.MockRepositoryI, hasher *mock_service.MockHasherI) {
+				expectReserveAttempt(mri, domain.SignInScopeAccount, "test_email")
 				mri.EXPECT().UserCredentials(gomock.Any(), gomock.Any()).Return(uuid.UUID{}, "hashed_pass", nil)
 				hasher.EXPECT().ComparePassword(gomock.Any(), gomock.Any()).Return(errors.New("invalid password"))
 			},
@@ -253,9 +316,13 @@ func TestAuthS_SignIn(t *testing.T) {
 				},
 			},
 			f: func(mri *mock_service.MockRepositoryI, hasher *mock_service.MockHasherI) {
+				expectReserveAttempt(mri, domain.SignInScopeAccount, "test_email")
 				mri.EXPECT().UserCredentials(gomock.Any(), gomock.Any()).Return(uuid.UUID{}, "hashed_pass", nil)
 				hasher.EXPECT().ComparePassword(gomock.Any(), gomock.Any()).Return(nil)
+				mri.EXPECT().UserByID(gomock.Any(), gomock.Any()).Return(domain.User{Role: domain.UserRoleUser}, nil)
+				mri.EXPECT().TwoFactor(gomock.Any(), gomock.Any()).Return(domain.TwoFactor{}, domain.ErrNotFound)
 				mri.EXPECT().CreateToken(gomock.Any(), gomock.Any()).Return(errors.New("repository error"))
+				expectReleaseAttempt(mri, domain.SignInScopeAccount, "test_email")
 			},
 			wantErr: true,
 		},
@@ -269,7 +336,7 @@ func TestAuthS_SignIn(t *testing.T) {
 
 			a := mockAuthService(t, ctrl, tt.f)
 
-			got, err := a.SignIn(tt.args.ctx, tt.args.data)
+			got, err := a.SignIn(tt.args.ctx, tt.args.data, dto.Device{})
 			if tt.wantErr {
 				require.Error(t, err)
 				return
@@ -458,7 +525,7 @@ func TestAuthS_generateAndSaveTokens(t *testing.T) {
 
 			a := mockAuthService(t, ctrl, tt.f)
 
-			got, err := a.generateAndSaveTokens(tt.args.ctx, tt.args.userID)
+			got, err := a.generateAndSaveTokens(tt.args.ctx, domain.User{ID: tt.args.userID, Role: domain.UserRoleUser}, uuid.New(), dto.Device{})
 			if tt.wantErr {
 				require.Error(t, err)
 				return
@@ -556,6 +623,651 @@ func TestAuthS_generateAccessToken(t *testing.T) {
 	}
 }
 
+func TestAuthS_RefreshTokenAtomic(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		createErr     error
+		wantErr       bool
+		wantCommits   int
+		wantRollbacks int
+	}{
+		{
+			name:        "rotation committed",
+			wantCommits: 1,
+		},
+		{
+			name:          "failed save rolls back rotation",
+			createErr:     domain.ErrFailedToCreate,
+			wantErr:       true,
+			wantRollbacks: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tokenID := uuid.New().String()
+			a := mockAuthService(t, ctrl, func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+				mri.EXPECT().Token(gomock.Any(), tokenID).Return(domain.Token{
+					UserID:    uuid.New(),
+					TokenID:   tokenID,
+					ExpiresAt: time.Now().Add(time.Hour),
+				}, nil)
+				mri.EXPECT().UserByID(gomock.Any(), gomock.Any()).Return(domain.User{Role: domain.UserRoleUser}, nil)
+				mri.EXPECT().RotateToken(gomock.Any(), tokenID).DoAndReturn(func(ctx context.Context, _ string) error {
+					assert.True(t, inFakeTx(ctx), "old token must be rotated inside the transaction")
+					return nil
+				})
+				mri.EXPECT().CreateToken(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, _ domain.Token) error {
+					assert.True(t, inFakeTx(ctx), "new token must be saved inside the transaction")
+					return tt.createErr
+				})
+			})
+
+			_, err := a.RefreshToken(context.Background(), tokenID, dto.Device{})
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			tx := a.tx.(*fakeTx)
+			assert.Equal(t, tt.wantCommits, tx.commits)
+			assert.Equal(t, tt.wantRollbacks, tx.rollbacks)
+		})
+	}
+}
+
+func TestAuthS_RefreshTokenReuse(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	familyID := uuid.New()
+	rotatedAt := time.Now().Add(-time.Minute)
+
+	a := mockAuthService(t, ctrl, func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+		mri.EXPECT().Token(gomock.Any(), "stolen").Return(domain.Token{
+			UserID:    userID,
+			TokenID:   "stolen",
+			FamilyID:  familyID,
+			ExpiresAt: time.Now().Add(time.Hour),
+			RotatedAt: &rotatedAt,
+		}, nil)
+		mri.EXPECT().DeleteTokenFamily(gomock.Any(), familyID).Return(nil)
+	})
+
+	_, err := a.RefreshToken(context.Background(), "stolen", dto.Device{})
+	require.ErrorIs(t, err, domain.ErrTokenReused)
+}
+
+func TestAuthS_RefreshTokenKeepsFamily(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	familyID := uuid.New()
+
+	a := mockAuthService(t, ctrl, func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+		mri.EXPECT().Token(gomock.Any(), gomock.Any()).Return(domain.Token{
+			UserID:    uuid.New(),
+			TokenID:   "current",
+			FamilyID:  familyID,
+			ExpiresAt: time.Now().Add(time.Hour),
+		}, nil)
+		mri.EXPECT().UserByID(gomock.Any(), gomock.Any()).Return(domain.User{Role: domain.UserRoleUser}, nil)
+		mri.EXPECT().RotateToken(gomock.Any(), "current").Return(nil)
+		mri.EXPECT().CreateToken(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, token domain.Token) error {
+			assert.Equal(t, familyID, token.FamilyID)
+			assert.Equal(t, "curl/8.0", token.UserAgent)
+			assert.Equal(t, "10.0.0.1", token.IP)
+			return nil
+		})
+	})
+
+	_, err := a.RefreshToken(context.Background(), "current", dto.Device{UserAgent: "curl/8.0", IP: "10.0.0.1"})
+	require.NoError(t, err)
+}
+
+func TestAuthS_SignInDisabled(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	disabledAt := time.Now()
+
+	a := mockAuthService(t, ctrl, func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+		expectReserveAttempt(mri, domain.SignInScopeAccount, "test_email")
+		mri.EXPECT().UserCredentials(gomock.Any(), "test_email").Return(userID, "hashed_pass", nil)
+		mhi.EXPECT().ComparePassword("hashed_pass", "test_password").Return(nil)
+		mri.EXPECT().UserByID(gomock.Any(), userID).Return(domain.User{ID: userID, DisabledAt: &disabledAt}, nil)
+		expectReleaseAttempt(mri, domain.SignInScopeAccount, "test_email")
+	})
+
+	_, err := a.SignIn(context.Background(), dto.UserSignIn{Email: "test_email", Password: "test_password"}, dto.Device{})
+	require.ErrorIs(t, err, domain.ErrAccountDisabled)
+}
+
+func TestAuthS_SignInChallenge(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	confirmedAt := time.Now()
+
+	tests := []struct {
+		name          string
+		f             func(*mock_service.MockRepositoryI, *mock_service.MockHasherI)
+		wantChallenge bool
+		wantErr       bool
+	}{
+		{
+			name: "two-factor enabled",
+			f: func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+				mri.EXPECT().TwoFactor(gomock.Any(), userID).Return(domain.TwoFactor{UserID: userID, ConfirmedAt: &confirmedAt}, nil)
+				mri.EXPECT().UserTwoFactorChallenge(gomock.Any(), userID).Return(domain.TwoFactorChallenge{}, domain.ErrNotFound)
+				mri.EXPECT().CreateTwoFactorChallenge(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, challenge domain.TwoFactorChallenge) error {
+					assert.Equal(t, userID, challenge.UserID)
+					assert.Len(t, challenge.TokenHash, 64)
+					assert.Equal(t, 0, challenge.Attempts)
+					assert.True(t, challenge.ExpiresAt.After(time.Now()))
+					return nil
+				})
+				expectReleaseAttempt(mri, domain.SignInScopeAccount, "test_email")
+			},
+			wantChallenge: true,
+		},
+		{
+			name: "carries attempts of previous challenge",
+			f: func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+				mri.EXPECT().TwoFactor(gomock.Any(), userID).Return(domain.TwoFactor{UserID: userID, ConfirmedAt: &confirmedAt}, nil)
+				mri.EXPECT().UserTwoFactorChallenge(gomock.Any(), userID).Return(domain.TwoFactorChallenge{
+					UserID:    userID,
+					Attempts:  domain.TwoFactorMaxAttempts - 1,
+					ExpiresAt: time.Now().Add(time.Minute),
+				}, nil)
+				mri.EXPECT().CreateTwoFactorChallenge(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, challenge domain.TwoFactorChallenge) error {
+					assert.Equal(t, domain.TwoFactorMaxAttempts-1, challenge.Attempts)
+					return nil
+				})
+				expectReleaseAttempt(mri, domain.SignInScopeAccount, "test_email")
+			},
+			wantChallenge: true,
+		},
+		{
+			name: "ignores expired previous challenge",
+			f: func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+				mri.EXPECT().TwoFactor(gomock.Any(), userID).Return(domain.TwoFactor{UserID: userID, ConfirmedAt: &confirmedAt}, nil)
+				mri.EXPECT().UserTwoFactorChallenge(gomock.Any(), userID).Return(domain.TwoFactorChallenge{
+					UserID:    userID,
+					Attempts:  domain.TwoFactorMaxAttempts,
+					ExpiresAt: time.Now().Add(-time.Minute),
+				}, nil)
+				mri.EXPECT().CreateTwoFactorChallenge(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, challenge domain.TwoFactorChallenge) error {
+					assert.Equal(t, 0, challenge.Attempts)
+					return nil
+				})
+				expectReleaseAttempt(mri, domain.SignInScopeAccount, "test_email")
+			},
+			wantChallenge: true,
+		},
+		{
+			name: "previous challenge locked",
+			f: func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+				mri.EXPECT().TwoFactor(gomock.Any(), userID).Return(domain.TwoFactor{UserID: userID, ConfirmedAt: &confirmedAt}, nil)
+				mri.EXPECT().UserTwoFactorChallenge(gomock.Any(), userID).Return(domain.TwoFactorChallenge{
+					UserID:    userID,
+					Attempts:  domain.TwoFactorMaxAttempts,
+					ExpiresAt: time.Now().Add(time.Minute),
+				}, nil)
+				expectReleaseAttempt(mri, domain.SignInScopeAccount, "test_email")
+			},
+			wantErr: true,
+		},
+		{
+			name: "enrollment not confirmed",
+			f: func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+				mri.EXPECT().TwoFactor(gomock.Any(), userID).Return(domain.TwoFactor{UserID: userID}, nil)
+				mri.EXPECT().CreateToken(gomock.Any(), gomock.Any()).Return(nil)
+				mri.EXPECT().ResetSignInAttempts(gomock.Any(), domain.SignInScopeAccount, "test_email").Return(nil)
+			},
+		},
+		{
+			name: "two-factor lookup error",
+			f: func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+				mri.EXPECT().TwoFactor(gomock.Any(), userID).Return(domain.TwoFactor{}, errors.New("db error"))
+				expectReleaseAttempt(mri, domain.SignInScopeAccount, "test_email")
+			},
+			wantErr: true,
+		},
+		{
+			name: "challenge save error",
+			f: func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+				mri.EXPECT().TwoFactor(gomock.Any(), userID).Return(domain.TwoFactor{UserID: userID, ConfirmedAt: &confirmedAt}, nil)
+				mri.EXPECT().UserTwoFactorChallenge(gomock.Any(), userID).Return(domain.TwoFactorChallenge{}, domain.ErrNotFound)
+				mri.EXPECT().CreateTwoFactorChallenge(gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+				expectReleaseAttempt(mri, domain.SignInScopeAccount, "test_email")
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			a := mockAuthService(t, ctrl, func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+				expectReserveAttempt(mri, domain.SignInScopeAccount, "test_email")
+				mri.EXPECT().UserCredentials(gomock.Any(), "test_email").Return(userID, "hashed_pass", nil)
+				mhi.EXPECT().ComparePassword("hashed_pass", "test_password").Return(nil)
+				mri.EXPECT().UserByID(gomock.Any(), userID).Return(domain.User{ID: userID, Role: domain.UserRoleUser}, nil)
+				tt.f(mri, mhi)
+			})
+
+			got, err := a.SignIn(context.Background(), dto.UserSignIn{Email: "test_email", Password: "test_password"}, dto.Device{})
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			if tt.wantChallenge {
+				assert.NotEmpty(t, got.ChallengeToken)
+				assert.Empty(t, got.AccessToken)
+				assert.Empty(t, got.RefreshToken)
+				return
+			}
+
+			assert.Empty(t, got.ChallengeToken)
+			assert.NotEmpty(t, got.AccessToken)
+		})
+	}
+}
+
+func TestAuthS_SignInTwoFactor(t *testing.T) {
+	t.Parallel()
+
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+
+	validCode, err := totp.Code(secret, totp.Step(time.Now()))
+	require.NoError(t, err)
+
+	userID := uuid.New()
+	confirmedAt := time.Now()
+	tf := domain.TwoFactor{UserID: userID, Secret: secret, ConfirmedAt: &confirmedAt}
+	challengeToken := "challenge"
+	tokenHash := utils.HashToken(challengeToken)
+	challenge := domain.TwoFactorChallenge{TokenHash: tokenHash, UserID: userID, ExpiresAt: time.Now().Add(time.Minute)}
+	user := domain.User{ID: userID, Email: "Alice@Example.com", Role: domain.UserRoleUser}
+	subject := "alice@example.com"
+
+	cfg, err := initConfig()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		code    string
+		f       func(*mock_service.MockRepositoryI)
+		wantErr error
+	}{
+		{
+			name: "success with totp",
+			code: validCode,
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().TwoFactorChallenge(gomock.Any(), tokenHash).Return(challenge, nil)
+				mri.EXPECT().UserByID(gomock.Any(), userID).Return(user, nil)
+				expectReserveAttempt(mri, domain.SignInScopeAccount, subject)
+				mri.EXPECT().TwoFactor(gomock.Any(), userID).Return(tf, nil)
+				mri.EXPECT().DeleteTwoFactorChallenge(gomock.Any(), tokenHash).Return(nil)
+				mri.EXPECT().UseTwoFactorStep(gomock.Any(), userID, gomock.Any()).Return(nil)
+				mri.EXPECT().CreateToken(gomock.Any(), gomock.Any()).Return(nil)
+				mri.EXPECT().ResetSignInAttempts(gomock.Any(), domain.SignInScopeAccount, subject).Return(nil)
+			},
+		},
+		{
+			name: "success with recovery code",
+			code: "ABCDE-12345",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().TwoFactorChallenge(gomock.Any(), tokenHash).Return(challenge, nil)
+				mri.EXPECT().UserByID(gomock.Any(), userID).Return(user, nil)
+				expectReserveAttempt(mri, domain.SignInScopeAccount, subject)
+				mri.EXPECT().TwoFactor(gomock.Any(), userID).Return(tf, nil)
+				mri.EXPECT().DeleteTwoFactorChallenge(gomock.Any(), tokenHash).Return(nil)
+				mri.EXPECT().UseRecoveryCode(gomock.Any(), userID, utils.HashToken("abcde12345")).Return(nil)
+				mri.EXPECT().CreateToken(gomock.Any(), gomock.Any()).Return(nil)
+				mri.EXPECT().ResetSignInAttempts(gomock.Any(), domain.SignInScopeAccount, subject).Return(nil)
+			},
+		},
+		{
+			name: "invalid code",
+			code: "000000",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().TwoFactorChallenge(gomock.Any(), tokenHash).Return(challenge, nil)
+				mri.EXPECT().UserByID(gomock.Any(), userID).Return(user, nil)
+				expectReserveAttempt(mri, domain.SignInScopeAccount, subject)
+				mri.EXPECT().TwoFactor(gomock.Any(), userID).Return(tf, nil)
+				mri.EXPECT().DeleteTwoFactorChallenge(gomock.Any(), tokenHash).Return(nil)
+				mri.EXPECT().UseRecoveryCode(gomock.Any(), userID, gomock.Any()).Return(domain.ErrNotFound)
+				mri.EXPECT().FailTwoFactorChallenge(gomock.Any(), tokenHash).Return(1, nil)
+			},
+			wantErr: domain.ErrInvalidOTP,
+		},
+		{
+			name: "too many attempts locks challenge",
+			code: "000000",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().TwoFactorChallenge(gomock.Any(), tokenHash).Return(challenge, nil)
+				mri.EXPECT().UserByID(gomock.Any(), userID).Return(user, nil)
+				expectReserveAttempt(mri, domain.SignInScopeAccount, subject)
+				mri.EXPECT().TwoFactor(gomock.Any(), userID).Return(tf, nil)
+				mri.EXPECT().DeleteTwoFactorChallenge(gomock.Any(), tokenHash).Return(nil)
+				mri.EXPECT().UseRecoveryCode(gomock.Any(), userID, gomock.Any()).Return(domain.ErrNotFound)
+				mri.EXPECT().FailTwoFactorChallenge(gomock.Any(), tokenHash).Return(domain.TwoFactorMaxAttempts, nil)
+			},
+			wantErr: domain.ErrInvalidOTP,
+		},
+		{
+			name: "replayed totp code",
+			code: validCode,
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().TwoFactorChallenge(gomock.Any(), tokenHash).Return(challenge, nil)
+				mri.EXPECT().UserByID(gomock.Any(), userID).Return(user, nil)
+				expectReserveAttempt(mri, domain.SignInScopeAccount, subject)
+				mri.EXPECT().TwoFactor(gomock.Any(), userID).Return(tf, nil)
+				mri.EXPECT().DeleteTwoFactorChallenge(gomock.Any(), tokenHash).Return(nil)
+				mri.EXPECT().UseTwoFactorStep(gomock.Any(), userID, gomock.Any()).Return(domain.MakeError(domain.ErrFailedToUpdate, domain.ErrInvalidOTP, "two-factor"))
+				mri.EXPECT().FailTwoFactorChallenge(gomock.Any(), tokenHash).Return(1, nil)
+			},
+			wantErr: domain.ErrInvalidOTP,
+		},
+		{
+			name: "unknown challenge",
+			code: validCode,
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().TwoFactorChallenge(gomock.Any(), tokenHash).Return(domain.TwoFactorChallenge{}, domain.ErrNotFound)
+			},
+			wantErr: domain.ErrNotFound,
+		},
+		{
+			name: "challenge already used",
+			code: validCode,
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().TwoFactorChallenge(gomock.Any(), tokenHash).Return(challenge, nil)
+				mri.EXPECT().UserByID(gomock.Any(), userID).Return(user, nil)
+				expectReserveAttempt(mri, domain.SignInScopeAccount, subject)
+				mri.EXPECT().TwoFactor(gomock.Any(), userID).Return(tf, nil)
+				mri.EXPECT().DeleteTwoFactorChallenge(gomock.Any(), tokenHash).Return(domain.ErrNotFound)
+				expectReleaseAttempt(mri, domain.SignInScopeAccount, subject)
+			},
+			wantErr: domain.ErrNotFound,
+		},
+		{
+			name: "expired challenge",
+			code: validCode,
+			f: func(mri *mock_service.MockRepositoryI) {
+				expired := challenge
+				expired.ExpiresAt = time.Now().Add(-time.Minute)
+				mri.EXPECT().TwoFactorChallenge(gomock.Any(), tokenHash).Return(expired, nil)
+				mri.EXPECT().DeleteTwoFactorChallenge(gomock.Any(), tokenHash).Return(nil)
+			},
+			wantErr: domain.ErrNotFound,
+		},
+		{
+			name: "locked challenge",
+			code: validCode,
+			f: func(mri *mock_service.MockRepositoryI) {
+				locked := challenge
+				locked.Attempts = domain.TwoFactorMaxAttempts
+				mri.EXPECT().TwoFactorChallenge(gomock.Any(), tokenHash).Return(locked, nil)
+			},
+			wantErr: domain.ErrTooManyAttempts,
+		},
+		{
+			name: "account locked",
+			code: validCode,
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().TwoFactorChallenge(gomock.Any(), tokenHash).Return(challenge, nil)
+				mri.EXPECT().UserByID(gomock.Any(), userID).Return(user, nil)
+				mri.EXPECT().ReserveSignInAttempt(gomock.Any(), domain.SignInScopeAccount, subject, gomock.Any(), gomock.Any()).Return(domain.SignInAttempt{
+					Scope:        domain.SignInScopeAccount,
+					Subject:      subject,
+					Failures:     cfg.Lockout.AccountThreshold,
+					LastFailedAt: time.Now(),
+				}, false, nil)
+			},
+			wantErr: domain.ErrTooManyAttempts,
+		},
+		{
+			name: "account disabled",
+			code: validCode,
+			f: func(mri *mock_service.MockRepositoryI) {
+				disabledAt := time.Now()
+				disabled := user
+				disabled.DisabledAt = &disabledAt
+				mri.EXPECT().TwoFactorChallenge(gomock.Any(), tokenHash).Return(challenge, nil)
+				mri.EXPECT().UserByID(gomock.Any(), userID).Return(disabled, nil)
+			},
+			wantErr: domain.ErrAccountDisabled,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			a := mockAuthService(t, ctrl, func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+				tt.f(mri)
+			})
+
+			got, err := a.SignInTwoFactor(context.Background(), dto.TwoFactorSignIn{ChallengeToken: challengeToken, Code: tt.code}, dto.Device{})
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotEmpty(t, got.AccessToken)
+			assert.NotEmpty(t, got.RefreshToken)
+		})
+	}
+}
+
+func TestAuthS_RefreshTokenDisabled(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	disabledAt := time.Now()
+
+	a := mockAuthService(t, ctrl, func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+		mri.EXPECT().Token(gomock.Any(), "current").Return(domain.Token{
+			UserID:    userID,
+			TokenID:   "current",
+			ExpiresAt: time.Now().Add(time.Hour),
+		}, nil)
+		mri.EXPECT().UserByID(gomock.Any(), userID).Return(domain.User{ID: userID, DisabledAt: &disabledAt}, nil)
+	})
+
+	_, err := a.RefreshToken(context.Background(), "current", dto.Device{})
+	require.ErrorIs(t, err, domain.ErrAccountDisabled)
+}
+
+func TestAuthS_AccessTokenRole(t *testing.T) {
+	t.Parallel()
+
+	disabledAt := time.Now()
+
+	tests := []struct {
+		name    string
+		user    domain.User
+		want    string
+		wantErr error
+	}{
+		{
+			name: "admin",
+			user: domain.User{Role: domain.UserRoleAdmin},
+			want: domain.UserRoleAdmin,
+		},
+		{
+			name: "user",
+			user: domain.User{Role: domain.UserRoleUser},
+			want: domain.UserRoleUser,
+		},
+		{
+			name: "missing role defaults to user",
+			user: domain.User{},
+			want: domain.UserRoleUser,
+		},
+		{
+			name:    "disabled account",
+			user:    domain.User{Role: domain.UserRoleUser, DisabledAt: &disabledAt},
+			wantErr: domain.ErrAccountDisabled,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userID := uuid.New()
+			a := mockAuthService(t, ctrl, func(mri *mock_service.MockRepositoryI, _ *mock_service.MockHasherI) {
+				user := tt.user
+				user.ID = userID
+				mri.EXPECT().UserByID(gomock.Any(), userID).Return(user, nil)
+			})
+
+			accessToken, err := a.generateAccessToken(userID)
+			require.NoError(t, err)
+
+			parsed, err := jwt.ParseInsecure([]byte(accessToken))
+			require.NoError(t, err)
+			_, ok := parsed.Get("role")
+			assert.False(t, ok)
+
+			got, err := a.ParseToken(context.Background(), accessToken)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, userID, got.UserID)
+			assert.Equal(t, tt.want, got.Role)
+		})
+	}
+}
+
+func writeSigningKey(t *testing.T, id string) (config.JWTKeyCfg, config.JWTKeyCfg) {
+	t.Helper()
+
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(private)
+	require.NoError(t, err)
+
+	publicDER, err := x509.MarshalPKIXPublicKey(public)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	privateFile := filepath.Join(dir, id+".pem")
+	publicFile := filepath.Join(dir, id+".pub.pem")
+
+	require.NoError(t, os.WriteFile(privateFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER}), 0o600))
+	require.NoError(t, os.WriteFile(publicFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}), 0o600))
+
+	return config.JWTKeyCfg{ID: id, Algorithm: "EdDSA", PrivateKeyFile: privateFile},
+		config.JWTKeyCfg{ID: id, Algorithm: "EdDSA", PublicKeyFile: publicFile}
+}
+
+func TestAuthS_AccessTokenKeyRotation(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := initConfig()
+	require.NoError(t, err)
+
+	oldPrivate, oldPublic := writeSigningKey(t, "old")
+	newPrivate, _ := writeSigningKey(t, "new")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mock_service.NewMockRepositoryI(ctrl)
+	repo.EXPECT().UserByID(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, userID uuid.UUID) (domain.User, error) {
+		return domain.User{ID: userID, Role: domain.UserRoleAdmin}, nil
+	}).AnyTimes()
+
+	authWith := func(signingKeyID string, keys ...config.JWTKeyCfg) *AuthS {
+		keyCfg := cfg
+		keyCfg.JwtSecret = ""
+		keyCfg.SigningKeyID = signingKeyID
+		keyCfg.Keys = keys
+
+		s, err := signer.NewSigner(keyCfg)
+		require.NoError(t, err)
+
+		return NewAuthService(repo, &fakeTx{}, nil, s, nil, keyCfg, logger.LoggerForTest())
+	}
+
+	before := authWith("old", oldPrivate)
+	rotated := authWith("new", newPrivate, oldPublic)
+	retired := authWith("new", newPrivate)
+
+	userID := uuid.New()
+	oldToken, err := before.generateAccessToken(userID)
+	require.NoError(t, err)
+
+	got, err := rotated.ParseToken(context.Background(), oldToken)
+	require.NoError(t, err)
+	assert.Equal(t, userID, got.UserID)
+	assert.Equal(t, domain.UserRoleAdmin, got.Role)
+
+	newToken, err := rotated.generateAccessToken(userID)
+	require.NoError(t, err)
+
+	_, err = rotated.ParseToken(context.Background(), newToken)
+	require.NoError(t, err)
+
+	_, err = before.ParseToken(context.Background(), newToken)
+	require.Error(t, err)
+
+	_, err = retired.ParseToken(context.Background(), oldToken)
+	require.Error(t, err)
+
+	assert.Equal(t, 2, rotated.JWKS().Len())
+	_, ok := rotated.JWKS().LookupKeyID("old")
+	assert.True(t, ok)
+}
+
+func TestAuthS_JWKS(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	a := mockAuthService(t, ctrl, nil)
+
+	assert.Equal(t, 0, a.JWKS().Len())
+}
+
 func TestAuthS_generateRefreshToken(t *testing.T) {
 	t.Parallel()
 
@@ -631,6 +1343,9 @@ func TestAuthS_ParseToken(t *testing.T) {
 
 				return string(accessToken), nil
 			},
+			f: func(mri *mock_service.MockRepositoryI, _ *mock_service.MockHasherI) {
+				mri.EXPECT().UserByID(gomock.Any(), gomock.Any()).Return(domain.User{Role: domain.UserRoleUser}, nil)
+			},
 			wantErr: false,
 		},
 		{
@@ -734,7 +1449,8 @@ func TestAuthS_ParseToken(t *testing.T) {
 			}
 
 			require.NoError(t, err)
-			require.Equal(t, got, userID)
+			require.Equal(t, got.UserID, userID)
+			require.Equal(t, got.Role, domain.UserRoleUser)
 		})
 	}
 }
@@ -764,7 +1480,8 @@ func TestAuthS_RefreshToken(t *testing.T) {
 					TokenID:   uuid.New().String(),
 					ExpiresAt: time.Now().Add(5 * time.Hour),
 				}, nil)
-				mri.EXPECT().DeleteToken(gomock.Any(), gomock.Any()).Return(nil)
+				mri.EXPECT().UserByID(gomock.Any(), gomock.Any()).Return(domain.User{Role: domain.UserRoleUser}, nil)
+				mri.EXPECT().RotateToken(gomock.Any(), gomock.Any()).Return(nil)
 				mri.EXPECT().CreateToken(gomock.Any(), gomock.Any()).Return(nil)
 			},
 			wantErr: false,
@@ -792,7 +1509,7 @@ func TestAuthS_RefreshToken(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "failed delete token",
+			name: "failed rotate token",
 			args: args{
 				ctx:     context.Background(),
 				tokenID: uuid.New().String(),
@@ -803,7 +1520,8 @@ func TestAuthS_RefreshToken(t *testing.T) {
 					TokenID:   uuid.New().String(),
 					ExpiresAt: time.Now().Add(5 * time.Hour),
 				}, nil)
-				mri.EXPECT().DeleteToken(gomock.Any(), gomock.Any()).Return(domain.ErrFailedToDelete)
+				mri.EXPECT().UserByID(gomock.Any(), gomock.Any()).Return(domain.User{Role: domain.UserRoleUser}, nil)
+				mri.EXPECT().RotateToken(gomock.Any(), gomock.Any()).Return(domain.ErrFailedToUpdate)
 			},
 			wantErr: true,
 		},
@@ -835,11 +1553,50 @@ func TestAuthS_RefreshToken(t *testing.T) {
 					TokenID:   uuid.New().String(),
 					ExpiresAt: time.Now().Add(5 * time.Hour),
 				}, nil)
-				mri.EXPECT().DeleteToken(gomock.Any(), gomock.Any()).Return(nil)
+				mri.EXPECT().UserByID(gomock.Any(), gomock.Any()).Return(domain.User{Role: domain.UserRoleUser}, nil)
+				mri.EXPECT().RotateToken(gomock.Any(), gomock.Any()).Return(nil)
 				mri.EXPECT().CreateToken(gomock.Any(), gomock.Any()).Return(domain.ErrFailedToCreate)
 			},
 			wantErr: true,
 		},
+		{
+			name: "rotated token reused",
+			args: args{
+				ctx:     context.Background(),
+				tokenID: uuid.New().String(),
+			},
+			f: func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+				rotatedAt := time.Now().Add(-time.Minute)
+				mri.EXPECT().Token(gomock.Any(), gomock.Any()).Return(domain.Token{
+					UserID:    uuid.New(),
+					TokenID:   uuid.New().String(),
+					FamilyID:  uuid.New(),
+					ExpiresAt: time.Now().Add(5 * time.Hour),
+					RotatedAt: &rotatedAt,
+				}, nil)
+				mri.EXPECT().DeleteTokenFamily(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "concurrently rotated token",
+			args: args{
+				ctx:     context.Background(),
+				tokenID: uuid.New().String(),
+			},
+			f: func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+				mri.EXPECT().Token(gomock.Any(), gomock.Any()).Return(domain.Token{
+					UserID:    uuid.New(),
+					TokenID:   uuid.New().String(),
+					FamilyID:  uuid.New(),
+					ExpiresAt: time.Now().Add(5 * time.Hour),
+				}, nil)
+				mri.EXPECT().UserByID(gomock.Any(), gomock.Any()).Return(domain.User{Role: domain.UserRoleUser}, nil)
+				mri.EXPECT().RotateToken(gomock.Any(), gomock.Any()).Return(domain.ErrNotFound)
+				mri.EXPECT().DeleteTokenFamily(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -850,7 +1607,7 @@ func TestAuthS_RefreshToken(t *testing.T) {
 
 			a := mockAuthService(t, ctrl, tt.f)
 
-			got, err := a.RefreshToken(tt.args.ctx, tt.args.tokenID)
+			got, err := a.RefreshToken(tt.args.ctx, tt.args.tokenID, dto.Device{})
 			if tt.wantErr {
 				require.Error(t, err)
 				return
@@ -862,3 +1619,242 @@ func TestAuthS_RefreshToken(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthS_lockedUntil(t *testing.T) {
+	t.Parallel()
+
+	a := &AuthS{token: config.AuthCfg{Lockout: config.LockoutCfg{
+		AccountThreshold: 3,
+		IPThreshold:      10,
+		BaseDelay:        time.Second,
+		MaxDelay:         time.Minute,
+		ResetAfter:       time.Hour,
+	}}}
+
+	failedAt := time.Now()
+
+	tests := []struct {
+		name      string
+		attempt   domain.SignInAttempt
+		wantDelay time.Duration
+	}{
+		{name: "below account threshold", attempt: domain.SignInAttempt{Scope: domain.SignInScopeAccount, Failures: 2, LastFailedAt: failedAt}},
+		{name: "at account threshold", attempt: domain.SignInAttempt{Scope: domain.SignInScopeAccount, Failures: 3, LastFailedAt: failedAt}, wantDelay: time.Second},
+		{name: "doubles after threshold", attempt: domain.SignInAttempt{Scope: domain.SignInScopeAccount, Failures: 5, LastFailedAt: failedAt}, wantDelay: 4 * time.Second},
+		{name: "capped at max delay", attempt: domain.SignInAttempt{Scope: domain.SignInScopeAccount, Failures: 50, LastFailedAt: failedAt}, wantDelay: time.Minute},
+		{name: "ip uses own threshold", attempt: domain.SignInAttempt{Scope: domain.SignInScopeIP, Failures: 5, LastFailedAt: failedAt}},
+		{name: "ip at threshold", attempt: domain.SignInAttempt{Scope: domain.SignInScopeIP, Failures: 11, LastFailedAt: failedAt}, wantDelay: 2 * time.Second},
+		{name: "stale failures", attempt: domain.SignInAttempt{Scope: domain.SignInScopeAccount, Failures: 50, LastFailedAt: failedAt.Add(-2 * time.Hour)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := a.lockedUntil(tt.attempt)
+			if tt.wantDelay == 0 {
+				assert.True(t, got.IsZero())
+				return
+			}
+
+			assert.Equal(t, failedAt.Add(tt.wantDelay), got)
+		})
+	}
+}
+
+func TestAuthS_SignInLockout(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	device := dto.Device{IP: "203.0.113.7"}
+	data := dto.UserSignIn{Email: "Test_Email ", Password: "test_password"}
+
+	cfg, err := initConfig()
+	require.NoError(t, err)
+	lockout := cfg.Lockout
+
+	locked := func(mri *mock_service.MockRepositoryI, scope, subject string, failures int) *gomock.Call {
+		return mri.EXPECT().ReserveSignInAttempt(gomock.Any(), scope, subject, gomock.Any(), gomock.Any()).Return(domain.SignInAttempt{
+			Scope:        scope,
+			Subject:      subject,
+			Failures:     failures,
+			LastFailedAt: time.Now(),
+		}, false, nil)
+	}
+
+	tests := []struct {
+		name           string
+		f              func(*mock_service.MockRepositoryI, *mock_service.MockHasherI)
+		wantErr        error
+		wantRetryAfter time.Duration
+	}{
+		{
+			name: "account locked",
+			f: func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+				locked(mri, domain.SignInScopeAccount, "test_email", lockout.AccountThreshold+1)
+			},
+			wantErr:        domain.ErrTooManyAttempts,
+			wantRetryAfter: 2 * lockout.BaseDelay,
+		},
+		{
+			name: "ip locked releases account reservation",
+			f: func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+				expectReserveAttempt(mri, domain.SignInScopeAccount, "test_email")
+				locked(mri, domain.SignInScopeIP, device.IP, lockout.IPThreshold)
+				expectReleaseAttempt(mri, domain.SignInScopeAccount, "test_email")
+			},
+			wantErr:        domain.ErrTooManyAttempts,
+			wantRetryAfter: lockout.BaseDelay,
+		},
+		{
+			name: "reservation passes limit to repository",
+			f: func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+				mri.EXPECT().ReserveSignInAttempt(gomock.Any(), domain.SignInScopeAccount, "test_email", gomock.Any(), domain.SignInLimit{
+					Threshold:  lockout.AccountThreshold,
+					BaseDelay:  lockout.BaseDelay,
+					MaxDelay:   lockout.MaxDelay,
+					ResetAfter: lockout.ResetAfter,
+				}).Return(domain.SignInAttempt{
+					Scope:        domain.SignInScopeAccount,
+					Subject:      "test_email",
+					Failures:     lockout.AccountThreshold + 1,
+					LastFailedAt: time.Now(),
+				}, true, nil)
+				mri.EXPECT().ReserveSignInAttempt(gomock.Any(), domain.SignInScopeIP, device.IP, gomock.Any(), domain.SignInLimit{
+					Threshold:  lockout.IPThreshold,
+					BaseDelay:  lockout.BaseDelay,
+					MaxDelay:   lockout.MaxDelay,
+					ResetAfter: lockout.ResetAfter,
+				}).Return(domain.SignInAttempt{Scope: domain.SignInScopeIP, Subject: device.IP, Failures: 1, LastFailedAt: time.Now()}, true, nil)
+				mri.EXPECT().UserCredentials(gomock.Any(), data.Email).Return(userID, "hashed_pass", nil)
+				mhi.EXPECT().ComparePassword("hashed_pass", data.Password).Return(nil)
+				mri.EXPECT().UserByID(gomock.Any(), userID).Return(domain.User{ID: userID, Role: domain.UserRoleUser}, nil)
+				mri.EXPECT().TwoFactor(gomock.Any(), userID).Return(domain.TwoFactor{}, domain.ErrNotFound)
+				mri.EXPECT().CreateToken(gomock.Any(), gomock.Any()).Return(nil)
+				mri.EXPECT().ResetSignInAttempts(gomock.Any(), domain.SignInScopeAccount, "test_email").Return(nil)
+				expectReleaseAttempt(mri, domain.SignInScopeIP, device.IP)
+			},
+		},
+		{
+			name: "wrong password keeps reservation",
+			f: func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+				expectReserveAttempt(mri, domain.SignInScopeAccount, "test_email")
+				expectReserveAttempt(mri, domain.SignInScopeIP, device.IP)
+				mri.EXPECT().UserCredentials(gomock.Any(), data.Email).Return(userID, "hashed_pass", nil)
+				mhi.EXPECT().ComparePassword("hashed_pass", data.Password).Return(errors.New("mismatch"))
+			},
+			wantErr: domain.ErrIncorrectPassword,
+		},
+		{
+			name: "unknown email keeps reservation",
+			f: func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+				expectReserveAttempt(mri, domain.SignInScopeAccount, "test_email")
+				expectReserveAttempt(mri, domain.SignInScopeIP, device.IP)
+				mri.EXPECT().UserCredentials(gomock.Any(), data.Email).Return(uuid.Nil, "", domain.MakeError(domain.ErrReceiving, domain.ErrNotFound, "user"))
+			},
+			wantErr: domain.ErrNotFound,
+		},
+		{
+			name: "unexpected error releases reservation",
+			f: func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+				expectReserveAttempt(mri, domain.SignInScopeAccount, "test_email")
+				expectReserveAttempt(mri, domain.SignInScopeIP, device.IP)
+				mri.EXPECT().UserCredentials(gomock.Any(), data.Email).Return(uuid.Nil, "", domain.ErrReceiving)
+				expectReleaseAttempt(mri, domain.SignInScopeAccount, "test_email")
+				expectReleaseAttempt(mri, domain.SignInScopeIP, device.IP)
+			},
+			wantErr: domain.ErrReceiving,
+		},
+		{
+			name: "reservation error",
+			f: func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+				mri.EXPECT().ReserveSignInAttempt(gomock.Any(), domain.SignInScopeAccount, "test_email", gomock.Any(), gomock.Any()).Return(domain.SignInAttempt{}, false, domain.ErrFailedToUpdate)
+			},
+			wantErr: domain.ErrFailedToUpdate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			a := mockAuthService(t, ctrl, tt.f)
+
+			got, err := a.SignIn(context.Background(), data, device)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+
+				var lockoutErr *domain.LockoutError
+				if tt.wantRetryAfter > 0 {
+					require.ErrorAs(t, err, &lockoutErr)
+					assert.InDelta(t, tt.wantRetryAfter.Seconds(), lockoutErr.RetryAfter.Seconds(), 1)
+				} else {
+					assert.False(t, errors.As(err, &lockoutErr))
+				}
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotEmpty(t, got.AccessToken)
+		})
+	}
+}
+
+func TestAuthS_PurgeExpiredTokens(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	start := time.Now()
+
+	s := mockAuthService(t, ctrl, func(mri *mock_service.MockRepositoryI, _ *mock_service.MockHasherI) {
+		mri.EXPECT().PurgeExpiredTokens(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, expiredBefore time.Time) (int64, error) {
+				assert.WithinDuration(t, start, expiredBefore, time.Second)
+				return 4, nil
+			})
+	})
+
+	purged, err := s.PurgeExpiredTokens(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), purged)
+}
+
+func TestAuthS_RunTokenPurge(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	purged := make(chan struct{}, 2)
+
+	s := mockAuthService(t, ctrl, func(mri *mock_service.MockRepositoryI, _ *mock_service.MockHasherI) {
+		mri.EXPECT().PurgeExpiredTokens(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(context.Context, time.Time) (int64, error) {
+				select {
+				case purged <- struct{}{}:
+				default:
+				}
+				return 0, nil
+			}).MinTimes(2)
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.RunTokenPurge(ctx, 10*time.Millisecond)
+	}()
+
+	<-purged
+	<-purged
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("token purge worker did not stop")
+	}
+}
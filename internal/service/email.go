@@ -0,0 +1,295 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"noteApp/internal/config"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+	"noteApp/pkg/utils"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const emailTokenSize = 32
+
+type EmailRI interface {
+	UserByID(ctx context.Context, userID uuid.UUID) (domain.User, error)
+	UserCredentials(ctx context.Context, email string) (uuid.UUID, string, error)
+	UpdateUser(ctx context.Context, user domain.UserUpdate) error
+	MarkEmailVerified(ctx context.Context, userID uuid.UUID) error
+	DeleteSessions(ctx context.Context, userID uuid.UUID) error
+	CreateEmailToken(ctx context.Context, token domain.EmailToken) error
+	ConsumeEmailToken(ctx context.Context, tokenHash, purpose string) (domain.EmailToken, error)
+	DeleteEmailTokens(ctx context.Context, userID uuid.UUID, purpose string) error
+}
+
+type EmailS struct {
+	repo   EmailRI
+	tx     TxManagerI
+	mailer MailerI
+	hasher HasherI
+	cfg    config.MailCfg
+	log    *logger.Logger
+}
+
+func NewEmailService(
+	repo EmailRI,
+	tx TxManagerI,
+	mailer MailerI,
+	hasher HasherI,
+	cfg config.MailCfg,
+	log *logger.Logger,
+) *EmailS {
+	return &EmailS{
+		repo:   repo,
+		tx:     tx,
+		mailer: mailer,
+		hasher: hasher,
+		cfg:    cfg,
+		log:    log,
+	}
+}
+
+func (e *EmailS) SendVerificationEmail(ctx context.Context, userID uuid.UUID) error {
+	user, err := e.repo.UserByID(ctx, userID)
+	if err != nil {
+		e.log.Error("failed to get user for email verification",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return err
+	}
+
+	if user.VerifiedAt != nil {
+		return domain.ErrEmailVerified
+	}
+
+	token, err := e.issueToken(ctx, userID, domain.EmailTokenVerify, e.cfg.VerifyTTL)
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("Hi %s,\n\nconfirm your email address by opening the link below:\n\n%s\n\nThe link expires in %s.\n",
+		user.Username, e.link("/verify-email", token), e.cfg.VerifyTTL)
+	if err := e.mailer.Send(ctx, user.Email, "Confirm your email address", body); err != nil {
+		e.log.Error("failed to send verification email",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return err
+	}
+
+	e.log.Info("verification email sent",
+		zap.String("user_id", userID.String()),
+	)
+
+	return nil
+}
+
+func (e *EmailS) VerifyEmail(ctx context.Context, data dto.EmailVerify) error {
+	var userID uuid.UUID
+	err := e.tx.WithinTx(ctx, func(ctx context.Context) error {
+		token, err := e.consumeToken(ctx, data.Token, domain.EmailTokenVerify)
+		if err != nil {
+			return err
+		}
+		userID = token.UserID
+
+		return e.repo.MarkEmailVerified(ctx, token.UserID)
+	})
+	if err != nil {
+		if !errors.Is(err, domain.ErrInvalidEmailToken) {
+			e.log.Error("failed to verify email",
+				zap.Error(err),
+			)
+		}
+		return err
+	}
+
+	e.log.Info("email verified",
+		zap.String("user_id", userID.String()),
+	)
+
+	return nil
+}
+
+func (e *EmailS) EmailVerified(ctx context.Context, userID uuid.UUID) error {
+	user, err := e.repo.UserByID(ctx, userID)
+	if err != nil {
+		e.log.Error("failed to get user for verification check",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return err
+	}
+
+	if user.VerifiedAt == nil {
+		return domain.ErrEmailNotVerified
+	}
+
+	return nil
+}
+
+func (e *EmailS) ForgotPassword(ctx context.Context, data dto.ForgotPassword) error {
+	userID, _, err := e.repo.UserCredentials(ctx, data.Email)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			e.log.Info("password reset requested for unknown email",
+				zap.String("email", data.Email),
+			)
+			return nil
+		}
+		e.log.Error("failed to get user for password reset",
+			zap.Error(err),
+			zap.String("email", data.Email),
+		)
+		return err
+	}
+
+	user, err := e.repo.UserByID(ctx, userID)
+	if err != nil {
+		e.log.Error("failed to get user for password reset",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return err
+	}
+
+	if user.DisabledAt != nil {
+		e.log.Warn("password reset requested for disabled account",
+			zap.String("user_id", userID.String()),
+		)
+		return nil
+	}
+
+	token, err := e.issueToken(ctx, userID, domain.EmailTokenReset, e.cfg.ResetTTL)
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("Hi %s,\n\nsomeone asked to reset the password of your account. Open the link below to choose a new one:\n\n%s\n\nThe link expires in %s. If you did not ask for this, ignore this email.\n",
+		user.Username, e.link("/reset-password", token), e.cfg.ResetTTL)
+	if err := e.mailer.Send(ctx, user.Email, "Reset your password", body); err != nil {
+		e.log.Error("failed to send password reset email",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return err
+	}
+
+	e.log.Info("password reset email sent",
+		zap.String("user_id", userID.String()),
+	)
+
+	return nil
+}
+
+func (e *EmailS) ResetPassword(ctx context.Context, data dto.PasswordReset) error {
+	hashedPassword, err := e.hasher.GenerateHash(data.Password)
+	if err != nil {
+		e.log.Error("failed to hash password during reset", zap.Error(err))
+		return err
+	}
+
+	var userID uuid.UUID
+	err = e.tx.WithinTx(ctx, func(ctx context.Context) error {
+		token, err := e.consumeToken(ctx, data.Token, domain.EmailTokenReset)
+		if err != nil {
+			return err
+		}
+		userID = token.UserID
+
+		if err := e.repo.UpdateUser(ctx, domain.UserUpdate{ID: token.UserID, Password: &hashedPassword}); err != nil {
+			return err
+		}
+
+		if err := e.repo.DeleteEmailTokens(ctx, token.UserID, domain.EmailTokenReset); err != nil {
+			return err
+		}
+
+		if err := e.repo.MarkEmailVerified(ctx, token.UserID); err != nil {
+			return err
+		}
+
+		return e.repo.DeleteSessions(ctx, token.UserID)
+	})
+	if err != nil {
+		if !errors.Is(err, domain.ErrInvalidEmailToken) {
+			e.log.Error("failed to reset password",
+				zap.Error(err),
+			)
+		}
+		return err
+	}
+
+	e.log.Info("password reset, all sessions revoked",
+		zap.String("user_id", userID.String()),
+	)
+
+	return nil
+}
+
+func (e *EmailS) issueToken(ctx context.Context, userID uuid.UUID, purpose string, ttl time.Duration) (string, error) {
+	token, err := utils.RandomToken(emailTokenSize)
+	if err != nil {
+		e.log.Error("failed to generate email token", zap.Error(err))
+		return "", err
+	}
+
+	if err := e.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := e.repo.DeleteEmailTokens(ctx, userID, purpose); err != nil {
+			return err
+		}
+
+		return e.repo.CreateEmailToken(ctx, domain.EmailToken{
+			TokenHash: utils.HashToken(token),
+			UserID:    userID,
+			Purpose:   purpose,
+			ExpiresAt: time.Now().Add(ttl),
+			CreatedAt: time.Now(),
+		})
+	}); err != nil {
+		e.log.Error("failed to save email token",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+			zap.String("purpose", purpose),
+		)
+		return "", err
+	}
+
+	return token, nil
+}
+
+func (e *EmailS) consumeToken(ctx context.Context, token, purpose string) (domain.EmailToken, error) {
+	emailToken, err := e.repo.ConsumeEmailToken(ctx, utils.HashToken(token), purpose)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			e.log.Warn("unknown email token presented",
+				zap.String("purpose", purpose),
+			)
+			return domain.EmailToken{}, domain.ErrInvalidEmailToken
+		}
+		return domain.EmailToken{}, err
+	}
+
+	if emailToken.ExpiresAt.Before(time.Now()) {
+		e.log.Warn("expired email token presented",
+			zap.String("user_id", emailToken.UserID.String()),
+			zap.String("purpose", purpose),
+		)
+		return domain.EmailToken{}, domain.ErrInvalidEmailToken
+	}
+
+	return emailToken, nil
+}
+
+func (e *EmailS) link(path, token string) string {
+	return strings.TrimRight(e.cfg.BaseURL, "/") + path + "?token=" + url.QueryEscape(token)
+}
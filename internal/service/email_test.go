@@ -0,0 +1,423 @@
+package service
+
+import (
+	"context"
+	"net/url"
+	"noteApp/internal/config"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	mock_service "noteApp/internal/service/mock"
+	"noteApp/pkg/logger"
+	"noteApp/pkg/utils"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testMailCfg = config.MailCfg{
+	Driver:    "file",
+	From:      "no-reply@example.com",
+	BaseURL:   "http://localhost:8080/",
+	VerifyTTL: 24 * time.Hour,
+	ResetTTL:  time.Hour,
+}
+
+var linkRe = regexp.MustCompile(`http://\S+`)
+
+func mockEmailService(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock_service.MockRepositoryI, *mock_service.MockMailerI, *mock_service.MockHasherI)) *EmailS {
+	t.Helper()
+
+	repo := mock_service.NewMockRepositoryI(ctrl)
+	mailer := mock_service.NewMockMailerI(ctrl)
+	hasher := mock_service.NewMockHasherI(ctrl)
+	if setupMock != nil {
+		setupMock(repo, mailer, hasher)
+	}
+
+	return NewEmailService(repo, &fakeTx{}, mailer, hasher, testMailCfg, logger.LoggerForTest())
+}
+
+func linkToken(t *testing.T, body, path string) string {
+	t.Helper()
+
+	link := linkRe.FindString(body)
+	require.NotEmpty(t, link)
+
+	u, err := url.Parse(link)
+	require.NoError(t, err)
+	assert.Equal(t, path, u.Path)
+
+	return u.Query().Get("token")
+}
+
+func TestEmailS_SendVerificationEmail(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	verifiedAt := time.Now()
+	user := domain.User{ID: userID, Username: "alice", Email: "alice@example.com"}
+
+	tests := []struct {
+		name    string
+		f       func(*mock_service.MockRepositoryI, *mock_service.MockMailerI, *mock_service.MockHasherI)
+		wantErr error
+	}{
+		{
+			name: "success",
+			f: func(mri *mock_service.MockRepositoryI, mmi *mock_service.MockMailerI, _ *mock_service.MockHasherI) {
+				var tokenHash string
+				mri.EXPECT().UserByID(gomock.Any(), userID).Return(user, nil)
+				mri.EXPECT().DeleteEmailTokens(gomock.Any(), userID, domain.EmailTokenVerify).Return(nil)
+				mri.EXPECT().CreateEmailToken(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, token domain.EmailToken) error {
+					assert.Equal(t, userID, token.UserID)
+					assert.Equal(t, domain.EmailTokenVerify, token.Purpose)
+					assert.WithinDuration(t, time.Now().Add(testMailCfg.VerifyTTL), token.ExpiresAt, time.Minute)
+					tokenHash = token.TokenHash
+					return nil
+				})
+				mmi.EXPECT().Send(gomock.Any(), "alice@example.com", gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _, _, body string) error {
+					token := linkToken(t, body, "/verify-email")
+					assert.Equal(t, tokenHash, utils.HashToken(token))
+					return nil
+				})
+			},
+		},
+		{
+			name: "already verified",
+			f: func(mri *mock_service.MockRepositoryI, _ *mock_service.MockMailerI, _ *mock_service.MockHasherI) {
+				verified := user
+				verified.VerifiedAt = &verifiedAt
+				mri.EXPECT().UserByID(gomock.Any(), userID).Return(verified, nil)
+			},
+			wantErr: domain.ErrEmailVerified,
+		},
+		{
+			name: "user not found",
+			f: func(mri *mock_service.MockRepositoryI, _ *mock_service.MockMailerI, _ *mock_service.MockHasherI) {
+				mri.EXPECT().UserByID(gomock.Any(), userID).Return(domain.User{}, domain.ErrNotFound)
+			},
+			wantErr: domain.ErrNotFound,
+		},
+		{
+			name: "mailer error",
+			f: func(mri *mock_service.MockRepositoryI, mmi *mock_service.MockMailerI, _ *mock_service.MockHasherI) {
+				mri.EXPECT().UserByID(gomock.Any(), userID).Return(user, nil)
+				mri.EXPECT().DeleteEmailTokens(gomock.Any(), userID, domain.EmailTokenVerify).Return(nil)
+				mri.EXPECT().CreateEmailToken(gomock.Any(), gomock.Any()).Return(nil)
+				mmi.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(domain.ErrFailedToCreate)
+			},
+			wantErr: domain.ErrFailedToCreate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := mockEmailService(t, ctrl, tt.f)
+
+			err := s.SendVerificationEmail(context.Background(), userID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestEmailS_VerifyEmail(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	token := "verify-token"
+
+	tests := []struct {
+		name    string
+		f       func(*mock_service.MockRepositoryI, *mock_service.MockMailerI, *mock_service.MockHasherI)
+		wantErr error
+	}{
+		{
+			name: "success",
+			f: func(mri *mock_service.MockRepositoryI, _ *mock_service.MockMailerI, _ *mock_service.MockHasherI) {
+				mri.EXPECT().ConsumeEmailToken(gomock.Any(), utils.HashToken(token), domain.EmailTokenVerify).Return(domain.EmailToken{
+					UserID:    userID,
+					ExpiresAt: time.Now().Add(time.Hour),
+				}, nil)
+				mri.EXPECT().MarkEmailVerified(gomock.Any(), userID).Return(nil)
+			},
+		},
+		{
+			name: "unknown token",
+			f: func(mri *mock_service.MockRepositoryI, _ *mock_service.MockMailerI, _ *mock_service.MockHasherI) {
+				mri.EXPECT().ConsumeEmailToken(gomock.Any(), gomock.Any(), domain.EmailTokenVerify).Return(domain.EmailToken{}, domain.MakeError(domain.ErrFailedToDelete, domain.ErrNotFound, "email token"))
+			},
+			wantErr: domain.ErrInvalidEmailToken,
+		},
+		{
+			name: "expired token",
+			f: func(mri *mock_service.MockRepositoryI, _ *mock_service.MockMailerI, _ *mock_service.MockHasherI) {
+				mri.EXPECT().ConsumeEmailToken(gomock.Any(), gomock.Any(), domain.EmailTokenVerify).Return(domain.EmailToken{
+					UserID:    userID,
+					ExpiresAt: time.Now().Add(-time.Minute),
+				}, nil)
+			},
+			wantErr: domain.ErrInvalidEmailToken,
+		},
+		{
+			name: "repository error",
+			f: func(mri *mock_service.MockRepositoryI, _ *mock_service.MockMailerI, _ *mock_service.MockHasherI) {
+				mri.EXPECT().ConsumeEmailToken(gomock.Any(), gomock.Any(), domain.EmailTokenVerify).Return(domain.EmailToken{
+					UserID:    userID,
+					ExpiresAt: time.Now().Add(time.Hour),
+				}, nil)
+				mri.EXPECT().MarkEmailVerified(gomock.Any(), userID).Return(domain.ErrFailedToUpdate)
+			},
+			wantErr: domain.ErrFailedToUpdate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := mockEmailService(t, ctrl, tt.f)
+
+			err := s.VerifyEmail(context.Background(), dto.EmailVerify{Token: token})
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestEmailS_EmailVerified(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	verifiedAt := time.Now()
+
+	tests := []struct {
+		name    string
+		f       func(*mock_service.MockRepositoryI, *mock_service.MockMailerI, *mock_service.MockHasherI)
+		wantErr error
+	}{
+		{
+			name: "verified",
+			f: func(mri *mock_service.MockRepositoryI, _ *mock_service.MockMailerI, _ *mock_service.MockHasherI) {
+				mri.EXPECT().UserByID(gomock.Any(), userID).Return(domain.User{ID: userID, VerifiedAt: &verifiedAt}, nil)
+			},
+		},
+		{
+			name: "not verified",
+			f: func(mri *mock_service.MockRepositoryI, _ *mock_service.MockMailerI, _ *mock_service.MockHasherI) {
+				mri.EXPECT().UserByID(gomock.Any(), userID).Return(domain.User{ID: userID}, nil)
+			},
+			wantErr: domain.ErrEmailNotVerified,
+		},
+		{
+			name: "repository error",
+			f: func(mri *mock_service.MockRepositoryI, _ *mock_service.MockMailerI, _ *mock_service.MockHasherI) {
+				mri.EXPECT().UserByID(gomock.Any(), userID).Return(domain.User{}, domain.ErrReceiving)
+			},
+			wantErr: domain.ErrReceiving,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := mockEmailService(t, ctrl, tt.f)
+
+			err := s.EmailVerified(context.Background(), userID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestEmailS_ForgotPassword(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	disabledAt := time.Now()
+	user := domain.User{ID: userID, Username: "alice", Email: "alice@example.com"}
+
+	tests := []struct {
+		name    string
+		f       func(*mock_service.MockRepositoryI, *mock_service.MockMailerI, *mock_service.MockHasherI)
+		wantErr error
+	}{
+		{
+			name: "success",
+			f: func(mri *mock_service.MockRepositoryI, mmi *mock_service.MockMailerI, _ *mock_service.MockHasherI) {
+				var tokenHash string
+				mri.EXPECT().UserCredentials(gomock.Any(), "alice@example.com").Return(userID, "hashed", nil)
+				mri.EXPECT().UserByID(gomock.Any(), userID).Return(user, nil)
+				mri.EXPECT().DeleteEmailTokens(gomock.Any(), userID, domain.EmailTokenReset).Return(nil)
+				mri.EXPECT().CreateEmailToken(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, token domain.EmailToken) error {
+					assert.Equal(t, domain.EmailTokenReset, token.Purpose)
+					assert.WithinDuration(t, time.Now().Add(testMailCfg.ResetTTL), token.ExpiresAt, time.Minute)
+					tokenHash = token.TokenHash
+					return nil
+				})
+				mmi.EXPECT().Send(gomock.Any(), "alice@example.com", gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _, _, body string) error {
+					token := linkToken(t, body, "/reset-password")
+					assert.Equal(t, tokenHash, utils.HashToken(token))
+					return nil
+				})
+			},
+		},
+		{
+			name: "unknown email",
+			f: func(mri *mock_service.MockRepositoryI, _ *mock_service.MockMailerI, _ *mock_service.MockHasherI) {
+				mri.EXPECT().UserCredentials(gomock.Any(), "alice@example.com").Return(uuid.Nil, "", domain.MakeError(domain.ErrReceiving, domain.ErrNotFound, "user"))
+			},
+		},
+		{
+			name: "disabled account",
+			f: func(mri *mock_service.MockRepositoryI, _ *mock_service.MockMailerI, _ *mock_service.MockHasherI) {
+				disabled := user
+				disabled.DisabledAt = &disabledAt
+				mri.EXPECT().UserCredentials(gomock.Any(), "alice@example.com").Return(userID, "hashed", nil)
+				mri.EXPECT().UserByID(gomock.Any(), userID).Return(disabled, nil)
+			},
+		},
+		{
+			name: "repository error",
+			f: func(mri *mock_service.MockRepositoryI, _ *mock_service.MockMailerI, _ *mock_service.MockHasherI) {
+				mri.EXPECT().UserCredentials(gomock.Any(), "alice@example.com").Return(uuid.Nil, "", domain.ErrReceiving)
+			},
+			wantErr: domain.ErrReceiving,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := mockEmailService(t, ctrl, tt.f)
+
+			err := s.ForgotPassword(context.Background(), dto.ForgotPassword{Email: "alice@example.com"})
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestEmailS_ResetPassword(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	token := "reset-token"
+	valid := domain.EmailToken{UserID: userID, Purpose: domain.EmailTokenReset, ExpiresAt: time.Now().Add(time.Hour)}
+
+	tests := []struct {
+		name          string
+		f             func(*mock_service.MockRepositoryI, *mock_service.MockMailerI, *mock_service.MockHasherI)
+		wantErr       error
+		wantCommits   int
+		wantRollbacks int
+	}{
+		{
+			name: "success",
+			f: func(mri *mock_service.MockRepositoryI, _ *mock_service.MockMailerI, mhi *mock_service.MockHasherI) {
+				mhi.EXPECT().GenerateHash("new_password").Return("new_hash", nil)
+				mri.EXPECT().ConsumeEmailToken(gomock.Any(), utils.HashToken(token), domain.EmailTokenReset).Return(valid, nil)
+				mri.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, upd domain.UserUpdate) error {
+					assert.Equal(t, userID, upd.ID)
+					require.NotNil(t, upd.Password)
+					assert.Equal(t, "new_hash", *upd.Password)
+					assert.Nil(t, upd.Email)
+					return nil
+				})
+				mri.EXPECT().DeleteEmailTokens(gomock.Any(), userID, domain.EmailTokenReset).Return(nil)
+				mri.EXPECT().MarkEmailVerified(gomock.Any(), userID).Return(nil)
+				mri.EXPECT().DeleteSessions(gomock.Any(), userID).Return(nil)
+			},
+			wantCommits: 1,
+		},
+		{
+			name: "unknown token",
+			f: func(mri *mock_service.MockRepositoryI, _ *mock_service.MockMailerI, mhi *mock_service.MockHasherI) {
+				mhi.EXPECT().GenerateHash(gomock.Any()).Return("new_hash", nil)
+				mri.EXPECT().ConsumeEmailToken(gomock.Any(), gomock.Any(), domain.EmailTokenReset).Return(domain.EmailToken{}, domain.ErrNotFound)
+			},
+			wantErr:       domain.ErrInvalidEmailToken,
+			wantRollbacks: 1,
+		},
+		{
+			name: "expired token",
+			f: func(mri *mock_service.MockRepositoryI, _ *mock_service.MockMailerI, mhi *mock_service.MockHasherI) {
+				expired := valid
+				expired.ExpiresAt = time.Now().Add(-time.Minute)
+				mhi.EXPECT().GenerateHash(gomock.Any()).Return("new_hash", nil)
+				mri.EXPECT().ConsumeEmailToken(gomock.Any(), gomock.Any(), domain.EmailTokenReset).Return(expired, nil)
+			},
+			wantErr:       domain.ErrInvalidEmailToken,
+			wantRollbacks: 1,
+		},
+		{
+			name: "sessions error",
+			f: func(mri *mock_service.MockRepositoryI, _ *mock_service.MockMailerI, mhi *mock_service.MockHasherI) {
+				mhi.EXPECT().GenerateHash(gomock.Any()).Return("new_hash", nil)
+				mri.EXPECT().ConsumeEmailToken(gomock.Any(), gomock.Any(), domain.EmailTokenReset).Return(valid, nil)
+				mri.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Return(nil)
+				mri.EXPECT().DeleteEmailTokens(gomock.Any(), userID, domain.EmailTokenReset).Return(nil)
+				mri.EXPECT().MarkEmailVerified(gomock.Any(), userID).Return(nil)
+				mri.EXPECT().DeleteSessions(gomock.Any(), userID).Return(domain.ErrFailedToDelete)
+			},
+			wantErr:       domain.ErrFailedToDelete,
+			wantRollbacks: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := mockEmailService(t, ctrl, tt.f)
+
+			err := s.ResetPassword(context.Background(), dto.PasswordReset{Token: token, Password: "new_password"})
+
+			tx := s.tx.(*fakeTx)
+			assert.Equal(t, tt.wantCommits, tx.commits)
+			assert.Equal(t, tt.wantRollbacks, tx.rollbacks)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const noteEventsPageSize = 100
+
+type EventRI interface {
+	CreateNoteEvents(ctx context.Context, event domain.NoteEvent) ([]domain.NoteEvent, error)
+	NoteEvents(ctx context.Context, userID uuid.UUID, afterID int64, limit int) ([]domain.NoteEvent, error)
+	LastNoteEventID(ctx context.Context, userID uuid.UUID) (int64, error)
+	PurgeNoteEvents(ctx context.Context, createdBefore time.Time) (int64, error)
+}
+
+type EventS struct {
+	repo   EventRI
+	broker BrokerI
+	log    *logger.Logger
+}
+
+func NewEventService(repo EventRI, broker BrokerI, log *logger.Logger) *EventS {
+	return &EventS{
+		repo:   repo,
+		broker: broker,
+		log:    log,
+	}
+}
+
+func (e *EventS) NoteEventStream(ctx context.Context, userID uuid.UUID, lastEventID *int64) (<-chan dto.NoteEventOutput, error) {
+	live, err := e.broker.Subscribe(ctx, domain.UserTopic(userID))
+	if err != nil {
+		e.log.Error("failed to subscribe to user events",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return nil, err
+	}
+
+	var since int64
+	if lastEventID != nil {
+		since = *lastEventID
+	} else if since, err = e.repo.LastNoteEventID(ctx, userID); err != nil {
+		e.log.Error("failed to get last note event ID from repository",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return nil, err
+	}
+
+	events, err := e.repo.NoteEvents(ctx, userID, since, noteEventsPageSize)
+	if err != nil {
+		e.log.Error("failed to get note events from repository",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+			zap.Int64("last_event_id", since),
+		)
+		return nil, err
+	}
+
+	out := make(chan dto.NoteEventOutput)
+	go func() {
+		defer close(out)
+
+		lastID := since
+		send := func(event dto.NoteEventOutput) bool {
+			select {
+			case out <- event:
+				lastID = event.ID
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for {
+			for _, v := range events {
+				if !send(noteEventDomainToDTO(v)) {
+					return
+				}
+			}
+
+			if len(events) < noteEventsPageSize {
+				break
+			}
+
+			if events, err = e.repo.NoteEvents(ctx, userID, lastID, noteEventsPageSize); err != nil {
+				e.log.Error("failed to get note events from repository",
+					zap.Error(err),
+					zap.String("user_id", userID.String()),
+					zap.Int64("last_event_id", lastID),
+				)
+				return
+			}
+		}
+
+		for payload := range live {
+			var event dto.NoteEventOutput
+			if err := json.Unmarshal(payload, &event); err != nil {
+				e.log.Warn("failed to decode user event", zap.Error(err))
+				continue
+			}
+
+			if event.ID <= lastID {
+				continue
+			}
+
+			if !send(event) {
+				return
+			}
+		}
+	}()
+
+	e.log.Debug("note event stream opened",
+		zap.String("user_id", userID.String()),
+		zap.Int64("last_event_id", since),
+		zap.Int("backlog", len(events)),
+	)
+
+	return out, nil
+}
+
+func (e *EventS) PurgeNoteEvents(ctx context.Context, retention time.Duration) (int64, error) {
+	purged, err := e.repo.PurgeNoteEvents(ctx, time.Now().Add(-retention))
+	if err != nil {
+		e.log.Error("failed to purge note events",
+			zap.Error(err),
+			zap.Duration("retention", retention),
+		)
+		return 0, err
+	}
+
+	if purged > 0 {
+		e.log.Info("old note events purged",
+			zap.Int64("purged", purged),
+			zap.Duration("retention", retention),
+		)
+	}
+
+	return purged, nil
+}
+
+func (e *EventS) RunEventPurge(ctx context.Context, interval, retention time.Duration) {
+	e.log.Info("note event purge worker started",
+		zap.Duration("interval", interval),
+		zap.Duration("retention", retention),
+	)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		_, _ = e.PurgeNoteEvents(ctx, retention)
+
+		select {
+		case <-ctx.Done():
+			e.log.Info("note event purge worker stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func noteEventDomainToDTO(event domain.NoteEvent) dto.NoteEventOutput {
+	return dto.NoteEventOutput{
+		ID:         event.ID,
+		Type:       event.Type,
+		NoteID:     event.NoteID,
+		UserID:     event.ActorID,
+		OccurredAt: event.CreatedAt,
+	}
+}
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	mock_service "noteApp/internal/service/mock"
+	"noteApp/pkg/logger"
+	"noteApp/pkg/pubsub"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func receiveEventIDs(t *testing.T, events <-chan dto.NoteEventOutput, n int) []int64 {
+	t.Helper()
+
+	ids := make([]int64, 0, n)
+	for len(ids) < n {
+		select {
+		case event, ok := <-events:
+			require.True(t, ok, "stream closed early")
+			ids = append(ids, event.ID)
+		case <-time.After(time.Second):
+			t.Fatalf("received %d of %d events", len(ids), n)
+		}
+	}
+
+	return ids
+}
+
+func TestEventS_NoteEventStream(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	noteID := uuid.New()
+
+	event := func(id int64) domain.NoteEvent {
+		return domain.NoteEvent{ID: id, UserID: userID, NoteID: noteID, ActorID: userID, Type: domain.NoteEventUpdated}
+	}
+
+	t.Run("replays backlog then streams live events", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		hub := pubsub.NewHub()
+		repo := mock_service.NewMockRepositoryI(ctrl)
+		repo.EXPECT().NoteEvents(gomock.Any(), userID, int64(5), noteEventsPageSize).
+			Return([]domain.NoteEvent{event(6), event(7)}, nil)
+
+		s := NewEventService(repo, hub, logger.LoggerForTest())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events, err := s.NoteEventStream(ctx, userID, &[]int64{5}[0])
+		require.NoError(t, err)
+
+		assert.Equal(t, []int64{6, 7}, receiveEventIDs(t, events, 2))
+
+		for _, id := range []int64{7, 8} {
+			payload, err := json.Marshal(noteEventDomainToDTO(event(id)))
+			require.NoError(t, err)
+			require.NoError(t, hub.Publish(context.Background(), domain.UserTopic(userID), payload))
+		}
+
+		assert.Equal(t, []int64{8}, receiveEventIDs(t, events, 1))
+
+		cancel()
+		for range events {
+		}
+	})
+
+	t.Run("pages through backlog", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		page := make([]domain.NoteEvent, 0, noteEventsPageSize)
+		for i := 1; i <= noteEventsPageSize; i++ {
+			page = append(page, event(int64(i)))
+		}
+
+		repo := mock_service.NewMockRepositoryI(ctrl)
+		gomock.InOrder(
+			repo.EXPECT().NoteEvents(gomock.Any(), userID, int64(0), noteEventsPageSize).Return(page, nil),
+			repo.EXPECT().NoteEvents(gomock.Any(), userID, int64(noteEventsPageSize), noteEventsPageSize).
+				Return([]domain.NoteEvent{event(noteEventsPageSize + 1)}, nil),
+		)
+
+		s := NewEventService(repo, pubsub.NewHub(), logger.LoggerForTest())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events, err := s.NoteEventStream(ctx, userID, &[]int64{0}[0])
+		require.NoError(t, err)
+
+		ids := receiveEventIDs(t, events, noteEventsPageSize+1)
+		assert.Equal(t, int64(noteEventsPageSize+1), ids[len(ids)-1])
+
+		cancel()
+		for range events {
+		}
+	})
+
+	t.Run("without last event ID streams live events only", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		hub := pubsub.NewHub()
+		repo := mock_service.NewMockRepositoryI(ctrl)
+		repo.EXPECT().LastNoteEventID(gomock.Any(), userID).Return(int64(9), nil)
+		repo.EXPECT().NoteEvents(gomock.Any(), userID, int64(9), noteEventsPageSize).Return(nil, nil)
+
+		s := NewEventService(repo, hub, logger.LoggerForTest())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events, err := s.NoteEventStream(ctx, userID, nil)
+		require.NoError(t, err)
+
+		for _, id := range []int64{9, 10} {
+			payload, err := json.Marshal(noteEventDomainToDTO(event(id)))
+			require.NoError(t, err)
+			require.NoError(t, hub.Publish(context.Background(), domain.UserTopic(userID), payload))
+		}
+
+		assert.Equal(t, []int64{10}, receiveEventIDs(t, events, 1))
+
+		cancel()
+		for range events {
+		}
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoErr := errors.New("db error")
+		repo := mock_service.NewMockRepositoryI(ctrl)
+		repo.EXPECT().NoteEvents(gomock.Any(), userID, int64(0), noteEventsPageSize).Return(nil, repoErr)
+
+		s := NewEventService(repo, pubsub.NewHub(), logger.LoggerForTest())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		_, err := s.NoteEventStream(ctx, userID, &[]int64{0}[0])
+		require.ErrorIs(t, err, repoErr)
+	})
+}
+
+func TestEventS_PurgeNoteEvents(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	retention := 24 * time.Hour
+	start := time.Now()
+
+	repo := mock_service.NewMockRepositoryI(ctrl)
+	repo.EXPECT().PurgeNoteEvents(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, createdBefore time.Time) (int64, error) {
+			assert.WithinDuration(t, start.Add(-retention), createdBefore, time.Second)
+			return 5, nil
+		})
+
+	s := NewEventService(repo, pubsub.NewHub(), logger.LoggerForTest())
+
+	purged, err := s.PurgeNoteEvents(context.Background(), retention)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), purged)
+}
+
+func TestEventS_RunEventPurge(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	purged := make(chan struct{}, 2)
+
+	repo := mock_service.NewMockRepositoryI(ctrl)
+	repo.EXPECT().PurgeNoteEvents(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(context.Context, time.Time) (int64, error) {
+			select {
+			case purged <- struct{}{}:
+			default:
+			}
+			return 0, nil
+		}).MinTimes(2)
+
+	s := NewEventService(repo, pubsub.NewHub(), logger.LoggerForTest())
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.RunEventPurge(ctx, 10*time.Millisecond, time.Hour)
+	}()
+
+	<-purged
+	<-purged
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("note event purge worker did not stop")
+	}
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+	"noteApp/pkg/utils"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const noteLinkTokenSize = 32
+
+type LinkRI interface {
+	CreateNoteLink(ctx context.Context, link domain.NoteLink) error
+	NoteLinks(ctx context.Context, userID, noteID uuid.UUID) ([]domain.NoteLink, error)
+	NoteLinkByToken(ctx context.Context, tokenHash string) (domain.NoteLink, error)
+	DeleteNoteLink(ctx context.Context, userID, noteID, linkID uuid.UUID) error
+}
+
+type LinkS struct {
+	repo   LinkRI
+	notes  NoteRI
+	hasher HasherI
+	log    *logger.Logger
+}
+
+func NewLinkService(repo LinkRI, notes NoteRI, hasher HasherI, log *logger.Logger) *LinkS {
+	return &LinkS{
+		repo:   repo,
+		notes:  notes,
+		hasher: hasher,
+		log:    log,
+	}
+}
+
+func (l *LinkS) CreateNoteLink(ctx context.Context, link dto.NoteLinkCreate) (dto.NoteLinkOutput, error) {
+	token, err := utils.RandomToken(noteLinkTokenSize)
+	if err != nil {
+		l.log.Error("failed to generate note link token", zap.Error(err))
+		return dto.NoteLinkOutput{}, err
+	}
+
+	input := domain.NoteLink{
+		ID:        uuid.New(),
+		NoteID:    link.NoteID,
+		OwnerID:   link.UserID,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: link.ExpiresAt,
+	}
+
+	if link.Password != "" {
+		hash, err := l.hasher.GenerateHash(link.Password)
+		if err != nil {
+			l.log.Error("failed to hash note link password", zap.Error(err))
+			return dto.NoteLinkOutput{}, err
+		}
+		input.PasswordHash = &hash
+	}
+
+	if err := l.repo.CreateNoteLink(ctx, input); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			l.log.Warn("note not found while creating link",
+				zap.String("user_id", link.UserID.String()),
+				zap.String("note_id", link.NoteID.String()),
+			)
+		} else {
+			l.log.Error("failed to create note link",
+				zap.Error(err),
+				zap.String("user_id", link.UserID.String()),
+				zap.String("note_id", link.NoteID.String()),
+			)
+		}
+		return dto.NoteLinkOutput{}, err
+	}
+
+	l.log.Info("note link created successfully",
+		zap.String("user_id", link.UserID.String()),
+		zap.String("note_id", link.NoteID.String()),
+		zap.String("link_id", input.ID.String()),
+	)
+
+	output := linkDomainToDTO(input)
+	output.Token = token
+
+	return output, nil
+}
+
+func (l *LinkS) NoteLinks(ctx context.Context, userID, noteID uuid.UUID) ([]dto.NoteLinkOutput, error) {
+	linksDB, err := l.repo.NoteLinks(ctx, userID, noteID)
+	if err != nil {
+		if !errors.Is(err, domain.ErrNotFound) {
+			l.log.Error("failed to get note links from repository",
+				zap.Error(err),
+				zap.String("user_id", userID.String()),
+				zap.String("note_id", noteID.String()),
+			)
+		}
+		return nil, err
+	}
+
+	links := make([]dto.NoteLinkOutput, 0, len(linksDB))
+	for _, v := range linksDB {
+		links = append(links, linkDomainToDTO(v))
+	}
+
+	return links, nil
+}
+
+func (l *LinkS) DeleteNoteLink(ctx context.Context, userID, noteID, linkID uuid.UUID) error {
+	if err := l.repo.DeleteNoteLink(ctx, userID, noteID, linkID); err != nil {
+		if !errors.Is(err, domain.ErrNotFound) {
+			l.log.Error("failed to delete note link",
+				zap.Error(err),
+				zap.String("user_id", userID.String()),
+				zap.String("note_id", noteID.String()),
+				zap.String("link_id", linkID.String()),
+			)
+		}
+		return err
+	}
+
+	l.log.Info("note link revoked",
+		zap.String("user_id", userID.String()),
+		zap.String("note_id", noteID.String()),
+		zap.String("link_id", linkID.String()),
+	)
+
+	return nil
+}
+
+func (l *LinkS) PublicNote(ctx context.Context, token, password string) (dto.PublicNoteOutput, error) {
+	link, err := l.repo.NoteLinkByToken(ctx, utils.HashToken(token))
+	if err != nil {
+		if !errors.Is(err, domain.ErrNotFound) {
+			l.log.Error("failed to get note link by token", zap.Error(err))
+		}
+		return dto.PublicNoteOutput{}, err
+	}
+
+	if link.PasswordHash != nil {
+		if password == "" || l.hasher.ComparePassword(*link.PasswordHash, password) != nil {
+			l.log.Debug("incorrect password for note link",
+				zap.String("link_id", link.ID.String()),
+			)
+			return dto.PublicNoteOutput{}, domain.ErrIncorrectPassword
+		}
+	}
+
+	note, err := l.notes.Note(ctx, link.OwnerID, link.NoteID)
+	if err != nil {
+		if !errors.Is(err, domain.ErrNotFound) {
+			l.log.Error("failed to get public note",
+				zap.Error(err),
+				zap.String("link_id", link.ID.String()),
+				zap.String("note_id", link.NoteID.String()),
+			)
+		}
+		return dto.PublicNoteOutput{}, err
+	}
+
+	return dto.PublicNoteOutput{
+		Heading:   note.Heading,
+		Content:   note.Content,
+		Done:      note.Done,
+		Tags:      note.Tags,
+		CreatedAt: note.CreatedAt,
+		UpdatedAt: note.UpdatedAt,
+	}, nil
+}
+
+func linkDomainToDTO(link domain.NoteLink) dto.NoteLinkOutput {
+	return dto.NoteLinkOutput{
+		ID:          link.ID,
+		HasPassword: link.PasswordHash != nil,
+		ExpiresAt:   link.ExpiresAt,
+		CreatedAt:   link.CreatedAt,
+	}
+}
@@ -0,0 +1,310 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	mock_service "noteApp/internal/service/mock"
+	"noteApp/pkg/logger"
+	"noteApp/pkg/utils"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockLinkService(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock_service.MockRepositoryI, *mock_service.MockHasherI)) *LinkS {
+	t.Helper()
+
+	repo := mock_service.NewMockRepositoryI(ctrl)
+	hasher := mock_service.NewMockHasherI(ctrl)
+	if setupMock != nil {
+		setupMock(repo, hasher)
+	}
+
+	return NewLinkService(repo, repo, hasher, logger.LoggerForTest())
+}
+
+func TestLinkS_CreateNoteLink(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	noteID := uuid.New()
+	expiresAt := time.Now().Add(time.Hour)
+	errHash := errors.New("hash error")
+
+	tests := []struct {
+		name            string
+		link            dto.NoteLinkCreate
+		f               func(*mock_service.MockRepositoryI, *mock_service.MockHasherI)
+		wantErr         error
+		wantHasPassword bool
+	}{
+		{
+			name: "without password",
+			link: dto.NoteLinkCreate{NoteID: noteID, UserID: userID, ExpiresAt: &expiresAt},
+			f: func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+				mri.EXPECT().CreateNoteLink(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, link domain.NoteLink) error {
+						assert.Equal(t, noteID, link.NoteID)
+						assert.Equal(t, userID, link.OwnerID)
+						assert.Nil(t, link.PasswordHash)
+						assert.Equal(t, &expiresAt, link.ExpiresAt)
+						assert.Len(t, link.TokenHash, 64)
+						return nil
+					})
+			},
+		},
+		{
+			name: "with password",
+			link: dto.NoteLinkCreate{NoteID: noteID, UserID: userID, Password: "secret"},
+			f: func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+				mhi.EXPECT().GenerateHash("secret").Return("hashed", nil)
+				mri.EXPECT().CreateNoteLink(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, link domain.NoteLink) error {
+						require.NotNil(t, link.PasswordHash)
+						assert.Equal(t, "hashed", *link.PasswordHash)
+						return nil
+					})
+			},
+			wantHasPassword: true,
+		},
+		{
+			name: "hash error",
+			link: dto.NoteLinkCreate{NoteID: noteID, UserID: userID, Password: "secret"},
+			f: func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+				mhi.EXPECT().GenerateHash("secret").Return("", errHash)
+			},
+			wantErr: errHash,
+		},
+		{
+			name: "note not found",
+			link: dto.NoteLinkCreate{NoteID: noteID, UserID: userID},
+			f: func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+				mri.EXPECT().CreateNoteLink(gomock.Any(), gomock.Any()).
+					Return(domain.MakeError(domain.ErrFailedToCreate, domain.ErrNotFound, "note"))
+			},
+			wantErr: domain.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := mockLinkService(t, ctrl, tt.f)
+
+			got, err := s.CreateNoteLink(context.Background(), tt.link)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotEmpty(t, got.Token)
+			assert.NotEqual(t, uuid.Nil, got.ID)
+			assert.Equal(t, tt.wantHasPassword, got.HasPassword)
+		})
+	}
+}
+
+func TestLinkS_NoteLinks(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	noteID := uuid.New()
+	hash := "hashed"
+
+	linksDB := []domain.NoteLink{
+		{ID: uuid.New(), NoteID: noteID, OwnerID: userID, TokenHash: "a", PasswordHash: &hash},
+		{ID: uuid.New(), NoteID: noteID, OwnerID: userID, TokenHash: "b"},
+	}
+
+	tests := []struct {
+		name    string
+		f       func(*mock_service.MockRepositoryI, *mock_service.MockHasherI)
+		want    []dto.NoteLinkOutput
+		wantErr error
+	}{
+		{
+			name: "success",
+			f: func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+				mri.EXPECT().NoteLinks(gomock.Any(), userID, noteID).Return(linksDB, nil)
+			},
+			want: []dto.NoteLinkOutput{
+				{ID: linksDB[0].ID, HasPassword: true},
+				{ID: linksDB[1].ID},
+			},
+		},
+		{
+			name: "note not found",
+			f: func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+				mri.EXPECT().NoteLinks(gomock.Any(), userID, noteID).Return(nil, domain.ErrNotFound)
+			},
+			wantErr: domain.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := mockLinkService(t, ctrl, tt.f)
+
+			got, err := s.NoteLinks(context.Background(), userID, noteID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLinkS_DeleteNoteLink(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	noteID := uuid.New()
+	linkID := uuid.New()
+
+	tests := []struct {
+		name    string
+		f       func(*mock_service.MockRepositoryI, *mock_service.MockHasherI)
+		wantErr error
+	}{
+		{
+			name: "success",
+			f: func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+				mri.EXPECT().DeleteNoteLink(gomock.Any(), userID, noteID, linkID).Return(nil)
+			},
+		},
+		{
+			name: "link not found",
+			f: func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+				mri.EXPECT().DeleteNoteLink(gomock.Any(), userID, noteID, linkID).
+					Return(domain.MakeError(domain.ErrFailedToDelete, domain.ErrNotFound, "note link"))
+			},
+			wantErr: domain.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := mockLinkService(t, ctrl, tt.f)
+
+			err := s.DeleteNoteLink(context.Background(), userID, noteID, linkID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestLinkS_PublicNote(t *testing.T) {
+	t.Parallel()
+
+	ownerID := uuid.New()
+	noteID := uuid.New()
+	token := "token"
+	hash := "hashed"
+
+	link := domain.NoteLink{ID: uuid.New(), NoteID: noteID, OwnerID: ownerID, TokenHash: utils.HashToken(token)}
+	protected := link
+	protected.PasswordHash = &hash
+
+	note := domain.Note{
+		ID:      noteID,
+		UserID:  ownerID,
+		Heading: "heading",
+		Content: "content",
+		Tags:    []string{"work"},
+	}
+
+	tests := []struct {
+		name     string
+		password string
+		f        func(*mock_service.MockRepositoryI, *mock_service.MockHasherI)
+		want     dto.PublicNoteOutput
+		wantErr  error
+	}{
+		{
+			name: "success",
+			f: func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+				mri.EXPECT().NoteLinkByToken(gomock.Any(), utils.HashToken(token)).Return(link, nil)
+				mri.EXPECT().Note(gomock.Any(), ownerID, noteID).Return(note, nil)
+			},
+			want: dto.PublicNoteOutput{Heading: "heading", Content: "content", Tags: []string{"work"}},
+		},
+		{
+			name:     "success with password",
+			password: "secret",
+			f: func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+				mri.EXPECT().NoteLinkByToken(gomock.Any(), utils.HashToken(token)).Return(protected, nil)
+				mhi.EXPECT().ComparePassword(hash, "secret").Return(nil)
+				mri.EXPECT().Note(gomock.Any(), ownerID, noteID).Return(note, nil)
+			},
+			want: dto.PublicNoteOutput{Heading: "heading", Content: "content", Tags: []string{"work"}},
+		},
+		{
+			name: "missing password",
+			f: func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+				mri.EXPECT().NoteLinkByToken(gomock.Any(), utils.HashToken(token)).Return(protected, nil)
+			},
+			wantErr: domain.ErrIncorrectPassword,
+		},
+		{
+			name:     "wrong password",
+			password: "wrong",
+			f: func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+				mri.EXPECT().NoteLinkByToken(gomock.Any(), utils.HashToken(token)).Return(protected, nil)
+				mhi.EXPECT().ComparePassword(hash, "wrong").Return(errors.New("mismatch"))
+			},
+			wantErr: domain.ErrIncorrectPassword,
+		},
+		{
+			name: "link not found or expired",
+			f: func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+				mri.EXPECT().NoteLinkByToken(gomock.Any(), utils.HashToken(token)).
+					Return(domain.NoteLink{}, domain.MakeError(domain.ErrReceiving, domain.ErrNotFound, "note link"))
+			},
+			wantErr: domain.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := mockLinkService(t, ctrl, tt.f)
+
+			got, err := s.PublicNote(context.Background(), token, tt.password)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
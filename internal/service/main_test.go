@@ -1,9 +1,11 @@
 package service
 
 import (
+	"context"
 	"fmt"
 	"noteApp/internal/config"
 	"os"
+	"sync"
 
 	"github.com/joho/godotenv"
 	"github.com/spf13/viper"
@@ -39,3 +41,30 @@ func initConfig() (config.AuthCfg, error) {
 
 	return cfg, nil
 }
+
+type fakeTxKey struct{}
+
+type fakeTx struct {
+	mu        sync.Mutex
+	commits   int
+	rollbacks int
+}
+
+func (f *fakeTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	err := fn(context.WithValue(ctx, fakeTxKey{}, f))
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err != nil {
+		f.rollbacks++
+	} else {
+		f.commits++
+	}
+
+	return err
+}
+
+func inFakeTx(ctx context.Context) bool {
+	_, ok := ctx.Value(fakeTxKey{}).(*fakeTx)
+	return ok
+}
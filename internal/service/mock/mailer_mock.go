@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: noteApp/internal/service (interfaces: MailerI)
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMailerI is a mock of MailerI interface.
+type MockMailerI struct {
+	ctrl     *gomock.Controller
+	recorder *MockMailerIMockRecorder
+}
+
+// MockMailerIMockRecorder is the mock recorder for MockMailerI.
+type MockMailerIMockRecorder struct {
+	mock *MockMailerI
+}
+
+// NewMockMailerI creates a new mock instance.
+func NewMockMailerI(ctrl *gomock.Controller) *MockMailerI {
+	mock := &MockMailerI{ctrl: ctrl}
+	mock.recorder = &MockMailerIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMailerI) EXPECT() *MockMailerIMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockMailerI) Send(arg0 context.Context, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockMailerIMockRecorder) Send(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockMailerI)(nil).Send), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,63 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: noteApp/internal/service (interfaces: NoteEventPublisherI)
+
+// Package mock_service is a generated GoMock package.
+package mock_service
+
+import (
+	context "context"
+	domain "noteApp/internal/models/domain"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockNoteEventPublisherI is a mock of NoteEventPublisherI interface.
+type MockNoteEventPublisherI struct {
+	ctrl     *gomock.Controller
+	recorder *MockNoteEventPublisherIMockRecorder
+}
+
+// MockNoteEventPublisherIMockRecorder is the mock recorder for MockNoteEventPublisherI.
+type MockNoteEventPublisherIMockRecorder struct {
+	mock *MockNoteEventPublisherI
+}
+
+// NewMockNoteEventPublisherI creates a new mock instance.
+func NewMockNoteEventPublisherI(ctrl *gomock.Controller) *MockNoteEventPublisherI {
+	mock := &MockNoteEventPublisherI{ctrl: ctrl}
+	mock.recorder = &MockNoteEventPublisherIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNoteEventPublisherI) EXPECT() *MockNoteEventPublisherIMockRecorder {
+	return m.recorder
+}
+
+// BroadcastNoteEvents mocks base method.
+func (m *MockNoteEventPublisherI) BroadcastNoteEvents(arg0 context.Context, arg1 string, arg2, arg3 uuid.UUID, arg4 []domain.NoteEvent) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "BroadcastNoteEvents", arg0, arg1, arg2, arg3, arg4)
+}
+
+// BroadcastNoteEvents indicates an expected call of BroadcastNoteEvents.
+func (mr *MockNoteEventPublisherIMockRecorder) BroadcastNoteEvents(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BroadcastNoteEvents", reflect.TypeOf((*MockNoteEventPublisherI)(nil).BroadcastNoteEvents), arg0, arg1, arg2, arg3, arg4)
+}
+
+// StoreNoteEvents mocks base method.
+func (m *MockNoteEventPublisherI) StoreNoteEvents(arg0 context.Context, arg1 string, arg2, arg3 uuid.UUID) []domain.NoteEvent {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreNoteEvents", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]domain.NoteEvent)
+	return ret0
+}
+
+// StoreNoteEvents indicates an expected call of StoreNoteEvents.
+func (mr *MockNoteEventPublisherIMockRecorder) StoreNoteEvents(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreNoteEvents", reflect.TypeOf((*MockNoteEventPublisherI)(nil).StoreNoteEvents), arg0, arg1, arg2, arg3)
+}
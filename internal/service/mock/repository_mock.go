@@ -9,6 +9,7 @@ import (
 	domain "noteApp/internal/models/domain"
 	dto "noteApp/internal/models/dto"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
@@ -37,119 +38,992 @@ func (m *MockRepositoryI) EXPECT() *MockRepositoryIMockRecorder {
 	return m.recorder
 }
 
+// BatchNotes mocks base method.
+func (m *MockRepositoryI) BatchNotes(arg0 context.Context, arg1 uuid.UUID, arg2 []domain.NoteBatchOp, arg3 bool) ([]error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchNotes", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchNotes indicates an expected call of BatchNotes.
+func (mr *MockRepositoryIMockRecorder) BatchNotes(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchNotes", reflect.TypeOf((*MockRepositoryI)(nil).BatchNotes), arg0, arg1, arg2, arg3)
+}
+
+// ConfirmTwoFactor mocks base method.
+func (m *MockRepositoryI) ConfirmTwoFactor(arg0 context.Context, arg1 uuid.UUID, arg2 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmTwoFactor", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmTwoFactor indicates an expected call of ConfirmTwoFactor.
+func (mr *MockRepositoryIMockRecorder) ConfirmTwoFactor(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTwoFactor", reflect.TypeOf((*MockRepositoryI)(nil).ConfirmTwoFactor), arg0, arg1, arg2)
+}
+
+// ConsumeEmailToken mocks base method.
+func (m *MockRepositoryI) ConsumeEmailToken(arg0 context.Context, arg1, arg2 string) (domain.EmailToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeEmailToken", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.EmailToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeEmailToken indicates an expected call of ConsumeEmailToken.
+func (mr *MockRepositoryIMockRecorder) ConsumeEmailToken(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeEmailToken", reflect.TypeOf((*MockRepositoryI)(nil).ConsumeEmailToken), arg0, arg1, arg2)
+}
+
+// CreateEmailToken mocks base method.
+func (m *MockRepositoryI) CreateEmailToken(arg0 context.Context, arg1 domain.EmailToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEmailToken", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateEmailToken indicates an expected call of CreateEmailToken.
+func (mr *MockRepositoryIMockRecorder) CreateEmailToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEmailToken", reflect.TypeOf((*MockRepositoryI)(nil).CreateEmailToken), arg0, arg1)
+}
+
 // CreateNote mocks base method.
 func (m *MockRepositoryI) CreateNote(arg0 context.Context, arg1 domain.Note) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateNote", arg0, arg1)
+	ret := m.ctrl.Call(m, "CreateNote", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateNote indicates an expected call of CreateNote.
+func (mr *MockRepositoryIMockRecorder) CreateNote(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNote", reflect.TypeOf((*MockRepositoryI)(nil).CreateNote), arg0, arg1)
+}
+
+// CreateNoteEvents mocks base method.
+func (m *MockRepositoryI) CreateNoteEvents(arg0 context.Context, arg1 domain.NoteEvent) ([]domain.NoteEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNoteEvents", arg0, arg1)
+	ret0, _ := ret[0].([]domain.NoteEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateNoteEvents indicates an expected call of CreateNoteEvents.
+func (mr *MockRepositoryIMockRecorder) CreateNoteEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNoteEvents", reflect.TypeOf((*MockRepositoryI)(nil).CreateNoteEvents), arg0, arg1)
+}
+
+// CreateNoteLink mocks base method.
+func (m *MockRepositoryI) CreateNoteLink(arg0 context.Context, arg1 domain.NoteLink) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNoteLink", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateNoteLink indicates an expected call of CreateNoteLink.
+func (mr *MockRepositoryIMockRecorder) CreateNoteLink(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNoteLink", reflect.TypeOf((*MockRepositoryI)(nil).CreateNoteLink), arg0, arg1)
+}
+
+// CreateNotebook mocks base method.
+func (m *MockRepositoryI) CreateNotebook(arg0 context.Context, arg1 domain.Notebook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNotebook", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateNotebook indicates an expected call of CreateNotebook.
+func (mr *MockRepositoryIMockRecorder) CreateNotebook(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNotebook", reflect.TypeOf((*MockRepositoryI)(nil).CreateNotebook), arg0, arg1)
+}
+
+// CreatePersonalToken mocks base method.
+func (m *MockRepositoryI) CreatePersonalToken(arg0 context.Context, arg1 domain.PersonalToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePersonalToken", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePersonalToken indicates an expected call of CreatePersonalToken.
+func (mr *MockRepositoryIMockRecorder) CreatePersonalToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePersonalToken", reflect.TypeOf((*MockRepositoryI)(nil).CreatePersonalToken), arg0, arg1)
+}
+
+// CreateToken mocks base method.
+func (m *MockRepositoryI) CreateToken(arg0 context.Context, arg1 domain.Token) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateToken", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateToken indicates an expected call of CreateToken.
+func (mr *MockRepositoryIMockRecorder) CreateToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateToken", reflect.TypeOf((*MockRepositoryI)(nil).CreateToken), arg0, arg1)
+}
+
+// CreateTwoFactorChallenge mocks base method.
+func (m *MockRepositoryI) CreateTwoFactorChallenge(arg0 context.Context, arg1 domain.TwoFactorChallenge) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTwoFactorChallenge", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTwoFactorChallenge indicates an expected call of CreateTwoFactorChallenge.
+func (mr *MockRepositoryIMockRecorder) CreateTwoFactorChallenge(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTwoFactorChallenge", reflect.TypeOf((*MockRepositoryI)(nil).CreateTwoFactorChallenge), arg0, arg1)
+}
+
+// CreateUser mocks base method.
+func (m *MockRepositoryI) CreateUser(arg0 context.Context, arg1 domain.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockRepositoryIMockRecorder) CreateUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockRepositoryI)(nil).CreateUser), arg0, arg1)
+}
+
+// DeleteEmailTokens mocks base method.
+func (m *MockRepositoryI) DeleteEmailTokens(arg0 context.Context, arg1 uuid.UUID, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEmailTokens", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEmailTokens indicates an expected call of DeleteEmailTokens.
+func (mr *MockRepositoryIMockRecorder) DeleteEmailTokens(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEmailTokens", reflect.TypeOf((*MockRepositoryI)(nil).DeleteEmailTokens), arg0, arg1, arg2)
+}
+
+// DeleteNote mocks base method.
+func (m *MockRepositoryI) DeleteNote(arg0 context.Context, arg1, arg2 uuid.UUID, arg3 *int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNote", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNote indicates an expected call of DeleteNote.
+func (mr *MockRepositoryIMockRecorder) DeleteNote(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNote", reflect.TypeOf((*MockRepositoryI)(nil).DeleteNote), arg0, arg1, arg2, arg3)
+}
+
+// DeleteNoteLink mocks base method.
+func (m *MockRepositoryI) DeleteNoteLink(arg0 context.Context, arg1, arg2, arg3 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNoteLink", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNoteLink indicates an expected call of DeleteNoteLink.
+func (mr *MockRepositoryIMockRecorder) DeleteNoteLink(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNoteLink", reflect.TypeOf((*MockRepositoryI)(nil).DeleteNoteLink), arg0, arg1, arg2, arg3)
+}
+
+// DeleteNotebook mocks base method.
+func (m *MockRepositoryI) DeleteNotebook(arg0 context.Context, arg1, arg2 uuid.UUID, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNotebook", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNotebook indicates an expected call of DeleteNotebook.
+func (mr *MockRepositoryIMockRecorder) DeleteNotebook(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNotebook", reflect.TypeOf((*MockRepositoryI)(nil).DeleteNotebook), arg0, arg1, arg2, arg3)
+}
+
+// DeleteOtherSessions mocks base method.
+func (m *MockRepositoryI) DeleteOtherSessions(arg0 context.Context, arg1, arg2 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOtherSessions", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOtherSessions indicates an expected call of DeleteOtherSessions.
+func (mr *MockRepositoryIMockRecorder) DeleteOtherSessions(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOtherSessions", reflect.TypeOf((*MockRepositoryI)(nil).DeleteOtherSessions), arg0, arg1, arg2)
+}
+
+// DeletePersonalToken mocks base method.
+func (m *MockRepositoryI) DeletePersonalToken(arg0 context.Context, arg1, arg2 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePersonalToken", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePersonalToken indicates an expected call of DeletePersonalToken.
+func (mr *MockRepositoryIMockRecorder) DeletePersonalToken(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePersonalToken", reflect.TypeOf((*MockRepositoryI)(nil).DeletePersonalToken), arg0, arg1, arg2)
+}
+
+// DeleteSession mocks base method.
+func (m *MockRepositoryI) DeleteSession(arg0 context.Context, arg1, arg2 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSession", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSession indicates an expected call of DeleteSession.
+func (mr *MockRepositoryIMockRecorder) DeleteSession(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*MockRepositoryI)(nil).DeleteSession), arg0, arg1, arg2)
+}
+
+// DeleteSessions mocks base method.
+func (m *MockRepositoryI) DeleteSessions(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSessions", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSessions indicates an expected call of DeleteSessions.
+func (mr *MockRepositoryIMockRecorder) DeleteSessions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSessions", reflect.TypeOf((*MockRepositoryI)(nil).DeleteSessions), arg0, arg1)
+}
+
+// DeleteTag mocks base method.
+func (m *MockRepositoryI) DeleteTag(arg0 context.Context, arg1, arg2 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTag", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTag indicates an expected call of DeleteTag.
+func (mr *MockRepositoryIMockRecorder) DeleteTag(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTag", reflect.TypeOf((*MockRepositoryI)(nil).DeleteTag), arg0, arg1, arg2)
+}
+
+// DeleteToken mocks base method.
+func (m *MockRepositoryI) DeleteToken(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteToken", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteToken indicates an expected call of DeleteToken.
+func (mr *MockRepositoryIMockRecorder) DeleteToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteToken", reflect.TypeOf((*MockRepositoryI)(nil).DeleteToken), arg0, arg1)
+}
+
+// DeleteTokenFamily mocks base method.
+func (m *MockRepositoryI) DeleteTokenFamily(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTokenFamily", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTokenFamily indicates an expected call of DeleteTokenFamily.
+func (mr *MockRepositoryIMockRecorder) DeleteTokenFamily(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTokenFamily", reflect.TypeOf((*MockRepositoryI)(nil).DeleteTokenFamily), arg0, arg1)
+}
+
+// DeleteTwoFactor mocks base method.
+func (m *MockRepositoryI) DeleteTwoFactor(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTwoFactor", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTwoFactor indicates an expected call of DeleteTwoFactor.
+func (mr *MockRepositoryIMockRecorder) DeleteTwoFactor(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTwoFactor", reflect.TypeOf((*MockRepositoryI)(nil).DeleteTwoFactor), arg0, arg1)
+}
+
+// DeleteTwoFactorChallenge mocks base method.
+func (m *MockRepositoryI) DeleteTwoFactorChallenge(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTwoFactorChallenge", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTwoFactorChallenge indicates an expected call of DeleteTwoFactorChallenge.
+func (mr *MockRepositoryIMockRecorder) DeleteTwoFactorChallenge(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTwoFactorChallenge", reflect.TypeOf((*MockRepositoryI)(nil).DeleteTwoFactorChallenge), arg0, arg1)
+}
+
+// DeleteUser mocks base method.
+func (m *MockRepositoryI) DeleteUser(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockRepositoryIMockRecorder) DeleteUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockRepositoryI)(nil).DeleteUser), arg0, arg1)
+}
+
+// EmptyTrash mocks base method.
+func (m *MockRepositoryI) EmptyTrash(arg0 context.Context, arg1 uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EmptyTrash", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EmptyTrash indicates an expected call of EmptyTrash.
+func (mr *MockRepositoryIMockRecorder) EmptyTrash(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EmptyTrash", reflect.TypeOf((*MockRepositoryI)(nil).EmptyTrash), arg0, arg1)
+}
+
+// FailTwoFactorChallenge mocks base method.
+func (m *MockRepositoryI) FailTwoFactorChallenge(arg0 context.Context, arg1 string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailTwoFactorChallenge", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FailTwoFactorChallenge indicates an expected call of FailTwoFactorChallenge.
+func (mr *MockRepositoryIMockRecorder) FailTwoFactorChallenge(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailTwoFactorChallenge", reflect.TypeOf((*MockRepositoryI)(nil).FailTwoFactorChallenge), arg0, arg1)
+}
+
+// FuzzySearchNotes mocks base method.
+func (m *MockRepositoryI) FuzzySearchNotes(arg0 context.Context, arg1 uuid.UUID, arg2 dto.NoteSearch) ([]domain.NoteSearchResult, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FuzzySearchNotes", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]domain.NoteSearchResult)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FuzzySearchNotes indicates an expected call of FuzzySearchNotes.
+func (mr *MockRepositoryIMockRecorder) FuzzySearchNotes(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FuzzySearchNotes", reflect.TypeOf((*MockRepositoryI)(nil).FuzzySearchNotes), arg0, arg1, arg2)
+}
+
+// LastNoteEventID mocks base method.
+func (m *MockRepositoryI) LastNoteEventID(arg0 context.Context, arg1 uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastNoteEventID", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LastNoteEventID indicates an expected call of LastNoteEventID.
+func (mr *MockRepositoryIMockRecorder) LastNoteEventID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastNoteEventID", reflect.TypeOf((*MockRepositoryI)(nil).LastNoteEventID), arg0, arg1)
+}
+
+// MarkEmailVerified mocks base method.
+func (m *MockRepositoryI) MarkEmailVerified(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEmailVerified", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkEmailVerified indicates an expected call of MarkEmailVerified.
+func (mr *MockRepositoryIMockRecorder) MarkEmailVerified(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEmailVerified", reflect.TypeOf((*MockRepositoryI)(nil).MarkEmailVerified), arg0, arg1)
+}
+
+// MergeTags mocks base method.
+func (m *MockRepositoryI) MergeTags(arg0 context.Context, arg1, arg2 uuid.UUID, arg3 []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeTags", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergeTags indicates an expected call of MergeTags.
+func (mr *MockRepositoryIMockRecorder) MergeTags(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeTags", reflect.TypeOf((*MockRepositoryI)(nil).MergeTags), arg0, arg1, arg2, arg3)
+}
+
+// MoveNote mocks base method.
+func (m *MockRepositoryI) MoveNote(arg0 context.Context, arg1, arg2 uuid.UUID, arg3 *uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveNote", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveNote indicates an expected call of MoveNote.
+func (mr *MockRepositoryIMockRecorder) MoveNote(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveNote", reflect.TypeOf((*MockRepositoryI)(nil).MoveNote), arg0, arg1, arg2, arg3)
+}
+
+// MoveNotebook mocks base method.
+func (m *MockRepositoryI) MoveNotebook(arg0 context.Context, arg1, arg2 uuid.UUID, arg3 *uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveNotebook", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveNotebook indicates an expected call of MoveNotebook.
+func (mr *MockRepositoryIMockRecorder) MoveNotebook(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveNotebook", reflect.TypeOf((*MockRepositoryI)(nil).MoveNotebook), arg0, arg1, arg2, arg3)
+}
+
+// Note mocks base method.
+func (m *MockRepositoryI) Note(arg0 context.Context, arg1, arg2 uuid.UUID) (domain.Note, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Note", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.Note)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Note indicates an expected call of Note.
+func (mr *MockRepositoryIMockRecorder) Note(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Note", reflect.TypeOf((*MockRepositoryI)(nil).Note), arg0, arg1, arg2)
+}
+
+// NoteChanges mocks base method.
+func (m *MockRepositoryI) NoteChanges(arg0 context.Context, arg1 uuid.UUID, arg2 int64, arg3 int) ([]domain.NoteChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NoteChanges", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]domain.NoteChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NoteChanges indicates an expected call of NoteChanges.
+func (mr *MockRepositoryIMockRecorder) NoteChanges(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NoteChanges", reflect.TypeOf((*MockRepositoryI)(nil).NoteChanges), arg0, arg1, arg2, arg3)
+}
+
+// NoteEvents mocks base method.
+func (m *MockRepositoryI) NoteEvents(arg0 context.Context, arg1 uuid.UUID, arg2 int64, arg3 int) ([]domain.NoteEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NoteEvents", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]domain.NoteEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NoteEvents indicates an expected call of NoteEvents.
+func (mr *MockRepositoryIMockRecorder) NoteEvents(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NoteEvents", reflect.TypeOf((*MockRepositoryI)(nil).NoteEvents), arg0, arg1, arg2, arg3)
+}
+
+// NoteLinkByToken mocks base method.
+func (m *MockRepositoryI) NoteLinkByToken(arg0 context.Context, arg1 string) (domain.NoteLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NoteLinkByToken", arg0, arg1)
+	ret0, _ := ret[0].(domain.NoteLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NoteLinkByToken indicates an expected call of NoteLinkByToken.
+func (mr *MockRepositoryIMockRecorder) NoteLinkByToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NoteLinkByToken", reflect.TypeOf((*MockRepositoryI)(nil).NoteLinkByToken), arg0, arg1)
+}
+
+// NoteLinks mocks base method.
+func (m *MockRepositoryI) NoteLinks(arg0 context.Context, arg1, arg2 uuid.UUID) ([]domain.NoteLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NoteLinks", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]domain.NoteLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NoteLinks indicates an expected call of NoteLinks.
+func (mr *MockRepositoryIMockRecorder) NoteLinks(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NoteLinks", reflect.TypeOf((*MockRepositoryI)(nil).NoteLinks), arg0, arg1, arg2)
+}
+
+// NoteRevision mocks base method.
+func (m *MockRepositoryI) NoteRevision(arg0 context.Context, arg1, arg2 uuid.UUID, arg3 int) (domain.NoteRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NoteRevision", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(domain.NoteRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NoteRevision indicates an expected call of NoteRevision.
+func (mr *MockRepositoryIMockRecorder) NoteRevision(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NoteRevision", reflect.TypeOf((*MockRepositoryI)(nil).NoteRevision), arg0, arg1, arg2, arg3)
+}
+
+// NoteRevisions mocks base method.
+func (m *MockRepositoryI) NoteRevisions(arg0 context.Context, arg1, arg2 uuid.UUID) ([]domain.NoteRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NoteRevisions", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]domain.NoteRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NoteRevisions indicates an expected call of NoteRevisions.
+func (mr *MockRepositoryIMockRecorder) NoteRevisions(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NoteRevisions", reflect.TypeOf((*MockRepositoryI)(nil).NoteRevisions), arg0, arg1, arg2)
+}
+
+// NoteShare mocks base method.
+func (m *MockRepositoryI) NoteShare(arg0 context.Context, arg1, arg2 uuid.UUID) (domain.NoteShare, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NoteShare", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.NoteShare)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NoteShare indicates an expected call of NoteShare.
+func (mr *MockRepositoryIMockRecorder) NoteShare(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NoteShare", reflect.TypeOf((*MockRepositoryI)(nil).NoteShare), arg0, arg1, arg2)
+}
+
+// NoteShares mocks base method.
+func (m *MockRepositoryI) NoteShares(arg0 context.Context, arg1, arg2 uuid.UUID) ([]domain.NoteShare, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NoteShares", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]domain.NoteShare)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NoteShares indicates an expected call of NoteShares.
+func (mr *MockRepositoryIMockRecorder) NoteShares(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NoteShares", reflect.TypeOf((*MockRepositoryI)(nil).NoteShares), arg0, arg1, arg2)
+}
+
+// NoteTombstones mocks base method.
+func (m *MockRepositoryI) NoteTombstones(arg0 context.Context, arg1 uuid.UUID, arg2 int64, arg3 int) ([]domain.NoteChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NoteTombstones", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]domain.NoteChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NoteTombstones indicates an expected call of NoteTombstones.
+func (mr *MockRepositoryIMockRecorder) NoteTombstones(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NoteTombstones", reflect.TypeOf((*MockRepositoryI)(nil).NoteTombstones), arg0, arg1, arg2, arg3)
+}
+
+// Notebook mocks base method.
+func (m *MockRepositoryI) Notebook(arg0 context.Context, arg1, arg2 uuid.UUID) (domain.Notebook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notebook", arg0, arg1, arg2)
+	ret0, _ := ret[0].(domain.Notebook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Notebook indicates an expected call of Notebook.
+func (mr *MockRepositoryIMockRecorder) Notebook(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notebook", reflect.TypeOf((*MockRepositoryI)(nil).Notebook), arg0, arg1, arg2)
+}
+
+// Notebooks mocks base method.
+func (m *MockRepositoryI) Notebooks(arg0 context.Context, arg1 uuid.UUID) ([]domain.Notebook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notebooks", arg0, arg1)
+	ret0, _ := ret[0].([]domain.Notebook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Notebooks indicates an expected call of Notebooks.
+func (mr *MockRepositoryIMockRecorder) Notebooks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notebooks", reflect.TypeOf((*MockRepositoryI)(nil).Notebooks), arg0, arg1)
+}
+
+// Notes mocks base method.
+func (m *MockRepositoryI) Notes(arg0 context.Context, arg1 uuid.UUID, arg2 dto.NoteFilter) ([]domain.Note, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notes", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]domain.Note)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Notes indicates an expected call of Notes.
+func (mr *MockRepositoryIMockRecorder) Notes(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notes", reflect.TypeOf((*MockRepositoryI)(nil).Notes), arg0, arg1, arg2)
+}
+
+// NotesByCursor mocks base method.
+func (m *MockRepositoryI) NotesByCursor(arg0 context.Context, arg1 uuid.UUID, arg2 dto.NoteFilter, arg3 *domain.NoteCursor) ([]domain.Note, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotesByCursor", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]domain.Note)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// NotesByCursor indicates an expected call of NotesByCursor.
+func (mr *MockRepositoryIMockRecorder) NotesByCursor(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotesByCursor", reflect.TypeOf((*MockRepositoryI)(nil).NotesByCursor), arg0, arg1, arg2, arg3)
+}
+
+// PersonalTokens mocks base method.
+func (m *MockRepositoryI) PersonalTokens(arg0 context.Context, arg1 uuid.UUID) ([]domain.PersonalToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PersonalTokens", arg0, arg1)
+	ret0, _ := ret[0].([]domain.PersonalToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PersonalTokens indicates an expected call of PersonalTokens.
+func (mr *MockRepositoryIMockRecorder) PersonalTokens(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PersonalTokens", reflect.TypeOf((*MockRepositoryI)(nil).PersonalTokens), arg0, arg1)
+}
+
+// PurgeExpiredTokens mocks base method.
+func (m *MockRepositoryI) PurgeExpiredTokens(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpiredTokens", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeExpiredTokens indicates an expected call of PurgeExpiredTokens.
+func (mr *MockRepositoryIMockRecorder) PurgeExpiredTokens(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpiredTokens", reflect.TypeOf((*MockRepositoryI)(nil).PurgeExpiredTokens), arg0, arg1)
+}
+
+// PurgeNote mocks base method.
+func (m *MockRepositoryI) PurgeNote(arg0 context.Context, arg1, arg2 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeNote", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// CreateNote indicates an expected call of CreateNote.
-func (mr *MockRepositoryIMockRecorder) CreateNote(arg0, arg1 interface{}) *gomock.Call {
+// PurgeNote indicates an expected call of PurgeNote.
+func (mr *MockRepositoryIMockRecorder) PurgeNote(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNote", reflect.TypeOf((*MockRepositoryI)(nil).CreateNote), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeNote", reflect.TypeOf((*MockRepositoryI)(nil).PurgeNote), arg0, arg1, arg2)
 }
 
-// CreateToken mocks base method.
-func (m *MockRepositoryI) CreateToken(arg0 context.Context, arg1 domain.Token) error {
+// PurgeNoteEvents mocks base method.
+func (m *MockRepositoryI) PurgeNoteEvents(arg0 context.Context, arg1 time.Time) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateToken", arg0, arg1)
+	ret := m.ctrl.Call(m, "PurgeNoteEvents", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeNoteEvents indicates an expected call of PurgeNoteEvents.
+func (mr *MockRepositoryIMockRecorder) PurgeNoteEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeNoteEvents", reflect.TypeOf((*MockRepositoryI)(nil).PurgeNoteEvents), arg0, arg1)
+}
+
+// PurgeTrash mocks base method.
+func (m *MockRepositoryI) PurgeTrash(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeTrash", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeTrash indicates an expected call of PurgeTrash.
+func (mr *MockRepositoryIMockRecorder) PurgeTrash(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeTrash", reflect.TypeOf((*MockRepositoryI)(nil).PurgeTrash), arg0, arg1)
+}
+
+// ReleaseSignInAttempt mocks base method.
+func (m *MockRepositoryI) ReleaseSignInAttempt(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseSignInAttempt", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// CreateToken indicates an expected call of CreateToken.
-func (mr *MockRepositoryIMockRecorder) CreateToken(arg0, arg1 interface{}) *gomock.Call {
+// ReleaseSignInAttempt indicates an expected call of ReleaseSignInAttempt.
+func (mr *MockRepositoryIMockRecorder) ReleaseSignInAttempt(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateToken", reflect.TypeOf((*MockRepositoryI)(nil).CreateToken), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseSignInAttempt", reflect.TypeOf((*MockRepositoryI)(nil).ReleaseSignInAttempt), arg0, arg1, arg2)
 }
 
-// CreateUser mocks base method.
-func (m *MockRepositoryI) CreateUser(arg0 context.Context, arg1 domain.User) error {
+// RenameNotebook mocks base method.
+func (m *MockRepositoryI) RenameNotebook(arg0 context.Context, arg1 domain.NotebookUpdate) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUser", arg0, arg1)
+	ret := m.ctrl.Call(m, "RenameNotebook", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// CreateUser indicates an expected call of CreateUser.
-func (mr *MockRepositoryIMockRecorder) CreateUser(arg0, arg1 interface{}) *gomock.Call {
+// RenameNotebook indicates an expected call of RenameNotebook.
+func (mr *MockRepositoryIMockRecorder) RenameNotebook(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockRepositoryI)(nil).CreateUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameNotebook", reflect.TypeOf((*MockRepositoryI)(nil).RenameNotebook), arg0, arg1)
 }
 
-// DeleteNote mocks base method.
-func (m *MockRepositoryI) DeleteNote(arg0 context.Context, arg1, arg2 uuid.UUID) error {
+// RenameTag mocks base method.
+func (m *MockRepositoryI) RenameTag(arg0 context.Context, arg1 domain.TagUpdate) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteNote", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "RenameTag", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteNote indicates an expected call of DeleteNote.
-func (mr *MockRepositoryIMockRecorder) DeleteNote(arg0, arg1, arg2 interface{}) *gomock.Call {
+// RenameTag indicates an expected call of RenameTag.
+func (mr *MockRepositoryIMockRecorder) RenameTag(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNote", reflect.TypeOf((*MockRepositoryI)(nil).DeleteNote), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameTag", reflect.TypeOf((*MockRepositoryI)(nil).RenameTag), arg0, arg1)
 }
 
-// DeleteToken mocks base method.
-func (m *MockRepositoryI) DeleteToken(arg0 context.Context, arg1 string) error {
+// ReplaceRecoveryCodes mocks base method.
+func (m *MockRepositoryI) ReplaceRecoveryCodes(arg0 context.Context, arg1 uuid.UUID, arg2 []string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteToken", arg0, arg1)
+	ret := m.ctrl.Call(m, "ReplaceRecoveryCodes", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteToken indicates an expected call of DeleteToken.
-func (mr *MockRepositoryIMockRecorder) DeleteToken(arg0, arg1 interface{}) *gomock.Call {
+// ReplaceRecoveryCodes indicates an expected call of ReplaceRecoveryCodes.
+func (mr *MockRepositoryIMockRecorder) ReplaceRecoveryCodes(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteToken", reflect.TypeOf((*MockRepositoryI)(nil).DeleteToken), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceRecoveryCodes", reflect.TypeOf((*MockRepositoryI)(nil).ReplaceRecoveryCodes), arg0, arg1, arg2)
 }
 
-// DeleteUser mocks base method.
-func (m *MockRepositoryI) DeleteUser(arg0 context.Context, arg1 uuid.UUID) error {
+// ReserveSignInAttempt mocks base method.
+func (m *MockRepositoryI) ReserveSignInAttempt(arg0 context.Context, arg1, arg2 string, arg3 time.Time, arg4 domain.SignInLimit) (domain.SignInAttempt, bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteUser", arg0, arg1)
+	ret := m.ctrl.Call(m, "ReserveSignInAttempt", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(domain.SignInAttempt)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ReserveSignInAttempt indicates an expected call of ReserveSignInAttempt.
+func (mr *MockRepositoryIMockRecorder) ReserveSignInAttempt(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveSignInAttempt", reflect.TypeOf((*MockRepositoryI)(nil).ReserveSignInAttempt), arg0, arg1, arg2, arg3, arg4)
+}
+
+// ResetSignInAttempts mocks base method.
+func (m *MockRepositoryI) ResetSignInAttempts(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetSignInAttempts", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteUser indicates an expected call of DeleteUser.
-func (mr *MockRepositoryIMockRecorder) DeleteUser(arg0, arg1 interface{}) *gomock.Call {
+// ResetSignInAttempts indicates an expected call of ResetSignInAttempts.
+func (mr *MockRepositoryIMockRecorder) ResetSignInAttempts(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockRepositoryI)(nil).DeleteUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetSignInAttempts", reflect.TypeOf((*MockRepositoryI)(nil).ResetSignInAttempts), arg0, arg1, arg2)
 }
 
-// Note mocks base method.
-func (m *MockRepositoryI) Note(arg0 context.Context, arg1, arg2 uuid.UUID) (domain.Note, error) {
+// RestoreNote mocks base method.
+func (m *MockRepositoryI) RestoreNote(arg0 context.Context, arg1, arg2 uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Note", arg0, arg1, arg2)
-	ret0, _ := ret[0].(domain.Note)
+	ret := m.ctrl.Call(m, "RestoreNote", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreNote indicates an expected call of RestoreNote.
+func (mr *MockRepositoryIMockRecorder) RestoreNote(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreNote", reflect.TypeOf((*MockRepositoryI)(nil).RestoreNote), arg0, arg1, arg2)
+}
+
+// RotateToken mocks base method.
+func (m *MockRepositoryI) RotateToken(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateToken", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RotateToken indicates an expected call of RotateToken.
+func (mr *MockRepositoryIMockRecorder) RotateToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateToken", reflect.TypeOf((*MockRepositoryI)(nil).RotateToken), arg0, arg1)
+}
+
+// SaveTwoFactor mocks base method.
+func (m *MockRepositoryI) SaveTwoFactor(arg0 context.Context, arg1 domain.TwoFactor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTwoFactor", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveTwoFactor indicates an expected call of SaveTwoFactor.
+func (mr *MockRepositoryIMockRecorder) SaveTwoFactor(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTwoFactor", reflect.TypeOf((*MockRepositoryI)(nil).SaveTwoFactor), arg0, arg1)
+}
+
+// SearchNotes mocks base method.
+func (m *MockRepositoryI) SearchNotes(arg0 context.Context, arg1 uuid.UUID, arg2 string, arg3 dto.Paginated) ([]domain.NoteSearchResult, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchNotes", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]domain.NoteSearchResult)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SearchNotes indicates an expected call of SearchNotes.
+func (mr *MockRepositoryIMockRecorder) SearchNotes(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchNotes", reflect.TypeOf((*MockRepositoryI)(nil).SearchNotes), arg0, arg1, arg2, arg3)
+}
+
+// Sessions mocks base method.
+func (m *MockRepositoryI) Sessions(arg0 context.Context, arg1 uuid.UUID) ([]domain.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sessions", arg0, arg1)
+	ret0, _ := ret[0].([]domain.Session)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Note indicates an expected call of Note.
-func (mr *MockRepositoryIMockRecorder) Note(arg0, arg1, arg2 interface{}) *gomock.Call {
+// Sessions indicates an expected call of Sessions.
+func (mr *MockRepositoryIMockRecorder) Sessions(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Note", reflect.TypeOf((*MockRepositoryI)(nil).Note), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sessions", reflect.TypeOf((*MockRepositoryI)(nil).Sessions), arg0, arg1)
 }
 
-// Notes mocks base method.
-func (m *MockRepositoryI) Notes(arg0 context.Context, arg1 uuid.UUID, arg2 dto.Paginated) ([]domain.Note, int, error) {
+// SetUserDisabled mocks base method.
+func (m *MockRepositoryI) SetUserDisabled(arg0 context.Context, arg1 uuid.UUID, arg2 bool) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Notes", arg0, arg1, arg2)
-	ret0, _ := ret[0].([]domain.Note)
+	ret := m.ctrl.Call(m, "SetUserDisabled", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserDisabled indicates an expected call of SetUserDisabled.
+func (mr *MockRepositoryIMockRecorder) SetUserDisabled(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserDisabled", reflect.TypeOf((*MockRepositoryI)(nil).SetUserDisabled), arg0, arg1, arg2)
+}
+
+// ShareNote mocks base method.
+func (m *MockRepositoryI) ShareNote(arg0 context.Context, arg1 domain.NoteShareTarget) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShareNote", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ShareNote indicates an expected call of ShareNote.
+func (mr *MockRepositoryIMockRecorder) ShareNote(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShareNote", reflect.TypeOf((*MockRepositoryI)(nil).ShareNote), arg0, arg1)
+}
+
+// SharedNotes mocks base method.
+func (m *MockRepositoryI) SharedNotes(arg0 context.Context, arg1 uuid.UUID, arg2 dto.Paginated) ([]domain.SharedNote, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SharedNotes", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]domain.SharedNote)
 	ret1, _ := ret[1].(int)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
-// Notes indicates an expected call of Notes.
-func (mr *MockRepositoryIMockRecorder) Notes(arg0, arg1, arg2 interface{}) *gomock.Call {
+// SharedNotes indicates an expected call of SharedNotes.
+func (mr *MockRepositoryIMockRecorder) SharedNotes(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notes", reflect.TypeOf((*MockRepositoryI)(nil).Notes), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SharedNotes", reflect.TypeOf((*MockRepositoryI)(nil).SharedNotes), arg0, arg1, arg2)
+}
+
+// Tags mocks base method.
+func (m *MockRepositoryI) Tags(arg0 context.Context, arg1 uuid.UUID) ([]domain.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Tags", arg0, arg1)
+	ret0, _ := ret[0].([]domain.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Tags indicates an expected call of Tags.
+func (mr *MockRepositoryIMockRecorder) Tags(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tags", reflect.TypeOf((*MockRepositoryI)(nil).Tags), arg0, arg1)
 }
 
 // Token mocks base method.
@@ -167,6 +1041,96 @@ func (mr *MockRepositoryIMockRecorder) Token(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Token", reflect.TypeOf((*MockRepositoryI)(nil).Token), arg0, arg1)
 }
 
+// TouchPersonalToken mocks base method.
+func (m *MockRepositoryI) TouchPersonalToken(arg0 context.Context, arg1 string) (domain.PersonalToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchPersonalToken", arg0, arg1)
+	ret0, _ := ret[0].(domain.PersonalToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TouchPersonalToken indicates an expected call of TouchPersonalToken.
+func (mr *MockRepositoryIMockRecorder) TouchPersonalToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchPersonalToken", reflect.TypeOf((*MockRepositoryI)(nil).TouchPersonalToken), arg0, arg1)
+}
+
+// TrashedNoteIDs mocks base method.
+func (m *MockRepositoryI) TrashedNoteIDs(arg0 context.Context, arg1 uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrashedNoteIDs", arg0, arg1)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TrashedNoteIDs indicates an expected call of TrashedNoteIDs.
+func (mr *MockRepositoryIMockRecorder) TrashedNoteIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrashedNoteIDs", reflect.TypeOf((*MockRepositoryI)(nil).TrashedNoteIDs), arg0, arg1)
+}
+
+// TrashedNotes mocks base method.
+func (m *MockRepositoryI) TrashedNotes(arg0 context.Context, arg1 uuid.UUID, arg2 dto.Paginated) ([]domain.TrashedNote, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrashedNotes", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]domain.TrashedNote)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// TrashedNotes indicates an expected call of TrashedNotes.
+func (mr *MockRepositoryIMockRecorder) TrashedNotes(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrashedNotes", reflect.TypeOf((*MockRepositoryI)(nil).TrashedNotes), arg0, arg1, arg2)
+}
+
+// TwoFactor mocks base method.
+func (m *MockRepositoryI) TwoFactor(arg0 context.Context, arg1 uuid.UUID) (domain.TwoFactor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TwoFactor", arg0, arg1)
+	ret0, _ := ret[0].(domain.TwoFactor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TwoFactor indicates an expected call of TwoFactor.
+func (mr *MockRepositoryIMockRecorder) TwoFactor(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TwoFactor", reflect.TypeOf((*MockRepositoryI)(nil).TwoFactor), arg0, arg1)
+}
+
+// TwoFactorChallenge mocks base method.
+func (m *MockRepositoryI) TwoFactorChallenge(arg0 context.Context, arg1 string) (domain.TwoFactorChallenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TwoFactorChallenge", arg0, arg1)
+	ret0, _ := ret[0].(domain.TwoFactorChallenge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TwoFactorChallenge indicates an expected call of TwoFactorChallenge.
+func (mr *MockRepositoryIMockRecorder) TwoFactorChallenge(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TwoFactorChallenge", reflect.TypeOf((*MockRepositoryI)(nil).TwoFactorChallenge), arg0, arg1)
+}
+
+// UnshareNote mocks base method.
+func (m *MockRepositoryI) UnshareNote(arg0 context.Context, arg1 domain.NoteShareTarget) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnshareNote", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnshareNote indicates an expected call of UnshareNote.
+func (mr *MockRepositoryIMockRecorder) UnshareNote(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnshareNote", reflect.TypeOf((*MockRepositoryI)(nil).UnshareNote), arg0, arg1)
+}
+
 // UpdateNote mocks base method.
 func (m *MockRepositoryI) UpdateNote(arg0 context.Context, arg1 domain.NoteUpdate) error {
 	m.ctrl.T.Helper()
@@ -195,6 +1159,34 @@ func (mr *MockRepositoryIMockRecorder) UpdateUser(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockRepositoryI)(nil).UpdateUser), arg0, arg1)
 }
 
+// UseRecoveryCode mocks base method.
+func (m *MockRepositoryI) UseRecoveryCode(arg0 context.Context, arg1 uuid.UUID, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockRepositoryIMockRecorder) UseRecoveryCode(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockRepositoryI)(nil).UseRecoveryCode), arg0, arg1, arg2)
+}
+
+// UseTwoFactorStep mocks base method.
+func (m *MockRepositoryI) UseTwoFactorStep(arg0 context.Context, arg1 uuid.UUID, arg2 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseTwoFactorStep", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseTwoFactorStep indicates an expected call of UseTwoFactorStep.
+func (mr *MockRepositoryIMockRecorder) UseTwoFactorStep(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTwoFactorStep", reflect.TypeOf((*MockRepositoryI)(nil).UseTwoFactorStep), arg0, arg1, arg2)
+}
+
 // UserByID mocks base method.
 func (m *MockRepositoryI) UserByID(arg0 context.Context, arg1 uuid.UUID) (domain.User, error) {
 	m.ctrl.T.Helper()
@@ -225,3 +1217,48 @@ func (mr *MockRepositoryIMockRecorder) UserCredentials(arg0, arg1 interface{}) *
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserCredentials", reflect.TypeOf((*MockRepositoryI)(nil).UserCredentials), arg0, arg1)
 }
+
+// UserTwoFactorChallenge mocks base method.
+func (m *MockRepositoryI) UserTwoFactorChallenge(arg0 context.Context, arg1 uuid.UUID) (domain.TwoFactorChallenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserTwoFactorChallenge", arg0, arg1)
+	ret0, _ := ret[0].(domain.TwoFactorChallenge)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserTwoFactorChallenge indicates an expected call of UserTwoFactorChallenge.
+func (mr *MockRepositoryIMockRecorder) UserTwoFactorChallenge(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserTwoFactorChallenge", reflect.TypeOf((*MockRepositoryI)(nil).UserTwoFactorChallenge), arg0, arg1)
+}
+
+// Users mocks base method.
+func (m *MockRepositoryI) Users(arg0 context.Context, arg1 dto.Paginated) ([]domain.User, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Users", arg0, arg1)
+	ret0, _ := ret[0].([]domain.User)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Users indicates an expected call of Users.
+func (mr *MockRepositoryIMockRecorder) Users(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Users", reflect.TypeOf((*MockRepositoryI)(nil).Users), arg0, arg1)
+}
+
+// WithinTx mocks base method.
+func (m *MockRepositoryI) WithinTx(arg0 context.Context, arg1 func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithinTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithinTx indicates an expected call of WithinTx.
+func (mr *MockRepositoryIMockRecorder) WithinTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithinTx", reflect.TypeOf((*MockRepositoryI)(nil).WithinTx), arg0, arg1)
+}
@@ -2,10 +2,16 @@ package service
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"noteApp/internal/models/domain"
 	"noteApp/internal/models/dto"
 	"noteApp/pkg/logger"
+	"noteApp/pkg/utils"
+	"noteApp/pkg/valid"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -14,25 +20,39 @@ import (
 type NoteRI interface {
 	CreateNote(ctx context.Context, note domain.Note) error
 	Note(ctx context.Context, userID, noteID uuid.UUID) (domain.Note, error)
-	Notes(ctx context.Context, userID uuid.UUID, p dto.Paginated) ([]domain.Note, int, error)
+	Notes(ctx context.Context, userID uuid.UUID, f dto.NoteFilter) ([]domain.Note, int, error)
+	NotesByCursor(ctx context.Context, userID uuid.UUID, f dto.NoteFilter, cursor *domain.NoteCursor) ([]domain.Note, bool, error)
+	SearchNotes(ctx context.Context, userID uuid.UUID, tsQuery string, p dto.Paginated) ([]domain.NoteSearchResult, int, error)
+	FuzzySearchNotes(ctx context.Context, userID uuid.UUID, s dto.NoteSearch) ([]domain.NoteSearchResult, int, error)
 	UpdateNote(ctx context.Context, note domain.NoteUpdate) error
-	DeleteNote(ctx context.Context, userID, noteID uuid.UUID) error
+	MoveNote(ctx context.Context, userID, noteID uuid.UUID, notebookID *uuid.UUID) error
+	DeleteNote(ctx context.Context, userID, noteID uuid.UUID, version *int) error
+	BatchNotes(ctx context.Context, userID uuid.UUID, ops []domain.NoteBatchOp, atomic bool) ([]error, error)
 }
 
 type NoteS struct {
-	repo NoteRI
-	log  *logger.Logger
+	repo   NoteRI
+	shares ShareRI
+	events EventRI
+	broker BrokerI
+	log    *logger.Logger
 }
 
-func NewNoteService(repo NoteRI, log *logger.Logger) *NoteS {
+func NewNoteService(repo NoteRI, shares ShareRI, events EventRI, broker BrokerI, log *logger.Logger) *NoteS {
 	return &NoteS{
-		repo: repo,
-		log:  log,
+		repo:   repo,
+		shares: shares,
+		events: events,
+		broker: broker,
+		log:    log,
 	}
 }
 
 func (n *NoteS) CreateNote(ctx context.Context, note dto.NoteCreate) (uuid.UUID, error) {
-	noteID := uuid.New()
+	noteID := note.ID
+	if noteID == uuid.Nil {
+		noteID = uuid.New()
+	}
 	input := noteCreateDTOtoDomain(note)
 	input.ID = noteID
 
@@ -59,13 +79,21 @@ func (n *NoteS) CreateNote(ctx context.Context, note dto.NoteCreate) (uuid.UUID,
 		zap.String("note_id", input.ID.String()),
 	)
 
+	n.publish(ctx, domain.NoteEventCreated, input.UserID, input.ID)
+
 	return noteID, nil
 }
 
 func (n *NoteS) Note(ctx context.Context, userID, noteID uuid.UUID) (dto.NoteOutput, error) {
 	noteDB, err := n.repo.Note(ctx, userID, noteID)
+	if errors.Is(err, domain.ErrNotFound) {
+		var share domain.NoteShare
+		if share, err = n.noteShare(ctx, userID, noteID, err); err == nil {
+			noteDB, err = n.repo.Note(ctx, share.OwnerID, noteID)
+		}
+	}
 	if err != nil {
-		if err == domain.ErrNotFound {
+		if errors.Is(err, domain.ErrNotFound) {
 			n.log.Warn("note not found",
 				zap.String("user_id", userID.String()),
 				zap.String("note_id", noteID.String()),
@@ -88,14 +116,16 @@ func (n *NoteS) Note(ctx context.Context, userID, noteID uuid.UUID) (dto.NoteOut
 	return noteDomainToDTO(noteDB), nil
 }
 
-func (n *NoteS) Notes(ctx context.Context, userID uuid.UUID, p dto.Paginated) (dto.PaginatedResponse, error) {
-	notesDB, total, err := n.repo.Notes(ctx, userID, p)
+func (n *NoteS) Notes(ctx context.Context, userID uuid.UUID, f dto.NoteFilter) (dto.PaginatedResponse, error) {
+	f.Tags = normalizeTags(f.Tags)
+
+	notesDB, total, err := n.repo.Notes(ctx, userID, f)
 	if err != nil {
 		n.log.Error("failed to get notes from repository",
 			zap.Error(err),
 			zap.String("user_id", userID.String()),
-			zap.Int("limit", p.Limit),
-			zap.Int("offset", p.Offset),
+			zap.Int("limit", f.Limit),
+			zap.Int("offset", f.Offset),
 		)
 		return dto.PaginatedResponse{}, err
 	}
@@ -103,13 +133,13 @@ func (n *NoteS) Notes(ctx context.Context, userID uuid.UUID, p dto.Paginated) (d
 	if total == 0 {
 		n.log.Debug("no notes found for user",
 			zap.String("user_id", userID.String()),
-			zap.Int("limit", p.Limit),
-			zap.Int("offset", p.Offset),
+			zap.Int("limit", f.Limit),
+			zap.Int("offset", f.Offset),
 		)
 		return dto.PaginatedResponse{}, nil
 	}
 
-	notes := make([]dto.NoteOutput, len(notesDB))
+	notes := make([]dto.NoteOutput, 0, len(notesDB))
 	for _, v := range notesDB {
 		notes = append(notes, noteDomainToDTO(v))
 	}
@@ -118,10 +148,141 @@ func (n *NoteS) Notes(ctx context.Context, userID uuid.UUID, p dto.Paginated) (d
 		zap.String("user_id", userID.String()),
 		zap.Int("count", len(notes)),
 		zap.Int("total", total),
-		zap.Int("page", p.Limit),
+		zap.Int("page", f.Limit),
 	)
 
-	return dto.MakePaginatedResponse(notes, total, p.Offset, p.Limit), nil
+	return dto.MakePaginatedResponse(notes, total, f.Offset, f.Limit), nil
+}
+
+func (n *NoteS) NotesByCursor(ctx context.Context, userID uuid.UUID, f dto.NoteFilter) (dto.CursorPaginatedResponse, error) {
+	f.Tags = normalizeTags(f.Tags)
+	if f.Sort == "" {
+		f.Sort = "created_at"
+	}
+	if f.Order == "" {
+		f.Order = "asc"
+	}
+
+	var cursor *domain.NoteCursor
+	if f.After != "" || f.Before != "" {
+		raw := f.After
+		if raw == "" {
+			raw = f.Before
+		}
+
+		decoded, err := decodeNoteCursor(raw)
+		if err != nil || decoded.Sort != f.Sort || decoded.Order != f.Order {
+			n.log.Debug("invalid notes cursor",
+				zap.String("user_id", userID.String()),
+				zap.String("cursor", raw),
+			)
+			return dto.CursorPaginatedResponse{}, domain.ErrInvalidCursor
+		}
+
+		decoded.Backward = f.Before != ""
+		cursor = &decoded
+	}
+
+	notesDB, hasMore, err := n.repo.NotesByCursor(ctx, userID, f, cursor)
+	if err != nil {
+		n.log.Error("failed to get notes by cursor from repository",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+			zap.Int("limit", f.Limit),
+		)
+		return dto.CursorPaginatedResponse{}, err
+	}
+
+	notes := make([]dto.NoteOutput, 0, len(notesDB))
+	for _, v := range notesDB {
+		notes = append(notes, noteDomainToDTO(v))
+	}
+
+	var nextCursor, prevCursor string
+	if len(notesDB) > 0 {
+		first := encodeNoteCursor(notesDB[0], f.Sort, f.Order)
+		last := encodeNoteCursor(notesDB[len(notesDB)-1], f.Sort, f.Order)
+
+		switch {
+		case cursor != nil && cursor.Backward:
+			nextCursor = last
+			if hasMore {
+				prevCursor = first
+			}
+		default:
+			if hasMore {
+				nextCursor = last
+			}
+			if cursor != nil {
+				prevCursor = first
+			}
+		}
+	}
+
+	n.log.Debug("notes fetched by cursor",
+		zap.String("user_id", userID.String()),
+		zap.Int("count", len(notes)),
+		zap.Bool("has_more", hasMore),
+	)
+
+	return dto.MakeCursorPaginatedResponse(notes, f.Limit, nextCursor, prevCursor), nil
+}
+
+func (n *NoteS) SearchNotes(ctx context.Context, userID uuid.UUID, s dto.NoteSearch) (dto.PaginatedResponse, error) {
+	var (
+		resultsDB []domain.NoteSearchResult
+		total     int
+		err       error
+	)
+
+	if s.Mode == domain.NoteSearchFuzzy {
+		s.Query = strings.TrimSpace(s.Query)
+		if s.Query == "" {
+			return dto.PaginatedResponse{}, domain.ErrEmptySearchQuery
+		}
+
+		resultsDB, total, err = n.repo.FuzzySearchNotes(ctx, userID, s)
+	} else {
+		tsQuery := utils.ToTSQuery(s.Query)
+		if tsQuery == "" {
+			n.log.Debug("search query has no searchable words",
+				zap.String("user_id", userID.String()),
+				zap.String("query", s.Query),
+			)
+			return dto.PaginatedResponse{}, domain.ErrEmptySearchQuery
+		}
+
+		resultsDB, total, err = n.repo.SearchNotes(ctx, userID, tsQuery, s.Paginated)
+	}
+	if err != nil {
+		n.log.Error("failed to search notes in repository",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+			zap.String("mode", s.Mode),
+			zap.Int("limit", s.Limit),
+			zap.Int("offset", s.Offset),
+		)
+		return dto.PaginatedResponse{}, err
+	}
+
+	results := make([]dto.NoteSearchOutput, 0, len(resultsDB))
+	for _, v := range resultsDB {
+		results = append(results, dto.NoteSearchOutput{
+			NoteOutput: noteDomainToDTO(v.Note),
+			Rank:       v.Rank,
+			Headline:   v.Headline,
+			Similarity: v.Similarity,
+		})
+	}
+
+	n.log.Debug("notes searched successfully",
+		zap.String("user_id", userID.String()),
+		zap.String("mode", s.Mode),
+		zap.Int("count", len(results)),
+		zap.Int("total", total),
+	)
+
+	return dto.MakePaginatedResponse(results, total, s.Offset, s.Limit), nil
 }
 
 func (n *NoteS) UpdateNote(ctx context.Context, note dto.NoteUpdate) error {
@@ -130,8 +291,19 @@ func (n *NoteS) UpdateNote(ctx context.Context, note dto.NoteUpdate) error {
 		return err
 	}
 	err := n.repo.UpdateNote(ctx, input)
+	if errors.Is(err, domain.ErrNotFound) {
+		var share domain.NoteShare
+		if share, err = n.noteShare(ctx, input.UserID, input.ID, err); err == nil {
+			if share.Permission != domain.NotePermissionWrite {
+				err = domain.MakeError(domain.ErrFailedToUpdate, domain.ErrForbidden, "note")
+			} else {
+				input.UserID = share.OwnerID
+				err = n.repo.UpdateNote(ctx, input)
+			}
+		}
+	}
 	if err != nil {
-		if errors.Is(err, domain.ErrNotFound) {
+		if errors.Is(err, domain.ErrNotFound) || errors.Is(err, domain.ErrVersionMismatch) || errors.Is(err, domain.ErrForbidden) {
 			n.log.Warn("failed to update note",
 				zap.String("user_id", input.UserID.String()),
 				zap.String("note_id", input.ID.String()),
@@ -152,15 +324,51 @@ func (n *NoteS) UpdateNote(ctx context.Context, note dto.NoteUpdate) error {
 		zap.String("note_id", input.ID.String()),
 	)
 
+	n.publish(ctx, domain.NoteEventUpdated, note.UserID, input.ID)
+
 	return nil
 }
 
-func (n *NoteS) DeleteNote(ctx context.Context, userID, noteID uuid.UUID) error {
-	if err := n.repo.DeleteNote(ctx, userID, noteID); err != nil {
+func (n *NoteS) MoveNote(ctx context.Context, move dto.NoteMove) error {
+	if err := n.repo.MoveNote(ctx, move.UserID, move.ID, move.NotebookID); err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
-			n.log.Warn("note not found during deletion",
+			n.log.Warn("note or notebook not found during move",
+				zap.String("user_id", move.UserID.String()),
+				zap.String("note_id", move.ID.String()),
+			)
+		} else {
+			n.log.Error("failed to move note in repository",
+				zap.Error(err),
+				zap.String("user_id", move.UserID.String()),
+				zap.String("note_id", move.ID.String()),
+			)
+		}
+		return err
+	}
+
+	n.log.Info("note moved successfully",
+		zap.String("user_id", move.UserID.String()),
+		zap.String("note_id", move.ID.String()),
+	)
+
+	n.publish(ctx, domain.NoteEventUpdated, move.UserID, move.ID)
+
+	return nil
+}
+
+func (n *NoteS) DeleteNote(ctx context.Context, userID, noteID uuid.UUID, version *int) error {
+	err := n.repo.DeleteNote(ctx, userID, noteID, version)
+	if errors.Is(err, domain.ErrNotFound) {
+		if _, err = n.noteShare(ctx, userID, noteID, err); err == nil {
+			err = domain.MakeError(domain.ErrFailedToDelete, domain.ErrForbidden, "note")
+		}
+	}
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) || errors.Is(err, domain.ErrVersionMismatch) || errors.Is(err, domain.ErrForbidden) {
+			n.log.Warn("note cannot be deleted",
 				zap.String("user_id", userID.String()),
 				zap.String("note_id", noteID.String()),
+				zap.Error(err),
 			)
 		} else {
 			n.log.Error("failed to delete note",
@@ -177,34 +385,375 @@ func (n *NoteS) DeleteNote(ctx context.Context, userID, noteID uuid.UUID) error
 		zap.String("note_id", noteID.String()),
 	)
 
+	n.publish(ctx, domain.NoteEventDeleted, userID, noteID)
+
 	return nil
 }
 
+func (n *NoteS) BatchNotes(ctx context.Context, userID uuid.UUID, batch dto.NoteBatch) (dto.NoteBatchResponse, error) {
+	mode := batch.Mode
+	if mode == "" {
+		mode = domain.NoteBatchAtomic
+	}
+	atomic := mode == domain.NoteBatchAtomic
+
+	response := dto.NoteBatchResponse{
+		Mode:    mode,
+		Results: make([]dto.NoteBatchResult, len(batch.Ops)),
+	}
+
+	ops := make([]domain.NoteBatchOp, 0, len(batch.Ops))
+	indexes := make([]int, 0, len(batch.Ops))
+	invalid := false
+	for i, v := range batch.Ops {
+		response.Results[i] = dto.NoteBatchResult{Index: i, ID: v.ID, Op: v.Op}
+
+		op, err := noteBatchOpDTOtoDomain(userID, v)
+		if err != nil {
+			response.Results[i].Status = domain.NoteOpStatusInvalid
+			response.Results[i].Error = err.Error()
+			invalid = true
+			continue
+		}
+
+		response.Results[i].ID = op.NoteID
+		ops = append(ops, op)
+		indexes = append(indexes, i)
+	}
+
+	if atomic && invalid {
+		for _, i := range indexes {
+			response.Results[i].Status = domain.NoteOpStatusAborted
+			response.Results[i].Error = domain.ErrBatchAborted.Error()
+		}
+
+		n.log.Debug("note batch rejected by validation",
+			zap.String("user_id", userID.String()),
+			zap.Int("ops", len(batch.Ops)),
+		)
+
+		return response, nil
+	}
+
+	if len(ops) == 0 {
+		return response, nil
+	}
+
+	errs, err := n.repo.BatchNotes(ctx, userID, ops, atomic)
+	if err != nil {
+		n.log.Error("failed to execute note batch in repository",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+			zap.String("mode", mode),
+		)
+		return dto.NoteBatchResponse{}, err
+	}
+
+	response.Committed = true
+	for j, err := range errs {
+		result := &response.Results[indexes[j]]
+		result.Status = noteOpStatus(err)
+		if err != nil {
+			result.Error = err.Error()
+			if atomic {
+				response.Committed = false
+			}
+		}
+	}
+
+	if response.Committed {
+		for j, op := range ops {
+			if errs[j] == nil {
+				n.publish(ctx, noteBatchEventType(op.Op), userID, op.NoteID)
+			}
+		}
+	}
+
+	n.log.Info("note batch executed",
+		zap.String("user_id", userID.String()),
+		zap.String("mode", mode),
+		zap.Int("ops", len(batch.Ops)),
+		zap.Bool("committed", response.Committed),
+	)
+
+	return response, nil
+}
+
+func (n *NoteS) NoteEvents(ctx context.Context, userID, noteID uuid.UUID) (<-chan []byte, error) {
+	if _, err := n.Note(ctx, userID, noteID); err != nil {
+		return nil, err
+	}
+
+	events, err := n.broker.Subscribe(ctx, domain.NoteTopic(noteID))
+	if err != nil {
+		n.log.Error("failed to subscribe to note events",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+			zap.String("note_id", noteID.String()),
+		)
+		return nil, err
+	}
+
+	n.log.Debug("subscribed to note events",
+		zap.String("user_id", userID.String()),
+		zap.String("note_id", noteID.String()),
+	)
+
+	out := make(chan []byte)
+	go n.forwardNoteEvents(ctx, userID, noteID, events, out)
+
+	return out, nil
+}
+
+func (n *NoteS) forwardNoteEvents(ctx context.Context, userID, noteID uuid.UUID, events <-chan []byte, out chan<- []byte) {
+	defer close(out)
+
+	for payload := range events {
+		var event dto.NoteEventOutput
+		if err := json.Unmarshal(payload, &event); err != nil {
+			n.log.Error("failed to unmarshal note event", zap.Error(err))
+			continue
+		}
+
+		_, err := n.Note(ctx, userID, noteID)
+		revoked := errors.Is(err, domain.ErrNotFound)
+
+		if event.Type != domain.NoteEventRevoked && (!revoked || event.Type == domain.NoteEventDeleted) {
+			select {
+			case out <- payload:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		if revoked {
+			n.log.Info("note events subscription closed: access revoked",
+				zap.String("user_id", userID.String()),
+				zap.String("note_id", noteID.String()),
+				zap.String("type", event.Type),
+			)
+			return
+		}
+	}
+}
+
+func (n *NoteS) publish(ctx context.Context, eventType string, userID, noteID uuid.UUID) {
+	n.BroadcastNoteEvents(ctx, eventType, userID, noteID, n.StoreNoteEvents(ctx, eventType, userID, noteID))
+}
+
+func (n *NoteS) StoreNoteEvents(ctx context.Context, eventType string, userID, noteID uuid.UUID) []domain.NoteEvent {
+	events, err := n.events.CreateNoteEvents(ctx, domain.NoteEvent{
+		NoteID:  noteID,
+		ActorID: userID,
+		Type:    eventType,
+	})
+	if err != nil {
+		n.log.Error("failed to store note event",
+			zap.Error(err),
+			zap.String("note_id", noteID.String()),
+			zap.String("type", eventType),
+		)
+	}
+
+	return events
+}
+
+func (n *NoteS) BroadcastNoteEvents(ctx context.Context, eventType string, userID, noteID uuid.UUID, events []domain.NoteEvent) {
+	for _, v := range events {
+		n.broadcast(ctx, domain.UserTopic(v.UserID), noteEventDomainToDTO(v))
+	}
+
+	n.broadcast(ctx, domain.NoteTopic(noteID), dto.NoteEventOutput{
+		Type:       eventType,
+		NoteID:     noteID,
+		UserID:     userID,
+		OccurredAt: time.Now().UTC(),
+	})
+}
+
+func (n *NoteS) broadcast(ctx context.Context, topic string, event dto.NoteEventOutput) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		n.log.Error("failed to marshal note event", zap.Error(err))
+		return
+	}
+
+	if err := n.broker.Publish(ctx, topic, payload); err != nil {
+		n.log.Warn("failed to publish note event",
+			zap.Error(err),
+			zap.String("topic", topic),
+			zap.String("type", event.Type),
+		)
+	}
+}
+
+func (n *NoteS) noteShare(ctx context.Context, userID, noteID uuid.UUID, notFound error) (domain.NoteShare, error) {
+	share, err := n.shares.NoteShare(ctx, userID, noteID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.NoteShare{}, notFound
+		}
+		return domain.NoteShare{}, err
+	}
+
+	return share, nil
+}
+
+type noteCursorPayload struct {
+	Sort  string    `json:"s"`
+	Order string    `json:"o"`
+	Value string    `json:"v"`
+	ID    uuid.UUID `json:"id"`
+}
+
+func encodeNoteCursor(note domain.Note, sort, order string) string {
+	payload := noteCursorPayload{
+		Sort:  sort,
+		Order: order,
+		ID:    note.ID,
+	}
+
+	switch sort {
+	case "updated_at":
+		payload.Value = note.UpdatedAt.Format(time.RFC3339Nano)
+	case "heading":
+		payload.Value = note.Heading
+	default:
+		payload.Value = note.CreatedAt.Format(time.RFC3339Nano)
+	}
+
+	data, _ := json.Marshal(payload)
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeNoteCursor(cursor string) (domain.NoteCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return domain.NoteCursor{}, err
+	}
+
+	var payload noteCursorPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return domain.NoteCursor{}, err
+	}
+
+	if payload.ID == uuid.Nil {
+		return domain.NoteCursor{}, domain.ErrInvalidCursor
+	}
+
+	if payload.Sort != "heading" {
+		if _, err := time.Parse(time.RFC3339Nano, payload.Value); err != nil {
+			return domain.NoteCursor{}, err
+		}
+	}
+
+	return domain.NoteCursor{
+		Sort:  payload.Sort,
+		Order: payload.Order,
+		Value: payload.Value,
+		ID:    payload.ID,
+	}, nil
+}
+
 func noteDomainToDTO(note domain.Note) dto.NoteOutput {
 	return dto.NoteOutput{
-		ID:        note.ID,
-		UserID:    note.UserID,
-		Heading:   note.Heading,
-		Content:   note.Content,
-		CreatedAt: note.CreatedAt,
-		UpdatedAt: note.UpdatedAt,
+		ID:         note.ID,
+		UserID:     note.UserID,
+		NotebookID: note.NotebookID,
+		Heading:    note.Heading,
+		Content:    note.Content,
+		Done:       note.Done,
+		Tags:       note.Tags,
+		CreatedAt:  note.CreatedAt,
+		UpdatedAt:  note.UpdatedAt,
+		Version:    note.Version,
 	}
 }
 
 func noteCreateDTOtoDomain(note dto.NoteCreate) domain.Note {
 	return domain.Note{
-		ID:      note.ID,
-		UserID:  note.UserID,
-		Heading: note.Heading,
-		Content: note.Content,
+		ID:         note.ID,
+		UserID:     note.UserID,
+		NotebookID: note.NotebookID,
+		Heading:    note.Heading,
+		Content:    note.Content,
+		Done:       note.Done,
+		Tags:       normalizeTags(note.Tags),
+	}
+}
+
+func noteBatchOpDTOtoDomain(userID uuid.UUID, op dto.NoteBatchOp) (domain.NoteBatchOp, error) {
+	if err := valid.ValidateStruct(op); err != nil {
+		return domain.NoteBatchOp{}, err
+	}
+
+	input := domain.NoteBatchOp{
+		Op:      op.Op,
+		NoteID:  op.ID,
+		Version: op.Version,
+	}
+
+	switch op.Op {
+	case domain.NoteOpCreate:
+		if input.NoteID == uuid.Nil {
+			input.NoteID = uuid.New()
+		}
+		input.Create = noteCreateDTOtoDomain(dto.NoteCreate{
+			ID:         input.NoteID,
+			UserID:     userID,
+			NotebookID: op.NotebookID,
+			Heading:    *op.Heading,
+			Content:    *op.Content,
+		})
+		if op.Done != nil {
+			input.Create.Done = *op.Done
+		}
+		if op.Tags != nil {
+			input.Create.Tags = normalizeTags(*op.Tags)
+		}
+		return input, input.Create.Validate()
+	case domain.NoteOpUpdate:
+		input.Update = noteUpdateDTOtoDomain(dto.NoteUpdate{
+			ID:      op.ID,
+			UserID:  userID,
+			Heading: op.Heading,
+			Content: op.Content,
+			Done:    op.Done,
+			Tags:    op.Tags,
+			Version: op.Version,
+		})
+		return input, input.Update.Validate()
+	}
+
+	return input, nil
+}
+
+func noteBatchEventType(op string) string {
+	switch op {
+	case domain.NoteOpCreate:
+		return domain.NoteEventCreated
+	case domain.NoteOpDelete:
+		return domain.NoteEventDeleted
+	default:
+		return domain.NoteEventUpdated
 	}
 }
 
 func noteUpdateDTOtoDomain(note dto.NoteUpdate) domain.NoteUpdate {
-	return domain.NoteUpdate{
+	input := domain.NoteUpdate{
 		ID:      note.ID,
 		UserID:  note.UserID,
 		Heading: note.Heading,
 		Content: note.Content,
+		Done:    note.Done,
+		Version: note.Version,
 	}
+
+	if note.Tags != nil {
+		tags := normalizeTags(*note.Tags)
+		input.Tags = &tags
+	}
+
+	return input
 }
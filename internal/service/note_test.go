@@ -2,12 +2,15 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"noteApp/internal/models/domain"
 	"noteApp/internal/models/dto"
 	mock_service "noteApp/internal/service/mock"
 	"noteApp/pkg/logger"
+	"noteApp/pkg/pubsub"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
@@ -22,8 +25,9 @@ func mockNoteService(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock
 	if setupMock != nil {
 		setupMock(repo)
 	}
+	repo.EXPECT().CreateNoteEvents(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
 
-	return NewNoteService(repo, logger.LoggerForTest())
+	return NewNoteService(repo, repo, repo, pubsub.NewHub(), logger.LoggerForTest())
 }
 
 func TestNoteS_CreateNote(t *testing.T) {
@@ -182,6 +186,7 @@ func TestNoteS_Note(t *testing.T) {
 			},
 			f: func(mri *mock_service.MockRepositoryI) {
 				mri.EXPECT().Note(gomock.Any(), gomock.Any(), gomock.Any()).Return(domain.Note{}, domain.ErrNotFound)
+				mri.EXPECT().NoteShare(gomock.Any(), gomock.Any(), gomock.Any()).Return(domain.NoteShare{}, domain.ErrNotFound)
 			},
 			wantErr: true,
 		},
@@ -225,7 +230,7 @@ func TestNoteS_Notes(t *testing.T) {
 	type args struct {
 		ctx    context.Context
 		userID uuid.UUID
-		p      dto.Paginated
+		f      dto.NoteFilter
 	}
 	tests := []struct {
 		name    string
@@ -239,9 +244,11 @@ func TestNoteS_Notes(t *testing.T) {
 			args: args{
 				ctx:    context.Background(),
 				userID: uuid.New(),
-				p: dto.Paginated{
-					Limit:  10,
-					Offset: 0,
+				f: dto.NoteFilter{
+					Paginated: dto.Paginated{
+						Limit:  10,
+						Offset: 0,
+					},
 				},
 			},
 			f: func(mri *mock_service.MockRepositoryI) {
@@ -255,13 +262,49 @@ func TestNoteS_Notes(t *testing.T) {
 			args: args{
 				ctx:    context.Background(),
 				userID: uuid.New(),
-				p: dto.Paginated{
-					Limit:  10,
-					Offset: 0,
+				f: dto.NoteFilter{
+					Paginated: dto.Paginated{
+						Limit:  10,
+						Offset: 0,
+					},
+					Done: &[]bool{true}[0],
 				},
 			},
 			f: func(mri *mock_service.MockRepositoryI) {
-				mri.EXPECT().Notes(gomock.Any(), gomock.Any(), gomock.Any()).Return([]domain.Note{}, 1, nil)
+				mri.EXPECT().Notes(gomock.Any(), gomock.Any(), dto.NoteFilter{
+					Paginated: dto.Paginated{
+						Limit:  10,
+						Offset: 0,
+					},
+					Done: &[]bool{true}[0],
+				}).Return([]domain.Note{{Done: true}}, 1, nil)
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name: "success with normalized tags",
+			args: args{
+				ctx:    context.Background(),
+				userID: uuid.New(),
+				f: dto.NoteFilter{
+					Paginated: dto.Paginated{
+						Limit:  10,
+						Offset: 0,
+					},
+					Tags:     []string{" work", "home ", "work"},
+					TagMatch: domain.TagMatchAll,
+				},
+			},
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().Notes(gomock.Any(), gomock.Any(), dto.NoteFilter{
+					Paginated: dto.Paginated{
+						Limit:  10,
+						Offset: 0,
+					},
+					Tags:     []string{"work", "home"},
+					TagMatch: domain.TagMatchAll,
+				}).Return([]domain.Note{{Tags: []string{"work", "home"}}}, 1, nil)
 			},
 			want:    1,
 			wantErr: false,
@@ -271,9 +314,11 @@ func TestNoteS_Notes(t *testing.T) {
 			args: args{
 				ctx:    context.Background(),
 				userID: uuid.New(),
-				p: dto.Paginated{
-					Limit:  10,
-					Offset: 2,
+				f: dto.NoteFilter{
+					Paginated: dto.Paginated{
+						Limit:  10,
+						Offset: 2,
+					},
 				},
 			},
 			f: func(mri *mock_service.MockRepositoryI) {
@@ -287,9 +332,11 @@ func TestNoteS_Notes(t *testing.T) {
 			args: args{
 				ctx:    context.Background(),
 				userID: uuid.New(),
-				p: dto.Paginated{
-					Limit:  10,
-					Offset: 0,
+				f: dto.NoteFilter{
+					Paginated: dto.Paginated{
+						Limit:  10,
+						Offset: 0,
+					},
 				},
 			},
 			f: func(mri *mock_service.MockRepositoryI) {
@@ -303,9 +350,11 @@ func TestNoteS_Notes(t *testing.T) {
 			args: args{
 				ctx:    context.Background(),
 				userID: uuid.New(),
-				p: dto.Paginated{
-					Limit:  10,
-					Offset: 10,
+				f: dto.NoteFilter{
+					Paginated: dto.Paginated{
+						Limit:  10,
+						Offset: 10,
+					},
 				},
 			},
 			f: func(mri *mock_service.MockRepositoryI) {
@@ -319,9 +368,11 @@ func TestNoteS_Notes(t *testing.T) {
 			args: args{
 				ctx:    context.Background(),
 				userID: uuid.New(),
-				p: dto.Paginated{
-					Limit:  -1,
-					Offset: 0,
+				f: dto.NoteFilter{
+					Paginated: dto.Paginated{
+						Limit:  -1,
+						Offset: 0,
+					},
 				},
 			},
 			f: func(mri *mock_service.MockRepositoryI) {
@@ -334,9 +385,11 @@ func TestNoteS_Notes(t *testing.T) {
 			args: args{
 				ctx:    context.Background(),
 				userID: uuid.New(),
-				p: dto.Paginated{
-					Limit:  10,
-					Offset: -1,
+				f: dto.NoteFilter{
+					Paginated: dto.Paginated{
+						Limit:  10,
+						Offset: -1,
+					},
 				},
 			},
 			f: func(mri *mock_service.MockRepositoryI) {
@@ -354,7 +407,7 @@ func TestNoteS_Notes(t *testing.T) {
 
 			repo := mockNoteService(t, ctrl, tt.f)
 
-			got, err := repo.Notes(tt.args.ctx, tt.args.userID, tt.args.p)
+			got, err := repo.Notes(tt.args.ctx, tt.args.userID, tt.args.f)
 			if tt.wantErr {
 				require.Error(t, err)
 				return
@@ -367,9 +420,247 @@ func TestNoteS_Notes(t *testing.T) {
 	}
 }
 
+func TestNoteS_NotesByCursor(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	notesDB := []domain.Note{
+		{ID: uuid.New(), UserID: userID, Heading: "a", CreatedAt: base},
+		{ID: uuid.New(), UserID: userID, Heading: "b", CreatedAt: base.Add(time.Second)},
+	}
+	firstCursor := encodeNoteCursor(notesDB[0], "created_at", "asc")
+	lastCursor := encodeNoteCursor(notesDB[1], "created_at", "asc")
+
+	notes := []dto.NoteOutput{noteDomainToDTO(notesDB[0]), noteDomainToDTO(notesDB[1])}
+
+	tests := []struct {
+		name    string
+		f       dto.NoteFilter
+		mock    func(*mock_service.MockRepositoryI)
+		want    dto.CursorPaginatedResponse
+		wantErr error
+	}{
+		{
+			name: "first page",
+			f:    dto.NoteFilter{Paginated: dto.Paginated{Limit: 10}, Paging: "cursor"},
+			mock: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().NotesByCursor(gomock.Any(), userID, dto.NoteFilter{
+					Paginated: dto.Paginated{Limit: 10},
+					Paging:    "cursor",
+					Sort:      "created_at",
+					Order:     "asc",
+				}, nil).Return(notesDB, true, nil)
+			},
+			want: dto.MakeCursorPaginatedResponse(notes, 10, lastCursor, ""),
+		},
+		{
+			name: "normalized tags",
+			f:    dto.NoteFilter{Paginated: dto.Paginated{Limit: 10}, Paging: "cursor", Tags: []string{"work ", " work"}},
+			mock: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().NotesByCursor(gomock.Any(), userID, dto.NoteFilter{
+					Paginated: dto.Paginated{Limit: 10},
+					Paging:    "cursor",
+					Tags:      []string{"work"},
+					Sort:      "created_at",
+					Order:     "asc",
+				}, nil).Return(notesDB, true, nil)
+			},
+			want: dto.MakeCursorPaginatedResponse(notes, 10, lastCursor, ""),
+		},
+		{
+			name: "after cursor",
+			f:    dto.NoteFilter{Paginated: dto.Paginated{Limit: 10}, After: firstCursor},
+			mock: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().NotesByCursor(gomock.Any(), userID, gomock.Any(), &domain.NoteCursor{
+					Sort:  "created_at",
+					Order: "asc",
+					Value: base.Format(time.RFC3339Nano),
+					ID:    notesDB[0].ID,
+				}).Return(notesDB[1:], false, nil)
+			},
+			want: dto.MakeCursorPaginatedResponse(notes[1:], 10, "", lastCursor),
+		},
+		{
+			name: "before cursor",
+			f:    dto.NoteFilter{Paginated: dto.Paginated{Limit: 10}, Before: lastCursor},
+			mock: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().NotesByCursor(gomock.Any(), userID, gomock.Any(), &domain.NoteCursor{
+					Sort:     "created_at",
+					Order:    "asc",
+					Value:    base.Add(time.Second).Format(time.RFC3339Nano),
+					ID:       notesDB[1].ID,
+					Backward: true,
+				}).Return(notesDB[:1], false, nil)
+			},
+			want: dto.MakeCursorPaginatedResponse(notes[:1], 10, firstCursor, ""),
+		},
+		{
+			name:    "malformed cursor",
+			f:       dto.NoteFilter{Paginated: dto.Paginated{Limit: 10}, After: "not-a-cursor"},
+			wantErr: domain.ErrInvalidCursor,
+		},
+		{
+			name:    "cursor for another sort",
+			f:       dto.NoteFilter{Paginated: dto.Paginated{Limit: 10}, After: firstCursor, Sort: "heading"},
+			wantErr: domain.ErrInvalidCursor,
+		},
+		{
+			name: "repository error",
+			f:    dto.NoteFilter{Paginated: dto.Paginated{Limit: 10}, Paging: "cursor"},
+			mock: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().NotesByCursor(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, false, domain.ErrReceiving)
+			},
+			wantErr: domain.ErrReceiving,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mockNoteService(t, ctrl, tt.mock)
+
+			got, err := service.NotesByCursor(context.Background(), userID, tt.f)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNoteS_SearchNotes(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	noteID := uuid.New()
+
+	tests := []struct {
+		name    string
+		search  dto.NoteSearch
+		f       func(*mock_service.MockRepositoryI)
+		want    dto.PaginatedResponse
+		wantErr bool
+		errIs   error
+	}{
+		{
+			name: "success",
+			search: dto.NoteSearch{
+				Paginated: dto.Paginated{Limit: 10},
+				Query:     `"shopping list" groc*`,
+			},
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().SearchNotes(gomock.Any(), userID, "('shopping' <-> 'list') & 'groc':*", dto.Paginated{Limit: 10}).
+					Return([]domain.NoteSearchResult{
+						{
+							Note:     domain.Note{ID: noteID, UserID: userID, Heading: "shopping list"},
+							Rank:     0.5,
+							Headline: "<mark>shopping</mark> <mark>list</mark>",
+						},
+					}, 1, nil)
+			},
+			want: dto.MakePaginatedResponse([]dto.NoteSearchOutput{
+				{
+					NoteOutput: dto.NoteOutput{ID: noteID, UserID: userID, Heading: "shopping list"},
+					Rank:       0.5,
+					Headline:   "<mark>shopping</mark> <mark>list</mark>",
+				},
+			}, 1, 0, 10),
+			wantErr: false,
+		},
+		{
+			name: "fuzzy",
+			search: dto.NoteSearch{
+				Paginated: dto.Paginated{Limit: 10},
+				Query:     " shoping ",
+				Mode:      domain.NoteSearchFuzzy,
+			},
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().FuzzySearchNotes(gomock.Any(), userID, dto.NoteSearch{
+					Paginated: dto.Paginated{Limit: 10},
+					Query:     "shoping",
+					Mode:      domain.NoteSearchFuzzy,
+				}).Return([]domain.NoteSearchResult{
+					{
+						Note:       domain.Note{ID: noteID, UserID: userID, Heading: "shopping list"},
+						Similarity: 0.7,
+					},
+				}, 1, nil)
+			},
+			want: dto.MakePaginatedResponse([]dto.NoteSearchOutput{
+				{
+					NoteOutput: dto.NoteOutput{ID: noteID, UserID: userID, Heading: "shopping list"},
+					Similarity: 0.7,
+				},
+			}, 1, 0, 10),
+			wantErr: false,
+		},
+		{
+			name: "fuzzy blank query",
+			search: dto.NoteSearch{
+				Paginated: dto.Paginated{Limit: 10},
+				Query:     "   ",
+				Mode:      domain.NoteSearchFuzzy,
+			},
+			wantErr: true,
+			errIs:   domain.ErrEmptySearchQuery,
+		},
+		{
+			name: "no searchable words",
+			search: dto.NoteSearch{
+				Paginated: dto.Paginated{Limit: 10},
+				Query:     `"" !*`,
+			},
+			wantErr: true,
+			errIs:   domain.ErrEmptySearchQuery,
+		},
+		{
+			name: "repository error",
+			search: dto.NoteSearch{
+				Paginated: dto.Paginated{Limit: 10},
+				Query:     "milk",
+			},
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().SearchNotes(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, 0, domain.ErrReceiving)
+			},
+			wantErr: true,
+			errIs:   domain.ErrReceiving,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mockNoteService(t, ctrl, tt.f)
+
+			got, err := service.SearchNotes(context.Background(), userID, tt.search)
+			if tt.wantErr {
+				require.Error(t, err)
+				require.ErrorIs(t, err, tt.errIs)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestNoteS_UpdateNote(t *testing.T) {
 	t.Parallel()
 
+	noteID := uuid.New()
+	userID := uuid.New()
+
 	type args struct {
 		ctx  context.Context
 		note dto.NoteUpdate
@@ -414,6 +705,37 @@ func TestNoteS_UpdateNote(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "success with tags only",
+			args: args{
+				ctx: context.Background(),
+				note: dto.NoteUpdate{
+					ID:     noteID,
+					UserID: userID,
+					Tags:   &[]string{" work ", "work", "home"},
+				},
+			},
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().UpdateNote(gomock.Any(), domain.NoteUpdate{
+					ID:     noteID,
+					UserID: userID,
+					Tags:   &[]string{"work", "home"},
+				}).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "blank tag",
+			args: args{
+				ctx: context.Background(),
+				note: dto.NoteUpdate{
+					ID:     uuid.New(),
+					UserID: uuid.New(),
+					Tags:   &[]string{" "},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "nil note id",
 			args: args{
@@ -486,6 +808,7 @@ func TestNoteS_UpdateNote(t *testing.T) {
 			},
 			f: func(mri *mock_service.MockRepositoryI) {
 				mri.EXPECT().UpdateNote(gomock.Any(), gomock.Any()).Return(domain.ErrNotFound)
+				mri.EXPECT().NoteShare(gomock.Any(), gomock.Any(), gomock.Any()).Return(domain.NoteShare{}, domain.ErrNotFound)
 			},
 			wantErr: true,
 		},
@@ -503,6 +826,7 @@ func TestNoteS_UpdateNote(t *testing.T) {
 			},
 			f: func(mri *mock_service.MockRepositoryI) {
 				mri.EXPECT().UpdateNote(gomock.Any(), gomock.Any()).Return(domain.ErrNotFound)
+				mri.EXPECT().NoteShare(gomock.Any(), gomock.Any(), gomock.Any()).Return(domain.NoteShare{}, domain.ErrNotFound)
 			},
 			wantErr: true,
 		},
@@ -544,6 +868,63 @@ func TestNoteS_UpdateNote(t *testing.T) {
 	}
 }
 
+func TestNoteS_MoveNote(t *testing.T) {
+	t.Parallel()
+
+	noteID := uuid.New()
+	notebookID := uuid.New()
+	userID := uuid.New()
+
+	tests := []struct {
+		name    string
+		move    dto.NoteMove
+		f       func(*mock_service.MockRepositoryI)
+		wantErr bool
+	}{
+		{
+			name: "success",
+			move: dto.NoteMove{
+				ID:         noteID,
+				UserID:     userID,
+				NotebookID: &notebookID,
+			},
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().MoveNote(gomock.Any(), userID, noteID, &notebookID).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "not found",
+			move: dto.NoteMove{
+				ID:     noteID,
+				UserID: userID,
+			},
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().MoveNote(gomock.Any(), userID, noteID, nil).Return(domain.ErrNotFound)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mockNoteService(t, ctrl, tt.f)
+
+			err := service.MoveNote(context.Background(), tt.move)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
 func TestNoteS_DeleteNote(t *testing.T) {
 	t.Parallel()
 
@@ -566,7 +947,7 @@ func TestNoteS_DeleteNote(t *testing.T) {
 				noteID: uuid.New(),
 			},
 			f: func(mri *mock_service.MockRepositoryI) {
-				mri.EXPECT().DeleteNote(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				mri.EXPECT().DeleteNote(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 			},
 			wantErr: false,
 		},
@@ -578,7 +959,8 @@ func TestNoteS_DeleteNote(t *testing.T) {
 				noteID: uuid.New(),
 			},
 			f: func(mri *mock_service.MockRepositoryI) {
-				mri.EXPECT().DeleteNote(gomock.Any(), gomock.Any(), gomock.Any()).Return(domain.ErrNotFound)
+				mri.EXPECT().DeleteNote(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(domain.ErrNotFound)
+				mri.EXPECT().NoteShare(gomock.Any(), gomock.Any(), gomock.Any()).Return(domain.NoteShare{}, domain.ErrNotFound)
 			},
 			wantErr: true,
 		},
@@ -590,7 +972,7 @@ func TestNoteS_DeleteNote(t *testing.T) {
 				noteID: uuid.New(),
 			},
 			f: func(mri *mock_service.MockRepositoryI) {
-				mri.EXPECT().DeleteNote(gomock.Any(), gomock.Any(), gomock.Any()).Return(domain.ErrFailedToDelete)
+				mri.EXPECT().DeleteNote(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(domain.ErrFailedToDelete)
 			},
 			wantErr: true,
 		},
@@ -602,7 +984,7 @@ func TestNoteS_DeleteNote(t *testing.T) {
 				noteID: uuid.New(),
 			},
 			f: func(mri *mock_service.MockRepositoryI) {
-				mri.EXPECT().DeleteNote(gomock.Any(), gomock.Any(), gomock.Any()).Return(domain.ErrFailedToDelete)
+				mri.EXPECT().DeleteNote(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(domain.ErrFailedToDelete)
 			},
 			wantErr: true,
 		},
@@ -616,13 +998,380 @@ func TestNoteS_DeleteNote(t *testing.T) {
 
 			repo := mockNoteService(t, ctrl, tt.f)
 
-			err := repo.DeleteNote(tt.args.ctx, tt.args.userID, tt.args.noteID)
+			err := repo.DeleteNote(tt.args.ctx, tt.args.userID, tt.args.noteID, nil)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestNoteS_UpdateNoteVersion(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	noteID := uuid.New()
+	userID := uuid.New()
+	done := true
+	version := 5
+
+	s := mockNoteService(t, ctrl, func(mri *mock_service.MockRepositoryI) {
+		mri.EXPECT().UpdateNote(gomock.Any(), domain.NoteUpdate{
+			ID:      noteID,
+			UserID:  userID,
+			Done:    &done,
+			Version: &version,
+		}).Return(domain.MakeError(domain.ErrFailedToUpdate, domain.ErrVersionMismatch, "note"))
+	})
+
+	err := s.UpdateNote(context.Background(), dto.NoteUpdate{
+		ID:      noteID,
+		UserID:  userID,
+		Done:    &done,
+		Version: &version,
+	})
+	require.ErrorIs(t, err, domain.ErrVersionMismatch)
+}
+
+func TestNoteS_SharedNoteAccess(t *testing.T) {
+	t.Parallel()
+
+	ownerID := uuid.New()
+	granteeID := uuid.New()
+	noteID := uuid.New()
+	heading := "shared heading"
+
+	share := func(permission string) domain.NoteShare {
+		return domain.NoteShare{NoteID: noteID, OwnerID: ownerID, UserID: granteeID, Permission: permission}
+	}
+
+	t.Run("read shared note", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		s := mockNoteService(t, ctrl, func(mri *mock_service.MockRepositoryI) {
+			gomock.InOrder(
+				mri.EXPECT().Note(gomock.Any(), granteeID, noteID).Return(domain.Note{}, domain.ErrNotFound),
+				mri.EXPECT().NoteShare(gomock.Any(), granteeID, noteID).Return(share(domain.NotePermissionRead), nil),
+				mri.EXPECT().Note(gomock.Any(), ownerID, noteID).Return(domain.Note{ID: noteID, UserID: ownerID}, nil),
+			)
+		})
+
+		note, err := s.Note(context.Background(), granteeID, noteID)
+		require.NoError(t, err)
+		assert.Equal(t, ownerID, note.UserID)
+	})
+
+	t.Run("update with read permission", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		s := mockNoteService(t, ctrl, func(mri *mock_service.MockRepositoryI) {
+			mri.EXPECT().UpdateNote(gomock.Any(), gomock.Any()).Return(domain.ErrNotFound)
+			mri.EXPECT().NoteShare(gomock.Any(), granteeID, noteID).Return(share(domain.NotePermissionRead), nil)
+		})
+
+		err := s.UpdateNote(context.Background(), dto.NoteUpdate{ID: noteID, UserID: granteeID, Heading: &heading})
+		require.ErrorIs(t, err, domain.ErrForbidden)
+	})
+
+	t.Run("update with write permission", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		s := mockNoteService(t, ctrl, func(mri *mock_service.MockRepositoryI) {
+			gomock.InOrder(
+				mri.EXPECT().UpdateNote(gomock.Any(), domain.NoteUpdate{ID: noteID, UserID: granteeID, Heading: &heading}).Return(domain.ErrNotFound),
+				mri.EXPECT().NoteShare(gomock.Any(), granteeID, noteID).Return(share(domain.NotePermissionWrite), nil),
+				mri.EXPECT().UpdateNote(gomock.Any(), domain.NoteUpdate{ID: noteID, UserID: ownerID, Heading: &heading}).Return(nil),
+			)
+		})
+
+		err := s.UpdateNote(context.Background(), dto.NoteUpdate{ID: noteID, UserID: granteeID, Heading: &heading})
+		require.NoError(t, err)
+	})
+
+	t.Run("delete as grantee", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		s := mockNoteService(t, ctrl, func(mri *mock_service.MockRepositoryI) {
+			mri.EXPECT().DeleteNote(gomock.Any(), granteeID, noteID, nil).Return(domain.ErrNotFound)
+			mri.EXPECT().NoteShare(gomock.Any(), granteeID, noteID).Return(share(domain.NotePermissionWrite), nil)
+		})
+
+		err := s.DeleteNote(context.Background(), granteeID, noteID, nil)
+		require.ErrorIs(t, err, domain.ErrForbidden)
+	})
+}
+
+func TestNoteS_NoteEvents(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	noteID := uuid.New()
+	heading := "live heading"
+
+	t.Run("note not found", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		s := mockNoteService(t, ctrl, func(mri *mock_service.MockRepositoryI) {
+			mri.EXPECT().Note(gomock.Any(), userID, noteID).Return(domain.Note{}, domain.ErrNotFound)
+			mri.EXPECT().NoteShare(gomock.Any(), userID, noteID).Return(domain.NoteShare{}, domain.ErrNotFound)
+		})
+
+		_, err := s.NoteEvents(context.Background(), userID, noteID)
+		require.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("receives update and delete", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		s := mockNoteService(t, ctrl, func(mri *mock_service.MockRepositoryI) {
+			mri.EXPECT().Note(gomock.Any(), userID, noteID).Return(domain.Note{ID: noteID, UserID: userID}, nil).Times(2)
+			mri.EXPECT().UpdateNote(gomock.Any(), gomock.Any()).Return(nil)
+			mri.EXPECT().DeleteNote(gomock.Any(), userID, noteID, nil).Return(nil)
+			mri.EXPECT().Note(gomock.Any(), userID, noteID).Return(domain.Note{}, domain.ErrNotFound)
+			mri.EXPECT().NoteShare(gomock.Any(), userID, noteID).Return(domain.NoteShare{}, domain.ErrNotFound)
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events, err := s.NoteEvents(ctx, userID, noteID)
+		require.NoError(t, err)
+
+		require.NoError(t, s.UpdateNote(context.Background(), dto.NoteUpdate{ID: noteID, UserID: userID, Heading: &heading}))
+		require.NoError(t, s.DeleteNote(context.Background(), userID, noteID, nil))
+
+		for _, want := range []string{domain.NoteEventUpdated, domain.NoteEventDeleted} {
+			var event dto.NoteEventOutput
+			require.NoError(t, json.Unmarshal(<-events, &event))
+			assert.Equal(t, want, event.Type)
+			assert.Equal(t, noteID, event.NoteID)
+			assert.Equal(t, userID, event.UserID)
+		}
+
+		_, ok := <-events
+		assert.False(t, ok)
+	})
+
+	t.Run("closes when share is revoked", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ownerID := uuid.New()
+
+		s := mockNoteService(t, ctrl, func(mri *mock_service.MockRepositoryI) {
+			mri.EXPECT().Note(gomock.Any(), userID, noteID).Return(domain.Note{}, domain.ErrNotFound).Times(2)
+			gomock.InOrder(
+				mri.EXPECT().NoteShare(gomock.Any(), userID, noteID).Return(domain.NoteShare{NoteID: noteID, OwnerID: ownerID, UserID: userID}, nil),
+				mri.EXPECT().NoteShare(gomock.Any(), userID, noteID).Return(domain.NoteShare{}, domain.ErrNotFound),
+			)
+			mri.EXPECT().Note(gomock.Any(), ownerID, noteID).Return(domain.Note{ID: noteID, UserID: ownerID}, nil)
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events, err := s.NoteEvents(ctx, userID, noteID)
+		require.NoError(t, err)
+
+		s.BroadcastNoteEvents(context.Background(), domain.NoteEventRevoked, ownerID, noteID, nil)
+
+		_, ok := <-events
+		assert.False(t, ok)
+	})
+}
+
+func TestNoteS_PublishUserEvents(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ownerID := uuid.New()
+	granteeID := uuid.New()
+	noteID := uuid.New()
+
+	stored := []domain.NoteEvent{
+		{ID: 7, UserID: ownerID, NoteID: noteID, ActorID: ownerID, Type: domain.NoteEventDeleted},
+		{ID: 8, UserID: granteeID, NoteID: noteID, ActorID: ownerID, Type: domain.NoteEventDeleted},
+	}
+
+	hub := pubsub.NewHub()
+	repo := mock_service.NewMockRepositoryI(ctrl)
+	repo.EXPECT().DeleteNote(gomock.Any(), ownerID, noteID, nil).Return(nil)
+	repo.EXPECT().CreateNoteEvents(gomock.Any(), domain.NoteEvent{
+		NoteID:  noteID,
+		ActorID: ownerID,
+		Type:    domain.NoteEventDeleted,
+	}).Return(stored, nil)
+
+	s := NewNoteService(repo, repo, repo, hub, logger.LoggerForTest())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ownerEvents, err := hub.Subscribe(ctx, domain.UserTopic(ownerID))
+	require.NoError(t, err)
+	granteeEvents, err := hub.Subscribe(ctx, domain.UserTopic(granteeID))
+	require.NoError(t, err)
+
+	require.NoError(t, s.DeleteNote(context.Background(), ownerID, noteID, nil))
+
+	for want, events := range map[int64]<-chan []byte{7: ownerEvents, 8: granteeEvents} {
+		var event dto.NoteEventOutput
+		require.NoError(t, json.Unmarshal(<-events, &event))
+		assert.Equal(t, want, event.ID)
+		assert.Equal(t, domain.NoteEventDeleted, event.Type)
+		assert.Equal(t, ownerID, event.UserID)
+	}
+}
+
+func TestNoteS_BatchNotes(t *testing.T) {
+	t.Parallel()
+
+	heading := "heading"
+	content := "content"
+	noteID := uuid.New()
+
+	tests := []struct {
+		name          string
+		batch         dto.NoteBatch
+		f             func(*mock_service.MockRepositoryI)
+		wantErr       bool
+		wantCommitted bool
+		wantStatuses  []string
+	}{
+		{
+			name: "atomic success",
+			batch: dto.NoteBatch{Ops: []dto.NoteBatchOp{
+				{Op: domain.NoteOpCreate, Heading: &heading, Content: &content},
+				{Op: domain.NoteOpDelete, ID: noteID},
+			}},
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().BatchNotes(gomock.Any(), gomock.Any(), gomock.Len(2), true).Return([]error{nil, nil}, nil)
+			},
+			wantCommitted: true,
+			wantStatuses:  []string{domain.NoteOpStatusApplied, domain.NoteOpStatusApplied},
+		},
+		{
+			name: "atomic invalid op aborts batch",
+			batch: dto.NoteBatch{Ops: []dto.NoteBatchOp{
+				{Op: domain.NoteOpCreate, Heading: &heading},
+				{Op: domain.NoteOpDelete, ID: noteID},
+			}},
+			wantStatuses: []string{domain.NoteOpStatusInvalid, domain.NoteOpStatusAborted},
+		},
+		{
+			name: "atomic rollback",
+			batch: dto.NoteBatch{Mode: domain.NoteBatchAtomic, Ops: []dto.NoteBatchOp{
+				{Op: domain.NoteOpUpdate, ID: noteID, Heading: &heading},
+				{Op: domain.NoteOpDelete, ID: uuid.New()},
+			}},
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().BatchNotes(gomock.Any(), gomock.Any(), gomock.Len(2), true).Return([]error{domain.ErrBatchAborted, domain.ErrNotFound}, nil)
+			},
+			wantStatuses: []string{domain.NoteOpStatusAborted, domain.NoteOpStatusNotFound},
+		},
+		{
+			name: "best effort",
+			batch: dto.NoteBatch{Mode: domain.NoteBatchBestEffort, Ops: []dto.NoteBatchOp{
+				{Op: domain.NoteOpUpdate},
+				{Op: domain.NoteOpUpdate, ID: noteID, Content: &content},
+				{Op: domain.NoteOpDelete, ID: uuid.New()},
+			}},
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().BatchNotes(gomock.Any(), gomock.Any(), gomock.Len(2), false).Return([]error{nil, domain.ErrVersionMismatch}, nil)
+			},
+			wantCommitted: true,
+			wantStatuses:  []string{domain.NoteOpStatusInvalid, domain.NoteOpStatusApplied, domain.NoteOpStatusConflict},
+		},
+		{
+			name: "repository error",
+			batch: dto.NoteBatch{Ops: []dto.NoteBatchOp{
+				{Op: domain.NoteOpDelete, ID: noteID},
+			}},
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().BatchNotes(gomock.Any(), gomock.Any(), gomock.Any(), true).Return(nil, domain.ErrFailedToUpdate)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := mockNoteService(t, ctrl, tt.f)
+
+			got, err := s.BatchNotes(context.Background(), uuid.New(), tt.batch)
 			if tt.wantErr {
 				require.Error(t, err)
 				return
 			}
 
 			require.NoError(t, err)
+			assert.Equal(t, tt.wantCommitted, got.Committed)
+			require.Len(t, got.Results, len(tt.wantStatuses))
+			for i, status := range tt.wantStatuses {
+				assert.Equal(t, i, got.Results[i].Index)
+				assert.Equal(t, status, got.Results[i].Status)
+			}
 		})
 	}
 }
+
+func TestNoteS_BatchNotesAssignsIDs(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	heading := "heading"
+	content := "content"
+	userID := uuid.New()
+
+	var ops []domain.NoteBatchOp
+	s := mockNoteService(t, ctrl, func(mri *mock_service.MockRepositoryI) {
+		mri.EXPECT().BatchNotes(gomock.Any(), userID, gomock.Any(), true).
+			DoAndReturn(func(_ context.Context, _ uuid.UUID, got []domain.NoteBatchOp, _ bool) ([]error, error) {
+				ops = got
+				return []error{nil}, nil
+			})
+	})
+
+	got, err := s.BatchNotes(context.Background(), userID, dto.NoteBatch{Ops: []dto.NoteBatchOp{
+		{Op: domain.NoteOpCreate, Heading: &heading, Content: &content},
+	}})
+	require.NoError(t, err)
+	require.Len(t, ops, 1)
+	assert.NotEqual(t, uuid.Nil, ops[0].NoteID)
+	assert.Equal(t, ops[0].NoteID, ops[0].Create.ID)
+	assert.Equal(t, userID, ops[0].Create.UserID)
+	assert.Equal(t, ops[0].NoteID, got.Results[0].ID)
+}
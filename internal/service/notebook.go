@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type NotebookRI interface {
+	CreateNotebook(ctx context.Context, notebook domain.Notebook) error
+	Notebook(ctx context.Context, userID, notebookID uuid.UUID) (domain.Notebook, error)
+	Notebooks(ctx context.Context, userID uuid.UUID) ([]domain.Notebook, error)
+	RenameNotebook(ctx context.Context, notebook domain.NotebookUpdate) error
+	MoveNotebook(ctx context.Context, userID, notebookID uuid.UUID, parentID *uuid.UUID) error
+	DeleteNotebook(ctx context.Context, userID, notebookID uuid.UUID, mode string) error
+}
+
+type NotebookS struct {
+	repo NotebookRI
+	log  *logger.Logger
+}
+
+func NewNotebookService(repo NotebookRI, log *logger.Logger) *NotebookS {
+	return &NotebookS{
+		repo: repo,
+		log:  log,
+	}
+}
+
+func (n *NotebookS) CreateNotebook(ctx context.Context, notebook dto.NotebookCreate) (uuid.UUID, error) {
+	input := domain.Notebook{
+		ID:       uuid.New(),
+		UserID:   notebook.UserID,
+		ParentID: notebook.ParentID,
+		Name:     notebook.Name,
+	}
+
+	if err := input.Validate(); err != nil {
+		n.log.Debug("notebook validation failed in service",
+			zap.String("user_id", input.UserID.String()),
+			zap.String("notebook_id", input.ID.String()),
+			zap.Error(err),
+		)
+		return uuid.Nil, err
+	}
+
+	if err := n.repo.CreateNotebook(ctx, input); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			n.log.Warn("parent notebook not found",
+				zap.String("user_id", input.UserID.String()),
+				zap.String("notebook_id", input.ID.String()),
+			)
+		} else {
+			n.log.Error("failed to create notebook in repository",
+				zap.Error(err),
+				zap.String("user_id", input.UserID.String()),
+				zap.String("notebook_id", input.ID.String()),
+			)
+		}
+		return uuid.Nil, err
+	}
+
+	n.log.Info("notebook created successfully in service",
+		zap.String("user_id", input.UserID.String()),
+		zap.String("notebook_id", input.ID.String()),
+	)
+
+	return input.ID, nil
+}
+
+func (n *NotebookS) Notebook(ctx context.Context, userID, notebookID uuid.UUID) (dto.NotebookOutput, error) {
+	notebookDB, err := n.repo.Notebook(ctx, userID, notebookID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			n.log.Warn("notebook not found",
+				zap.String("user_id", userID.String()),
+				zap.String("notebook_id", notebookID.String()),
+			)
+		} else {
+			n.log.Error("failed to get notebook from repository",
+				zap.Error(err),
+				zap.String("user_id", userID.String()),
+				zap.String("notebook_id", notebookID.String()),
+			)
+		}
+		return dto.NotebookOutput{}, err
+	}
+
+	return notebookDomainToDTO(notebookDB), nil
+}
+
+func (n *NotebookS) Notebooks(ctx context.Context, userID uuid.UUID) ([]dto.NotebookOutput, error) {
+	notebooksDB, err := n.repo.Notebooks(ctx, userID)
+	if err != nil {
+		n.log.Error("failed to get notebooks from repository",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return nil, err
+	}
+
+	notebooks := make([]dto.NotebookOutput, 0, len(notebooksDB))
+	for _, v := range notebooksDB {
+		notebooks = append(notebooks, notebookDomainToDTO(v))
+	}
+
+	n.log.Debug("notebooks fetched and mapped successfully",
+		zap.String("user_id", userID.String()),
+		zap.Int("count", len(notebooks)),
+	)
+
+	return notebooks, nil
+}
+
+func (n *NotebookS) RenameNotebook(ctx context.Context, notebook dto.NotebookUpdate) error {
+	input := domain.NotebookUpdate{
+		ID:     notebook.ID,
+		UserID: notebook.UserID,
+		Name:   notebook.Name,
+	}
+
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	if err := n.repo.RenameNotebook(ctx, input); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			n.log.Warn("notebook not found during rename",
+				zap.String("user_id", input.UserID.String()),
+				zap.String("notebook_id", input.ID.String()),
+			)
+		} else {
+			n.log.Error("failed to rename notebook in repository",
+				zap.Error(err),
+				zap.String("user_id", input.UserID.String()),
+				zap.String("notebook_id", input.ID.String()),
+			)
+		}
+		return err
+	}
+
+	n.log.Info("notebook renamed successfully",
+		zap.String("user_id", input.UserID.String()),
+		zap.String("notebook_id", input.ID.String()),
+	)
+
+	return nil
+}
+
+func (n *NotebookS) MoveNotebook(ctx context.Context, move dto.NotebookMove) error {
+	if move.ParentID != nil && *move.ParentID == move.ID {
+		return domain.ErrNotebookCycle
+	}
+
+	if err := n.repo.MoveNotebook(ctx, move.UserID, move.ID, move.ParentID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) || errors.Is(err, domain.ErrNotebookCycle) {
+			n.log.Warn("failed to move notebook",
+				zap.String("user_id", move.UserID.String()),
+				zap.String("notebook_id", move.ID.String()),
+				zap.Error(err),
+			)
+		} else {
+			n.log.Error("failed to move notebook in repository",
+				zap.Error(err),
+				zap.String("user_id", move.UserID.String()),
+				zap.String("notebook_id", move.ID.String()),
+			)
+		}
+		return err
+	}
+
+	n.log.Info("notebook moved successfully",
+		zap.String("user_id", move.UserID.String()),
+		zap.String("notebook_id", move.ID.String()),
+	)
+
+	return nil
+}
+
+func (n *NotebookS) DeleteNotebook(ctx context.Context, userID, notebookID uuid.UUID, mode string) error {
+	if mode == "" {
+		mode = domain.NotebookDeleteMove
+	}
+
+	if err := n.repo.DeleteNotebook(ctx, userID, notebookID, mode); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			n.log.Warn("notebook not found during deletion",
+				zap.String("user_id", userID.String()),
+				zap.String("notebook_id", notebookID.String()),
+			)
+		} else {
+			n.log.Error("failed to delete notebook",
+				zap.Error(err),
+				zap.String("user_id", userID.String()),
+				zap.String("notebook_id", notebookID.String()),
+			)
+		}
+		return err
+	}
+
+	n.log.Info("notebook deleted successfully",
+		zap.String("user_id", userID.String()),
+		zap.String("notebook_id", notebookID.String()),
+		zap.String("mode", mode),
+	)
+
+	return nil
+}
+
+func notebookDomainToDTO(notebook domain.Notebook) dto.NotebookOutput {
+	return dto.NotebookOutput{
+		ID:        notebook.ID,
+		ParentID:  notebook.ParentID,
+		Name:      notebook.Name,
+		Notes:     notebook.Notes,
+		CreatedAt: notebook.CreatedAt,
+		UpdatedAt: notebook.UpdatedAt,
+	}
+}
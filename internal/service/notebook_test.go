@@ -0,0 +1,301 @@
+package service
+
+import (
+	"context"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	mock_service "noteApp/internal/service/mock"
+	"noteApp/pkg/logger"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockNotebookService(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock_service.MockRepositoryI)) *NotebookS {
+	t.Helper()
+
+	repo := mock_service.NewMockRepositoryI(ctrl)
+	if setupMock != nil {
+		setupMock(repo)
+	}
+
+	return NewNotebookService(repo, logger.LoggerForTest())
+}
+
+func TestNotebookS_CreateNotebook(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	parentID := uuid.New()
+
+	tests := []struct {
+		name     string
+		notebook dto.NotebookCreate
+		f        func(*mock_service.MockRepositoryI)
+		wantErr  bool
+		errIs    error
+	}{
+		{
+			name: "success",
+			notebook: dto.NotebookCreate{
+				UserID:   userID,
+				ParentID: &parentID,
+				Name:     "work",
+			},
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().CreateNotebook(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, notebook domain.Notebook) error {
+						assert.Equal(t, userID, notebook.UserID)
+						assert.Equal(t, &parentID, notebook.ParentID)
+						assert.Equal(t, "work", notebook.Name)
+						return nil
+					})
+			},
+			wantErr: false,
+		},
+		{
+			name: "parent not found",
+			notebook: dto.NotebookCreate{
+				UserID:   userID,
+				ParentID: &parentID,
+				Name:     "work",
+			},
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().CreateNotebook(gomock.Any(), gomock.Any()).Return(domain.ErrNotFound)
+			},
+			wantErr: true,
+			errIs:   domain.ErrNotFound,
+		},
+		{
+			name: "empty name",
+			notebook: dto.NotebookCreate{
+				UserID: userID,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mockNotebookService(t, ctrl, tt.f)
+
+			id, err := service.CreateNotebook(context.Background(), tt.notebook)
+			if tt.wantErr {
+				require.Error(t, err)
+				if tt.errIs != nil {
+					require.ErrorIs(t, err, tt.errIs)
+				}
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotEqual(t, uuid.Nil, id)
+		})
+	}
+}
+
+func TestNotebookS_Notebooks(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	parentID := uuid.New()
+
+	tests := []struct {
+		name    string
+		f       func(*mock_service.MockRepositoryI)
+		want    []dto.NotebookOutput
+		wantErr bool
+	}{
+		{
+			name: "success",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().Notebooks(gomock.Any(), userID).Return([]domain.Notebook{
+					{ID: parentID, Name: "work", Notes: 2},
+					{ParentID: &parentID, Name: "archive"},
+				}, nil)
+			},
+			want: []dto.NotebookOutput{
+				{ID: parentID, Name: "work", Notes: 2},
+				{ParentID: &parentID, Name: "archive"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "no notebooks",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().Notebooks(gomock.Any(), userID).Return(nil, nil)
+			},
+			want:    []dto.NotebookOutput{},
+			wantErr: false,
+		},
+		{
+			name: "repository error",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().Notebooks(gomock.Any(), userID).Return(nil, domain.ErrReceiving)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mockNotebookService(t, ctrl, tt.f)
+
+			got, err := service.Notebooks(context.Background(), userID)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNotebookS_MoveNotebook(t *testing.T) {
+	t.Parallel()
+
+	notebookID := uuid.New()
+	parentID := uuid.New()
+	userID := uuid.New()
+
+	tests := []struct {
+		name    string
+		move    dto.NotebookMove
+		f       func(*mock_service.MockRepositoryI)
+		wantErr bool
+		errIs   error
+	}{
+		{
+			name: "success",
+			move: dto.NotebookMove{
+				ID:       notebookID,
+				UserID:   userID,
+				ParentID: &parentID,
+			},
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().MoveNotebook(gomock.Any(), userID, notebookID, &parentID).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "to root",
+			move: dto.NotebookMove{
+				ID:     notebookID,
+				UserID: userID,
+			},
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().MoveNotebook(gomock.Any(), userID, notebookID, nil).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "into itself",
+			move: dto.NotebookMove{
+				ID:       notebookID,
+				UserID:   userID,
+				ParentID: &notebookID,
+			},
+			wantErr: true,
+			errIs:   domain.ErrNotebookCycle,
+		},
+		{
+			name: "into descendant",
+			move: dto.NotebookMove{
+				ID:       notebookID,
+				UserID:   userID,
+				ParentID: &parentID,
+			},
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().MoveNotebook(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(domain.ErrNotebookCycle)
+			},
+			wantErr: true,
+			errIs:   domain.ErrNotebookCycle,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mockNotebookService(t, ctrl, tt.f)
+
+			err := service.MoveNotebook(context.Background(), tt.move)
+			if tt.wantErr {
+				require.Error(t, err)
+				if tt.errIs != nil {
+					require.ErrorIs(t, err, tt.errIs)
+				}
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestNotebookS_DeleteNotebook(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		mode     string
+		wantMode string
+		err      error
+		wantErr  bool
+	}{
+		{
+			name:     "default mode",
+			mode:     "",
+			wantMode: domain.NotebookDeleteMove,
+			wantErr:  false,
+		},
+		{
+			name:     "delete mode",
+			mode:     domain.NotebookDeleteDelete,
+			wantMode: domain.NotebookDeleteDelete,
+			wantErr:  false,
+		},
+		{
+			name:     "not found",
+			mode:     domain.NotebookDeleteMove,
+			wantMode: domain.NotebookDeleteMove,
+			err:      domain.ErrNotFound,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mockNotebookService(t, ctrl, func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().DeleteNotebook(gomock.Any(), gomock.Any(), gomock.Any(), tt.wantMode).Return(tt.err)
+			})
+
+			err := service.DeleteNotebook(context.Background(), uuid.New(), uuid.New(), tt.mode)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
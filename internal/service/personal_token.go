@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+	"noteApp/pkg/utils"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const personalTokenSize = 32
+
+type PersonalTokenRI interface {
+	CreatePersonalToken(ctx context.Context, token domain.PersonalToken) error
+	PersonalTokens(ctx context.Context, userID uuid.UUID) ([]domain.PersonalToken, error)
+	TouchPersonalToken(ctx context.Context, tokenHash string) (domain.PersonalToken, error)
+	DeletePersonalToken(ctx context.Context, userID, tokenID uuid.UUID) error
+}
+
+type PersonalTokenS struct {
+	repo PersonalTokenRI
+	log  *logger.Logger
+}
+
+func NewPersonalTokenService(repo PersonalTokenRI, log *logger.Logger) *PersonalTokenS {
+	return &PersonalTokenS{
+		repo: repo,
+		log:  log,
+	}
+}
+
+func (p *PersonalTokenS) CreatePersonalToken(ctx context.Context, token dto.PersonalTokenCreate) (dto.PersonalTokenOutput, error) {
+	secret, err := utils.RandomToken(personalTokenSize)
+	if err != nil {
+		p.log.Error("failed to generate personal access token", zap.Error(err))
+		return dto.PersonalTokenOutput{}, err
+	}
+	secret = domain.PersonalTokenPrefix + secret
+
+	input := domain.PersonalToken{
+		ID:        uuid.New(),
+		UserID:    token.UserID,
+		Name:      token.Name,
+		TokenHash: utils.HashToken(secret),
+		Scopes:    uniqueScopes(token.Scopes),
+		ExpiresAt: token.ExpiresAt,
+		CreatedAt: time.Now(),
+	}
+
+	if err := p.repo.CreatePersonalToken(ctx, input); err != nil {
+		p.log.Error("failed to create personal access token",
+			zap.Error(err),
+			zap.String("user_id", token.UserID.String()),
+		)
+		return dto.PersonalTokenOutput{}, err
+	}
+
+	p.log.Info("personal access token created",
+		zap.String("user_id", token.UserID.String()),
+		zap.String("token_id", input.ID.String()),
+		zap.Strings("scopes", input.Scopes),
+	)
+
+	output := personalTokenDomainToDTO(input)
+	output.Token = secret
+
+	return output, nil
+}
+
+func (p *PersonalTokenS) PersonalTokens(ctx context.Context, userID uuid.UUID) ([]dto.PersonalTokenOutput, error) {
+	tokensDB, err := p.repo.PersonalTokens(ctx, userID)
+	if err != nil {
+		p.log.Error("failed to get personal access tokens from repository",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return nil, err
+	}
+
+	tokens := make([]dto.PersonalTokenOutput, 0, len(tokensDB))
+	for _, v := range tokensDB {
+		tokens = append(tokens, personalTokenDomainToDTO(v))
+	}
+
+	return tokens, nil
+}
+
+func (p *PersonalTokenS) DeletePersonalToken(ctx context.Context, userID, tokenID uuid.UUID) error {
+	if err := p.repo.DeletePersonalToken(ctx, userID, tokenID); err != nil {
+		if !errors.Is(err, domain.ErrNotFound) {
+			p.log.Error("failed to delete personal access token",
+				zap.Error(err),
+				zap.String("user_id", userID.String()),
+				zap.String("token_id", tokenID.String()),
+			)
+		}
+		return err
+	}
+
+	p.log.Info("personal access token revoked",
+		zap.String("user_id", userID.String()),
+		zap.String("token_id", tokenID.String()),
+	)
+
+	return nil
+}
+
+func (p *PersonalTokenS) AuthenticatePersonalToken(ctx context.Context, token string) (dto.TokenClaims, error) {
+	if !strings.HasPrefix(token, domain.PersonalTokenPrefix) {
+		return dto.TokenClaims{}, domain.ErrNotFound
+	}
+
+	tokenDB, err := p.repo.TouchPersonalToken(ctx, utils.HashToken(token))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			p.log.Debug("unknown, expired or disabled personal access token")
+		} else {
+			p.log.Error("failed to authenticate personal access token", zap.Error(err))
+		}
+		return dto.TokenClaims{}, err
+	}
+
+	return dto.TokenClaims{
+		UserID: tokenDB.UserID,
+		Scopes: tokenDB.Scopes,
+	}, nil
+}
+
+func uniqueScopes(scopes []string) []string {
+	seen := make(map[string]bool, len(scopes))
+	unique := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if !seen[scope] {
+			seen[scope] = true
+			unique = append(unique, scope)
+		}
+	}
+
+	return unique
+}
+
+func personalTokenDomainToDTO(token domain.PersonalToken) dto.PersonalTokenOutput {
+	return dto.PersonalTokenOutput{
+		ID:         token.ID,
+		Name:       token.Name,
+		Scopes:     token.Scopes,
+		ExpiresAt:  token.ExpiresAt,
+		LastUsedAt: token.LastUsedAt,
+		CreatedAt:  token.CreatedAt,
+	}
+}
@@ -0,0 +1,265 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	mock_service "noteApp/internal/service/mock"
+	"noteApp/pkg/logger"
+	"noteApp/pkg/utils"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockPersonalTokenService(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock_service.MockRepositoryI)) *PersonalTokenS {
+	t.Helper()
+
+	repo := mock_service.NewMockRepositoryI(ctrl)
+	if setupMock != nil {
+		setupMock(repo)
+	}
+
+	return NewPersonalTokenService(repo, logger.LoggerForTest())
+}
+
+func TestPersonalTokenS_CreatePersonalToken(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	expiresAt := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		input   dto.PersonalTokenCreate
+		f       func(*mock_service.MockRepositoryI, *domain.PersonalToken)
+		wantErr bool
+	}{
+		{
+			name: "success",
+			input: dto.PersonalTokenCreate{
+				UserID:    userID,
+				Name:      "ci",
+				Scopes:    []string{domain.ScopeNotesRead, domain.ScopeNotesWrite, domain.ScopeNotesRead},
+				ExpiresAt: &expiresAt,
+			},
+			f: func(mri *mock_service.MockRepositoryI, saved *domain.PersonalToken) {
+				mri.EXPECT().CreatePersonalToken(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, token domain.PersonalToken) error {
+					*saved = token
+					return nil
+				})
+			},
+		},
+		{
+			name: "repository error",
+			input: dto.PersonalTokenCreate{
+				UserID: userID,
+				Name:   "ci",
+				Scopes: []string{domain.ScopeNotesRead},
+			},
+			f: func(mri *mock_service.MockRepositoryI, saved *domain.PersonalToken) {
+				mri.EXPECT().CreatePersonalToken(gomock.Any(), gomock.Any()).Return(domain.ErrFailedToCreate)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			var saved domain.PersonalToken
+			p := mockPersonalTokenService(t, ctrl, func(mri *mock_service.MockRepositoryI) {
+				tt.f(mri, &saved)
+			})
+
+			got, err := p.CreatePersonalToken(context.Background(), tt.input)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(got.Token, domain.PersonalTokenPrefix))
+			assert.Equal(t, utils.HashToken(got.Token), saved.TokenHash)
+			assert.NotContains(t, saved.TokenHash, got.Token)
+			assert.Equal(t, userID, saved.UserID)
+			assert.Equal(t, []string{domain.ScopeNotesRead, domain.ScopeNotesWrite}, saved.Scopes)
+			assert.Equal(t, saved.ID, got.ID)
+			assert.Equal(t, saved.Scopes, got.Scopes)
+			assert.Equal(t, tt.input.ExpiresAt, got.ExpiresAt)
+		})
+	}
+}
+
+func TestPersonalTokenS_PersonalTokens(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	tokenID := uuid.New()
+	createdAt := time.Now()
+
+	tests := []struct {
+		name    string
+		f       func(*mock_service.MockRepositoryI)
+		want    []dto.PersonalTokenOutput
+		wantErr bool
+	}{
+		{
+			name: "success",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().PersonalTokens(gomock.Any(), userID).Return([]domain.PersonalToken{
+					{ID: tokenID, UserID: userID, Name: "ci", TokenHash: "hash", Scopes: []string{domain.ScopeNotesRead}, CreatedAt: createdAt},
+				}, nil)
+			},
+			want: []dto.PersonalTokenOutput{
+				{ID: tokenID, Name: "ci", Scopes: []string{domain.ScopeNotesRead}, CreatedAt: createdAt},
+			},
+		},
+		{
+			name: "no tokens",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().PersonalTokens(gomock.Any(), userID).Return(nil, nil)
+			},
+			want: []dto.PersonalTokenOutput{},
+		},
+		{
+			name: "repository error",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().PersonalTokens(gomock.Any(), userID).Return(nil, errors.New("db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			p := mockPersonalTokenService(t, ctrl, tt.f)
+
+			got, err := p.PersonalTokens(context.Background(), userID)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPersonalTokenS_DeletePersonalToken(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	tokenID := uuid.New()
+
+	tests := []struct {
+		name    string
+		f       func(*mock_service.MockRepositoryI)
+		wantErr error
+	}{
+		{
+			name: "success",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().DeletePersonalToken(gomock.Any(), userID, tokenID).Return(nil)
+			},
+		},
+		{
+			name: "not found",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().DeletePersonalToken(gomock.Any(), userID, tokenID).Return(domain.MakeError(domain.ErrFailedToDelete, domain.ErrNotFound, "personal access token"))
+			},
+			wantErr: domain.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			p := mockPersonalTokenService(t, ctrl, tt.f)
+
+			err := p.DeletePersonalToken(context.Background(), userID, tokenID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestPersonalTokenS_AuthenticatePersonalToken(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	token := domain.PersonalTokenPrefix + "secret"
+
+	tests := []struct {
+		name    string
+		token   string
+		f       func(*mock_service.MockRepositoryI)
+		want    dto.TokenClaims
+		wantErr error
+	}{
+		{
+			name:  "success",
+			token: token,
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().TouchPersonalToken(gomock.Any(), utils.HashToken(token)).Return(domain.PersonalToken{
+					UserID: userID,
+					Scopes: []string{domain.ScopeNotesRead},
+				}, nil)
+			},
+			want: dto.TokenClaims{UserID: userID, Scopes: []string{domain.ScopeNotesRead}},
+		},
+		{
+			name:    "missing prefix",
+			token:   "secret",
+			wantErr: domain.ErrNotFound,
+		},
+		{
+			name:  "unknown or expired token",
+			token: token,
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().TouchPersonalToken(gomock.Any(), utils.HashToken(token)).Return(domain.PersonalToken{}, domain.MakeError(domain.ErrReceiving, domain.ErrNotFound, "personal access token"))
+			},
+			wantErr: domain.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			p := mockPersonalTokenService(t, ctrl, tt.f)
+
+			got, err := p.AuthenticatePersonalToken(context.Background(), tt.token)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+
+	"github.com/google/uuid"
+	"github.com/pmezard/go-difflib/difflib"
+	"go.uber.org/zap"
+)
+
+type RevisionRI interface {
+	NoteRevisions(ctx context.Context, userID, noteID uuid.UUID) ([]domain.NoteRevision, error)
+	NoteRevision(ctx context.Context, userID, noteID uuid.UUID, revision int) (domain.NoteRevision, error)
+}
+
+type RevisionNoteI interface {
+	Note(ctx context.Context, userID, noteID uuid.UUID) (dto.NoteOutput, error)
+	UpdateNote(ctx context.Context, note dto.NoteUpdate) error
+}
+
+type RevisionS struct {
+	repo  RevisionRI
+	notes RevisionNoteI
+	log   *logger.Logger
+}
+
+func NewRevisionService(repo RevisionRI, notes RevisionNoteI, log *logger.Logger) *RevisionS {
+	return &RevisionS{
+		repo:  repo,
+		notes: notes,
+		log:   log,
+	}
+}
+
+func (r *RevisionS) NoteRevisions(ctx context.Context, userID, noteID uuid.UUID) ([]dto.NoteRevisionOutput, error) {
+	revisionsDB, err := r.repo.NoteRevisions(ctx, userID, noteID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			r.log.Warn("note not found while listing revisions",
+				zap.String("user_id", userID.String()),
+				zap.String("note_id", noteID.String()),
+			)
+		} else {
+			r.log.Error("failed to get revisions from repository",
+				zap.Error(err),
+				zap.String("user_id", userID.String()),
+				zap.String("note_id", noteID.String()),
+			)
+		}
+		return nil, err
+	}
+
+	revisions := make([]dto.NoteRevisionOutput, 0, len(revisionsDB))
+	for _, v := range revisionsDB {
+		revisions = append(revisions, revisionDomainToDTO(v))
+	}
+
+	return revisions, nil
+}
+
+func (r *RevisionS) NoteRevision(ctx context.Context, userID, noteID uuid.UUID, revision int) (dto.NoteRevisionOutput, error) {
+	revisionDB, err := r.repo.NoteRevision(ctx, userID, noteID, revision)
+	if err != nil {
+		if !errors.Is(err, domain.ErrNotFound) {
+			r.log.Error("failed to get revision from repository",
+				zap.Error(err),
+				zap.String("user_id", userID.String()),
+				zap.String("note_id", noteID.String()),
+				zap.Int("revision", revision),
+			)
+		}
+		return dto.NoteRevisionOutput{}, err
+	}
+
+	return revisionDomainToDTO(revisionDB), nil
+}
+
+func (r *RevisionS) DiffNoteRevisions(ctx context.Context, userID, noteID uuid.UUID, diff dto.NoteRevisionDiff) (dto.NoteDiffOutput, error) {
+	from, err := r.repo.NoteRevision(ctx, userID, noteID, diff.From)
+	if err != nil {
+		return dto.NoteDiffOutput{}, err
+	}
+
+	toName := "current"
+	var toHeading, toContent string
+	if diff.To == 0 {
+		note, err := r.notes.Note(ctx, userID, noteID)
+		if err != nil {
+			return dto.NoteDiffOutput{}, err
+		}
+		toHeading, toContent = note.Heading, note.Content
+	} else {
+		to, err := r.repo.NoteRevision(ctx, userID, noteID, diff.To)
+		if err != nil {
+			return dto.NoteDiffOutput{}, err
+		}
+		toName = fmt.Sprintf("revision %d", diff.To)
+		toHeading, toContent = to.Heading, to.Content
+	}
+
+	text, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(revisionText(from.Heading, from.Content)),
+		B:        difflib.SplitLines(revisionText(toHeading, toContent)),
+		FromFile: fmt.Sprintf("revision %d", diff.From),
+		ToFile:   toName,
+		Context:  3,
+	})
+	if err != nil {
+		r.log.Error("failed to build revision diff",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+			zap.String("note_id", noteID.String()),
+		)
+		return dto.NoteDiffOutput{}, err
+	}
+
+	return dto.NoteDiffOutput{
+		From: diff.From,
+		To:   diff.To,
+		Diff: text,
+	}, nil
+}
+
+func (r *RevisionS) RestoreNoteRevision(ctx context.Context, userID, noteID uuid.UUID, revision int, version *int) error {
+	rev, err := r.repo.NoteRevision(ctx, userID, noteID, revision)
+	if err != nil {
+		return err
+	}
+
+	err = r.notes.UpdateNote(ctx, dto.NoteUpdate{
+		ID:      noteID,
+		UserID:  userID,
+		Heading: &rev.Heading,
+		Content: &rev.Content,
+		Version: version,
+	})
+	if err != nil {
+		r.log.Error("failed to restore note revision",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+			zap.String("note_id", noteID.String()),
+			zap.Int("revision", revision),
+		)
+		return err
+	}
+
+	r.log.Info("note revision restored",
+		zap.String("user_id", userID.String()),
+		zap.String("note_id", noteID.String()),
+		zap.Int("revision", revision),
+	)
+
+	return nil
+}
+
+func revisionText(heading, content string) string {
+	return heading + "\n\n" + content
+}
+
+func revisionDomainToDTO(revision domain.NoteRevision) dto.NoteRevisionOutput {
+	return dto.NoteRevisionOutput{
+		Revision:  revision.Revision,
+		Heading:   revision.Heading,
+		Content:   revision.Content,
+		CreatedAt: revision.CreatedAt,
+	}
+}
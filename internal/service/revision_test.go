@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	mock_service "noteApp/internal/service/mock"
+	"noteApp/pkg/logger"
+	"noteApp/pkg/pubsub"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockRevisionService(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock_service.MockRepositoryI)) *RevisionS {
+	t.Helper()
+
+	repo := mock_service.NewMockRepositoryI(ctrl)
+	if setupMock != nil {
+		setupMock(repo)
+	}
+
+	repo.EXPECT().CreateNoteEvents(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	notes := NewNoteService(repo, repo, repo, pubsub.NewHub(), logger.LoggerForTest())
+
+	return NewRevisionService(repo, notes, logger.LoggerForTest())
+}
+
+func TestRevisionS_NoteRevisions(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	noteID := uuid.New()
+
+	tests := []struct {
+		name    string
+		f       func(*mock_service.MockRepositoryI)
+		want    []dto.NoteRevisionOutput
+		wantErr bool
+	}{
+		{
+			name: "success",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().NoteRevisions(gomock.Any(), userID, noteID).Return([]domain.NoteRevision{
+					{NoteID: noteID, Revision: 2, Heading: "b"},
+					{NoteID: noteID, Revision: 1, Heading: "a"},
+				}, nil)
+			},
+			want: []dto.NoteRevisionOutput{
+				{Revision: 2, Heading: "b"},
+				{Revision: 1, Heading: "a"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "no revisions",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().NoteRevisions(gomock.Any(), userID, noteID).Return(nil, nil)
+			},
+			want:    []dto.NoteRevisionOutput{},
+			wantErr: false,
+		},
+		{
+			name: "note not found",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().NoteRevisions(gomock.Any(), userID, noteID).Return(nil, domain.ErrNotFound)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mockRevisionService(t, ctrl, tt.f)
+
+			got, err := service.NoteRevisions(context.Background(), userID, noteID)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRevisionS_DiffNoteRevisions(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	noteID := uuid.New()
+
+	tests := []struct {
+		name    string
+		diff    dto.NoteRevisionDiff
+		f       func(*mock_service.MockRepositoryI)
+		want    dto.NoteDiffOutput
+		wantErr bool
+	}{
+		{
+			name: "between revisions",
+			diff: dto.NoteRevisionDiff{From: 1, To: 2},
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().NoteRevision(gomock.Any(), userID, noteID, 1).
+					Return(domain.NoteRevision{Revision: 1, Heading: "list", Content: "milk\nbread"}, nil)
+				mri.EXPECT().NoteRevision(gomock.Any(), userID, noteID, 2).
+					Return(domain.NoteRevision{Revision: 2, Heading: "list", Content: "milk\neggs"}, nil)
+			},
+			want: dto.NoteDiffOutput{
+				From: 1,
+				To:   2,
+				Diff: "--- revision 1\n+++ revision 2\n@@ -1,4 +1,4 @@\n list\n \n milk\n-bread\n+eggs\n",
+			},
+			wantErr: false,
+		},
+		{
+			name: "against current note",
+			diff: dto.NoteRevisionDiff{From: 1},
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().NoteRevision(gomock.Any(), userID, noteID, 1).
+					Return(domain.NoteRevision{Revision: 1, Heading: "old", Content: "text"}, nil)
+				mri.EXPECT().Note(gomock.Any(), userID, noteID).
+					Return(domain.Note{Heading: "new", Content: "text"}, nil)
+			},
+			want: dto.NoteDiffOutput{
+				From: 1,
+				Diff: "--- revision 1\n+++ current\n@@ -1,3 +1,3 @@\n-old\n+new\n \n text\n",
+			},
+			wantErr: false,
+		},
+		{
+			name: "revision not found",
+			diff: dto.NoteRevisionDiff{From: 1, To: 5},
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().NoteRevision(gomock.Any(), userID, noteID, 1).Return(domain.NoteRevision{}, nil)
+				mri.EXPECT().NoteRevision(gomock.Any(), userID, noteID, 5).Return(domain.NoteRevision{}, domain.ErrNotFound)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mockRevisionService(t, ctrl, tt.f)
+
+			got, err := service.DiffNoteRevisions(context.Background(), userID, noteID, tt.diff)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRevisionS_RestoreNoteRevision(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	noteID := uuid.New()
+
+	tests := []struct {
+		name    string
+		f       func(*mock_service.MockRepositoryI)
+		wantErr bool
+	}{
+		{
+			name: "success",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().NoteRevision(gomock.Any(), userID, noteID, 3).
+					Return(domain.NoteRevision{Revision: 3, Heading: "old heading", Content: "old content"}, nil)
+				mri.EXPECT().UpdateNote(gomock.Any(), domain.NoteUpdate{
+					ID:      noteID,
+					UserID:  userID,
+					Heading: &[]string{"old heading"}[0],
+					Content: &[]string{"old content"}[0],
+					Version: &[]int{5}[0],
+				}).Return(nil)
+				mri.EXPECT().CreateNoteEvents(gomock.Any(), domain.NoteEvent{
+					NoteID:  noteID,
+					ActorID: userID,
+					Type:    domain.NoteEventUpdated,
+				}).Return(nil, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "version mismatch",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().NoteRevision(gomock.Any(), userID, noteID, 3).Return(domain.NoteRevision{Heading: "h", Content: "c"}, nil)
+				mri.EXPECT().UpdateNote(gomock.Any(), gomock.Any()).
+					Return(domain.MakeError(domain.ErrFailedToUpdate, domain.ErrVersionMismatch, "note"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "revision not found",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().NoteRevision(gomock.Any(), userID, noteID, 3).Return(domain.NoteRevision{}, domain.ErrNotFound)
+			},
+			wantErr: true,
+		},
+		{
+			name: "update failed",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().NoteRevision(gomock.Any(), userID, noteID, 3).Return(domain.NoteRevision{Heading: "h", Content: "c"}, nil)
+				mri.EXPECT().UpdateNote(gomock.Any(), gomock.Any()).Return(domain.ErrFailedToUpdate)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mockRevisionService(t, ctrl, tt.f)
+
+			err := service.RestoreNoteRevision(context.Background(), userID, noteID, 3, &[]int{5}[0])
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
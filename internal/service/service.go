@@ -1,8 +1,14 @@
 package service
 
 import (
+	"context"
 	"noteApp/internal/config"
+	"noteApp/internal/models/domain"
 	"noteApp/pkg/logger"
+
+	"github.com/google/uuid"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jwt"
 )
 
 type HasherI interface {
@@ -10,27 +16,98 @@ type HasherI interface {
 	ComparePassword(hash string, password string) error
 }
 
+type SignerI interface {
+	Sign(token jwt.Token) ([]byte, error)
+	Verify(token []byte) (jwt.Token, error)
+	PublicKeys() jwk.Set
+}
+
+type MailerI interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+type BrokerI interface {
+	Publish(ctx context.Context, topic string, payload []byte) error
+	Subscribe(ctx context.Context, topic string) (<-chan []byte, error)
+}
+
+type TxManagerI interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type NoteEventPublisherI interface {
+	StoreNoteEvents(ctx context.Context, eventType string, userID, noteID uuid.UUID) []domain.NoteEvent
+	BroadcastNoteEvents(ctx context.Context, eventType string, userID, noteID uuid.UUID, events []domain.NoteEvent)
+}
+
 type RepositoryI interface {
+	TxManagerI
+	AdminRI
 	AuthRI
+	EmailRI
+	EventRI
+	LinkRI
 	NoteRI
+	NotebookRI
+	PersonalTokenRI
+	RevisionRI
+	SessionRI
+	ShareRI
+	SyncRI
+	TagRI
+	TrashRI
+	TwoFactorRI
 	UserRI
 }
 
 type Service struct {
+	*AdminS
 	*AuthS
+	*EmailS
+	*EventS
+	*LinkS
 	*NoteS
+	*NotebookS
+	*PersonalTokenS
+	*RevisionS
+	*SessionS
+	*ShareS
+	*SyncS
+	*TagS
+	*TrashS
+	*TwoFactorS
 	*UserS
 }
 
 func NewService(
 	repos RepositoryI,
 	hasher HasherI,
+	signer SignerI,
+	broker BrokerI,
+	mailer MailerI,
 	cfg config.AuthCfg,
+	mailCfg config.MailCfg,
 	log *logger.Logger,
 ) Service {
+	notes := NewNoteService(repos, repos, repos, broker, log)
+	emails := NewEmailService(repos, repos, mailer, hasher, mailCfg, log)
+
 	return Service{
-		AuthS: NewAuthService(repos, hasher, cfg, log),
-		NoteS: NewNoteService(repos, log),
-		UserS: NewUserService(repos, repos, hasher, log),
+		AdminS:         NewAdminService(repos, repos, hasher, log),
+		AuthS:          NewAuthService(repos, repos, hasher, signer, emails, cfg, log),
+		EmailS:         emails,
+		EventS:         NewEventService(repos, broker, log),
+		LinkS:          NewLinkService(repos, repos, hasher, log),
+		NoteS:          notes,
+		NotebookS:      NewNotebookService(repos, log),
+		PersonalTokenS: NewPersonalTokenService(repos, log),
+		RevisionS:      NewRevisionService(repos, notes, log),
+		SessionS:       NewSessionService(repos, log),
+		ShareS:         NewShareService(repos, notes, log),
+		SyncS:          NewSyncService(repos, notes, log),
+		TagS:           NewTagService(repos, log),
+		TrashS:         NewTrashService(repos, repos, notes, log),
+		TwoFactorS:     NewTwoFactorService(repos, repos, log),
+		UserS:          NewUserService(repos, repos, repos, hasher, log),
 	}
 }
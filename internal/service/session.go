@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type SessionRI interface {
+	Token(ctx context.Context, tokenID string) (domain.Token, error)
+	Sessions(ctx context.Context, userID uuid.UUID) ([]domain.Session, error)
+	DeleteSession(ctx context.Context, userID, sessionID uuid.UUID) error
+	DeleteOtherSessions(ctx context.Context, userID, keepID uuid.UUID) error
+}
+
+type SessionS struct {
+	repo SessionRI
+	log  *logger.Logger
+}
+
+func NewSessionService(repo SessionRI, log *logger.Logger) *SessionS {
+	return &SessionS{
+		repo: repo,
+		log:  log,
+	}
+}
+
+func (s *SessionS) Sessions(ctx context.Context, userID uuid.UUID, tokenID string) ([]dto.SessionOutput, error) {
+	sessionsDB, err := s.repo.Sessions(ctx, userID)
+	if err != nil {
+		s.log.Error("failed to get sessions from repository",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return nil, err
+	}
+
+	current, _ := s.currentSession(ctx, userID, tokenID)
+
+	sessions := make([]dto.SessionOutput, 0, len(sessionsDB))
+	for _, v := range sessionsDB {
+		session := sessionDomainToDTO(v)
+		session.Current = v.ID == current
+		sessions = append(sessions, session)
+	}
+
+	return sessions, nil
+}
+
+func (s *SessionS) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	if err := s.repo.DeleteSession(ctx, userID, sessionID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			s.log.Warn("session not found during revoke",
+				zap.String("user_id", userID.String()),
+				zap.String("session_id", sessionID.String()),
+			)
+		} else {
+			s.log.Error("failed to revoke session",
+				zap.Error(err),
+				zap.String("user_id", userID.String()),
+				zap.String("session_id", sessionID.String()),
+			)
+		}
+		return err
+	}
+
+	s.log.Info("session revoked",
+		zap.String("user_id", userID.String()),
+		zap.String("session_id", sessionID.String()),
+	)
+
+	return nil
+}
+
+func (s *SessionS) RevokeOtherSessions(ctx context.Context, userID uuid.UUID, tokenID string) error {
+	current, err := s.currentSession(ctx, userID, tokenID)
+	if err != nil {
+		s.log.Warn("current session not found",
+			zap.String("user_id", userID.String()),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	if err := s.repo.DeleteOtherSessions(ctx, userID, current); err != nil {
+		s.log.Error("failed to revoke other sessions",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return err
+	}
+
+	s.log.Info("other sessions revoked",
+		zap.String("user_id", userID.String()),
+		zap.String("session_id", current.String()),
+	)
+
+	return nil
+}
+
+func (s *SessionS) currentSession(ctx context.Context, userID uuid.UUID, tokenID string) (uuid.UUID, error) {
+	if tokenID == "" {
+		return uuid.Nil, domain.MakeError(domain.ErrReceiving, domain.ErrNotFound, "session")
+	}
+
+	token, err := s.repo.Token(ctx, tokenID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if token.UserID != userID || token.RotatedAt != nil {
+		return uuid.Nil, domain.MakeError(domain.ErrReceiving, domain.ErrNotFound, "session")
+	}
+
+	return token.FamilyID, nil
+}
+
+func sessionDomainToDTO(session domain.Session) dto.SessionOutput {
+	return dto.SessionOutput{
+		ID:         session.ID,
+		UserAgent:  session.UserAgent,
+		IP:         session.IP,
+		CreatedAt:  session.CreatedAt,
+		LastUsedAt: session.LastUsedAt,
+	}
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"noteApp/internal/models/domain"
+	mock_service "noteApp/internal/service/mock"
+	"noteApp/pkg/logger"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockSessionService(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock_service.MockRepositoryI)) *SessionS {
+	t.Helper()
+
+	repo := mock_service.NewMockRepositoryI(ctrl)
+	if setupMock != nil {
+		setupMock(repo)
+	}
+
+	return NewSessionService(repo, logger.LoggerForTest())
+}
+
+func TestSessionS_Sessions(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	current := uuid.New()
+	other := uuid.New()
+	rotatedAt := time.Now()
+
+	sessions := []domain.Session{
+		{ID: current, UserID: userID, UserAgent: "firefox", IP: "10.0.0.1"},
+		{ID: other, UserID: userID, UserAgent: "curl", IP: "10.0.0.2"},
+	}
+
+	tests := []struct {
+		name        string
+		tokenID     string
+		f           func(*mock_service.MockRepositoryI)
+		wantErr     bool
+		wantCurrent []bool
+	}{
+		{
+			name:    "marks current session",
+			tokenID: "current",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().Sessions(gomock.Any(), userID).Return(sessions, nil)
+				mri.EXPECT().Token(gomock.Any(), "current").Return(domain.Token{UserID: userID, FamilyID: current}, nil)
+			},
+			wantCurrent: []bool{true, false},
+		},
+		{
+			name: "without refresh token",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().Sessions(gomock.Any(), userID).Return(sessions, nil)
+			},
+			wantCurrent: []bool{false, false},
+		},
+		{
+			name:    "rotated refresh token",
+			tokenID: "rotated",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().Sessions(gomock.Any(), userID).Return(sessions, nil)
+				mri.EXPECT().Token(gomock.Any(), "rotated").Return(domain.Token{UserID: userID, FamilyID: current, RotatedAt: &rotatedAt}, nil)
+			},
+			wantCurrent: []bool{false, false},
+		},
+		{
+			name: "repository error",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().Sessions(gomock.Any(), userID).Return(nil, domain.ErrReceiving)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := mockSessionService(t, ctrl, tt.f)
+
+			got, err := s.Sessions(context.Background(), userID, tt.tokenID)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, got, len(tt.wantCurrent))
+			for i, current := range tt.wantCurrent {
+				assert.Equal(t, sessions[i].ID, got[i].ID)
+				assert.Equal(t, sessions[i].UserAgent, got[i].UserAgent)
+				assert.Equal(t, current, got[i].Current)
+			}
+		})
+	}
+}
+
+func TestSessionS_RevokeSession(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	sessionID := uuid.New()
+
+	s := mockSessionService(t, ctrl, func(mri *mock_service.MockRepositoryI) {
+		mri.EXPECT().DeleteSession(gomock.Any(), userID, sessionID).Return(nil)
+		mri.EXPECT().DeleteSession(gomock.Any(), userID, gomock.Any()).Return(domain.ErrNotFound)
+	})
+
+	require.NoError(t, s.RevokeSession(context.Background(), userID, sessionID))
+	require.ErrorIs(t, s.RevokeSession(context.Background(), userID, uuid.New()), domain.ErrNotFound)
+}
+
+func TestSessionS_RevokeOtherSessions(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	familyID := uuid.New()
+
+	tests := []struct {
+		name    string
+		tokenID string
+		f       func(*mock_service.MockRepositoryI)
+		wantErr error
+	}{
+		{
+			name:    "success",
+			tokenID: "current",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().Token(gomock.Any(), "current").Return(domain.Token{UserID: userID, FamilyID: familyID}, nil)
+				mri.EXPECT().DeleteOtherSessions(gomock.Any(), userID, familyID).Return(nil)
+			},
+		},
+		{
+			name:    "token of another user",
+			tokenID: "foreign",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().Token(gomock.Any(), "foreign").Return(domain.Token{UserID: uuid.New(), FamilyID: familyID}, nil)
+			},
+			wantErr: domain.ErrNotFound,
+		},
+		{
+			name:    "unknown token",
+			tokenID: "unknown",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().Token(gomock.Any(), "unknown").Return(domain.Token{}, domain.ErrNotFound)
+			},
+			wantErr: domain.ErrNotFound,
+		},
+		{
+			name:    "repository error",
+			tokenID: "current",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().Token(gomock.Any(), "current").Return(domain.Token{UserID: userID, FamilyID: familyID}, nil)
+				mri.EXPECT().DeleteOtherSessions(gomock.Any(), userID, familyID).Return(domain.ErrFailedToDelete)
+			},
+			wantErr: domain.ErrFailedToDelete,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := mockSessionService(t, ctrl, tt.f)
+
+			err := s.RevokeOtherSessions(context.Background(), userID, tt.tokenID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type ShareRI interface {
+	ShareNote(ctx context.Context, target domain.NoteShareTarget) error
+	UnshareNote(ctx context.Context, target domain.NoteShareTarget) error
+	NoteShares(ctx context.Context, ownerID, noteID uuid.UUID) ([]domain.NoteShare, error)
+	NoteShare(ctx context.Context, userID, noteID uuid.UUID) (domain.NoteShare, error)
+	SharedNotes(ctx context.Context, userID uuid.UUID, p dto.Paginated) ([]domain.SharedNote, int, error)
+}
+
+type ShareS struct {
+	repo   ShareRI
+	events NoteEventPublisherI
+	log    *logger.Logger
+}
+
+func NewShareService(repo ShareRI, events NoteEventPublisherI, log *logger.Logger) *ShareS {
+	return &ShareS{
+		repo:   repo,
+		events: events,
+		log:    log,
+	}
+}
+
+func (s *ShareS) ShareNote(ctx context.Context, share dto.NoteShareCreate) error {
+	target := domain.NoteShareTarget{
+		NoteID:     share.NoteID,
+		OwnerID:    share.OwnerID,
+		Username:   share.Username,
+		Email:      share.Email,
+		Permission: share.Permission,
+	}
+
+	if err := s.repo.ShareNote(ctx, target); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			s.log.Warn("note or grantee not found while sharing",
+				zap.String("user_id", share.OwnerID.String()),
+				zap.String("note_id", share.NoteID.String()),
+			)
+		} else {
+			s.log.Error("failed to share note",
+				zap.Error(err),
+				zap.String("user_id", share.OwnerID.String()),
+				zap.String("note_id", share.NoteID.String()),
+			)
+		}
+		return err
+	}
+
+	s.log.Info("note shared successfully",
+		zap.String("user_id", share.OwnerID.String()),
+		zap.String("note_id", share.NoteID.String()),
+		zap.String("permission", share.Permission),
+	)
+
+	return nil
+}
+
+func (s *ShareS) UnshareNote(ctx context.Context, share dto.NoteShareDelete) error {
+	target := domain.NoteShareTarget{
+		NoteID:   share.NoteID,
+		OwnerID:  share.OwnerID,
+		Username: share.Username,
+		Email:    share.Email,
+	}
+
+	if err := s.repo.UnshareNote(ctx, target); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			s.log.Warn("note share not found",
+				zap.String("user_id", share.OwnerID.String()),
+				zap.String("note_id", share.NoteID.String()),
+			)
+		} else {
+			s.log.Error("failed to unshare note",
+				zap.Error(err),
+				zap.String("user_id", share.OwnerID.String()),
+				zap.String("note_id", share.NoteID.String()),
+			)
+		}
+		return err
+	}
+
+	s.log.Info("note unshared successfully",
+		zap.String("user_id", share.OwnerID.String()),
+		zap.String("note_id", share.NoteID.String()),
+	)
+
+	s.events.BroadcastNoteEvents(ctx, domain.NoteEventRevoked, share.OwnerID, share.NoteID, nil)
+
+	return nil
+}
+
+func (s *ShareS) NoteShares(ctx context.Context, ownerID, noteID uuid.UUID) ([]dto.NoteShareOutput, error) {
+	sharesDB, err := s.repo.NoteShares(ctx, ownerID, noteID)
+	if err != nil {
+		if !errors.Is(err, domain.ErrNotFound) {
+			s.log.Error("failed to get note shares from repository",
+				zap.Error(err),
+				zap.String("user_id", ownerID.String()),
+				zap.String("note_id", noteID.String()),
+			)
+		}
+		return nil, err
+	}
+
+	shares := make([]dto.NoteShareOutput, 0, len(sharesDB))
+	for _, v := range sharesDB {
+		shares = append(shares, dto.NoteShareOutput{
+			UserID:     v.UserID,
+			Username:   v.Username,
+			Email:      v.Email,
+			Permission: v.Permission,
+			CreatedAt:  v.CreatedAt,
+		})
+	}
+
+	return shares, nil
+}
+
+func (s *ShareS) SharedNotes(ctx context.Context, userID uuid.UUID, p dto.Paginated) (dto.PaginatedResponse, error) {
+	notesDB, total, err := s.repo.SharedNotes(ctx, userID, p)
+	if err != nil {
+		s.log.Error("failed to get shared notes from repository",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return dto.PaginatedResponse{}, err
+	}
+
+	if total == 0 {
+		return dto.PaginatedResponse{}, nil
+	}
+
+	notes := make([]dto.SharedNoteOutput, 0, len(notesDB))
+	for _, v := range notesDB {
+		notes = append(notes, dto.SharedNoteOutput{
+			NoteOutput: noteDomainToDTO(v.Note),
+			Permission: v.Permission,
+		})
+	}
+
+	return dto.MakePaginatedResponse(notes, total, p.Offset, p.Limit), nil
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	mock_service "noteApp/internal/service/mock"
+	"noteApp/pkg/logger"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockShareService(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock_service.MockRepositoryI)) *ShareS {
+	t.Helper()
+
+	repo := mock_service.NewMockRepositoryI(ctrl)
+	if setupMock != nil {
+		setupMock(repo)
+	}
+
+	return NewShareService(repo, mock_service.NewMockNoteEventPublisherI(ctrl), logger.LoggerForTest())
+}
+
+func TestShareS_ShareNote(t *testing.T) {
+	t.Parallel()
+
+	ownerID := uuid.New()
+	noteID := uuid.New()
+
+	share := dto.NoteShareCreate{
+		NoteID:     noteID,
+		OwnerID:    ownerID,
+		Username:   "bob",
+		Permission: domain.NotePermissionWrite,
+	}
+	target := domain.NoteShareTarget{
+		NoteID:     noteID,
+		OwnerID:    ownerID,
+		Username:   "bob",
+		Permission: domain.NotePermissionWrite,
+	}
+
+	tests := []struct {
+		name    string
+		f       func(*mock_service.MockRepositoryI)
+		wantErr error
+	}{
+		{
+			name: "success",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().ShareNote(gomock.Any(), target).Return(nil)
+			},
+		},
+		{
+			name: "note or user not found",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().ShareNote(gomock.Any(), target).
+					Return(domain.MakeError(domain.ErrFailedToCreate, domain.ErrNotFound, "note or user"))
+			},
+			wantErr: domain.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := mockShareService(t, ctrl, tt.f)
+
+			err := s.ShareNote(context.Background(), share)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestShareS_UnshareNote(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ownerID := uuid.New()
+	noteID := uuid.New()
+
+	repo := mock_service.NewMockRepositoryI(ctrl)
+	repo.EXPECT().UnshareNote(gomock.Any(), domain.NoteShareTarget{
+		NoteID:  noteID,
+		OwnerID: ownerID,
+		Email:   "bob@example.com",
+	}).Return(nil)
+
+	events := mock_service.NewMockNoteEventPublisherI(ctrl)
+	events.EXPECT().BroadcastNoteEvents(gomock.Any(), domain.NoteEventRevoked, ownerID, noteID, nil)
+
+	s := NewShareService(repo, events, logger.LoggerForTest())
+
+	err := s.UnshareNote(context.Background(), dto.NoteShareDelete{
+		NoteID:  noteID,
+		OwnerID: ownerID,
+		Email:   "bob@example.com",
+	})
+	require.NoError(t, err)
+}
+
+func TestShareS_NoteShares(t *testing.T) {
+	t.Parallel()
+
+	ownerID := uuid.New()
+	noteID := uuid.New()
+	granteeID := uuid.New()
+	createdAt := time.Now()
+
+	tests := []struct {
+		name    string
+		f       func(*mock_service.MockRepositoryI)
+		want    []dto.NoteShareOutput
+		wantErr bool
+	}{
+		{
+			name: "success",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().NoteShares(gomock.Any(), ownerID, noteID).Return([]domain.NoteShare{
+					{NoteID: noteID, OwnerID: ownerID, UserID: granteeID, Username: "bob", Email: "bob@example.com", Permission: "read", CreatedAt: createdAt},
+				}, nil)
+			},
+			want: []dto.NoteShareOutput{
+				{UserID: granteeID, Username: "bob", Email: "bob@example.com", Permission: "read", CreatedAt: createdAt},
+			},
+		},
+		{
+			name: "repository error",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().NoteShares(gomock.Any(), ownerID, noteID).Return(nil, errors.New("db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := mockShareService(t, ctrl, tt.f)
+
+			got, err := s.NoteShares(context.Background(), ownerID, noteID)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestShareS_SharedNotes(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	ownerID := uuid.New()
+	noteID := uuid.New()
+	p := dto.Paginated{Limit: 10}
+
+	s := mockShareService(t, ctrl, func(mri *mock_service.MockRepositoryI) {
+		mri.EXPECT().SharedNotes(gomock.Any(), userID, p).Return([]domain.SharedNote{
+			{Note: domain.Note{ID: noteID, UserID: ownerID}, Permission: "write"},
+		}, 1, nil)
+	})
+
+	got, err := s.SharedNotes(context.Background(), userID, p)
+	require.NoError(t, err)
+	assert.Equal(t, dto.MakePaginatedResponse([]dto.SharedNoteOutput{
+		{NoteOutput: dto.NoteOutput{ID: noteID, UserID: ownerID}, Permission: "write"},
+	}, 1, 0, 10), got)
+}
@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+	"noteApp/pkg/valid"
+	"sort"
+	"strconv"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const syncDefaultLimit = 100
+
+type SyncRI interface {
+	NoteChanges(ctx context.Context, userID uuid.UUID, since int64, limit int) ([]domain.NoteChange, error)
+	NoteTombstones(ctx context.Context, userID uuid.UUID, since int64, limit int) ([]domain.NoteChange, error)
+}
+
+type SyncNoteI interface {
+	CreateNote(ctx context.Context, note dto.NoteCreate) (uuid.UUID, error)
+	Note(ctx context.Context, userID, noteID uuid.UUID) (dto.NoteOutput, error)
+	UpdateNote(ctx context.Context, note dto.NoteUpdate) error
+	DeleteNote(ctx context.Context, userID, noteID uuid.UUID, version *int) error
+}
+
+type SyncS struct {
+	repo  SyncRI
+	notes SyncNoteI
+	log   *logger.Logger
+}
+
+func NewSyncService(repo SyncRI, notes SyncNoteI, log *logger.Logger) *SyncS {
+	return &SyncS{
+		repo:  repo,
+		notes: notes,
+		log:   log,
+	}
+}
+
+func (s *SyncS) SyncChanges(ctx context.Context, userID uuid.UUID, p dto.SyncPull) (dto.SyncPullResponse, error) {
+	since, err := decodeSyncToken(p.Since)
+	if err != nil {
+		s.log.Debug("invalid sync token",
+			zap.String("user_id", userID.String()),
+			zap.String("since", p.Since),
+		)
+		return dto.SyncPullResponse{}, domain.ErrInvalidSyncToken
+	}
+
+	limit := p.Limit
+	if limit == 0 {
+		limit = syncDefaultLimit
+	}
+
+	notes, err := s.repo.NoteChanges(ctx, userID, since, limit+1)
+	if err != nil {
+		s.log.Error("failed to get note changes from repository",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+			zap.Int64("since", since),
+		)
+		return dto.SyncPullResponse{}, err
+	}
+
+	tombstones, err := s.repo.NoteTombstones(ctx, userID, since, limit+1)
+	if err != nil {
+		s.log.Error("failed to get note tombstones from repository",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+			zap.Int64("since", since),
+		)
+		return dto.SyncPullResponse{}, err
+	}
+
+	changes := append(notes, tombstones...)
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Seq < changes[j].Seq
+	})
+
+	hasMore := len(changes) > limit
+	if hasMore {
+		changes = changes[:limit]
+	}
+
+	response := dto.SyncPullResponse{
+		Notes:   make([]dto.NoteOutput, 0, len(changes)),
+		Deleted: make([]uuid.UUID, 0),
+		Token:   encodeSyncToken(since),
+		HasMore: hasMore,
+	}
+
+	for _, v := range changes {
+		if v.Deleted {
+			response.Deleted = append(response.Deleted, v.ID)
+		} else {
+			response.Notes = append(response.Notes, noteDomainToDTO(v.Note))
+		}
+	}
+
+	if len(changes) > 0 {
+		response.Token = encodeSyncToken(changes[len(changes)-1].Seq)
+	}
+
+	s.log.Debug("sync changes fetched",
+		zap.String("user_id", userID.String()),
+		zap.Int64("since", since),
+		zap.Int("notes", len(response.Notes)),
+		zap.Int("deleted", len(response.Deleted)),
+		zap.Bool("has_more", hasMore),
+	)
+
+	return response, nil
+}
+
+func (s *SyncS) ApplySyncChanges(ctx context.Context, userID uuid.UUID, push dto.SyncPush) dto.SyncPushResponse {
+	results := make([]dto.SyncResult, 0, len(push.Changes))
+	applied := 0
+
+	for _, change := range push.Changes {
+		result := s.applySyncChange(ctx, userID, change)
+		if result.Status == domain.NoteOpStatusApplied {
+			applied++
+		}
+		results = append(results, result)
+	}
+
+	s.log.Info("sync changes applied",
+		zap.String("user_id", userID.String()),
+		zap.Int("changes", len(push.Changes)),
+		zap.Int("applied", applied),
+	)
+
+	return dto.SyncPushResponse{Results: results}
+}
+
+func (s *SyncS) applySyncChange(ctx context.Context, userID uuid.UUID, change dto.SyncChange) dto.SyncResult {
+	result := dto.SyncResult{
+		ID: change.ID,
+		Op: change.Op,
+	}
+
+	if err := valid.ValidateStruct(change); err != nil {
+		result.Status = domain.NoteOpStatusInvalid
+		result.Error = err.Error()
+		return result
+	}
+
+	var err error
+	switch change.Op {
+	case domain.NoteOpCreate:
+		if _, err = s.notes.Note(ctx, userID, change.ID); err == nil {
+			err = domain.MakeError(domain.ErrFailedToCreate, domain.ErrAlreadyExists, "note")
+		} else if errors.Is(err, domain.ErrNotFound) {
+			_, err = s.notes.CreateNote(ctx, syncChangeToNoteCreate(userID, change))
+		}
+	case domain.NoteOpUpdate:
+		err = s.notes.UpdateNote(ctx, dto.NoteUpdate{
+			ID:      change.ID,
+			UserID:  userID,
+			Heading: change.Heading,
+			Content: change.Content,
+			Done:    change.Done,
+			Tags:    change.Tags,
+			Version: change.Version,
+		})
+	case domain.NoteOpDelete:
+		err = s.notes.DeleteNote(ctx, userID, change.ID, change.Version)
+	}
+
+	result.Status = noteOpStatus(err)
+	if err != nil {
+		result.Error = err.Error()
+		if result.Status == domain.NoteOpStatusFailed {
+			s.log.Error("failed to apply sync change",
+				zap.Error(err),
+				zap.String("user_id", userID.String()),
+				zap.String("note_id", change.ID.String()),
+				zap.String("op", change.Op),
+			)
+		}
+	}
+
+	if (result.Status == domain.NoteOpStatusApplied && change.Op != domain.NoteOpDelete) || result.Status == domain.NoteOpStatusConflict {
+		if note, err := s.notes.Note(ctx, userID, change.ID); err == nil {
+			result.Note = &note
+		}
+	}
+
+	return result
+}
+
+func syncChangeToNoteCreate(userID uuid.UUID, change dto.SyncChange) dto.NoteCreate {
+	note := dto.NoteCreate{
+		ID:         change.ID,
+		UserID:     userID,
+		NotebookID: change.NotebookID,
+		Heading:    *change.Heading,
+		Content:    *change.Content,
+	}
+
+	if change.Done != nil {
+		note.Done = *change.Done
+	}
+
+	if change.Tags != nil {
+		note.Tags = *change.Tags
+	}
+
+	return note
+}
+
+func noteOpStatus(err error) string {
+	switch {
+	case err == nil:
+		return domain.NoteOpStatusApplied
+	case errors.Is(err, domain.ErrVersionMismatch), errors.Is(err, domain.ErrAlreadyExists):
+		return domain.NoteOpStatusConflict
+	case errors.Is(err, domain.ErrForbidden):
+		return domain.NoteOpStatusForbidden
+	case errors.Is(err, domain.ErrNotFound):
+		return domain.NoteOpStatusNotFound
+	case errors.Is(err, domain.ErrBatchAborted):
+		return domain.NoteOpStatusAborted
+	default:
+		return domain.NoteOpStatusFailed
+	}
+}
+
+func encodeSyncToken(seq int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(seq, 10)))
+}
+
+func decodeSyncToken(token string) (int64, error) {
+	if token == "" {
+		return 0, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, err
+	}
+
+	seq, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	if seq < 0 {
+		return 0, domain.ErrInvalidSyncToken
+	}
+
+	return seq, nil
+}
@@ -0,0 +1,277 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	mock_service "noteApp/internal/service/mock"
+	"noteApp/pkg/logger"
+	"noteApp/pkg/pubsub"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockSyncService(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock_service.MockRepositoryI)) *SyncS {
+	t.Helper()
+
+	repo := mock_service.NewMockRepositoryI(ctrl)
+	if setupMock != nil {
+		setupMock(repo)
+	}
+	repo.EXPECT().CreateNoteEvents(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	notes := NewNoteService(repo, repo, repo, pubsub.NewHub(), logger.LoggerForTest())
+
+	return NewSyncService(repo, notes, logger.LoggerForTest())
+}
+
+func TestSyncS_SyncChanges(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	first := uuid.New()
+	second := uuid.New()
+	trashed := uuid.New()
+	purged := uuid.New()
+
+	tests := []struct {
+		name        string
+		p           dto.SyncPull
+		f           func(*mock_service.MockRepositoryI)
+		wantNotes   []uuid.UUID
+		wantDeleted []uuid.UUID
+		wantToken   string
+		wantMore    bool
+		wantErr     error
+	}{
+		{
+			name: "merges notes and tombstones by sequence",
+			p:    dto.SyncPull{Since: encodeSyncToken(10)},
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().NoteChanges(gomock.Any(), userID, int64(10), syncDefaultLimit+1).Return([]domain.NoteChange{
+					{Note: domain.Note{ID: first}, Seq: 11},
+					{Note: domain.Note{ID: trashed}, Seq: 13, Deleted: true},
+					{Note: domain.Note{ID: second}, Seq: 14},
+				}, nil)
+				mri.EXPECT().NoteTombstones(gomock.Any(), userID, int64(10), syncDefaultLimit+1).Return([]domain.NoteChange{
+					{Note: domain.Note{ID: purged}, Seq: 12, Deleted: true},
+				}, nil)
+			},
+			wantNotes:   []uuid.UUID{first, second},
+			wantDeleted: []uuid.UUID{purged, trashed},
+			wantToken:   encodeSyncToken(14),
+		},
+		{
+			name: "limit reached",
+			p:    dto.SyncPull{Limit: 2},
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().NoteChanges(gomock.Any(), userID, int64(0), 3).Return([]domain.NoteChange{
+					{Note: domain.Note{ID: first}, Seq: 1},
+					{Note: domain.Note{ID: second}, Seq: 3},
+				}, nil)
+				mri.EXPECT().NoteTombstones(gomock.Any(), userID, int64(0), 3).Return([]domain.NoteChange{
+					{Note: domain.Note{ID: purged}, Seq: 2, Deleted: true},
+				}, nil)
+			},
+			wantNotes:   []uuid.UUID{first},
+			wantDeleted: []uuid.UUID{purged},
+			wantToken:   encodeSyncToken(2),
+			wantMore:    true,
+		},
+		{
+			name: "no changes keeps token",
+			p:    dto.SyncPull{Since: encodeSyncToken(42)},
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().NoteChanges(gomock.Any(), userID, int64(42), syncDefaultLimit+1).Return(nil, nil)
+				mri.EXPECT().NoteTombstones(gomock.Any(), userID, int64(42), syncDefaultLimit+1).Return(nil, nil)
+			},
+			wantNotes:   []uuid.UUID{},
+			wantDeleted: []uuid.UUID{},
+			wantToken:   encodeSyncToken(42),
+		},
+		{
+			name:    "invalid token",
+			p:       dto.SyncPull{Since: "not-a-token"},
+			wantErr: domain.ErrInvalidSyncToken,
+		},
+		{
+			name: "repository error",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().NoteChanges(gomock.Any(), userID, int64(0), syncDefaultLimit+1).Return(nil, errors.New("db error"))
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := mockSyncService(t, ctrl, tt.f)
+
+			got, err := s.SyncChanges(context.Background(), userID, tt.p)
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+
+			require.NoError(t, err)
+
+			notes := make([]uuid.UUID, 0, len(got.Notes))
+			for _, v := range got.Notes {
+				notes = append(notes, v.ID)
+			}
+
+			assert.Equal(t, tt.wantNotes, notes)
+			assert.Equal(t, tt.wantDeleted, got.Deleted)
+			assert.Equal(t, tt.wantToken, got.Token)
+			assert.Equal(t, tt.wantMore, got.HasMore)
+		})
+	}
+}
+
+func TestSyncS_ApplySyncChanges(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	noteID := uuid.New()
+	heading := "offline heading"
+	content := "offline content"
+	version := 2
+
+	current := domain.Note{ID: noteID, UserID: userID, Heading: heading, Content: content, Version: 3}
+
+	tests := []struct {
+		name       string
+		change     dto.SyncChange
+		f          func(*mock_service.MockRepositoryI)
+		wantStatus string
+		wantNote   bool
+	}{
+		{
+			name:   "create applied",
+			change: dto.SyncChange{Op: domain.NoteOpCreate, ID: noteID, Heading: &heading, Content: &content},
+			f: func(mri *mock_service.MockRepositoryI) {
+				gomock.InOrder(
+					mri.EXPECT().Note(gomock.Any(), userID, noteID).Return(domain.Note{}, domain.ErrNotFound),
+					mri.EXPECT().NoteShare(gomock.Any(), userID, noteID).Return(domain.NoteShare{}, domain.ErrNotFound),
+					mri.EXPECT().CreateNote(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, note domain.Note) error {
+						assert.Equal(t, noteID, note.ID)
+						assert.Equal(t, userID, note.UserID)
+						return nil
+					}),
+					mri.EXPECT().Note(gomock.Any(), userID, noteID).Return(current, nil),
+				)
+			},
+			wantStatus: domain.NoteOpStatusApplied,
+			wantNote:   true,
+		},
+		{
+			name:   "create of existing note conflicts",
+			change: dto.SyncChange{Op: domain.NoteOpCreate, ID: noteID, Heading: &heading, Content: &content},
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().Note(gomock.Any(), userID, noteID).Return(current, nil).Times(2)
+			},
+			wantStatus: domain.NoteOpStatusConflict,
+			wantNote:   true,
+		},
+		{
+			name:   "create with taken id conflicts",
+			change: dto.SyncChange{Op: domain.NoteOpCreate, ID: noteID, Heading: &heading, Content: &content},
+			f: func(mri *mock_service.MockRepositoryI) {
+				gomock.InOrder(
+					mri.EXPECT().Note(gomock.Any(), userID, noteID).Return(domain.Note{}, domain.ErrNotFound),
+					mri.EXPECT().NoteShare(gomock.Any(), userID, noteID).Return(domain.NoteShare{}, domain.ErrNotFound),
+					mri.EXPECT().CreateNote(gomock.Any(), gomock.Any()).Return(domain.MakeError(domain.ErrFailedToCreate, domain.ErrAlreadyExists, "note")),
+					mri.EXPECT().Note(gomock.Any(), userID, noteID).Return(domain.Note{}, domain.ErrNotFound),
+					mri.EXPECT().NoteShare(gomock.Any(), userID, noteID).Return(domain.NoteShare{}, domain.ErrNotFound),
+				)
+			},
+			wantStatus: domain.NoteOpStatusConflict,
+		},
+		{
+			name:   "update with stale version conflicts",
+			change: dto.SyncChange{Op: domain.NoteOpUpdate, ID: noteID, Heading: &heading, Version: &version},
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().UpdateNote(gomock.Any(), domain.NoteUpdate{
+					ID:      noteID,
+					UserID:  userID,
+					Heading: &heading,
+					Version: &version,
+				}).Return(domain.MakeError(domain.ErrFailedToUpdate, domain.ErrVersionMismatch, "note"))
+				mri.EXPECT().Note(gomock.Any(), userID, noteID).Return(current, nil)
+			},
+			wantStatus: domain.NoteOpStatusConflict,
+			wantNote:   true,
+		},
+		{
+			name:   "delete applied",
+			change: dto.SyncChange{Op: domain.NoteOpDelete, ID: noteID, Version: &version},
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().DeleteNote(gomock.Any(), userID, noteID, &version).Return(nil)
+			},
+			wantStatus: domain.NoteOpStatusApplied,
+		},
+		{
+			name:   "delete of missing note",
+			change: dto.SyncChange{Op: domain.NoteOpDelete, ID: noteID},
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().DeleteNote(gomock.Any(), userID, noteID, nil).Return(domain.ErrNotFound)
+				mri.EXPECT().NoteShare(gomock.Any(), userID, noteID).Return(domain.NoteShare{}, domain.ErrNotFound)
+			},
+			wantStatus: domain.NoteOpStatusNotFound,
+		},
+		{
+			name:       "create without content is invalid",
+			change:     dto.SyncChange{Op: domain.NoteOpCreate, ID: noteID, Heading: &heading},
+			wantStatus: domain.NoteOpStatusInvalid,
+		},
+		{
+			name:       "unknown op is invalid",
+			change:     dto.SyncChange{Op: "merge", ID: noteID},
+			wantStatus: domain.NoteOpStatusInvalid,
+		},
+		{
+			name:   "update of missing note",
+			change: dto.SyncChange{Op: domain.NoteOpUpdate, ID: noteID, Heading: &heading},
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().UpdateNote(gomock.Any(), gomock.Any()).Return(domain.ErrNotFound)
+				mri.EXPECT().NoteShare(gomock.Any(), userID, noteID).Return(domain.NoteShare{}, domain.ErrNotFound)
+			},
+			wantStatus: domain.NoteOpStatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := mockSyncService(t, ctrl, tt.f)
+
+			got := s.ApplySyncChanges(context.Background(), userID, dto.SyncPush{Changes: []dto.SyncChange{tt.change}})
+			require.Len(t, got.Results, 1)
+
+			result := got.Results[0]
+			assert.Equal(t, noteID, result.ID)
+			assert.Equal(t, tt.change.Op, result.Op)
+			assert.Equal(t, tt.wantStatus, result.Status)
+			assert.Equal(t, tt.wantStatus == domain.NoteOpStatusApplied, result.Error == "")
+			if tt.wantNote {
+				require.NotNil(t, result.Note)
+				assert.Equal(t, 3, result.Note.Version)
+			} else {
+				assert.Nil(t, result.Note)
+			}
+		})
+	}
+}
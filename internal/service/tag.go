@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type TagRI interface {
+	Tags(ctx context.Context, userID uuid.UUID) ([]domain.Tag, error)
+	RenameTag(ctx context.Context, tag domain.TagUpdate) error
+	MergeTags(ctx context.Context, userID, targetID uuid.UUID, sourceIDs []uuid.UUID) error
+	DeleteTag(ctx context.Context, userID, tagID uuid.UUID) error
+}
+
+type TagS struct {
+	repo TagRI
+	log  *logger.Logger
+}
+
+func NewTagService(repo TagRI, log *logger.Logger) *TagS {
+	return &TagS{
+		repo: repo,
+		log:  log,
+	}
+}
+
+func (t *TagS) Tags(ctx context.Context, userID uuid.UUID) ([]dto.TagOutput, error) {
+	tagsDB, err := t.repo.Tags(ctx, userID)
+	if err != nil {
+		t.log.Error("failed to get tags from repository",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return nil, err
+	}
+
+	tags := make([]dto.TagOutput, 0, len(tagsDB))
+	for _, v := range tagsDB {
+		tags = append(tags, tagDomainToDTO(v))
+	}
+
+	t.log.Debug("tags fetched and mapped successfully",
+		zap.String("user_id", userID.String()),
+		zap.Int("count", len(tags)),
+	)
+
+	return tags, nil
+}
+
+func (t *TagS) RenameTag(ctx context.Context, tag dto.TagUpdate) error {
+	input := domain.TagUpdate{
+		ID:     tag.ID,
+		UserID: tag.UserID,
+		Name:   strings.TrimSpace(tag.Name),
+	}
+
+	if err := input.Validate(); err != nil {
+		t.log.Debug("tag validation failed in service",
+			zap.String("user_id", input.UserID.String()),
+			zap.String("tag_id", input.ID.String()),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	if err := t.repo.RenameTag(ctx, input); err != nil {
+		if errors.Is(err, domain.ErrNotFound) || errors.Is(err, domain.ErrAlreadyExists) {
+			t.log.Warn("failed to rename tag",
+				zap.String("user_id", input.UserID.String()),
+				zap.String("tag_id", input.ID.String()),
+				zap.Error(err),
+			)
+		} else {
+			t.log.Error("failed to rename tag in repository",
+				zap.Error(err),
+				zap.String("user_id", input.UserID.String()),
+				zap.String("tag_id", input.ID.String()),
+			)
+		}
+		return err
+	}
+
+	t.log.Info("tag renamed successfully",
+		zap.String("user_id", input.UserID.String()),
+		zap.String("tag_id", input.ID.String()),
+	)
+
+	return nil
+}
+
+func (t *TagS) MergeTags(ctx context.Context, merge dto.TagMerge) error {
+	for _, id := range merge.SourceIDs {
+		if id == merge.TargetID {
+			t.log.Debug("tag merge into itself rejected",
+				zap.String("user_id", merge.UserID.String()),
+				zap.String("tag_id", merge.TargetID.String()),
+			)
+			return domain.ErrTagMergeSelf
+		}
+	}
+
+	if err := t.repo.MergeTags(ctx, merge.UserID, merge.TargetID, merge.SourceIDs); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			t.log.Warn("tags not found during merge",
+				zap.String("user_id", merge.UserID.String()),
+				zap.String("tag_id", merge.TargetID.String()),
+			)
+		} else {
+			t.log.Error("failed to merge tags in repository",
+				zap.Error(err),
+				zap.String("user_id", merge.UserID.String()),
+				zap.String("tag_id", merge.TargetID.String()),
+			)
+		}
+		return err
+	}
+
+	t.log.Info("tags merged successfully",
+		zap.String("user_id", merge.UserID.String()),
+		zap.String("tag_id", merge.TargetID.String()),
+		zap.Int("merged", len(merge.SourceIDs)),
+	)
+
+	return nil
+}
+
+func (t *TagS) DeleteTag(ctx context.Context, userID, tagID uuid.UUID) error {
+	if err := t.repo.DeleteTag(ctx, userID, tagID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			t.log.Warn("tag not found during deletion",
+				zap.String("user_id", userID.String()),
+				zap.String("tag_id", tagID.String()),
+			)
+		} else {
+			t.log.Error("failed to delete tag",
+				zap.Error(err),
+				zap.String("user_id", userID.String()),
+				zap.String("tag_id", tagID.String()),
+			)
+		}
+		return err
+	}
+
+	t.log.Info("tag deleted successfully",
+		zap.String("user_id", userID.String()),
+		zap.String("tag_id", tagID.String()),
+	)
+
+	return nil
+}
+
+func tagDomainToDTO(tag domain.Tag) dto.TagOutput {
+	return dto.TagOutput{
+		ID:        tag.ID,
+		Name:      tag.Name,
+		Notes:     tag.Notes,
+		CreatedAt: tag.CreatedAt,
+	}
+}
+
+func normalizeTags(tags []string) []string {
+	if tags == nil {
+		return nil
+	}
+
+	seen := make(map[string]struct{}, len(tags))
+	result := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		result = append(result, tag)
+	}
+
+	return result
+}
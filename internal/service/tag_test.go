@@ -0,0 +1,285 @@
+package service
+
+import (
+	"context"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	mock_service "noteApp/internal/service/mock"
+	"noteApp/pkg/logger"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockTagService(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock_service.MockRepositoryI)) *TagS {
+	t.Helper()
+
+	repo := mock_service.NewMockRepositoryI(ctrl)
+	if setupMock != nil {
+		setupMock(repo)
+	}
+
+	return NewTagService(repo, logger.LoggerForTest())
+}
+
+func TestTagS_Tags(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+
+	tests := []struct {
+		name    string
+		f       func(*mock_service.MockRepositoryI)
+		want    []dto.TagOutput
+		wantErr bool
+	}{
+		{
+			name: "success",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().Tags(gomock.Any(), userID).Return([]domain.Tag{
+					{Name: "home", Notes: 1},
+					{Name: "work", Notes: 2},
+				}, nil)
+			},
+			want: []dto.TagOutput{
+				{Name: "home", Notes: 1},
+				{Name: "work", Notes: 2},
+			},
+			wantErr: false,
+		},
+		{
+			name: "no tags",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().Tags(gomock.Any(), userID).Return(nil, nil)
+			},
+			want:    []dto.TagOutput{},
+			wantErr: false,
+		},
+		{
+			name: "repository error",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().Tags(gomock.Any(), userID).Return(nil, domain.ErrReceiving)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mockTagService(t, ctrl, tt.f)
+
+			got, err := service.Tags(context.Background(), userID)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTagS_RenameTag(t *testing.T) {
+	t.Parallel()
+
+	tagID := uuid.New()
+	userID := uuid.New()
+
+	tests := []struct {
+		name    string
+		tag     dto.TagUpdate
+		f       func(*mock_service.MockRepositoryI)
+		wantErr bool
+		errIs   error
+	}{
+		{
+			name: "success",
+			tag: dto.TagUpdate{
+				ID:     tagID,
+				UserID: userID,
+				Name:   " personal ",
+			},
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().RenameTag(gomock.Any(), domain.TagUpdate{
+					ID:     tagID,
+					UserID: userID,
+					Name:   "personal",
+				}).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "blank name",
+			tag: dto.TagUpdate{
+				ID:     tagID,
+				UserID: userID,
+				Name:   "   ",
+			},
+			wantErr: true,
+		},
+		{
+			name: "already exists",
+			tag: dto.TagUpdate{
+				ID:     tagID,
+				UserID: userID,
+				Name:   "personal",
+			},
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().RenameTag(gomock.Any(), gomock.Any()).Return(domain.ErrAlreadyExists)
+			},
+			wantErr: true,
+			errIs:   domain.ErrAlreadyExists,
+		},
+		{
+			name: "not found",
+			tag: dto.TagUpdate{
+				ID:     tagID,
+				UserID: userID,
+				Name:   "personal",
+			},
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().RenameTag(gomock.Any(), gomock.Any()).Return(domain.ErrNotFound)
+			},
+			wantErr: true,
+			errIs:   domain.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mockTagService(t, ctrl, tt.f)
+
+			err := service.RenameTag(context.Background(), tt.tag)
+			if tt.wantErr {
+				require.Error(t, err)
+				if tt.errIs != nil {
+					require.ErrorIs(t, err, tt.errIs)
+				}
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestTagS_MergeTags(t *testing.T) {
+	t.Parallel()
+
+	targetID := uuid.New()
+	sourceID := uuid.New()
+	userID := uuid.New()
+
+	tests := []struct {
+		name    string
+		merge   dto.TagMerge
+		f       func(*mock_service.MockRepositoryI)
+		wantErr error
+	}{
+		{
+			name: "success",
+			merge: dto.TagMerge{
+				TargetID:  targetID,
+				UserID:    userID,
+				SourceIDs: []uuid.UUID{sourceID},
+			},
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().MergeTags(gomock.Any(), userID, targetID, []uuid.UUID{sourceID}).Return(nil)
+			},
+		},
+		{
+			name: "merge into itself",
+			merge: dto.TagMerge{
+				TargetID:  targetID,
+				UserID:    userID,
+				SourceIDs: []uuid.UUID{sourceID, targetID},
+			},
+			wantErr: domain.ErrTagMergeSelf,
+		},
+		{
+			name: "not found",
+			merge: dto.TagMerge{
+				TargetID:  targetID,
+				UserID:    userID,
+				SourceIDs: []uuid.UUID{sourceID},
+			},
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().MergeTags(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(domain.ErrNotFound)
+			},
+			wantErr: domain.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mockTagService(t, ctrl, tt.f)
+
+			err := service.MergeTags(context.Background(), tt.merge)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestTagS_DeleteTag(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		f       func(*mock_service.MockRepositoryI)
+		wantErr bool
+	}{
+		{
+			name: "success",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().DeleteTag(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "not found",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().DeleteTag(gomock.Any(), gomock.Any(), gomock.Any()).Return(domain.ErrNotFound)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := mockTagService(t, ctrl, tt.f)
+
+			err := service.DeleteTag(context.Background(), uuid.New(), uuid.New())
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type TrashRI interface {
+	TrashedNotes(ctx context.Context, userID uuid.UUID, p dto.Paginated) ([]domain.TrashedNote, int, error)
+	RestoreNote(ctx context.Context, userID, noteID uuid.UUID) error
+	TrashedNoteIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+	PurgeNote(ctx context.Context, userID, noteID uuid.UUID) error
+	EmptyTrash(ctx context.Context, userID uuid.UUID) (int64, error)
+	PurgeTrash(ctx context.Context, deletedBefore time.Time) (int64, error)
+}
+
+type TrashS struct {
+	repo   TrashRI
+	tx     TxManagerI
+	events NoteEventPublisherI
+	log    *logger.Logger
+}
+
+func NewTrashService(repo TrashRI, tx TxManagerI, events NoteEventPublisherI, log *logger.Logger) *TrashS {
+	return &TrashS{
+		repo:   repo,
+		tx:     tx,
+		events: events,
+		log:    log,
+	}
+}
+
+func (t *TrashS) TrashedNotes(ctx context.Context, userID uuid.UUID, p dto.Paginated) (dto.PaginatedResponse, error) {
+	notesDB, total, err := t.repo.TrashedNotes(ctx, userID, p)
+	if err != nil {
+		t.log.Error("failed to get trashed notes from repository",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+			zap.Int("limit", p.Limit),
+			zap.Int("offset", p.Offset),
+		)
+		return dto.PaginatedResponse{}, err
+	}
+
+	if total == 0 {
+		return dto.PaginatedResponse{}, nil
+	}
+
+	notes := make([]dto.TrashedNoteOutput, 0, len(notesDB))
+	for _, v := range notesDB {
+		notes = append(notes, dto.TrashedNoteOutput{
+			NoteOutput: noteDomainToDTO(v.Note),
+			DeletedAt:  v.DeletedAt,
+		})
+	}
+
+	return dto.MakePaginatedResponse(notes, total, p.Offset, p.Limit), nil
+}
+
+func (t *TrashS) RestoreNote(ctx context.Context, userID, noteID uuid.UUID) error {
+	if err := t.repo.RestoreNote(ctx, userID, noteID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			t.log.Warn("trashed note not found during restore",
+				zap.String("user_id", userID.String()),
+				zap.String("note_id", noteID.String()),
+			)
+		} else {
+			t.log.Error("failed to restore note",
+				zap.Error(err),
+				zap.String("user_id", userID.String()),
+				zap.String("note_id", noteID.String()),
+			)
+		}
+		return err
+	}
+
+	t.log.Info("note restored from trash",
+		zap.String("user_id", userID.String()),
+		zap.String("note_id", noteID.String()),
+	)
+
+	events := t.events.StoreNoteEvents(ctx, domain.NoteEventCreated, userID, noteID)
+	t.events.BroadcastNoteEvents(ctx, domain.NoteEventCreated, userID, noteID, events)
+
+	return nil
+}
+
+func (t *TrashS) PurgeNote(ctx context.Context, userID, noteID uuid.UUID) error {
+	var events []domain.NoteEvent
+	err := t.tx.WithinTx(ctx, func(ctx context.Context) error {
+		events = t.events.StoreNoteEvents(ctx, domain.NoteEventDeleted, userID, noteID)
+		return t.repo.PurgeNote(ctx, userID, noteID)
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			t.log.Warn("trashed note not found during purge",
+				zap.String("user_id", userID.String()),
+				zap.String("note_id", noteID.String()),
+			)
+		} else {
+			t.log.Error("failed to purge note",
+				zap.Error(err),
+				zap.String("user_id", userID.String()),
+				zap.String("note_id", noteID.String()),
+			)
+		}
+		return err
+	}
+
+	t.log.Info("note permanently deleted",
+		zap.String("user_id", userID.String()),
+		zap.String("note_id", noteID.String()),
+	)
+
+	t.events.BroadcastNoteEvents(ctx, domain.NoteEventDeleted, userID, noteID, events)
+
+	return nil
+}
+
+func (t *TrashS) EmptyTrash(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var (
+		purged  int64
+		noteIDs []uuid.UUID
+		events  [][]domain.NoteEvent
+	)
+	err := t.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if noteIDs, err = t.repo.TrashedNoteIDs(ctx, userID); err != nil {
+			return err
+		}
+
+		events = make([][]domain.NoteEvent, 0, len(noteIDs))
+		for _, noteID := range noteIDs {
+			events = append(events, t.events.StoreNoteEvents(ctx, domain.NoteEventDeleted, userID, noteID))
+		}
+
+		purged, err = t.repo.EmptyTrash(ctx, userID)
+		return err
+	})
+	if err != nil {
+		t.log.Error("failed to empty trash",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return 0, err
+	}
+
+	t.log.Info("trash emptied",
+		zap.String("user_id", userID.String()),
+		zap.Int64("purged", purged),
+	)
+
+	for i, noteID := range noteIDs {
+		t.events.BroadcastNoteEvents(ctx, domain.NoteEventDeleted, userID, noteID, events[i])
+	}
+
+	return purged, nil
+}
+
+func (t *TrashS) PurgeTrash(ctx context.Context, retention time.Duration) (int64, error) {
+	purged, err := t.repo.PurgeTrash(ctx, time.Now().Add(-retention))
+	if err != nil {
+		t.log.Error("failed to purge trash",
+			zap.Error(err),
+			zap.Duration("retention", retention),
+		)
+		return 0, err
+	}
+
+	if purged > 0 {
+		t.log.Info("expired notes purged from trash",
+			zap.Int64("purged", purged),
+			zap.Duration("retention", retention),
+		)
+	}
+
+	return purged, nil
+}
+
+func (t *TrashS) RunTrashPurge(ctx context.Context, interval, retention time.Duration) {
+	t.log.Info("trash purge worker started",
+		zap.Duration("interval", interval),
+		zap.Duration("retention", retention),
+	)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		_, _ = t.PurgeTrash(ctx, retention)
+
+		select {
+		case <-ctx.Done():
+			t.log.Info("trash purge worker stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
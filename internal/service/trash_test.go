@@ -0,0 +1,305 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	mock_service "noteApp/internal/service/mock"
+	"noteApp/pkg/logger"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockTrashService(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock_service.MockRepositoryI, *mock_service.MockNoteEventPublisherI)) *TrashS {
+	t.Helper()
+
+	repo := mock_service.NewMockRepositoryI(ctrl)
+	events := mock_service.NewMockNoteEventPublisherI(ctrl)
+	if setupMock != nil {
+		setupMock(repo, events)
+	}
+
+	return NewTrashService(repo, &fakeTx{}, events, logger.LoggerForTest())
+}
+
+func TestTrashS_TrashedNotes(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	noteID := uuid.New()
+	deletedAt := time.Now()
+	p := dto.Paginated{Limit: 10}
+
+	tests := []struct {
+		name    string
+		f       func(*mock_service.MockRepositoryI, *mock_service.MockNoteEventPublisherI)
+		want    dto.PaginatedResponse
+		wantErr bool
+	}{
+		{
+			name: "success",
+			f: func(mri *mock_service.MockRepositoryI, mpi *mock_service.MockNoteEventPublisherI) {
+				mri.EXPECT().TrashedNotes(gomock.Any(), userID, p).Return([]domain.TrashedNote{
+					{Note: domain.Note{ID: noteID, UserID: userID, Heading: "a"}, DeletedAt: deletedAt},
+				}, 1, nil)
+			},
+			want: dto.MakePaginatedResponse([]dto.TrashedNoteOutput{
+				{NoteOutput: dto.NoteOutput{ID: noteID, UserID: userID, Heading: "a"}, DeletedAt: deletedAt},
+			}, 1, 0, 10),
+			wantErr: false,
+		},
+		{
+			name: "empty trash",
+			f: func(mri *mock_service.MockRepositoryI, mpi *mock_service.MockNoteEventPublisherI) {
+				mri.EXPECT().TrashedNotes(gomock.Any(), userID, p).Return(nil, 0, nil)
+			},
+			want:    dto.PaginatedResponse{},
+			wantErr: false,
+		},
+		{
+			name: "repository error",
+			f: func(mri *mock_service.MockRepositoryI, mpi *mock_service.MockNoteEventPublisherI) {
+				mri.EXPECT().TrashedNotes(gomock.Any(), userID, p).Return(nil, 0, errors.New("db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := mockTrashService(t, ctrl, tt.f)
+
+			got, err := s.TrashedNotes(context.Background(), userID, p)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTrashS_RestoreNote(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	noteID := uuid.New()
+	events := []domain.NoteEvent{{ID: 1, NoteID: noteID, UserID: userID, ActorID: userID}}
+
+	tests := []struct {
+		name    string
+		f       func(*mock_service.MockRepositoryI, *mock_service.MockNoteEventPublisherI)
+		wantErr error
+	}{
+		{
+			name: "success",
+			f: func(mri *mock_service.MockRepositoryI, mpi *mock_service.MockNoteEventPublisherI) {
+				mri.EXPECT().RestoreNote(gomock.Any(), userID, noteID).Return(nil)
+				mpi.EXPECT().StoreNoteEvents(gomock.Any(), domain.NoteEventCreated, userID, noteID).Return(events)
+				mpi.EXPECT().BroadcastNoteEvents(gomock.Any(), domain.NoteEventCreated, userID, noteID, events)
+			},
+		},
+		{
+			name: "not in trash",
+			f: func(mri *mock_service.MockRepositoryI, mpi *mock_service.MockNoteEventPublisherI) {
+				mri.EXPECT().RestoreNote(gomock.Any(), userID, noteID).
+					Return(domain.MakeError(domain.ErrFailedToUpdate, domain.ErrNotFound, "note"))
+			},
+			wantErr: domain.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := mockTrashService(t, ctrl, tt.f)
+
+			err := s.RestoreNote(context.Background(), userID, noteID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestTrashS_PurgeNote(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	noteID := uuid.New()
+	events := []domain.NoteEvent{{ID: 1, NoteID: noteID, UserID: userID, ActorID: userID}}
+
+	tests := []struct {
+		name    string
+		f       func(*mock_service.MockRepositoryI, *mock_service.MockNoteEventPublisherI)
+		wantErr error
+	}{
+		{
+			name: "success",
+			f: func(mri *mock_service.MockRepositoryI, mpi *mock_service.MockNoteEventPublisherI) {
+				mpi.EXPECT().StoreNoteEvents(gomock.Any(), domain.NoteEventDeleted, userID, noteID).Return(events)
+				mri.EXPECT().PurgeNote(gomock.Any(), userID, noteID).Return(nil)
+				mpi.EXPECT().BroadcastNoteEvents(gomock.Any(), domain.NoteEventDeleted, userID, noteID, events)
+			},
+		},
+		{
+			name: "not in trash",
+			f: func(mri *mock_service.MockRepositoryI, mpi *mock_service.MockNoteEventPublisherI) {
+				mpi.EXPECT().StoreNoteEvents(gomock.Any(), domain.NoteEventDeleted, userID, noteID).Return(events)
+				mri.EXPECT().PurgeNote(gomock.Any(), userID, noteID).
+					Return(domain.MakeError(domain.ErrFailedToDelete, domain.ErrNotFound, "note"))
+			},
+			wantErr: domain.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := mockTrashService(t, ctrl, tt.f)
+
+			err := s.PurgeNote(context.Background(), userID, noteID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestTrashS_EmptyTrash(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	noteIDs := []uuid.UUID{uuid.New(), uuid.New()}
+
+	tests := []struct {
+		name       string
+		f          func(*mock_service.MockRepositoryI, *mock_service.MockNoteEventPublisherI)
+		wantPurged int64
+		wantErr    error
+	}{
+		{
+			name: "success",
+			f: func(mri *mock_service.MockRepositoryI, mpi *mock_service.MockNoteEventPublisherI) {
+				mri.EXPECT().TrashedNoteIDs(gomock.Any(), userID).Return(noteIDs, nil)
+				for _, noteID := range noteIDs {
+					events := []domain.NoteEvent{{ID: 1, NoteID: noteID, UserID: userID, ActorID: userID, Type: domain.NoteEventDeleted}}
+					mpi.EXPECT().StoreNoteEvents(gomock.Any(), domain.NoteEventDeleted, userID, noteID).Return(events)
+					mpi.EXPECT().BroadcastNoteEvents(gomock.Any(), domain.NoteEventDeleted, userID, noteID, events)
+				}
+				mri.EXPECT().EmptyTrash(gomock.Any(), userID).Return(int64(2), nil)
+			},
+			wantPurged: 2,
+		},
+		{
+			name: "repository error",
+			f: func(mri *mock_service.MockRepositoryI, mpi *mock_service.MockNoteEventPublisherI) {
+				mri.EXPECT().TrashedNoteIDs(gomock.Any(), userID).
+					Return(nil, domain.MakeError(domain.ErrReceiving, errors.New("db down"), "trash"))
+			},
+			wantErr: domain.ErrReceiving,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := mockTrashService(t, ctrl, tt.f)
+
+			purged, err := s.EmptyTrash(context.Background(), userID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPurged, purged)
+		})
+	}
+}
+
+func TestTrashS_PurgeTrash(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	retention := 24 * time.Hour
+	start := time.Now()
+
+	s := mockTrashService(t, ctrl, func(mri *mock_service.MockRepositoryI, _ *mock_service.MockNoteEventPublisherI) {
+		mri.EXPECT().PurgeTrash(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, deletedBefore time.Time) (int64, error) {
+				assert.WithinDuration(t, start.Add(-retention), deletedBefore, time.Second)
+				return 3, nil
+			})
+	})
+
+	purged, err := s.PurgeTrash(context.Background(), retention)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), purged)
+}
+
+func TestTrashS_RunTrashPurge(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	purged := make(chan struct{}, 2)
+
+	s := mockTrashService(t, ctrl, func(mri *mock_service.MockRepositoryI, _ *mock_service.MockNoteEventPublisherI) {
+		mri.EXPECT().PurgeTrash(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(context.Context, time.Time) (int64, error) {
+				select {
+				case purged <- struct{}{}:
+				default:
+				}
+				return 0, nil
+			}).MinTimes(2)
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.RunTrashPurge(ctx, 10*time.Millisecond, time.Hour)
+	}()
+
+	<-purged
+	<-purged
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("trash purge worker did not stop")
+	}
+}
@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	"noteApp/pkg/logger"
+	"noteApp/pkg/totp"
+	"noteApp/pkg/utils"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	totpSkew         = 1
+	recoveryCodeSize = 10
+)
+
+type TwoFactorRI interface {
+	UserByID(ctx context.Context, userID uuid.UUID) (domain.User, error)
+	TwoFactor(ctx context.Context, userID uuid.UUID) (domain.TwoFactor, error)
+	SaveTwoFactor(ctx context.Context, tf domain.TwoFactor) error
+	ConfirmTwoFactor(ctx context.Context, userID uuid.UUID, step int64) error
+	UseTwoFactorStep(ctx context.Context, userID uuid.UUID, step int64) error
+	DeleteTwoFactor(ctx context.Context, userID uuid.UUID) error
+	ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string) error
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) error
+}
+
+type otpRI interface {
+	UseTwoFactorStep(ctx context.Context, userID uuid.UUID, step int64) error
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) error
+}
+
+type TwoFactorS struct {
+	repo TwoFactorRI
+	tx   TxManagerI
+	log  *logger.Logger
+}
+
+func NewTwoFactorService(repo TwoFactorRI, tx TxManagerI, log *logger.Logger) *TwoFactorS {
+	return &TwoFactorS{
+		repo: repo,
+		tx:   tx,
+		log:  log,
+	}
+}
+
+func (t *TwoFactorS) EnrollTwoFactor(ctx context.Context, userID uuid.UUID) (dto.TwoFactorEnrollment, error) {
+	tf, err := t.repo.TwoFactor(ctx, userID)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		t.log.Error("failed to get two-factor settings",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return dto.TwoFactorEnrollment{}, err
+	}
+
+	if err == nil && tf.Enabled() {
+		return dto.TwoFactorEnrollment{}, domain.ErrTwoFactorEnabled
+	}
+
+	user, err := t.repo.UserByID(ctx, userID)
+	if err != nil {
+		t.log.Error("failed to get user for two-factor enrollment",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return dto.TwoFactorEnrollment{}, err
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.log.Error("failed to generate totp secret", zap.Error(err))
+		return dto.TwoFactorEnrollment{}, err
+	}
+
+	if err := t.repo.SaveTwoFactor(ctx, domain.TwoFactor{
+		UserID:    userID,
+		Secret:    secret,
+		CreatedAt: time.Now(),
+	}); err != nil {
+		if !errors.Is(err, domain.ErrTwoFactorEnabled) {
+			t.log.Error("failed to save two-factor secret",
+				zap.Error(err),
+				zap.String("user_id", userID.String()),
+			)
+		}
+		return dto.TwoFactorEnrollment{}, err
+	}
+
+	t.log.Info("two-factor enrollment started",
+		zap.String("user_id", userID.String()),
+	)
+
+	return dto.TwoFactorEnrollment{
+		Secret: secret,
+		URI:    totp.URI(domain.TwoFactorIssuer, user.Email, secret),
+	}, nil
+}
+
+func (t *TwoFactorS) ConfirmTwoFactor(ctx context.Context, data dto.TwoFactorCode) ([]string, error) {
+	tf, err := t.twoFactor(ctx, data.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if tf.Enabled() {
+		return nil, domain.ErrTwoFactorEnabled
+	}
+
+	step, ok := totp.Validate(tf.Secret, data.Code, time.Now(), totpSkew)
+	if !ok {
+		t.log.Warn("invalid code during two-factor confirmation",
+			zap.String("user_id", data.UserID.String()),
+		)
+		return nil, domain.ErrInvalidOTP
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		t.log.Error("failed to generate recovery codes", zap.Error(err))
+		return nil, err
+	}
+
+	if err := t.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := t.repo.ConfirmTwoFactor(ctx, data.UserID, step); err != nil {
+			return err
+		}
+
+		return t.repo.ReplaceRecoveryCodes(ctx, data.UserID, hashes)
+	}); err != nil {
+		t.log.Error("failed to confirm two-factor",
+			zap.Error(err),
+			zap.String("user_id", data.UserID.String()),
+		)
+		return nil, err
+	}
+
+	t.log.Info("two-factor authentication enabled",
+		zap.String("user_id", data.UserID.String()),
+	)
+
+	return codes, nil
+}
+
+func (t *TwoFactorS) DisableTwoFactor(ctx context.Context, data dto.TwoFactorCode) error {
+	tf, err := t.twoFactor(ctx, data.UserID)
+	if err != nil {
+		return err
+	}
+
+	if !tf.Enabled() {
+		return domain.ErrNoTwoFactor
+	}
+
+	if err := t.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := verifyOTP(ctx, t.repo, tf, data.Code); err != nil {
+			return err
+		}
+
+		return t.repo.DeleteTwoFactor(ctx, data.UserID)
+	}); err != nil {
+		if errors.Is(err, domain.ErrInvalidOTP) {
+			t.log.Warn("invalid code while disabling two-factor",
+				zap.String("user_id", data.UserID.String()),
+			)
+		} else {
+			t.log.Error("failed to disable two-factor",
+				zap.Error(err),
+				zap.String("user_id", data.UserID.String()),
+			)
+		}
+		return err
+	}
+
+	t.log.Info("two-factor authentication disabled",
+		zap.String("user_id", data.UserID.String()),
+	)
+
+	return nil
+}
+
+func (t *TwoFactorS) twoFactor(ctx context.Context, userID uuid.UUID) (domain.TwoFactor, error) {
+	tf, err := t.repo.TwoFactor(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.TwoFactor{}, domain.ErrNoTwoFactor
+		}
+		t.log.Error("failed to get two-factor settings",
+			zap.Error(err),
+			zap.String("user_id", userID.String()),
+		)
+		return domain.TwoFactor{}, err
+	}
+
+	return tf, nil
+}
+
+func verifyOTP(ctx context.Context, repo otpRI, tf domain.TwoFactor, code string) error {
+	if step, ok := totp.Validate(tf.Secret, code, time.Now(), totpSkew); ok {
+		return repo.UseTwoFactorStep(ctx, tf.UserID, step)
+	}
+
+	if err := repo.UseRecoveryCode(ctx, tf.UserID, utils.HashToken(normalizeRecoveryCode(code))); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrInvalidOTP
+		}
+		return err
+	}
+
+	return nil
+}
+
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, domain.RecoveryCodeCount)
+	hashes := make([]string, 0, domain.RecoveryCodeCount)
+	for range domain.RecoveryCodeCount {
+		b := make([]byte, recoveryCodeSize)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+
+		raw := hex.EncodeToString(b)
+		codes = append(codes, raw[:len(raw)/2]+"-"+raw[len(raw)/2:])
+		hashes = append(hashes, utils.HashToken(raw))
+	}
+
+	return codes, hashes, nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
@@ -0,0 +1,305 @@
+package service
+
+import (
+	"context"
+	"net/url"
+	"noteApp/internal/models/domain"
+	"noteApp/internal/models/dto"
+	mock_service "noteApp/internal/service/mock"
+	"noteApp/pkg/logger"
+	"noteApp/pkg/totp"
+	"noteApp/pkg/utils"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockTwoFactorService(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock_service.MockRepositoryI)) *TwoFactorS {
+	t.Helper()
+
+	repo := mock_service.NewMockRepositoryI(ctrl)
+	if setupMock != nil {
+		setupMock(repo)
+	}
+
+	return NewTwoFactorService(repo, &fakeTx{}, logger.LoggerForTest())
+}
+
+func TestTwoFactorS_EnrollTwoFactor(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	confirmedAt := time.Now()
+
+	tests := []struct {
+		name    string
+		f       func(*mock_service.MockRepositoryI)
+		wantErr error
+	}{
+		{
+			name: "success",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().TwoFactor(gomock.Any(), userID).Return(domain.TwoFactor{}, domain.ErrNotFound)
+				mri.EXPECT().UserByID(gomock.Any(), userID).Return(domain.User{ID: userID, Email: "alice@example.com"}, nil)
+				mri.EXPECT().SaveTwoFactor(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, tf domain.TwoFactor) error {
+					assert.Equal(t, userID, tf.UserID)
+					assert.NotEmpty(t, tf.Secret)
+					return nil
+				})
+			},
+		},
+		{
+			name: "restart pending enrollment",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().TwoFactor(gomock.Any(), userID).Return(domain.TwoFactor{UserID: userID, Secret: "OLD"}, nil)
+				mri.EXPECT().UserByID(gomock.Any(), userID).Return(domain.User{ID: userID, Email: "alice@example.com"}, nil)
+				mri.EXPECT().SaveTwoFactor(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "already enabled",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().TwoFactor(gomock.Any(), userID).Return(domain.TwoFactor{UserID: userID, ConfirmedAt: &confirmedAt}, nil)
+			},
+			wantErr: domain.ErrTwoFactorEnabled,
+		},
+		{
+			name: "enabled concurrently",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().TwoFactor(gomock.Any(), userID).Return(domain.TwoFactor{}, domain.ErrNotFound)
+				mri.EXPECT().UserByID(gomock.Any(), userID).Return(domain.User{ID: userID, Email: "alice@example.com"}, nil)
+				mri.EXPECT().SaveTwoFactor(gomock.Any(), gomock.Any()).Return(domain.MakeError(domain.ErrFailedToCreate, domain.ErrTwoFactorEnabled, "two-factor"))
+			},
+			wantErr: domain.ErrTwoFactorEnabled,
+		},
+		{
+			name: "repository error",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().TwoFactor(gomock.Any(), userID).Return(domain.TwoFactor{}, domain.ErrReceiving)
+			},
+			wantErr: domain.ErrReceiving,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := mockTwoFactorService(t, ctrl, tt.f)
+
+			got, err := s.EnrollTwoFactor(context.Background(), userID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotEmpty(t, got.Secret)
+
+			u, err := url.Parse(got.URI)
+			require.NoError(t, err)
+			assert.Equal(t, "otpauth", u.Scheme)
+			assert.Equal(t, "/"+domain.TwoFactorIssuer+":alice@example.com", u.Path)
+			assert.Equal(t, got.Secret, u.Query().Get("secret"))
+		})
+	}
+}
+
+func TestTwoFactorS_ConfirmTwoFactor(t *testing.T) {
+	t.Parallel()
+
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+
+	step := totp.Step(time.Now())
+	validCode, err := totp.Code(secret, step)
+	require.NoError(t, err)
+
+	userID := uuid.New()
+	confirmedAt := time.Now()
+	pending := domain.TwoFactor{UserID: userID, Secret: secret}
+
+	tests := []struct {
+		name    string
+		code    string
+		f       func(*mock_service.MockRepositoryI)
+		wantErr error
+	}{
+		{
+			name: "success",
+			code: validCode,
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().TwoFactor(gomock.Any(), userID).Return(pending, nil)
+				mri.EXPECT().ConfirmTwoFactor(gomock.Any(), userID, step).Return(nil)
+				mri.EXPECT().ReplaceRecoveryCodes(gomock.Any(), userID, gomock.Any()).DoAndReturn(func(_ context.Context, _ uuid.UUID, hashes []string) error {
+					assert.Len(t, hashes, domain.RecoveryCodeCount)
+					return nil
+				})
+			},
+		},
+		{
+			name: "invalid code",
+			code: "000000",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().TwoFactor(gomock.Any(), userID).Return(pending, nil)
+			},
+			wantErr: domain.ErrInvalidOTP,
+		},
+		{
+			name: "not enrolled",
+			code: validCode,
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().TwoFactor(gomock.Any(), userID).Return(domain.TwoFactor{}, domain.ErrNotFound)
+			},
+			wantErr: domain.ErrNoTwoFactor,
+		},
+		{
+			name: "already enabled",
+			code: validCode,
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().TwoFactor(gomock.Any(), userID).Return(domain.TwoFactor{UserID: userID, Secret: secret, ConfirmedAt: &confirmedAt}, nil)
+			},
+			wantErr: domain.ErrTwoFactorEnabled,
+		},
+		{
+			name: "recovery codes error",
+			code: validCode,
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().TwoFactor(gomock.Any(), userID).Return(pending, nil)
+				mri.EXPECT().ConfirmTwoFactor(gomock.Any(), userID, gomock.Any()).Return(nil)
+				mri.EXPECT().ReplaceRecoveryCodes(gomock.Any(), userID, gomock.Any()).Return(domain.ErrFailedToCreate)
+			},
+			wantErr: domain.ErrFailedToCreate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := mockTwoFactorService(t, ctrl, tt.f)
+
+			got, err := s.ConfirmTwoFactor(context.Background(), dto.TwoFactorCode{UserID: userID, Code: tt.code})
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, got, domain.RecoveryCodeCount)
+			for _, code := range got {
+				assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{10}-[0-9a-f]{10}$`), code)
+			}
+		})
+	}
+}
+
+func Test_generateRecoveryCodes(t *testing.T) {
+	t.Parallel()
+
+	codes, hashes, err := generateRecoveryCodes()
+	require.NoError(t, err)
+	require.Len(t, codes, domain.RecoveryCodeCount)
+	require.Len(t, hashes, domain.RecoveryCodeCount)
+
+	seen := make(map[string]bool, len(codes))
+	for i, code := range codes {
+		assert.GreaterOrEqual(t, len(normalizeRecoveryCode(code))*4, 80)
+		assert.Equal(t, utils.HashToken(normalizeRecoveryCode(code)), hashes[i])
+		assert.False(t, seen[code])
+		seen[code] = true
+	}
+}
+
+func TestTwoFactorS_DisableTwoFactor(t *testing.T) {
+	t.Parallel()
+
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+
+	step := totp.Step(time.Now())
+	validCode, err := totp.Code(secret, step)
+	require.NoError(t, err)
+
+	userID := uuid.New()
+	confirmedAt := time.Now()
+	enabled := domain.TwoFactor{UserID: userID, Secret: secret, ConfirmedAt: &confirmedAt}
+
+	tests := []struct {
+		name    string
+		code    string
+		f       func(*mock_service.MockRepositoryI)
+		wantErr error
+	}{
+		{
+			name: "success with totp",
+			code: validCode,
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().TwoFactor(gomock.Any(), userID).Return(enabled, nil)
+				mri.EXPECT().UseTwoFactorStep(gomock.Any(), userID, step).Return(nil)
+				mri.EXPECT().DeleteTwoFactor(gomock.Any(), userID).Return(nil)
+			},
+		},
+		{
+			name: "success with recovery code",
+			code: "abcde-12345",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().TwoFactor(gomock.Any(), userID).Return(enabled, nil)
+				mri.EXPECT().UseRecoveryCode(gomock.Any(), userID, utils.HashToken("abcde12345")).Return(nil)
+				mri.EXPECT().DeleteTwoFactor(gomock.Any(), userID).Return(nil)
+			},
+		},
+		{
+			name: "invalid code",
+			code: "000000",
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().TwoFactor(gomock.Any(), userID).Return(enabled, nil)
+				mri.EXPECT().UseRecoveryCode(gomock.Any(), userID, gomock.Any()).Return(domain.MakeError(domain.ErrFailedToUpdate, domain.ErrNotFound, "recovery code"))
+			},
+			wantErr: domain.ErrInvalidOTP,
+		},
+		{
+			name: "pending enrollment",
+			code: validCode,
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().TwoFactor(gomock.Any(), userID).Return(domain.TwoFactor{UserID: userID, Secret: secret}, nil)
+			},
+			wantErr: domain.ErrNoTwoFactor,
+		},
+		{
+			name: "not enrolled",
+			code: validCode,
+			f: func(mri *mock_service.MockRepositoryI) {
+				mri.EXPECT().TwoFactor(gomock.Any(), userID).Return(domain.TwoFactor{}, domain.ErrNotFound)
+			},
+			wantErr: domain.ErrNoTwoFactor,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := mockTwoFactorService(t, ctrl, tt.f)
+
+			err := s.DisableTwoFactor(context.Background(), dto.TwoFactorCode{UserID: userID, Code: tt.code})
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
@@ -15,6 +15,7 @@ type UserRI interface {
 	UserByID(ctx context.Context, userID uuid.UUID) (domain.User, error)
 	UpdateUser(ctx context.Context, user domain.UserUpdate) error
 	DeleteUser(ctx context.Context, id uuid.UUID) error
+	DeleteSessions(ctx context.Context, userID uuid.UUID) error
 }
 
 type UserCred interface {
@@ -24,6 +25,7 @@ type UserCred interface {
 type UserS struct {
 	repo   UserRI
 	cred   UserCred
+	tx     TxManagerI
 	hasher HasherI
 	log    *logger.Logger
 }
@@ -31,65 +33,77 @@ type UserS struct {
 func NewUserService(
 	repo UserRI,
 	cred UserCred,
+	tx TxManagerI,
 	hasher HasherI,
 	log *logger.Logger,
 ) *UserS {
 	return &UserS{
 		repo:   repo,
 		cred:   cred,
+		tx:     tx,
 		hasher: hasher,
 		log:    log,
 	}
 }
 
 func (u *UserS) UpdateUserPassword(ctx context.Context, updPass dto.UserUpdPassword) error {
-	userDB, err := u.repo.UserByID(ctx, updPass.UserID)
-	if err != nil {
-		u.log.Error("failed to get user by ID during password update",
-			zap.Error(err),
-			zap.String("user_id", updPass.UserID.String()),
-		)
-		return err
-	}
+	return u.tx.WithinTx(ctx, func(ctx context.Context) error {
+		userDB, err := u.repo.UserByID(ctx, updPass.UserID)
+		if err != nil {
+			u.log.Error("failed to get user by ID during password update",
+				zap.Error(err),
+				zap.String("user_id", updPass.UserID.String()),
+			)
+			return err
+		}
 
-	userID, oldPassword, _ := u.cred.UserCredentials(ctx, userDB.Email)
+		userID, oldPassword, _ := u.cred.UserCredentials(ctx, userDB.Email)
 
-	if err := u.hasher.ComparePassword(oldPassword, updPass.OldPassword); err != nil {
-		u.log.Warn("incorrect old password provided",
-			zap.String("user_id", userID.String()),
-			zap.String("email", userDB.Email),
-		)
-		return domain.ErrIncorrectPassword
-	}
+		if err := u.hasher.ComparePassword(oldPassword, updPass.OldPassword); err != nil {
+			u.log.Warn("incorrect old password provided",
+				zap.String("user_id", userID.String()),
+				zap.String("email", userDB.Email),
+			)
+			return domain.ErrIncorrectPassword
+		}
 
-	hashedPassword, err := u.hasher.GenerateHash(updPass.NewPassword)
-	if err != nil {
-		u.log.Error("failed to hash new password",
-			zap.Error(err),
-			zap.String("user_id", userID.String()),
-		)
-		return err
-	}
+		hashedPassword, err := u.hasher.GenerateHash(updPass.NewPassword)
+		if err != nil {
+			u.log.Error("failed to hash new password",
+				zap.Error(err),
+				zap.String("user_id", userID.String()),
+			)
+			return err
+		}
 
-	inputUser := domain.UserUpdate{
-		ID:       userID,
-		Password: &hashedPassword,
-	}
+		inputUser := domain.UserUpdate{
+			ID:       userID,
+			Password: &hashedPassword,
+		}
 
-	if err := u.repo.UpdateUser(ctx, inputUser); err != nil {
-		u.log.Error("failed to update user in repo",
-			zap.Error(err),
-			zap.String("user_id", userID.String()),
-		)
+		if err := u.repo.UpdateUser(ctx, inputUser); err != nil {
+			u.log.Error("failed to update user in repo",
+				zap.Error(err),
+				zap.String("user_id", userID.String()),
+			)
 
-		return err
-	}
+			return err
+		}
 
-	u.log.Info("user password updated successfully",
-		zap.String("user_id", userID.String()),
-	)
+		if err := u.repo.DeleteSessions(ctx, userID); err != nil {
+			u.log.Error("failed to revoke sessions after password update",
+				zap.Error(err),
+				zap.String("user_id", userID.String()),
+			)
+			return err
+		}
 
-	return nil
+		u.log.Info("user password updated successfully, all sessions revoked",
+			zap.String("user_id", userID.String()),
+		)
+
+		return nil
+	})
 }
 
 func (u *UserS) UserByID(ctx context.Context, id uuid.UUID) (dto.UserOutput, error) {
@@ -114,12 +128,13 @@ func (u *UserS) UserByID(ctx context.Context, id uuid.UUID) (dto.UserOutput, err
 	)
 
 	return dto.UserOutput{
-		ID:        userDB.ID,
-		Username:  userDB.Username,
-		Email:     userDB.Email,
-		ImageURL:  userDB.ImageURL,
-		CreatedAt: userDB.CreatedAt,
-		UpdatedAt: userDB.UpdatedAt,
+		ID:         userDB.ID,
+		Username:   userDB.Username,
+		Email:      userDB.Email,
+		ImageURL:   userDB.ImageURL,
+		VerifiedAt: userDB.VerifiedAt,
+		CreatedAt:  userDB.CreatedAt,
+		UpdatedAt:  userDB.UpdatedAt,
 	}, nil
 }
 
@@ -25,7 +25,7 @@ func mockUserService(t *testing.T, ctrl *gomock.Controller, setupMock func(*mock
 		setupMock(repo, hasher)
 	}
 
-	return NewUserService(repo, repo, hasher, logger.LoggerForTest())
+	return NewUserService(repo, repo, &fakeTx{}, hasher, logger.LoggerForTest())
 }
 
 func TestUserS_UpdateUserPassword(t *testing.T) {
@@ -57,6 +57,7 @@ func TestUserS_UpdateUserPassword(t *testing.T) {
 				mhi.EXPECT().ComparePassword(gomock.Any(), gomock.Any()).Return(nil)
 				mhi.EXPECT().GenerateHash(gomock.Any()).Return("hashed_password", nil)
 				mri.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Return(nil)
+				mri.EXPECT().DeleteSessions(gomock.Any(), gomock.Any()).Return(nil)
 			},
 			wantErr: false,
 		},
@@ -150,6 +151,57 @@ func TestUserS_UpdateUserPassword(t *testing.T) {
 	}
 }
 
+func TestUserS_UpdateUserPasswordInTx(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	u := mockUserService(t, ctrl, func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+		mri.EXPECT().UserByID(gomock.Any(), gomock.Any()).Return(domain.User{}, nil)
+		mri.EXPECT().UserCredentials(gomock.Any(), gomock.Any()).Return(uuid.New(), "old_password", nil)
+		mhi.EXPECT().ComparePassword(gomock.Any(), gomock.Any()).Return(nil)
+		mhi.EXPECT().GenerateHash(gomock.Any()).Return("hashed_password", nil)
+		mri.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, _ domain.UserUpdate) error {
+			assert.True(t, inFakeTx(ctx))
+			return domain.ErrFailedToUpdate
+		})
+	})
+
+	err := u.UpdateUserPassword(context.Background(), dto.UserUpdPassword{
+		UserID:      uuid.New(),
+		OldPassword: "old_password",
+		NewPassword: "new_password",
+	})
+	require.ErrorIs(t, err, domain.ErrFailedToUpdate)
+	assert.Equal(t, 1, u.tx.(*fakeTx).rollbacks)
+}
+
+func TestUserS_UpdateUserPasswordRevokesSessions(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	u := mockUserService(t, ctrl, func(mri *mock_service.MockRepositoryI, mhi *mock_service.MockHasherI) {
+		mri.EXPECT().UserByID(gomock.Any(), userID).Return(domain.User{ID: userID, Email: "test@example.com"}, nil)
+		mri.EXPECT().UserCredentials(gomock.Any(), "test@example.com").Return(userID, "old_password", nil)
+		mhi.EXPECT().ComparePassword(gomock.Any(), gomock.Any()).Return(nil)
+		mhi.EXPECT().GenerateHash(gomock.Any()).Return("hashed_password", nil)
+		mri.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Return(nil)
+		mri.EXPECT().DeleteSessions(gomock.Any(), userID).Return(domain.ErrFailedToDelete)
+	})
+
+	err := u.UpdateUserPassword(context.Background(), dto.UserUpdPassword{
+		UserID:      userID,
+		OldPassword: "old_password",
+		NewPassword: "new_password",
+	})
+	require.ErrorIs(t, err, domain.ErrFailedToDelete)
+	assert.Equal(t, 1, u.tx.(*fakeTx).rollbacks)
+}
+
 func TestUserS_UserByID(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,3 @@
+ALTER TABLE notes ALTER COLUMN done DROP NOT NULL;
+
+ALTER TABLE notes ALTER COLUMN done DROP DEFAULT;
@@ -0,0 +1,5 @@
+UPDATE notes SET done = FALSE WHERE done IS NULL;
+
+ALTER TABLE notes ALTER COLUMN done SET DEFAULT FALSE;
+
+ALTER TABLE notes ALTER COLUMN done SET NOT NULL;
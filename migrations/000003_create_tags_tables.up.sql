@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS tags(
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+    name VARCHAR(64) NOT NULL CHECK (name <> ''),
+    created_at TIMESTAMP,
+    UNIQUE (user_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS note_tags(
+    note_id UUID NOT NULL REFERENCES "notes" ("id") ON DELETE CASCADE,
+    tag_id UUID NOT NULL REFERENCES "tags" ("id") ON DELETE CASCADE,
+    PRIMARY KEY (note_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS note_tags_tag_id_idx ON note_tags (tag_id);
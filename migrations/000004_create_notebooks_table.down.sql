@@ -0,0 +1,3 @@
+ALTER TABLE notes DROP COLUMN IF EXISTS notebook_id;
+
+DROP TABLE IF EXISTS notebooks;
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS notebooks(
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+    parent_id UUID REFERENCES "notebooks" ("id") ON DELETE SET NULL,
+    name VARCHAR(255) NOT NULL CHECK (name <> ''),
+    created_at TIMESTAMP,
+    updated_at TIMESTAMP,
+    CHECK (parent_id <> id)
+);
+
+CREATE INDEX IF NOT EXISTS notebooks_user_id_idx ON notebooks (user_id);
+
+CREATE INDEX IF NOT EXISTS notebooks_parent_id_idx ON notebooks (parent_id);
+
+ALTER TABLE notes ADD COLUMN IF NOT EXISTS notebook_id UUID REFERENCES "notebooks" ("id") ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS notes_notebook_id_idx ON notes (notebook_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS notes_search_vector_idx;
+
+ALTER TABLE notes DROP COLUMN IF EXISTS search_vector;
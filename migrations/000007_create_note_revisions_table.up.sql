@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS note_revisions(
+    note_id UUID NOT NULL REFERENCES "notes" ("id") ON DELETE CASCADE,
+    revision INT NOT NULL CHECK (revision > 0),
+    heading VARCHAR(255) NOT NULL,
+    content TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (note_id, revision)
+);
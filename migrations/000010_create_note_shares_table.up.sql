@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS note_shares(
+    note_id UUID NOT NULL REFERENCES "notes" ("id") ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+    permission VARCHAR(8) NOT NULL CHECK (permission IN ('read', 'write')),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (note_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS note_shares_user_id_idx ON note_shares (user_id);
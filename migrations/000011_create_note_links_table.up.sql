@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS note_links(
+    id UUID PRIMARY KEY,
+    note_id UUID NOT NULL REFERENCES "notes" ("id") ON DELETE CASCADE,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    password_hash VARCHAR(255),
+    expires_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS note_links_note_id_idx ON note_links (note_id);
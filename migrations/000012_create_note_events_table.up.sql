@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS note_events(
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+    note_id UUID NOT NULL,
+    actor_id UUID NOT NULL,
+    type VARCHAR(16) NOT NULL CHECK (type IN ('created', 'updated', 'deleted')),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS note_events_user_id_id_idx ON note_events (user_id, id);
@@ -0,0 +1,18 @@
+DROP TRIGGER IF EXISTS tags_bump_sync_seq ON tags;
+DROP FUNCTION IF EXISTS tags_bump_sync_seq();
+
+DROP TRIGGER IF EXISTS note_tags_bump_sync_seq ON note_tags;
+DROP FUNCTION IF EXISTS note_tags_bump_sync_seq();
+
+DROP TRIGGER IF EXISTS notes_record_tombstone ON notes;
+DROP FUNCTION IF EXISTS notes_record_tombstone();
+
+DROP TRIGGER IF EXISTS notes_bump_sync_seq ON notes;
+DROP FUNCTION IF EXISTS notes_bump_sync_seq();
+
+DROP TABLE IF EXISTS note_tombstones;
+
+DROP INDEX IF EXISTS notes_user_id_sync_seq_idx;
+ALTER TABLE notes DROP COLUMN IF EXISTS sync_seq;
+
+DROP SEQUENCE IF EXISTS notes_sync_seq;
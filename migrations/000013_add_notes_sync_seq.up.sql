@@ -0,0 +1,64 @@
+CREATE SEQUENCE IF NOT EXISTS notes_sync_seq;
+
+ALTER TABLE notes ADD COLUMN IF NOT EXISTS sync_seq BIGINT NOT NULL DEFAULT nextval('notes_sync_seq');
+
+CREATE INDEX IF NOT EXISTS notes_user_id_sync_seq_idx ON notes (user_id, sync_seq);
+
+CREATE TABLE IF NOT EXISTS note_tombstones(
+    note_id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    sync_seq BIGINT NOT NULL DEFAULT nextval('notes_sync_seq'),
+    deleted_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS note_tombstones_user_id_sync_seq_idx ON note_tombstones (user_id, sync_seq);
+
+CREATE OR REPLACE FUNCTION notes_bump_sync_seq() RETURNS trigger AS $$
+BEGIN
+    NEW.sync_seq := nextval('notes_sync_seq');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER notes_bump_sync_seq
+    BEFORE UPDATE ON notes
+    FOR EACH ROW EXECUTE FUNCTION notes_bump_sync_seq();
+
+CREATE OR REPLACE FUNCTION notes_record_tombstone() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO note_tombstones (note_id, user_id) VALUES (OLD.id, OLD.user_id)
+    ON CONFLICT (note_id) DO UPDATE SET user_id = EXCLUDED.user_id, sync_seq = nextval('notes_sync_seq'), deleted_at = NOW();
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER notes_record_tombstone
+    AFTER DELETE ON notes
+    FOR EACH ROW EXECUTE FUNCTION notes_record_tombstone();
+
+CREATE OR REPLACE FUNCTION note_tags_bump_sync_seq() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        UPDATE notes SET sync_seq = nextval('notes_sync_seq') WHERE id = OLD.note_id;
+    ELSE
+        UPDATE notes SET sync_seq = nextval('notes_sync_seq') WHERE id = NEW.note_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER note_tags_bump_sync_seq
+    AFTER INSERT OR DELETE ON note_tags
+    FOR EACH ROW EXECUTE FUNCTION note_tags_bump_sync_seq();
+
+CREATE OR REPLACE FUNCTION tags_bump_sync_seq() RETURNS trigger AS $$
+BEGIN
+    UPDATE notes SET sync_seq = nextval('notes_sync_seq')
+    WHERE id IN (SELECT note_id FROM note_tags WHERE tag_id = NEW.id);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER tags_bump_sync_seq
+    AFTER UPDATE OF name ON tags
+    FOR EACH ROW EXECUTE FUNCTION tags_bump_sync_seq();
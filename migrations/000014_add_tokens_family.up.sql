@@ -0,0 +1,4 @@
+ALTER TABLE tokens ADD COLUMN IF NOT EXISTS family_id UUID NOT NULL DEFAULT gen_random_uuid();
+ALTER TABLE tokens ADD COLUMN IF NOT EXISTS rotated_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS tokens_family_id_idx ON tokens (family_id);
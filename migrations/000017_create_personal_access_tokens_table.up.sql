@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS personal_access_tokens(
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL CHECK (name <> ''),
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    scopes TEXT[] NOT NULL,
+    expires_at TIMESTAMP,
+    last_used_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS personal_access_tokens_user_id_idx ON personal_access_tokens (user_id);
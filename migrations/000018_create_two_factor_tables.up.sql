@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS user_totp(
+    user_id UUID PRIMARY KEY REFERENCES "users" ("id") ON DELETE CASCADE,
+    secret VARCHAR(64) NOT NULL,
+    last_step BIGINT,
+    confirmed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS recovery_codes(
+    user_id UUID NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+    code_hash CHAR(64) NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, code_hash)
+);
+
+CREATE TABLE IF NOT EXISTS two_factor_challenges(
+    token_hash CHAR(64) PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+    attempts INT NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS two_factor_challenges_user_id_idx ON two_factor_challenges (user_id);
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS sign_in_attempts(
+    scope VARCHAR(16) NOT NULL CHECK (scope IN ('account', 'ip')),
+    subject VARCHAR(255) NOT NULL,
+    failures INT NOT NULL DEFAULT 0,
+    last_failed_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (scope, subject)
+);
@@ -0,0 +1,22 @@
+CREATE OR REPLACE FUNCTION notes_record_tombstone() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO note_tombstones (note_id, user_id) VALUES (OLD.id, OLD.user_id)
+    ON CONFLICT (note_id) DO UPDATE SET user_id = EXCLUDED.user_id, sync_seq = nextval('notes_sync_seq'), deleted_at = NOW();
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS notes_bump_sync_seq ON notes;
+
+CREATE OR REPLACE FUNCTION notes_bump_sync_seq() RETURNS trigger AS $$
+BEGIN
+    NEW.sync_seq := nextval('notes_sync_seq');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER notes_bump_sync_seq
+    BEFORE UPDATE ON notes
+    FOR EACH ROW EXECUTE FUNCTION notes_bump_sync_seq();
+
+DROP FUNCTION IF EXISTS notes_next_sync_seq(UUID);
@@ -0,0 +1,27 @@
+CREATE OR REPLACE FUNCTION notes_next_sync_seq(owner UUID) RETURNS BIGINT AS $$
+BEGIN
+    PERFORM pg_advisory_xact_lock(hashtext('notes_sync_seq'), hashtext(owner::text));
+    RETURN nextval('notes_sync_seq');
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION notes_bump_sync_seq() RETURNS trigger AS $$
+BEGIN
+    NEW.sync_seq := notes_next_sync_seq(NEW.user_id);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS notes_bump_sync_seq ON notes;
+
+CREATE TRIGGER notes_bump_sync_seq
+    BEFORE INSERT OR UPDATE ON notes
+    FOR EACH ROW EXECUTE FUNCTION notes_bump_sync_seq();
+
+CREATE OR REPLACE FUNCTION notes_record_tombstone() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO note_tombstones (note_id, user_id, sync_seq) VALUES (OLD.id, OLD.user_id, notes_next_sync_seq(OLD.user_id))
+    ON CONFLICT (note_id) DO UPDATE SET user_id = EXCLUDED.user_id, sync_seq = EXCLUDED.sync_seq, deleted_at = NOW();
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
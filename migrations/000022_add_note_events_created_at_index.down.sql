@@ -0,0 +1 @@
+DROP INDEX IF EXISTS note_events_created_at_idx;
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS note_events_created_at_idx ON note_events (created_at);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS notes_content_trgm_idx;
+
+DROP INDEX IF EXISTS notes_heading_trgm_idx;
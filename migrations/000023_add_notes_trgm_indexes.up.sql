@@ -0,0 +1,3 @@
+CREATE INDEX IF NOT EXISTS notes_heading_trgm_idx ON notes USING GIN (heading gin_trgm_ops);
+
+CREATE INDEX IF NOT EXISTS notes_content_trgm_idx ON notes USING GIN (content gin_trgm_ops);
@@ -0,0 +1,124 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/smtp"
+	"noteApp/internal/config"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Mailer interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+func New(cfg config.MailCfg) (Mailer, func() error, error) {
+	switch cfg.Driver {
+	case "smtp":
+		return NewSMTP(cfg), func() error { return nil }, nil
+	case "file":
+		return NewFile(cfg)
+	default:
+		return nil, nil, fmt.Errorf("unknown mail driver %q", cfg.Driver)
+	}
+}
+
+type SMTP struct {
+	addr string
+	host string
+	from string
+	auth smtp.Auth
+}
+
+func NewSMTP(cfg config.MailCfg) *SMTP {
+	var auth smtp.Auth
+	if cfg.SMTPUsername != "" {
+		auth = smtp.PlainAuth("", cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPHost)
+	}
+
+	return &SMTP{
+		addr: net.JoinHostPort(cfg.SMTPHost, cfg.SMTPPort),
+		host: cfg.SMTPHost,
+		from: cfg.From,
+		auth: auth,
+	}
+}
+
+func (s *SMTP) Send(ctx context.Context, to, subject, body string) error {
+	msg, err := message(s.from, to, subject, body, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := smtp.SendMail(s.addr, s.auth, s.from, []string{to}, msg); err != nil {
+		return fmt.Errorf("failed to send mail via %s: %w", s.host, err)
+	}
+
+	return nil
+}
+
+type File struct {
+	mu   sync.Mutex
+	w    io.Writer
+	from string
+}
+
+func NewFile(cfg config.MailCfg) (*File, func() error, error) {
+	if cfg.FilePath == "" {
+		return &File{w: os.Stdout, from: cfg.From}, func() error { return nil }, nil
+	}
+
+	f, err := os.OpenFile(cfg.FilePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open mail file: %w", err)
+	}
+
+	return &File{w: f, from: cfg.From}, f.Close, nil
+}
+
+func (f *File) Send(ctx context.Context, to, subject, body string) error {
+	msg, err := message(f.from, to, subject, body, time.Now())
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, err := f.w.Write(append(msg, "\r\n"...)); err != nil {
+		return fmt.Errorf("failed to write mail: %w", err)
+	}
+
+	return nil
+}
+
+func message(from, to, subject, body string, date time.Time) ([]byte, error) {
+	for _, header := range []string{from, to, subject} {
+		if strings.ContainsAny(header, "\r\n") {
+			return nil, fmt.Errorf("invalid mail header %q", header)
+		}
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", to)
+	fmt.Fprintf(&b, "Subject: %s\r\n", subject)
+	fmt.Fprintf(&b, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(body, "\r\n", "\n"), "\n", "\r\n"))
+	b.WriteString("\r\n")
+
+	return b.Bytes(), nil
+}
@@ -0,0 +1,85 @@
+package mailer
+
+import (
+	"context"
+	"noteApp/internal/config"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessage(t *testing.T) {
+	t.Parallel()
+
+	date := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	got, err := message("no-reply@example.com", "alice@example.com", "Hello", "line one\nline two", date)
+	require.NoError(t, err)
+
+	want := "From: no-reply@example.com\r\n" +
+		"To: alice@example.com\r\n" +
+		"Subject: Hello\r\n" +
+		"Date: Tue, 02 Jan 2024 03:04:05 +0000\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: text/plain; charset=UTF-8\r\n" +
+		"\r\n" +
+		"line one\r\nline two\r\n"
+	assert.Equal(t, want, string(got))
+
+	_, err = message("no-reply@example.com", "alice@example.com\r\nBcc: eve@example.com", "Hello", "body", date)
+	require.Error(t, err)
+
+	_, err = message("no-reply@example.com", "alice@example.com", "Hello\nBcc: eve@example.com", "body", date)
+	require.Error(t, err)
+}
+
+func TestFile_Send(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "mail.log")
+
+	m, closeFile, err := New(config.MailCfg{Driver: "file", From: "no-reply@example.com", FilePath: path})
+	require.NoError(t, err)
+
+	require.NoError(t, m.Send(context.Background(), "alice@example.com", "First", "first body"))
+	require.NoError(t, m.Send(context.Background(), "bob@example.com", "Second", "second body"))
+	require.NoError(t, closeFile())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	content := string(data)
+	assert.Equal(t, 2, strings.Count(content, "From: no-reply@example.com\r\n"))
+	assert.Contains(t, content, "To: alice@example.com\r\nSubject: First\r\n")
+	assert.Contains(t, content, "To: bob@example.com\r\nSubject: Second\r\n")
+	assert.Contains(t, content, "\r\n\r\nsecond body\r\n")
+
+	err = m.Send(context.Background(), "alice@example.com\nBcc: eve@example.com", "Third", "body")
+	require.Error(t, err)
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	m, _, err := New(config.MailCfg{Driver: "smtp", From: "no-reply@example.com", SMTPHost: "localhost", SMTPPort: "25"})
+	require.NoError(t, err)
+	assert.IsType(t, &SMTP{}, m)
+	assert.Equal(t, "localhost:25", m.(*SMTP).addr)
+	assert.Nil(t, m.(*SMTP).auth)
+
+	m, _, err = New(config.MailCfg{Driver: "smtp", From: "no-reply@example.com", SMTPHost: "localhost", SMTPPort: "587", SMTPUsername: "user", SMTPPassword: "pass"})
+	require.NoError(t, err)
+	assert.NotNil(t, m.(*SMTP).auth)
+
+	m, _, err = New(config.MailCfg{Driver: "file", From: "no-reply@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, os.Stdout, m.(*File).w)
+
+	_, _, err = New(config.MailCfg{Driver: "pigeon"})
+	require.Error(t, err)
+}
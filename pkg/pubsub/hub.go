@@ -0,0 +1,58 @@
+package pubsub
+
+import (
+	"context"
+	"sync"
+)
+
+const subscriberBuffer = 16
+
+type Hub struct {
+	mu   sync.RWMutex
+	subs map[string]map[chan []byte]struct{}
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		subs: make(map[string]map[chan []byte]struct{}),
+	}
+}
+
+func (h *Hub) Publish(_ context.Context, topic string, payload []byte) error {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for ch := range h.subs[topic] {
+		select {
+		case ch <- payload:
+		default:
+		}
+	}
+
+	return nil
+}
+
+func (h *Hub) Subscribe(ctx context.Context, topic string) (<-chan []byte, error) {
+	ch := make(chan []byte, subscriberBuffer)
+
+	h.mu.Lock()
+	if h.subs[topic] == nil {
+		h.subs[topic] = make(map[chan []byte]struct{})
+	}
+	h.subs[topic][ch] = struct{}{}
+	h.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		h.mu.Lock()
+		delete(h.subs[topic], ch)
+		if len(h.subs[topic]) == 0 {
+			delete(h.subs, topic)
+		}
+		close(ch)
+		h.mu.Unlock()
+	}()
+
+	return ch, nil
+}
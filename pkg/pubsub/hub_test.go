@@ -0,0 +1,75 @@
+package pubsub
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHub_Publish(t *testing.T) {
+	t.Parallel()
+
+	h := NewHub()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	first, err := h.Subscribe(ctx, "notes:1")
+	require.NoError(t, err)
+	second, err := h.Subscribe(ctx, "notes:1")
+	require.NoError(t, err)
+	other, err := h.Subscribe(ctx, "notes:2")
+	require.NoError(t, err)
+
+	require.NoError(t, h.Publish(context.Background(), "notes:1", []byte("updated")))
+
+	assert.Equal(t, []byte("updated"), <-first)
+	assert.Equal(t, []byte("updated"), <-second)
+	assert.Empty(t, other)
+}
+
+func TestHub_SlowSubscriber(t *testing.T) {
+	t.Parallel()
+
+	h := NewHub()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch, err := h.Subscribe(ctx, "notes:1")
+	require.NoError(t, err)
+
+	for i := 0; i < subscriberBuffer+5; i++ {
+		require.NoError(t, h.Publish(context.Background(), "notes:1", []byte("event")))
+	}
+
+	assert.Len(t, ch, subscriberBuffer)
+}
+
+func TestHub_Unsubscribe(t *testing.T) {
+	t.Parallel()
+
+	h := NewHub()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, err := h.Subscribe(ctx, "notes:1")
+	require.NoError(t, err)
+
+	cancel()
+
+	select {
+	case _, ok := <-ch:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("subscription was not closed")
+	}
+
+	require.NoError(t, h.Publish(context.Background(), "notes:1", []byte("event")))
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	assert.Empty(t, h.subs)
+}
@@ -0,0 +1,166 @@
+package signer
+
+import (
+	"fmt"
+	"noteApp/internal/config"
+	"os"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+)
+
+var keyTypes = map[jwa.SignatureAlgorithm]jwa.KeyType{
+	jwa.RS256: jwa.RSA,
+	jwa.EdDSA: jwa.OKP,
+}
+
+type Signer struct {
+	alg     jwa.SignatureAlgorithm
+	signing interface{}
+	verify  jwt.ParseOption
+	public  jwk.Set
+}
+
+func NewSigner(cfg config.AuthCfg) (*Signer, error) {
+	if len(cfg.Keys) == 0 {
+		if cfg.JwtSecret == "" {
+			return nil, fmt.Errorf("jwt secret is not set")
+		}
+
+		return &Signer{
+			alg:     jwa.HS256,
+			signing: []byte(cfg.JwtSecret),
+			verify:  jwt.WithKey(jwa.HS256, []byte(cfg.JwtSecret)),
+			public:  jwk.NewSet(),
+		}, nil
+	}
+
+	s := &Signer{
+		public: jwk.NewSet(),
+	}
+
+	for _, keyCfg := range cfg.Keys {
+		if _, ok := s.public.LookupKeyID(keyCfg.ID); ok {
+			return nil, fmt.Errorf("duplicate key id %q", keyCfg.ID)
+		}
+
+		private, public, err := loadKey(keyCfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load key %q: %w", keyCfg.ID, err)
+		}
+
+		if err := s.public.AddKey(public); err != nil {
+			return nil, fmt.Errorf("failed to add key %q: %w", keyCfg.ID, err)
+		}
+
+		if keyCfg.ID != cfg.SigningKeyID {
+			continue
+		}
+
+		if private == nil {
+			return nil, fmt.Errorf("signing key %q has no private key", keyCfg.ID)
+		}
+
+		s.alg = jwa.SignatureAlgorithm(keyCfg.Algorithm)
+		s.signing = private
+	}
+
+	if s.signing == nil {
+		return nil, fmt.Errorf("signing key %q is not configured", cfg.SigningKeyID)
+	}
+
+	s.verify = jwt.WithKeySet(s.public)
+
+	return s, nil
+}
+
+func (s *Signer) Sign(token jwt.Token) ([]byte, error) {
+	return jwt.Sign(token, jwt.WithKey(s.alg, s.signing))
+}
+
+func (s *Signer) Verify(token []byte) (jwt.Token, error) {
+	return jwt.Parse(token, s.verify)
+}
+
+func (s *Signer) PublicKeys() jwk.Set {
+	return s.public
+}
+
+func loadKey(cfg config.JWTKeyCfg) (jwk.Key, jwk.Key, error) {
+	alg := jwa.SignatureAlgorithm(cfg.Algorithm)
+	kty, ok := keyTypes[alg]
+	if !ok {
+		return nil, nil, fmt.Errorf("unsupported algorithm %q", cfg.Algorithm)
+	}
+
+	path := cfg.PublicKeyFile
+	if cfg.PrivateKeyFile != "" {
+		path = cfg.PrivateKeyFile
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key, err := jwk.ParseKey(data, jwk.WithPEM(true))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	if key.KeyType() != kty {
+		return nil, nil, fmt.Errorf("%s is not a %s key", path, alg)
+	}
+
+	if err := setHeaders(key, cfg.ID, alg); err != nil {
+		return nil, nil, err
+	}
+
+	public, err := jwk.PublicKeyOf(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get public key: %w", err)
+	}
+
+	if err := setHeaders(public, cfg.ID, alg); err != nil {
+		return nil, nil, err
+	}
+
+	if cfg.PrivateKeyFile == "" {
+		if isPrivate(key) {
+			return nil, nil, fmt.Errorf("%s contains a private key", path)
+		}
+		return nil, public, nil
+	}
+
+	if !isPrivate(key) {
+		return nil, nil, fmt.Errorf("%s does not contain a private key", path)
+	}
+
+	return key, public, nil
+}
+
+func setHeaders(key jwk.Key, id string, alg jwa.SignatureAlgorithm) error {
+	if err := key.Set(jwk.KeyIDKey, id); err != nil {
+		return fmt.Errorf("failed to set key id: %w", err)
+	}
+
+	if err := key.Set(jwk.AlgorithmKey, alg); err != nil {
+		return fmt.Errorf("failed to set key algorithm: %w", err)
+	}
+
+	if err := key.Set(jwk.KeyUsageKey, jwk.ForSignature); err != nil {
+		return fmt.Errorf("failed to set key usage: %w", err)
+	}
+
+	return nil
+}
+
+func isPrivate(key jwk.Key) bool {
+	switch key.(type) {
+	case jwk.RSAPrivateKey, jwk.OKPPrivateKey:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,360 @@
+package signer
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"noteApp/internal/config"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type keyFiles struct {
+	private string
+	public  string
+}
+
+func writeKey(t *testing.T, name string, private crypto.Signer) keyFiles {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(private)
+	require.NoError(t, err)
+
+	publicDER, err := x509.MarshalPKIXPublicKey(private.Public())
+	require.NoError(t, err)
+
+	files := keyFiles{
+		private: filepath.Join(dir, name+".pem"),
+		public:  filepath.Join(dir, name+".pub.pem"),
+	}
+
+	require.NoError(t, os.WriteFile(files.private, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER}), 0o600))
+	require.NoError(t, os.WriteFile(files.public, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}), 0o600))
+
+	return files
+}
+
+func rsaKey(t *testing.T, name string) keyFiles {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	return writeKey(t, name, key)
+}
+
+func ed25519Key(t *testing.T, name string) keyFiles {
+	t.Helper()
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	return writeKey(t, name, key)
+}
+
+func testToken(t *testing.T) jwt.Token {
+	t.Helper()
+
+	tkn := jwt.New()
+	require.NoError(t, tkn.Set(jwt.SubjectKey, "user"))
+	require.NoError(t, tkn.Set(jwt.ExpirationKey, time.Now().Add(time.Hour)))
+
+	return tkn
+}
+
+func TestNewSigner(t *testing.T) {
+	t.Parallel()
+
+	rsaFiles := rsaKey(t, "rsa")
+	edFiles := ed25519Key(t, "ed")
+
+	tests := []struct {
+		name    string
+		cfg     config.AuthCfg
+		wantErr bool
+	}{
+		{
+			name: "secret",
+			cfg:  config.AuthCfg{JwtSecret: "secret"},
+		},
+		{
+			name:    "no secret and no keys",
+			cfg:     config.AuthCfg{},
+			wantErr: true,
+		},
+		{
+			name: "rsa signing key",
+			cfg: config.AuthCfg{
+				SigningKeyID: "rsa",
+				Keys:         []config.JWTKeyCfg{{ID: "rsa", Algorithm: "RS256", PrivateKeyFile: rsaFiles.private}},
+			},
+		},
+		{
+			name: "ed25519 signing key with retired rsa key",
+			cfg: config.AuthCfg{
+				SigningKeyID: "ed",
+				Keys: []config.JWTKeyCfg{
+					{ID: "ed", Algorithm: "EdDSA", PrivateKeyFile: edFiles.private},
+					{ID: "rsa", Algorithm: "RS256", PublicKeyFile: rsaFiles.public},
+				},
+			},
+		},
+		{
+			name: "signing key not configured",
+			cfg: config.AuthCfg{
+				SigningKeyID: "missing",
+				Keys:         []config.JWTKeyCfg{{ID: "rsa", Algorithm: "RS256", PrivateKeyFile: rsaFiles.private}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "signing key without private key",
+			cfg: config.AuthCfg{
+				SigningKeyID: "rsa",
+				Keys:         []config.JWTKeyCfg{{ID: "rsa", Algorithm: "RS256", PublicKeyFile: rsaFiles.public}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "private key in public key file",
+			cfg: config.AuthCfg{
+				SigningKeyID: "ed",
+				Keys: []config.JWTKeyCfg{
+					{ID: "ed", Algorithm: "EdDSA", PrivateKeyFile: edFiles.private},
+					{ID: "rsa", Algorithm: "RS256", PublicKeyFile: rsaFiles.private},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate key id",
+			cfg: config.AuthCfg{
+				SigningKeyID: "rsa",
+				Keys: []config.JWTKeyCfg{
+					{ID: "rsa", Algorithm: "RS256", PrivateKeyFile: rsaFiles.private},
+					{ID: "rsa", Algorithm: "RS256", PublicKeyFile: rsaFiles.public},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "algorithm does not match key",
+			cfg: config.AuthCfg{
+				SigningKeyID: "rsa",
+				Keys:         []config.JWTKeyCfg{{ID: "rsa", Algorithm: "EdDSA", PrivateKeyFile: rsaFiles.private}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unsupported algorithm",
+			cfg: config.AuthCfg{
+				SigningKeyID: "rsa",
+				Keys:         []config.JWTKeyCfg{{ID: "rsa", Algorithm: "HS256", PrivateKeyFile: rsaFiles.private}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing file",
+			cfg: config.AuthCfg{
+				SigningKeyID: "rsa",
+				Keys:         []config.JWTKeyCfg{{ID: "rsa", Algorithm: "RS256", PrivateKeyFile: filepath.Join(t.TempDir(), "missing.pem")}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := NewSigner(tt.cfg)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, got)
+		})
+	}
+}
+
+func TestSigner_SignVerify(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		cfg   func(t *testing.T) config.AuthCfg
+		kid   string
+		alg   string
+		nKeys int
+	}{
+		{
+			name: "secret",
+			cfg: func(t *testing.T) config.AuthCfg {
+				return config.AuthCfg{JwtSecret: "secret"}
+			},
+			alg: "HS256",
+		},
+		{
+			name: "rsa",
+			cfg: func(t *testing.T) config.AuthCfg {
+				files := rsaKey(t, "rsa")
+				return config.AuthCfg{
+					SigningKeyID: "2024-01",
+					Keys:         []config.JWTKeyCfg{{ID: "2024-01", Algorithm: "RS256", PrivateKeyFile: files.private}},
+				}
+			},
+			kid:   "2024-01",
+			alg:   "RS256",
+			nKeys: 1,
+		},
+		{
+			name: "ed25519",
+			cfg: func(t *testing.T) config.AuthCfg {
+				files := ed25519Key(t, "ed")
+				return config.AuthCfg{
+					SigningKeyID: "2024-06",
+					Keys:         []config.JWTKeyCfg{{ID: "2024-06", Algorithm: "EdDSA", PrivateKeyFile: files.private}},
+				}
+			},
+			kid:   "2024-06",
+			alg:   "EdDSA",
+			nKeys: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, err := NewSigner(tt.cfg(t))
+			require.NoError(t, err)
+
+			signed, err := s.Sign(testToken(t))
+			require.NoError(t, err)
+
+			msg, err := jws.Parse(signed)
+			require.NoError(t, err)
+			headers := msg.Signatures()[0].ProtectedHeaders()
+			assert.Equal(t, tt.kid, headers.KeyID())
+			assert.Equal(t, tt.alg, headers.Algorithm().String())
+
+			verified, err := s.Verify(signed)
+			require.NoError(t, err)
+			assert.Equal(t, "user", verified.Subject())
+
+			assert.Equal(t, tt.nKeys, s.PublicKeys().Len())
+		})
+	}
+}
+
+func TestSigner_Rotation(t *testing.T) {
+	t.Parallel()
+
+	oldFiles := rsaKey(t, "old")
+	newFiles := ed25519Key(t, "new")
+	otherFiles := rsaKey(t, "other")
+
+	oldSigner, err := NewSigner(config.AuthCfg{
+		SigningKeyID: "old",
+		Keys:         []config.JWTKeyCfg{{ID: "old", Algorithm: "RS256", PrivateKeyFile: oldFiles.private}},
+	})
+	require.NoError(t, err)
+
+	rotated, err := NewSigner(config.AuthCfg{
+		SigningKeyID: "new",
+		Keys: []config.JWTKeyCfg{
+			{ID: "new", Algorithm: "EdDSA", PrivateKeyFile: newFiles.private},
+			{ID: "old", Algorithm: "RS256", PublicKeyFile: oldFiles.public},
+		},
+	})
+	require.NoError(t, err)
+
+	dropped, err := NewSigner(config.AuthCfg{
+		SigningKeyID: "new",
+		Keys:         []config.JWTKeyCfg{{ID: "new", Algorithm: "EdDSA", PrivateKeyFile: newFiles.private}},
+	})
+	require.NoError(t, err)
+
+	forged, err := NewSigner(config.AuthCfg{
+		SigningKeyID: "old",
+		Keys:         []config.JWTKeyCfg{{ID: "old", Algorithm: "RS256", PrivateKeyFile: otherFiles.private}},
+	})
+	require.NoError(t, err)
+
+	oldToken, err := oldSigner.Sign(testToken(t))
+	require.NoError(t, err)
+
+	newToken, err := rotated.Sign(testToken(t))
+	require.NoError(t, err)
+
+	forgedToken, err := forged.Sign(testToken(t))
+	require.NoError(t, err)
+
+	secret, err := NewSigner(config.AuthCfg{JwtSecret: "secret"})
+	require.NoError(t, err)
+
+	secretToken, err := secret.Sign(testToken(t))
+	require.NoError(t, err)
+
+	_, err = rotated.Verify(oldToken)
+	assert.NoError(t, err, "retired key still verifies")
+
+	_, err = rotated.Verify(newToken)
+	assert.NoError(t, err)
+
+	_, err = dropped.Verify(oldToken)
+	assert.Error(t, err, "removed key no longer verifies")
+
+	_, err = rotated.Verify(forgedToken)
+	assert.Error(t, err, "same kid signed by another key")
+
+	_, err = rotated.Verify(secretToken)
+	assert.Error(t, err, "hmac token rejected once keys are configured")
+}
+
+func TestSigner_PublicKeys(t *testing.T) {
+	t.Parallel()
+
+	rsaFiles := rsaKey(t, "rsa")
+	edFiles := ed25519Key(t, "ed")
+
+	s, err := NewSigner(config.AuthCfg{
+		SigningKeyID: "ed",
+		Keys: []config.JWTKeyCfg{
+			{ID: "ed", Algorithm: "EdDSA", PrivateKeyFile: edFiles.private},
+			{ID: "rsa", Algorithm: "RS256", PrivateKeyFile: rsaFiles.private},
+		},
+	})
+	require.NoError(t, err)
+
+	set := s.PublicKeys()
+	require.Equal(t, 2, set.Len())
+
+	for _, kid := range []string{"ed", "rsa"} {
+		key, ok := set.LookupKeyID(kid)
+		require.True(t, ok)
+		assert.Equal(t, jwk.ForSignature.String(), key.KeyUsage())
+
+		switch key.(type) {
+		case jwk.RSAPrivateKey, jwk.OKPPrivateKey:
+			t.Fatalf("key %q exposes private material", kid)
+		}
+	}
+
+	secret, err := NewSigner(config.AuthCfg{JwtSecret: "secret"})
+	require.NoError(t, err)
+	assert.Equal(t, 0, secret.PublicKeys().Len())
+}
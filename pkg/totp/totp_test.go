@@ -0,0 +1,111 @@
+package totp
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "287082"},
+		{unix: 1111111109, want: "081804"},
+		{unix: 1111111111, want: "050471"},
+		{unix: 1234567890, want: "005924"},
+		{unix: 2000000000, want: "279037"},
+		{unix: 20000000000, want: "353130"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Code(rfcSecret, Step(time.Unix(tt.unix, 0)))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := Code("not base32!", 1)
+	require.Error(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1111111111, 0)
+	step := Step(now)
+
+	previous, err := Code(rfcSecret, step-1)
+	require.NoError(t, err)
+
+	tooOld, err := Code(rfcSecret, step-2)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		code     string
+		skew     int
+		wantStep int64
+		wantOK   bool
+	}{
+		{name: "current step", code: "050471", skew: 1, wantStep: step, wantOK: true},
+		{name: "previous step within skew", code: previous, skew: 1, wantStep: step - 1, wantOK: true},
+		{name: "previous step without skew", code: previous, skew: 0},
+		{name: "outside skew", code: tooOld, skew: 1},
+		{name: "wrong code", code: "000000", skew: 1},
+		{name: "wrong length", code: "50471", skew: 1},
+		{name: "empty", code: "", skew: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			gotStep, ok := Validate(rfcSecret, tt.code, now, tt.skew)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantStep, gotStep)
+		})
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	t.Parallel()
+
+	first, err := GenerateSecret()
+	require.NoError(t, err)
+
+	second, err := GenerateSecret()
+	require.NoError(t, err)
+
+	assert.NotEqual(t, first, second)
+	assert.Len(t, first, 32)
+
+	code, err := Code(first, Step(time.Now()))
+	require.NoError(t, err)
+	assert.Len(t, code, Digits)
+}
+
+func TestURI(t *testing.T) {
+	t.Parallel()
+
+	uri := URI("NoteApp", "user@example.com", rfcSecret)
+
+	u, err := url.Parse(uri)
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth", u.Scheme)
+	assert.Equal(t, "totp", u.Host)
+	assert.Equal(t, "/NoteApp:user@example.com", u.Path)
+	assert.Equal(t, rfcSecret, u.Query().Get("secret"))
+	assert.Equal(t, "NoteApp", u.Query().Get("issuer"))
+	assert.Equal(t, "6", u.Query().Get("digits"))
+	assert.Equal(t, "30", u.Query().Get("period"))
+}
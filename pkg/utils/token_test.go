@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRandomToken(t *testing.T) {
+	first, err := RandomToken(32)
+	require.NoError(t, err)
+
+	second, err := RandomToken(32)
+	require.NoError(t, err)
+
+	require.NotEqual(t, first, second)
+
+	decoded, err := base64.RawURLEncoding.DecodeString(first)
+	require.NoError(t, err)
+	require.Len(t, decoded, 32)
+}
+
+func TestHashToken(t *testing.T) {
+	require.Equal(t,
+		"2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+		HashToken("hello"),
+	)
+	require.NotEqual(t, HashToken("hello"), HashToken("hello!"))
+}
@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+func ToTSQuery(q string) string {
+	var terms []string
+
+	for i, part := range strings.Split(q, `"`) {
+		if i%2 == 1 {
+			var words []string
+			for _, field := range strings.Fields(part) {
+				words = append(words, fieldTerms(field)...)
+			}
+
+			switch {
+			case len(words) > 1:
+				terms = append(terms, "("+strings.Join(words, " <-> ")+")")
+			case len(words) == 1:
+				terms = append(terms, words[0])
+			}
+			continue
+		}
+
+		for _, field := range strings.Fields(part) {
+			terms = append(terms, fieldTerms(field)...)
+		}
+	}
+
+	return strings.Join(terms, " & ")
+}
+
+func fieldTerms(field string) []string {
+	words := strings.FieldsFunc(strings.ToLower(field), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		terms = append(terms, "'"+word+"'")
+	}
+
+	if len(terms) > 0 && strings.HasSuffix(field, "*") {
+		terms[len(terms)-1] += ":*"
+	}
+
+	return terms
+}
@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestToTSQuery(t *testing.T) {
+	tests := []struct {
+		name string
+		q    string
+		want string
+	}{
+		{
+			name: "single word",
+			q:    "Groceries",
+			want: "'groceries'",
+		},
+		{
+			name: "several words",
+			q:    "buy  milk",
+			want: "'buy' & 'milk'",
+		},
+		{
+			name: "prefix",
+			q:    "groc*",
+			want: "'groc':*",
+		},
+		{
+			name: "phrase",
+			q:    `"quick brown fox" jump*`,
+			want: "('quick' <-> 'brown' <-> 'fox') & 'jump':*",
+		},
+		{
+			name: "phrase with prefix",
+			q:    `"shopping li*"`,
+			want: "('shopping' <-> 'li':*)",
+		},
+		{
+			name: "unclosed quote",
+			q:    `todo "next week`,
+			want: "'todo' & ('next' <-> 'week')",
+		},
+		{
+			name: "operators are stripped",
+			q:    "a&b | !c:* 'd'",
+			want: "'a' & 'b' & 'c':* & 'd'",
+		},
+		{
+			name: "only punctuation",
+			q:    `"" * & !`,
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, ToTSQuery(tt.q))
+		})
+	}
+}